@@ -9,7 +9,22 @@ import (
 // ClusterClaimSpec defines the desired state of the ClusterClaim.
 type ClusterClaimSpec struct {
 	// ClusterPoolName is the name of the cluster pool from which to claim a cluster.
-	ClusterPoolName string `json:"clusterPoolName"`
+	// Exactly one of ClusterPoolName and ClusterPoolSelector must be set.
+	// +optional
+	ClusterPoolName string `json:"clusterPoolName,omitempty"`
+
+	// ClusterPoolSelector selects the cluster pools in the namespace of the claim from which a cluster may be
+	// claimed. The claim is satisfied by one of the selected pools, chosen according to ClusterPoolOrdering.
+	// Exactly one of ClusterPoolName and ClusterPoolSelector must be set.
+	// +optional
+	ClusterPoolSelector *metav1.LabelSelector `json:"clusterPoolSelector,omitempty"`
+
+	// ClusterPoolOrdering is the policy used to order the pools selected by ClusterPoolSelector. The claim is
+	// satisfied by the first pool in the order that has a cluster ready to be claimed or, when none do, by the
+	// first pool in the order once it has a cluster ready.
+	// The default is MostReady.
+	// +optional
+	ClusterPoolOrdering ClusterPoolOrderingPolicy `json:"clusterPoolOrdering,omitempty"`
 
 	// Subjects hold references to which to authorize access to the claimed cluster.
 	// +optional
@@ -21,14 +36,72 @@ type ClusterClaimSpec struct {
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
 
+	// Priority is the priority of the claim. Pending claims with a higher priority are assigned clusters before
+	// those with a lower priority. Claims with the same priority are assigned clusters in order of creation.
+	// The default is 0. The priority may be negative.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
 	// Lifetime is the maximum lifetime of the claim after it is assigned a cluster. If the claim still exists
 	// when the lifetime has elapsed, the claim will be deleted by Hive.
 	// +optional
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// CopyConnectionSecrets, when true, has Hive copy the admin kubeconfig and admin password secrets of the claimed
+	// cluster into the namespace of the claim. The copies are kept in sync with the originals and are owned by the
+	// claim, so they are deleted along with it. The names of the copies are reported in the status of the claim.
+	// +optional
+	CopyConnectionSecrets bool `json:"copyConnectionSecrets,omitempty"`
 }
 
+// ClusterPoolOrderingPolicy is a policy for ordering the cluster pools selected by a ClusterClaim.
+// +kubebuilder:validation:Enum="";MostReady;Priority
+type ClusterPoolOrderingPolicy string
+
+const (
+	// MostReadyClusterPoolOrdering orders the selected pools by the number of clusters ready to be claimed, most first.
+	MostReadyClusterPoolOrdering ClusterPoolOrderingPolicy = "MostReady"
+	// PriorityClusterPoolOrdering orders the selected pools by the integer value of their
+	// hive.openshift.io/cluster-pool-priority label, highest first, and then by the number of clusters ready to be
+	// claimed. Pools without the label have a priority of 0.
+	PriorityClusterPoolOrdering ClusterPoolOrderingPolicy = "Priority"
+)
+
 // ClusterClaimStatus defines the observed state of ClusterClaim.
 type ClusterClaimStatus struct {
+	// ClusterPoolName is the name of the cluster pool from which the cluster was assigned to the claim.
+	// +optional
+	ClusterPoolName string `json:"clusterPoolName,omitempty"`
+
+	// QueuePosition is the position of the claim in the queue of pending claims of the cluster pool, starting at 1.
+	// It is only set while the claim is waiting for a cluster.
+	// +optional
+	QueuePosition *int32 `json:"queuePosition,omitempty"`
+
+	// EstimatedWait is an estimate of how long the claim will wait for a cluster, based on the clusters being
+	// installed for the pool and on how long the clusters of the pool have taken to install. It is only set while
+	// the claim is waiting for a cluster and the pool has installed a cluster from which to estimate.
+	// +optional
+	EstimatedWait *metav1.Duration `json:"estimatedWait,omitempty"`
+
+	// APIURL is the URL where the API of the claimed cluster can be accessed.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// WebConsoleURL is the URL for the web console UI of the claimed cluster.
+	// +optional
+	WebConsoleURL string `json:"webConsoleURL,omitempty"`
+
+	// AdminKubeconfigSecretRef references the copy, in the namespace of the claim, of the secret containing the
+	// admin kubeconfig of the claimed cluster. It is only set when CopyConnectionSecrets is true.
+	// +optional
+	AdminKubeconfigSecretRef *corev1.LocalObjectReference `json:"adminKubeconfigSecretRef,omitempty"`
+
+	// AdminPasswordSecretRef references the copy, in the namespace of the claim, of the secret containing the
+	// admin username and password of the claimed cluster. It is only set when CopyConnectionSecrets is true.
+	// +optional
+	AdminPasswordSecretRef *corev1.LocalObjectReference `json:"adminPasswordSecretRef,omitempty"`
+
 	// Conditions includes more detailed status for the cluster pool.
 	// +optional
 	Conditions []ClusterClaimCondition `json:"conditions,omitempty"`
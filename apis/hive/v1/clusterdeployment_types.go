@@ -96,6 +96,14 @@ type ClusterDeploymentSpec struct {
 	// used to abandon ongoing cluster deprovision.
 	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
 
+	// PreDeprovisionHooks are Jobs run against the still-running cluster when the ClusterDeployment is deleted.
+	// The hooks run one at a time, in the order they are listed, and the cluster is not deprovisioned until they
+	// have finished, or a running hook has reached its timeout. The hooks are not run when the cluster was never
+	// installed, when PreserveOnDelete is set, when the cluster is hibernating or unreachable, or when the namespace
+	// of the ClusterDeployment is being deleted.
+	// +optional
+	PreDeprovisionHooks []PreDeprovisionHook `json:"preDeprovisionHooks,omitempty"`
+
 	// ControlPlaneConfig contains additional configuration for the target cluster's control plane
 	// +optional
 	ControlPlaneConfig ControlPlaneConfigSpec `json:"controlPlaneConfig,omitempty"`
@@ -116,6 +124,12 @@ type ClusterDeploymentSpec struct {
 	// ClusterMetadata contains metadata information about the installed cluster.
 	ClusterMetadata *ClusterMetadata `json:"clusterMetadata,omitempty"`
 
+	// Adoption, when set, adopts a pre-existing cluster using only its admin kubeconfig. Hive connects to the
+	// cluster to fill in the ClusterMetadata, the platform region and the base domain, and then marks the cluster
+	// as installed.
+	// +optional
+	Adoption *ClusterAdoption `json:"adoption,omitempty"`
+
 	// Installed is true if the cluster has been installed
 	// +optional
 	Installed bool `json:"installed"`
@@ -146,10 +160,25 @@ type ClusterDeploymentSpec struct {
 	// +optional
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// Expiration configures when the cluster is deleted, and when warnings are given before the deletion.
+	// Takes precedence over the hive.openshift.io/delete-after annotation.
+	// +optional
+	Expiration *ClusterExpiration `json:"expiration,omitempty"`
+
+	// Upgrade, when set, makes Hive upgrade the installed cluster to the given release by setting the desired update
+	// of the ClusterVersion of the cluster.
+	// +optional
+	Upgrade *ClusterUpgrade `json:"upgrade,omitempty"`
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
 
+	// InstallRetryBackoff is the exponential backoff between attempts to install the cluster after failures that are
+	// retried with backoff. By default the delay starts at 1m and doubles with each attempt, up to 24h.
+	// +optional
+	InstallRetryBackoff *InstallRetryBackoff `json:"installRetryBackoff,omitempty"`
+
 	// MachineManagement contains machine management settings including the strategy that will be used when
 	// provisioning worker machines.
 	// +optional
@@ -231,6 +260,107 @@ type Provisioning struct {
 	// additional features of the installer.
 	// +optional
 	InstallerEnv []corev1.EnvVar `json:"installerEnv,omitempty"`
+
+	// Hooks are Jobs run at defined points of the install. The hooks of a phase run one at a time, in the
+	// order they are listed.
+	// +optional
+	Hooks []ProvisionHook `json:"hooks,omitempty"`
+
+	// DryRun renders the install-config and manifests the installer would use without creating any
+	// infrastructure. The results are stored in a ConfigMap referenced by the ClusterProvision. The
+	// ClusterDeployment is not installed while DryRun is set; unset it to start a real install.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ProvisionHookPhase is the point of the install at which a hook runs.
+// +kubebuilder:validation:Enum=PreInstall;PostInstall
+type ProvisionHookPhase string
+
+const (
+	// PreInstallProvisionHookPhase hooks run before the first install attempt is started.
+	PreInstallProvisionHookPhase ProvisionHookPhase = "PreInstall"
+
+	// PostInstallProvisionHookPhase hooks run after the install has completed, before the ClusterDeployment
+	// is marked as installed.
+	PostInstallProvisionHookPhase ProvisionHookPhase = "PostInstall"
+)
+
+// ProvisionHookFailurePolicy determines what happens when a hook fails.
+// +kubebuilder:validation:Enum=Fail;Ignore
+type ProvisionHookFailurePolicy string
+
+const (
+	// FailProvisionHookFailurePolicy stops the install when the hook fails.
+	FailProvisionHookFailurePolicy ProvisionHookFailurePolicy = "Fail"
+
+	// IgnoreProvisionHookFailurePolicy continues the install when the hook fails.
+	IgnoreProvisionHookFailurePolicy ProvisionHookFailurePolicy = "Ignore"
+)
+
+// ProvisionHook is a Job run by Hive at a defined point of the install.
+type ProvisionHook struct {
+	// Name is the name of the hook, which must be unique within the ClusterDeployment. The Job running the
+	// hook is named after the ClusterDeployment and the hook.
+	Name string `json:"name"`
+
+	// Phase is the point of the install at which the hook runs.
+	Phase ProvisionHookPhase `json:"phase"`
+
+	// FailurePolicy determines what happens when the hook fails. With Fail, the install does not proceed
+	// until the failed hook Job is deleted, which runs the hook again. With Ignore, the install proceeds.
+	// The default is Fail.
+	// +optional
+	FailurePolicy ProvisionHookFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Template is the pod template of the hook Job. Once the cluster has been installed, the admin kubeconfig
+	// of the cluster is mounted into the containers, with its path set in the KUBECONFIG environment variable.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Template corev1.PodTemplateSpec `json:"template"`
+
+	// BackoffLimit is the number of retries of the hook Job before it is considered failed. The default is 6.
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// ActiveDeadlineSeconds is how long the hook Job may run before it is considered failed.
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// PreDeprovisionHook is a Job run by Hive against the still-running cluster before the cluster is deprovisioned.
+type PreDeprovisionHook struct {
+	// Name is the name of the hook, which must be unique within the pre-deprovision hooks of the
+	// ClusterDeployment. The Job running the hook is named after the ClusterDeployment and the hook.
+	Name string `json:"name"`
+
+	// FailurePolicy determines what happens when the hook fails. With Fail, the cluster is not deprovisioned
+	// until the failed hook Job is deleted, which runs the hook again. With Ignore, the deprovision proceeds.
+	// The default is Fail.
+	// +optional
+	FailurePolicy ProvisionHookFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Template is the pod template of the hook Job. The admin kubeconfig of the cluster is mounted into the
+	// containers, with its path set in the KUBECONFIG environment variable.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Template corev1.PodTemplateSpec `json:"template"`
+
+	// BackoffLimit is the number of retries of the hook Job before it is considered failed. The default is 6.
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// ActiveDeadlineSeconds is the timeout of the hook: how long the hook Job may run before it is considered
+	// failed.
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// Timeout is how long the deprovision waits for the hook Job to finish once it has been created. When the hook
+	// is still running after the timeout, the cluster is deprovisioned without waiting for it or the hooks listed
+	// after it. A hook that has failed with the Fail failure policy holds the deprovision regardless of the timeout.
+	// The default is two hours.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // ClusterImageSetReference is a reference to a ClusterImageSet
@@ -239,6 +369,19 @@ type ClusterImageSetReference struct {
 	Name string `json:"name"`
 }
 
+// InstallRetryBackoff is the exponential backoff between attempts to install a cluster.
+type InstallRetryBackoff struct {
+	// InitialDelay is the delay before the second attempt. The delay doubles with each subsequent attempt.
+	// The default is 1m.
+	// +optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+
+	// MaxDelay is the longest delay between attempts.
+	// The default is 24h.
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+}
+
 // ClusterPoolReference is a reference to a ClusterPool
 type ClusterPoolReference struct {
 	// Namespace is the namespace where the ClusterPool resides.
@@ -248,6 +391,77 @@ type ClusterPoolReference struct {
 	// ClaimName is the name of the ClusterClaim that claimed the cluster from the pool.
 	// +optional
 	ClaimName string `json:"claimName,omitempty"`
+	// CustomizationRef is the ClusterPool inventory entry (a ClusterDeploymentCustomization in the namespace of the
+	// ClusterPool) that was used to customize this ClusterDeployment.
+	// +optional
+	CustomizationRef *corev1.LocalObjectReference `json:"customizationRef,omitempty"`
+}
+
+// ClusterExpiration configures when a cluster is deleted. Exactly one of DeleteAt and DeleteAfter must be set.
+type ClusterExpiration struct {
+	// DeleteAt is the time at which the cluster is deleted.
+	// +optional
+	DeleteAt *metav1.Time `json:"deleteAt,omitempty"`
+
+	// DeleteAfter is the duration, from the creation of the ClusterDeployment, after which the cluster is deleted.
+	// +optional
+	DeleteAfter *metav1.Duration `json:"deleteAfter,omitempty"`
+
+	// WarningLeadTimes are the durations before the deletion of the cluster at which the ExpiringSoon condition
+	// is updated and a warning Event is emitted. Defaults to 24h and 1h.
+	// +optional
+	WarningLeadTimes []metav1.Duration `json:"warningLeadTimes,omitempty"`
+}
+
+// ClusterUpgrade is the release an installed cluster is upgraded to. Exactly one of Version and ImageSetRef must be
+// set.
+type ClusterUpgrade struct {
+	// Version is the OpenShift version to upgrade to. It must be one of the available updates of the cluster.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// ImageSetRef is a reference to a ClusterImageSet whose release image the cluster is upgraded to. The version of
+	// the release image must be known, either from the available updates or update history of the cluster, or from
+	// the tag of the image.
+	// +optional
+	ImageSetRef *ClusterImageSetReference `json:"imageSetRef,omitempty"`
+
+	// Force makes the cluster upgrade even when the release image cannot be verified, or when the upgrade is
+	// blocked by the preconditions of the cluster version operator.
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// ClusterUpgradeHistory is an upgrade of a cluster, as reported by the ClusterVersion of the cluster.
+type ClusterUpgradeHistory struct {
+	// State is Completed when the upgrade has finished, or Partial when it is still in progress or was abandoned
+	// for another upgrade.
+	State string `json:"state"`
+
+	// Version is the version the cluster is upgraded to.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Image is the release image the cluster is upgraded to.
+	Image string `json:"image"`
+
+	// StartedTime is the time at which the upgrade started.
+	StartedTime metav1.Time `json:"startedTime"`
+
+	// CompletionTime is the time at which the upgrade finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ClusterAdoption contains the references needed to adopt a pre-existing cluster.
+type ClusterAdoption struct {
+	// AdminKubeconfigSecretRef references the secret containing the admin kubeconfig for the cluster.
+	AdminKubeconfigSecretRef corev1.LocalObjectReference `json:"adminKubeconfigSecretRef"`
+
+	// AdminPasswordSecretRef references the secret containing the admin username/password which can be used to
+	// login to the web console of the cluster.
+	// +optional
+	AdminPasswordSecretRef *corev1.LocalObjectReference `json:"adminPasswordSecretRef,omitempty"`
 }
 
 // ClusterMetadata contains metadata information about the installed cluster.
@@ -308,6 +522,16 @@ type ClusterDeploymentStatus struct {
 	// InstalledTimestamp is the time we first detected that the cluster has been successfully installed.
 	InstalledTimestamp *metav1.Time `json:"installedTimestamp,omitempty"`
 
+	// ExpirationTimestamp is the time at which the cluster will be deleted, computed from the Expiration of the
+	// spec or the hive.openshift.io/delete-after annotation.
+	// +optional
+	ExpirationTimestamp *metav1.Time `json:"expirationTimestamp,omitempty"`
+
+	// UpgradeHistory contains the most recent upgrades of the cluster, newest first, as reported by the
+	// ClusterVersion of the cluster. It is kept while Spec.Upgrade is set.
+	// +optional
+	UpgradeHistory []ClusterUpgradeHistory `json:"upgradeHistory,omitempty"`
+
 	// ProvisionRef is a reference to the last ClusterProvision created for the deployment
 	// +optional
 	ProvisionRef *corev1.LocalObjectReference `json:"provisionRef,omitempty"`
@@ -396,6 +620,37 @@ const (
 	// and the controllers can begin the cluster install.
 	RequirementsMetCondition ClusterDeploymentConditionType = "RequirementsMet"
 
+	// PreflightChecksPassedCondition records the result of the cloud quota and permission checks run before a
+	// provision is started. Its LastProbeTime is the time at which the checks were last run.
+	PreflightChecksPassedCondition ClusterDeploymentConditionType = "PreflightChecksPassed"
+
+	// ProvisionHookFailedCondition is set True when a provision hook with the Fail failure policy has failed,
+	// which stops the install.
+	ProvisionHookFailedCondition ClusterDeploymentConditionType = "ProvisionHookFailed"
+
+	// PreDeprovisionHooksCompleteCondition is set False while the pre-deprovision hooks of a deleted
+	// ClusterDeployment are running or when a hook with the Fail failure policy has failed, and True once all of
+	// the hooks have finished and the cluster can be deprovisioned.
+	PreDeprovisionHooksCompleteCondition ClusterDeploymentConditionType = "PreDeprovisionHooksComplete"
+
+	// ProvisionDryRunCompleteCondition is set True when a dry run provision has rendered the install assets.
+	ProvisionDryRunCompleteCondition ClusterDeploymentConditionType = "ProvisionDryRunComplete"
+
+	// ClusterExpiringSoonCondition is set True when the cluster is within the largest warning lead time of its
+	// expiration.
+	ClusterExpiringSoonCondition ClusterDeploymentConditionType = "ExpiringSoon"
+
+	// ClusterAdoptionFailedCondition is set True when Hive cannot read the metadata of a cluster being adopted.
+	ClusterAdoptionFailedCondition ClusterDeploymentConditionType = "ClusterAdoptionFailed"
+
+	// UpgradingCondition is set True while the cluster is upgrading to the release of Spec.Upgrade, and False once
+	// the cluster has reached it.
+	UpgradingCondition ClusterDeploymentConditionType = "Upgrading"
+
+	// UpgradeFailedCondition is set True when the upgrade of the cluster to the release of Spec.Upgrade cannot be
+	// started, or the cluster version operator reports that it is failing.
+	UpgradeFailedCondition ClusterDeploymentConditionType = "UpgradeFailed"
+
 	// AuthenticationFailureCondition is true when platform credentials cannot be used because of authentication failure
 	AuthenticationFailureClusterDeploymentCondition ClusterDeploymentConditionType = "AuthenticationFailure"
 
@@ -424,6 +679,8 @@ var PositivePolarityClusterDeploymentConditions = []ClusterDeploymentConditionTy
 	ClusterInstallCompletedClusterDeploymentCondition,
 	ClusterInstallRequirementsMetClusterDeploymentCondition,
 	RequirementsMetCondition,
+	ProvisionDryRunCompleteCondition,
+	PreDeprovisionHooksCompleteCondition,
 }
 
 // Cluster hibernating reasons
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterDeploymentCustomizationSpec defines the desired state of ClusterDeploymentCustomization.
+type ClusterDeploymentCustomizationSpec struct {
+	// InstallConfigPatches is a list of patches to be applied to the install-config of a ClusterDeployment
+	// created from a ClusterPool that consumes this customization.
+	// +optional
+	InstallConfigPatches []PatchEntity `json:"installConfigPatches,omitempty"`
+}
+
+// PatchEntity represents a JSON patch (RFC 6902) operation to be applied to an install-config.
+type PatchEntity struct {
+	// Op is the operation to perform: add, remove, replace, move, copy, test
+	// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
+	// +required
+	Op string `json:"op"`
+	// Path is the JSON path to the value to be modified
+	// +required
+	Path string `json:"path"`
+	// From is the JSON path from which to move or copy a value. Only used with the move and copy operations.
+	// +optional
+	From string `json:"from,omitempty"`
+	// Value is the value to be used in the operation
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// ClusterDeploymentCustomizationStatus defines the observed state of ClusterDeploymentCustomization.
+type ClusterDeploymentCustomizationStatus struct {
+	// ClusterDeploymentRef is a reference to the ClusterDeployment that this customization was applied to.
+	// The ClusterDeployment is expected to reside in a namespace of the same name.
+	// +optional
+	ClusterDeploymentRef *corev1.LocalObjectReference `json:"clusterDeploymentRef,omitempty"`
+
+	// ClusterPoolRef is a reference to the ClusterPool that consumed this customization.
+	// +optional
+	ClusterPoolRef *corev1.LocalObjectReference `json:"clusterPoolRef,omitempty"`
+
+	// LastAppliedTime is the last time the customization was applied to a ClusterDeployment.
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentCustomization is an entry in a ClusterPool inventory. It holds cluster-specific settings
+// which are applied to the install-config of a single ClusterDeployment created for the pool. The
+// customization is in use for as long as the ClusterDeployment it was applied to exists.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ClusterPool",type="string",JSONPath=".status.clusterPoolRef.name"
+// +kubebuilder:printcolumn:name="ClusterDeployment",type="string",JSONPath=".status.clusterDeploymentRef.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=clusterdeploymentcustomizations,scope=Namespaced
+type ClusterDeploymentCustomization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterDeploymentCustomizationSpec   `json:"spec"`
+	Status ClusterDeploymentCustomizationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentCustomizationList contains a list of ClusterDeploymentCustomizations.
+type ClusterDeploymentCustomizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDeploymentCustomization `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDeploymentCustomization{}, &ClusterDeploymentCustomizationList{})
+}
@@ -16,6 +16,13 @@ type ClusterDeprovisionSpec struct {
 
 	// Platform contains platform-specific configuration for a ClusterDeprovision
 	Platform ClusterDeprovisionPlatform `json:"platform,omitempty"`
+
+	// DryRun lists the cloud resources that the deprovision would delete without deleting anything. The list is
+	// stored in a ConfigMap referenced by the status, along with a count of the resources of each type. A dry run
+	// does not require an owning ClusterDeployment. Dry runs are supported on AWS, Azure and GCP. DryRun cannot be
+	// changed once the ClusterDeprovision has been created.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // ClusterDeprovisionStatus defines the observed state of ClusterDeprovision
@@ -26,6 +33,28 @@ type ClusterDeprovisionStatus struct {
 	// Conditions includes more detailed status for the cluster deprovision
 	// +optional
 	Conditions []ClusterDeprovisionCondition `json:"conditions,omitempty"`
+
+	// DryRunResultRef references the ConfigMap holding the list of the resources found by a dry run.
+	// +optional
+	DryRunResultRef *corev1.LocalObjectReference `json:"dryRunResultRef,omitempty"`
+
+	// DryRunResources is the number of resources of each type found by a dry run.
+	// +optional
+	DryRunResources []DeprovisionResourceCount `json:"dryRunResources,omitempty"`
+
+	// LeakedResources is the number of resources of each type that were still found once the deprovision had
+	// finished.
+	// +optional
+	LeakedResources []DeprovisionResourceCount `json:"leakedResources,omitempty"`
+}
+
+// DeprovisionResourceCount is the number of cloud resources of a type.
+type DeprovisionResourceCount struct {
+	// Type is the platform-specific type of the resources, for example ec2:instance on AWS.
+	Type string `json:"type"`
+
+	// Count is the number of resources of the type.
+	Count int `json:"count"`
 }
 
 // ClusterDeprovisionPlatform contains platform-specific configuration for the
@@ -119,6 +148,7 @@ type OvirtClusterDeprovision struct {
 // +kubebuilder:printcolumn:name="InfraID",type="string",JSONPath=".spec.infraID"
 // +kubebuilder:printcolumn:name="ClusterID",type="string",JSONPath=".spec.clusterID"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="DryRun",type="boolean",JSONPath=".spec.dryRun"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:path=clusterdeprovisions,shortName=cdr,scope=Namespaced
 type ClusterDeprovision struct {
@@ -158,6 +188,14 @@ const (
 
 	// DeprovisionFailedClusterDeprovisionCondition is true when deprovision attempt failed
 	DeprovisionFailedClusterDeprovisionCondition ClusterDeprovisionConditionType = "DeprovisionFailed"
+
+	// DryRunCompleteClusterDeprovisionCondition is true when a dry run has listed the resources the deprovision
+	// would delete.
+	DryRunCompleteClusterDeprovisionCondition ClusterDeprovisionConditionType = "DryRunComplete"
+
+	// ResourcesLeakedClusterDeprovisionCondition is true when resources of the cluster were still found once the
+	// deprovision had finished. It is unknown while the scan for them is pending, or if the scan could not be done.
+	ResourcesLeakedClusterDeprovisionCondition ClusterDeprovisionConditionType = "ResourcesLeaked"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
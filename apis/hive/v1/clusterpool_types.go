@@ -12,6 +12,14 @@ type ClusterPoolSpec struct {
 	// +required
 	Platform Platform `json:"platform"`
 
+	// PlatformVariants is a weighted list of variants of Platform, each with its own region and credentials, across
+	// which the clusters of the pool are spread. When set, each new cluster is created with the region and
+	// credentials of one of the variants, chosen in proportion to their weights. Variants that keep failing to
+	// provision are skipped for a time that grows with each failure, while there are other variants to use.
+	// Only supported for AWS, Azure and GCP.
+	// +optional
+	PlatformVariants []ClusterPoolPlatformVariant `json:"platformVariants,omitempty"`
+
 	// PullSecretRef is the reference to the secret to use when pulling images.
 	// +optional
 	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
@@ -21,6 +29,13 @@ type ClusterPoolSpec struct {
 	// +required
 	Size int32 `json:"size"`
 
+	// RunningCount is the number of unclaimed clusters in the pool that are kept running, so that they are ready to
+	// use as soon as they are claimed. The remaining unclaimed clusters are kept hibernating.
+	// By default no unclaimed clusters are kept running.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunningCount int32 `json:"runningCount,omitempty"`
+
 	// MaxSize is the maximum number of clusters that will be provisioned including clusters that have been claimed
 	// and ones waiting to be used.
 	// By default there is no limit.
@@ -33,6 +48,16 @@ type ClusterPoolSpec struct {
 	// +optional
 	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
 
+	// MaxStaleReplacements is the maximum number of stale clusters that will be replaced at a time. An unclaimed
+	// cluster is stale when the pool spec has changed since the cluster was created in a way that affects how clusters
+	// are installed, for example the ImageSetRef, the install-config template, or the platform. A stale cluster is
+	// only deleted when the pool is otherwise at its desired size, and the replacements still installing count
+	// against this limit, so that the capacity of the pool is kept up while clusters are replaced.
+	// By default stale clusters are replaced one at a time. Set to 0 to not replace stale clusters.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxStaleReplacements *int32 `json:"maxStaleReplacements,omitempty"`
+
 	// BaseDomain is the base domain to use for all clusters created in this pool.
 	// +required
 	BaseDomain string `json:"baseDomain"`
@@ -71,6 +96,185 @@ type ClusterPoolSpec struct {
 	// ClaimLifetime defines the lifetimes for claims for the cluster pool.
 	// +optional
 	ClaimLifetime *ClusterPoolClaimLifetime `json:"claimLifetime,omitempty"`
+
+	// Inventory maintains a list of entries consumed by the ClusterPool to customize the default ClusterDeployment.
+	// When set, each new cluster in the pool consumes one unused entry, and the pool will not grow beyond the number
+	// of entries available. An entry is released when the ClusterDeployment that consumed it is deleted.
+	// +optional
+	Inventory []InventoryEntry `json:"inventory,omitempty"`
+
+	// Schedules is a list of time windows during which Size and MaxConcurrent are overridden. When more than one
+	// schedule is active, the first one in the list takes precedence. Outside of all schedules the pool uses Size
+	// and MaxConcurrent.
+	// +optional
+	Schedules []ClusterPoolSchedule `json:"schedules,omitempty"`
+
+	// ClaimQuotas caps the number of clusters that may be claimed from the pool at the same time by the claims of a
+	// namespace, user or group. Claims that would exceed a quota remain pending until clusters claimed under the quota
+	// are released.
+	// +optional
+	ClaimQuotas []ClusterPoolClaimQuota `json:"claimQuotas,omitempty"`
+
+	// MaxUnclaimedAge is the maximum age of an unclaimed cluster in the pool. Unclaimed clusters older than this are
+	// deleted and replaced, so that clusters do not sit in the pool until, for example, their certificates expire.
+	// +optional
+	MaxUnclaimedAge *metav1.Duration `json:"maxUnclaimedAge,omitempty"`
+
+	// BrokenClusterPolicy determines when unclaimed clusters that are failing are deleted and replaced. When not
+	// set, failing clusters are left in the pool.
+	// +optional
+	BrokenClusterPolicy *ClusterPoolBrokenClusterPolicy `json:"brokenClusterPolicy,omitempty"`
+
+	// Autoscaling, when set, has the pool choose its own size, in place of Size, from the rate at which clusters
+	// have recently been claimed and from how long its clusters take to provision. An active schedule still takes
+	// precedence.
+	// +optional
+	Autoscaling *ClusterPoolAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// ClusterPoolAutoscaling configures the autoscaling of a ClusterPool.
+type ClusterPoolAutoscaling struct {
+	// MinSize is the smallest size to which the pool will be scaled.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MinSize int32 `json:"minSize"`
+
+	// MaxSize is the largest size to which the pool will be scaled.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MaxSize int32 `json:"maxSize"`
+
+	// Window is how far back claims are counted to measure the rate at which clusters are claimed.
+	// The default is 1h.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// ClusterPoolBrokenClusterPolicy determines when unclaimed clusters in a ClusterPool are considered broken.
+type ClusterPoolBrokenClusterPolicy struct {
+	// Threshold is how long an unclaimed cluster must have been failing before it is considered broken. A cluster is
+	// failing while its ProvisionFailed or ProvisionStopped condition is true or, for a running cluster, while its
+	// Unreachable condition is true.
+	// +required
+	Threshold metav1.Duration `json:"threshold"`
+}
+
+// ClusterPoolRecycleReason is the reason for which an unclaimed cluster in a ClusterPool was recycled.
+type ClusterPoolRecycleReason string
+
+const (
+	// MaxUnclaimedAgeExceededRecycleReason is used for clusters that were older than the MaxUnclaimedAge of the pool.
+	MaxUnclaimedAgeExceededRecycleReason ClusterPoolRecycleReason = "MaxUnclaimedAgeExceeded"
+	// ProvisionFailedRecycleReason is used for clusters whose ProvisionFailed condition was true beyond the threshold.
+	ProvisionFailedRecycleReason ClusterPoolRecycleReason = "ProvisionFailed"
+	// ProvisionStoppedRecycleReason is used for clusters whose ProvisionStopped condition was true beyond the
+	// threshold.
+	ProvisionStoppedRecycleReason ClusterPoolRecycleReason = "ProvisionStopped"
+	// UnreachableRecycleReason is used for running clusters whose Unreachable condition was true beyond the threshold.
+	UnreachableRecycleReason ClusterPoolRecycleReason = "Unreachable"
+)
+
+// ClusterPoolRecycledClusters is the number of unclaimed clusters recycled by a ClusterPool for a reason.
+type ClusterPoolRecycledClusters struct {
+	// Reason is the reason for which the clusters were recycled.
+	Reason ClusterPoolRecycleReason `json:"reason"`
+	// Count is the number of clusters recycled for the reason.
+	Count int32 `json:"count"`
+}
+
+// ClusterPoolClaimQuota caps the number of clusters claimed from a ClusterPool by the claims made in a namespace, or
+// requested by a user or the members of a group. The requester of a claim is recorded on the claim when it is created.
+// Exactly one of Namespace, User and Group must be set.
+type ClusterPoolClaimQuota struct {
+	// Namespace applies the quota to the claims in the namespace. Claims are always made in the namespace of their
+	// pool, so this must be the namespace of the pool, and the quota then applies to all the claims for the pool.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// User applies the quota to the claims created by the user.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// Group applies the quota to the claims created by members of the group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// MaxClaimed is the maximum number of clusters that may be claimed from the pool at the same time by the claims
+	// to which the quota applies.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MaxClaimed int32 `json:"maxClaimed"`
+}
+
+// ClusterPoolPlatformVariant overrides the region and credentials of the platform of a ClusterPool for some of the
+// clusters of the pool.
+type ClusterPoolPlatformVariant struct {
+	// Name identifies the variant.
+	// +required
+	Name string `json:"name"`
+
+	// Weight is the share of the clusters of the pool to create with the variant, relative to the weights of the
+	// other variants. New clusters are not created with a variant of weight 0. The default is 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// Region overrides the region of the platform.
+	// +required
+	Region string `json:"region"`
+
+	// CredentialsSecretRef overrides the secret holding the cloud credentials of the platform. When not set, the
+	// credentials of the platform are used.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// ClusterPoolSchedule overrides the size of a ClusterPool during a recurring time window.
+type ClusterPoolSchedule struct {
+	// Name identifies the schedule.
+	// +required
+	Name string `json:"name"`
+
+	// Start is a cron expression for the times at which the window starts, for example "0 8 * * 1-5" for 8am on
+	// weekdays. The expression is evaluated in UTC unless it is prefixed with CRON_TZ=<time zone>.
+	// +required
+	Start string `json:"start"`
+
+	// Duration is how long the window lasts after each start time.
+	// +required
+	Duration metav1.Duration `json:"duration"`
+
+	// Size is the number of clusters that we should keep provisioned and waiting for use while the schedule is
+	// active.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	Size int32 `json:"size"`
+
+	// MaxConcurrent is the maximum number of clusters that will be provisioned or deprovisioned at a time while the
+	// schedule is active. When not set, MaxConcurrent from the ClusterPool spec is used.
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+}
+
+// InventoryEntryKind is the Kind of the inventory entry.
+// +kubebuilder:validation:Enum="";ClusterDeploymentCustomization
+type InventoryEntryKind string
+
+const (
+	// ClusterDeploymentCustomizationInventoryEntry is an inventory entry referring to a
+	// ClusterDeploymentCustomization in the namespace of the ClusterPool.
+	ClusterDeploymentCustomizationInventoryEntry InventoryEntryKind = "ClusterDeploymentCustomization"
+)
+
+// InventoryEntry maintains a reference to a custom resource consumed by a clusterpool to customize the cluster deployment.
+type InventoryEntry struct {
+	// Kind denotes the kind of the referenced resource. The default is ClusterDeploymentCustomization, which is also
+	// currently the only supported value.
+	// +optional
+	Kind InventoryEntryKind `json:"kind,omitempty"`
+	// Name is the name of the referenced resource.
+	// +required
+	Name string `json:"name"`
 }
 
 // ClusterPoolClaimLifetime defines the lifetimes for claims for the cluster pool.
@@ -94,11 +298,102 @@ type ClusterPoolStatus struct {
 	// Ready is the number of unclaimed clusters that have been installed and are ready to be claimed.
 	Ready int32 `json:"ready"`
 
+	// Running is the number of unclaimed clusters that have been installed and are running, and so can be used as
+	// soon as they are claimed. This is a subset of Ready.
+	// +optional
+	Running int32 `json:"running,omitempty"`
+
+	// Stale is the number of unclaimed clusters that were created from an older version of the pool spec and are
+	// due to be replaced.
+	// +optional
+	Stale int32 `json:"stale,omitempty"`
+
+	// Hibernating is the number of unclaimed clusters that have been installed and are hibernating, or are in the
+	// process of hibernating or resuming. This is a subset of Ready.
+	// +optional
+	Hibernating int32 `json:"hibernating,omitempty"`
+
+	// PlatformVariants is the number of unclaimed clusters in the pool created from each platform variant.
+	// +optional
+	PlatformVariants []ClusterPoolPlatformVariantStatus `json:"platformVariants,omitempty"`
+
+	// Recycled is the number of unclaimed clusters that the pool has deleted and replaced because they were too old
+	// or broken, by reason.
+	// +optional
+	Recycled []ClusterPoolRecycledClusters `json:"recycled,omitempty"`
+
+	// ActiveSchedule is the name of the schedule currently overriding the size of the pool, if any.
+	// +optional
+	ActiveSchedule string `json:"activeSchedule,omitempty"`
+
+	// Autoscaling is the state of the autoscaling of the pool, when enabled.
+	// +optional
+	Autoscaling *ClusterPoolAutoscalingStatus `json:"autoscaling,omitempty"`
+
 	// Conditions includes more detailed status for the cluster pool
 	// +optional
 	Conditions []ClusterPoolCondition `json:"conditions,omitempty"`
 }
 
+// ClusterPoolAutoscalingStatus is the observed state of the autoscaling of a ClusterPool.
+type ClusterPoolAutoscalingStatus struct {
+	// TargetSize is the size chosen for the pool.
+	TargetSize int32 `json:"targetSize"`
+
+	// RecentClaims counts the claims recently assigned clusters from the pool, within the autoscaling window, by the
+	// interval in which they were assigned.
+	// +optional
+	RecentClaims []ClusterPoolClaimCount `json:"recentClaims,omitempty"`
+
+	// ProvisionTime is the average time taken by the clusters of the pool to provision.
+	// +optional
+	ProvisionTime *metav1.Duration `json:"provisionTime,omitempty"`
+
+	// Message explains how the target size was chosen.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterPoolClaimCount is the number of claims assigned clusters from a ClusterPool during an interval.
+type ClusterPoolClaimCount struct {
+	// Start is the start of the interval.
+	Start metav1.Time `json:"start"`
+
+	// Count is the number of claims assigned clusters during the interval.
+	Count int32 `json:"count"`
+}
+
+// ClusterPoolPlatformVariantStatus is the observed state of a platform variant of a ClusterPool.
+type ClusterPoolPlatformVariantStatus struct {
+	// Name is the name of the variant.
+	Name string `json:"name"`
+
+	// Size is the number of unclaimed clusters in the pool created from the variant.
+	Size int32 `json:"size"`
+
+	// Ready is the number of unclaimed clusters created from the variant that are ready to be claimed.
+	Ready int32 `json:"ready"`
+
+	// Failing is true when clusters created from the variant keep failing to provision, in which case the variant is
+	// skipped when creating new clusters until RetryTime.
+	// +optional
+	Failing bool `json:"failing,omitempty"`
+
+	// ConsecutiveFailures is the number of times in a row that clusters created from the variant have been found to
+	// keep failing to provision. Each failure doubles the time for which the variant is skipped. It is reset once a
+	// cluster created from the variant installs after the last failure.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// LastFailureTime is the last time that clusters created from the variant were found to keep failing to provision.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// RetryTime is the time from which a failing variant is used again to create new clusters.
+	// +optional
+	RetryTime *metav1.Time `json:"retryTime,omitempty"`
+}
+
 // ClusterPoolCondition contains details for the current condition of a cluster pool
 type ClusterPoolCondition struct {
 	// Type is the type of the condition.
@@ -129,6 +424,12 @@ const (
 	// ClusterPoolCapacityAvailableCondition is set to provide information on whether the cluster pool has capacity
 	// available to create more clusters for the pool.
 	ClusterPoolCapacityAvailableCondition ClusterPoolConditionType = "CapacityAvailable"
+	// ClusterPoolInventoryValidCondition is set to provide information on whether the cluster pool inventory is valid.
+	// The inventory is invalid when an entry refers to a resource that does not exist or is of an unsupported kind.
+	ClusterPoolInventoryValidCondition ClusterPoolConditionType = "InventoryValid"
+	// ClusterPoolScheduleActiveCondition is set to provide information on whether one of the schedules of the
+	// cluster pool is currently overriding its size.
+	ClusterPoolScheduleActiveCondition ClusterPoolConditionType = "ScheduleActive"
 )
 
 // +genclient
@@ -140,6 +441,7 @@ const (
 // +kubebuilder:subresource:status
 // +kubebuilder:subresource:scale:specpath=.spec.size,statuspath=.status.size
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Running",type="string",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".spec.size"
 // +kubebuilder:printcolumn:name="BaseDomain",type="string",JSONPath=".spec.baseDomain"
 // +kubebuilder:printcolumn:name="ImageSet",type="string",JSONPath=".spec.imageSetRef.name"
@@ -44,6 +44,11 @@ type ClusterProvisionSpec struct {
 
 	// PrevInfraID is the infra ID of the previous failed provision attempt.
 	PrevInfraID *string `json:"prevInfraID,omitempty"`
+
+	// DryRun is true for provisions that only render the install-config and manifests, without creating
+	// any infrastructure.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // ClusterProvisionStatus defines the observed state of ClusterProvision.
@@ -54,8 +59,63 @@ type ClusterProvisionStatus struct {
 	// Conditions includes more detailed status for the cluster provision
 	// +optional
 	Conditions []ClusterProvisionCondition `json:"conditions,omitempty"`
+
+	// RetryPolicy is the retry policy for the failure of a failed provision, as determined from the install log.
+	// +optional
+	RetryPolicy InstallFailureRetryPolicy `json:"retryPolicy,omitempty"`
+
+	// InstallPhases are the phases of the install reached so far, in the order they were reached, as reported
+	// by the installer.
+	// +optional
+	InstallPhases []InstallPhase `json:"installPhases,omitempty"`
+
+	// DryRunResultRef references the ConfigMap holding the redacted install-config, the list of manifests and
+	// the release image rendered by a dry run provision.
+	// +optional
+	DryRunResultRef *corev1.LocalObjectReference `json:"dryRunResultRef,omitempty"`
 }
 
+// InstallPhaseName is the name of a phase of the install.
+type InstallPhaseName string
+
+const (
+	// InfrastructureInstallPhase is the creation of the cloud infrastructure of the cluster.
+	InfrastructureInstallPhase InstallPhaseName = "Infrastructure"
+	// WaitingForAPIInstallPhase is the wait for the Kubernetes API of the cluster to come up on the bootstrap machine.
+	WaitingForAPIInstallPhase InstallPhaseName = "WaitingForAPI"
+	// BootstrapInstallPhase is the wait for the control plane to take over from the bootstrap machine, and the
+	// removal of the bootstrap resources.
+	BootstrapInstallPhase InstallPhaseName = "Bootstrap"
+	// ClusterOperatorsInstallPhase is the wait for the cluster operators to become available.
+	ClusterOperatorsInstallPhase InstallPhaseName = "ClusterOperators"
+)
+
+// InstallPhase is a phase of the install and the time it started and completed.
+type InstallPhase struct {
+	// Name is the name of the phase.
+	Name InstallPhaseName `json:"name"`
+	// StartTime is the time the phase started.
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is the time the phase completed. It is not set while the phase is in progress, or when the
+	// install failed during the phase.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// InstallFailureRetryPolicy determines whether, and how soon, a failed install is retried.
+// +kubebuilder:validation:Enum="";Retry;RetryWithBackoff;Stop
+type InstallFailureRetryPolicy string
+
+const (
+	// RetryInstallFailureRetryPolicy retries the install immediately.
+	RetryInstallFailureRetryPolicy InstallFailureRetryPolicy = "Retry"
+	// RetryWithBackoffInstallFailureRetryPolicy retries the install after an exponentially increasing delay. This is
+	// the policy used for failures without a retry policy.
+	RetryWithBackoffInstallFailureRetryPolicy InstallFailureRetryPolicy = "RetryWithBackoff"
+	// StopInstallFailureRetryPolicy does not retry the install, as the failure is not expected to go away on a retry.
+	StopInstallFailureRetryPolicy InstallFailureRetryPolicy = "Stop"
+)
+
 // ClusterProvisionStage is the stage of provisioning.
 type ClusterProvisionStage string
 
@@ -136,6 +136,25 @@ type HiveConfigSpec struct {
 	// If not set, no verification will be performed.
 	// +optional
 	ReleaseImageVerificationConfigMapRef *ReleaseImageVerificationConfigMapReference `json:"releaseImageVerificationConfigMapRef,omitempty"`
+
+	// RedactionRulesConfigMapRef is a reference to a ConfigMap in the TargetNamespace holding additional rules
+	// for redacting sensitive text, such as internal hostnames, tokens and proxy credentials, from install logs
+	// and condition messages. The ConfigMap must have a "rules" key containing a yaml list of rules, each with a
+	// name, a regex and an optional replacement which defaults to REDACTED. The hive controllers are restarted when
+	// the ConfigMap changes. The controllers are not deployed, and do not start, while the ConfigMap is missing or
+	// malformed.
+	// +optional
+	RedactionRulesConfigMapRef *corev1.LocalObjectReference `json:"redactionRulesConfigMapRef,omitempty"`
+
+	// ClusterExpiration configures the policies enforced on the expiration of ClusterDeployments.
+	// +optional
+	ClusterExpiration *ClusterExpirationConfig `json:"clusterExpiration,omitempty"`
+
+	// OrphanedInfraReaper enables the periodic search for cloud infrastructure of clusters that no longer have a
+	// ClusterDeployment. Currently only AWS is searched.
+	// +optional
+	OrphanedInfraReaper *OrphanedInfraReaperConfig `json:"orphanedInfraReaper,omitempty"`
+
 	// ArgoCD specifies configuration for ArgoCD integration. If enabled, Hive will automatically add provisioned
 	// clusters to ArgoCD, and remove them when they are deprovisioned.
 	ArgoCD ArgoCDConfig `json:"argoCDConfig,omitempty"`
@@ -143,6 +162,28 @@ type HiveConfigSpec struct {
 	FeatureGates *FeatureGateSelection `json:"featureGates,omitempty"`
 }
 
+// ClusterExpirationConfig contains the policies enforced on the expiration of ClusterDeployments.
+type ClusterExpirationConfig struct {
+	// MaxExtension is the furthest the deletion time of a ClusterDeployment may be moved past its original deletion
+	// time, in total over all updates, or set for a cluster which had none from the time of the update.
+	// If not set, expirations can be extended without limit.
+	// +optional
+	MaxExtension *metav1.Duration `json:"maxExtension,omitempty"`
+}
+
+// OrphanedInfraReaperConfig contains the configuration of the search for orphaned cloud infrastructure.
+type OrphanedInfraReaperConfig struct {
+	// ScanInterval is the time between searches for orphaned infrastructure. Defaults to 6h.
+	// +optional
+	ScanInterval *metav1.Duration `json:"scanInterval,omitempty"`
+
+	// DeprovisionAfter, if set, is how long infrastructure must have been found orphaned before a ClusterDeprovision
+	// is created to delete it. Only infrastructure of clusters which Hive has a record of managing is deleted. If not
+	// set, orphaned infrastructure is only reported.
+	// +optional
+	DeprovisionAfter *metav1.Duration `json:"deprovisionAfter,omitempty"`
+}
+
 // ReleaseImageVerificationConfigMapReference is a reference to the ConfigMap that
 // will be used to verify release images.
 type ReleaseImageVerificationConfigMapReference struct {
@@ -362,6 +403,20 @@ type FailedProvisionConfig struct {
 	// DEPRECATED: This flag is no longer respected and will be removed in the future.
 	SkipGatherLogs bool                      `json:"skipGatherLogs,omitempty"`
 	AWS            *FailedProvisionAWSConfig `json:"aws,omitempty"`
+
+	// GCP contains settings to upload the logs of failed installs to a GCS bucket.
+	// +optional
+	GCP *FailedProvisionGCPConfig `json:"gcp,omitempty"`
+
+	// Azure contains settings to upload the logs of failed installs to an Azure Blob storage container.
+	// +optional
+	Azure *FailedProvisionAzureConfig `json:"azure,omitempty"`
+
+	// PersistentVolumeClaim contains settings to copy the logs of failed installs to a persistent volume.
+	// +optional
+	PersistentVolumeClaim *FailedProvisionPersistentVolumeClaimConfig `json:"persistentVolumeClaim,omitempty"`
+
+	// Only one of the above may be configured at a time.
 }
 
 // ManageDNSConfig contains the domain being managed, and the cloud-specific
@@ -412,6 +467,40 @@ type FailedProvisionAWSConfig struct {
 	Bucket string `json:"bucket,omitempty"`
 }
 
+// FailedProvisionGCPConfig contains GCP-specific info to upload log files.
+type FailedProvisionGCPConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// GCS. It will need permission to create objects in the bucket.
+	// Secret should have a key named osServiceAccount.json containing the service account key.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Bucket is the GCS bucket to store the logs in.
+	Bucket string `json:"bucket"`
+}
+
+// FailedProvisionAzureConfig contains Azure-specific info to upload log files.
+type FailedProvisionAzureConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Azure Blob storage. The service principal will need the Storage Blob Data Contributor role on the container.
+	// Secret should have a key named osServicePrincipal.json containing the service principal.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// StorageAccount is the name of the storage account of the container.
+	StorageAccount string `json:"storageAccount"`
+
+	// Container is the Blob storage container to store the logs in.
+	Container string `json:"container"`
+}
+
+// FailedProvisionPersistentVolumeClaimConfig contains info to copy log files to a persistent volume.
+type FailedProvisionPersistentVolumeClaimConfig struct {
+	// ClaimName is the name of the PersistentVolumeClaim in the namespace of each ClusterDeployment to copy logs to.
+	// The claim is mounted into the install pods, so it needs the ReadWriteMany access mode for installs of
+	// ClusterDeployments in the same namespace to run at the same time. Logs of failed installs in namespaces
+	// without the claim are not kept.
+	ClaimName string `json:"claimName"`
+}
+
 // ManageDNSAWSConfig contains AWS-specific info to manage a given domain.
 type ManageDNSAWSConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -523,6 +612,7 @@ const (
 	UnreachableControllerName          ControllerName = "unreachable"
 	VeleroBackupControllerName         ControllerName = "velerobackup"
 	MetricsControllerName              ControllerName = "metrics"
+	OrphanedInfraControllerName        ControllerName = "orphanedInfra"
 	ClustersyncControllerName          ControllerName = "clustersync"
 	MachineManagementControllerName    ControllerName = "machineManagement"
 	AWSPrivateLinkControllerName       ControllerName = "awsprivatelink"
@@ -234,6 +234,22 @@ func (in *AWSServiceProviderCredentials) DeepCopy() *AWSServiceProviderCredentia
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfig) DeepCopyInto(out *ArgoCDConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfig.
+func (in *ArgoCDConfig) DeepCopy() *ArgoCDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureClusterDeprovision) DeepCopyInto(out *AzureClusterDeprovision) {
 	*out = *in
@@ -470,6 +486,28 @@ func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAdoption) DeepCopyInto(out *ClusterAdoption) {
+	*out = *in
+	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAdoption.
+func (in *ClusterAdoption) DeepCopy() *ClusterAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
 	*out = *in
@@ -552,6 +590,11 @@ func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 	*out = *in
+	if in.ClusterPoolSelector != nil {
+		in, out := &in.ClusterPoolSelector, &out.ClusterPoolSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Subjects != nil {
 		in, out := &in.Subjects, &out.Subjects
 		*out = make([]rbacv1.Subject, len(*in))
@@ -578,6 +621,26 @@ func (in *ClusterClaimSpec) DeepCopy() *ClusterClaimSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
 	*out = *in
+	if in.QueuePosition != nil {
+		in, out := &in.QueuePosition, &out.QueuePosition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EstimatedWait != nil {
+		in, out := &in.EstimatedWait, &out.EstimatedWait
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AdminKubeconfigSecretRef != nil {
+		in, out := &in.AdminKubeconfigSecretRef, &out.AdminKubeconfigSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ClusterClaimCondition, len(*in))
@@ -649,6 +712,118 @@ func (in *ClusterDeploymentCondition) DeepCopy() *ClusterDeploymentCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentCustomization) DeepCopyInto(out *ClusterDeploymentCustomization) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomization.
+func (in *ClusterDeploymentCustomization) DeepCopy() *ClusterDeploymentCustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentCustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeploymentCustomization) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentCustomizationList) DeepCopyInto(out *ClusterDeploymentCustomizationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDeploymentCustomization, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomizationList.
+func (in *ClusterDeploymentCustomizationList) DeepCopy() *ClusterDeploymentCustomizationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentCustomizationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeploymentCustomizationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentCustomizationSpec) DeepCopyInto(out *ClusterDeploymentCustomizationSpec) {
+	*out = *in
+	if in.InstallConfigPatches != nil {
+		in, out := &in.InstallConfigPatches, &out.InstallConfigPatches
+		*out = make([]PatchEntity, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomizationSpec.
+func (in *ClusterDeploymentCustomizationSpec) DeepCopy() *ClusterDeploymentCustomizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentCustomizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentCustomizationStatus) DeepCopyInto(out *ClusterDeploymentCustomizationStatus) {
+	*out = *in
+	if in.ClusterDeploymentRef != nil {
+		in, out := &in.ClusterDeploymentRef, &out.ClusterDeploymentRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ClusterPoolRef != nil {
+		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomizationStatus.
+func (in *ClusterDeploymentCustomizationStatus) DeepCopy() *ClusterDeploymentCustomizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentCustomizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDeploymentList) DeepCopyInto(out *ClusterDeploymentList) {
 	*out = *in
@@ -691,6 +866,13 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.PreDeprovisionHooks != nil {
+		in, out := &in.PreDeprovisionHooks, &out.PreDeprovisionHooks
+		*out = make([]PreDeprovisionHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.ControlPlaneConfig.DeepCopyInto(&out.ControlPlaneConfig)
 	if in.Ingress != nil {
 		in, out := &in.Ingress, &out.Ingress
@@ -709,6 +891,11 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(ClusterMetadata)
 		**out = **in
 	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(ClusterAdoption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Provisioning != nil {
 		in, out := &in.Provisioning, &out.Provisioning
 		*out = new(Provisioning)
@@ -722,18 +909,33 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 	if in.ClusterPoolRef != nil {
 		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
 		*out = new(ClusterPoolReference)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HibernateAfter != nil {
 		in, out := &in.HibernateAfter, &out.HibernateAfter
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = new(ClusterExpiration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(ClusterUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InstallAttemptsLimit != nil {
 		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
 		*out = new(int32)
 		**out = **in
 	}
+	if in.InstallRetryBackoff != nil {
+		in, out := &in.InstallRetryBackoff, &out.InstallRetryBackoff
+		*out = new(InstallRetryBackoff)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MachineManagement != nil {
 		in, out := &in.MachineManagement, &out.MachineManagement
 		*out = new(MachineManagement)
@@ -795,6 +997,17 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.ExpirationTimestamp != nil {
+		in, out := &in.ExpirationTimestamp, &out.ExpirationTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.UpgradeHistory != nil {
+		in, out := &in.UpgradeHistory, &out.UpgradeHistory
+		*out = make([]ClusterUpgradeHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ProvisionRef != nil {
 		in, out := &in.ProvisionRef, &out.ProvisionRef
 		*out = new(corev1.LocalObjectReference)
@@ -970,6 +1183,21 @@ func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DryRunResultRef != nil {
+		in, out := &in.DryRunResultRef, &out.DryRunResultRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.DryRunResources != nil {
+		in, out := &in.DryRunResources, &out.DryRunResources
+		*out = make([]DeprovisionResourceCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.LeakedResources != nil {
+		in, out := &in.LeakedResources, &out.LeakedResources
+		*out = make([]DeprovisionResourceCount, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -983,6 +1211,57 @@ func (in *ClusterDeprovisionStatus) DeepCopy() *ClusterDeprovisionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExpiration) DeepCopyInto(out *ClusterExpiration) {
+	*out = *in
+	if in.DeleteAt != nil {
+		in, out := &in.DeleteAt, &out.DeleteAt
+		*out = (*in).DeepCopy()
+	}
+	if in.DeleteAfter != nil {
+		in, out := &in.DeleteAfter, &out.DeleteAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WarningLeadTimes != nil {
+		in, out := &in.WarningLeadTimes, &out.WarningLeadTimes
+		*out = make([]metav1.Duration, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExpiration.
+func (in *ClusterExpiration) DeepCopy() *ClusterExpiration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExpiration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExpirationConfig) DeepCopyInto(out *ClusterExpirationConfig) {
+	*out = *in
+	if in.MaxExtension != nil {
+		in, out := &in.MaxExtension, &out.MaxExtension
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExpirationConfig.
+func (in *ClusterExpirationConfig) DeepCopy() *ClusterExpirationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExpirationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
 	*out = *in
@@ -1221,6 +1500,89 @@ func (in *ClusterPool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscaling) DeepCopyInto(out *ClusterPoolAutoscaling) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscaling.
+func (in *ClusterPoolAutoscaling) DeepCopy() *ClusterPoolAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscalingStatus) DeepCopyInto(out *ClusterPoolAutoscalingStatus) {
+	*out = *in
+	if in.RecentClaims != nil {
+		in, out := &in.RecentClaims, &out.RecentClaims
+		*out = make([]ClusterPoolClaimCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProvisionTime != nil {
+		in, out := &in.ProvisionTime, &out.ProvisionTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscalingStatus.
+func (in *ClusterPoolAutoscalingStatus) DeepCopy() *ClusterPoolAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolBrokenClusterPolicy) DeepCopyInto(out *ClusterPoolBrokenClusterPolicy) {
+	*out = *in
+	out.Threshold = in.Threshold
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolBrokenClusterPolicy.
+func (in *ClusterPoolBrokenClusterPolicy) DeepCopy() *ClusterPoolBrokenClusterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolBrokenClusterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolClaimCount) DeepCopyInto(out *ClusterPoolClaimCount) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimCount.
+func (in *ClusterPoolClaimCount) DeepCopy() *ClusterPoolClaimCount {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolClaimCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
 	*out = *in
@@ -1247,6 +1609,22 @@ func (in *ClusterPoolClaimLifetime) DeepCopy() *ClusterPoolClaimLifetime {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolClaimQuota) DeepCopyInto(out *ClusterPoolClaimQuota) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimQuota.
+func (in *ClusterPoolClaimQuota) DeepCopy() *ClusterPoolClaimQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolClaimQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolCondition) DeepCopyInto(out *ClusterPoolCondition) {
 	*out = *in
@@ -1298,9 +1676,80 @@ func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolPlatformVariant) DeepCopyInto(out *ClusterPoolPlatformVariant) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolPlatformVariant.
+func (in *ClusterPoolPlatformVariant) DeepCopy() *ClusterPoolPlatformVariant {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolPlatformVariant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolPlatformVariantStatus) DeepCopyInto(out *ClusterPoolPlatformVariantStatus) {
+	*out = *in
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RetryTime != nil {
+		in, out := &in.RetryTime, &out.RetryTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolPlatformVariantStatus.
+func (in *ClusterPoolPlatformVariantStatus) DeepCopy() *ClusterPoolPlatformVariantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolPlatformVariantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolRecycledClusters) DeepCopyInto(out *ClusterPoolRecycledClusters) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolRecycledClusters.
+func (in *ClusterPoolRecycledClusters) DeepCopy() *ClusterPoolRecycledClusters {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolRecycledClusters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
 	*out = *in
+	if in.CustomizationRef != nil {
+		in, out := &in.CustomizationRef, &out.CustomizationRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -1314,10 +1763,39 @@ func (in *ClusterPoolReference) DeepCopy() *ClusterPoolReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolSchedule) DeepCopyInto(out *ClusterPoolSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSchedule.
+func (in *ClusterPoolSchedule) DeepCopy() *ClusterPoolSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 	*out = *in
 	in.Platform.DeepCopyInto(&out.Platform)
+	if in.PlatformVariants != nil {
+		in, out := &in.PlatformVariants, &out.PlatformVariants
+		*out = make([]ClusterPoolPlatformVariant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PullSecretRef != nil {
 		in, out := &in.PullSecretRef, &out.PullSecretRef
 		*out = new(corev1.LocalObjectReference)
@@ -1333,6 +1811,11 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxStaleReplacements != nil {
+		in, out := &in.MaxStaleReplacements, &out.MaxStaleReplacements
+		*out = new(int32)
+		**out = **in
+	}
 	out.ImageSetRef = in.ImageSetRef
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
@@ -1363,6 +1846,38 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 		*out = new(ClusterPoolClaimLifetime)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]InventoryEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ClusterPoolSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClaimQuotas != nil {
+		in, out := &in.ClaimQuotas, &out.ClaimQuotas
+		*out = make([]ClusterPoolClaimQuota, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxUnclaimedAge != nil {
+		in, out := &in.MaxUnclaimedAge, &out.MaxUnclaimedAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BrokenClusterPolicy != nil {
+		in, out := &in.BrokenClusterPolicy, &out.BrokenClusterPolicy
+		*out = new(ClusterPoolBrokenClusterPolicy)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1379,6 +1894,23 @@ func (in *ClusterPoolSpec) DeepCopy() *ClusterPoolSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
 	*out = *in
+	if in.PlatformVariants != nil {
+		in, out := &in.PlatformVariants, &out.PlatformVariants
+		*out = make([]ClusterPoolPlatformVariantStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Recycled != nil {
+		in, out := &in.Recycled, &out.Recycled
+		*out = make([]ClusterPoolRecycledClusters, len(*in))
+		copy(*out, *in)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ClusterPoolCondition, len(*in))
@@ -1551,6 +2083,18 @@ func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InstallPhases != nil {
+		in, out := &in.InstallPhases, &out.InstallPhases
+		*out = make([]InstallPhase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DryRunResultRef != nil {
+		in, out := &in.DryRunResultRef, &out.DryRunResultRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -1763,6 +2307,48 @@ func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgrade) DeepCopyInto(out *ClusterUpgrade) {
+	*out = *in
+	if in.ImageSetRef != nil {
+		in, out := &in.ImageSetRef, &out.ImageSetRef
+		*out = new(ClusterImageSetReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgrade.
+func (in *ClusterUpgrade) DeepCopy() *ClusterUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeHistory) DeepCopyInto(out *ClusterUpgradeHistory) {
+	*out = *in
+	in.StartedTime.DeepCopyInto(&out.StartedTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeHistory.
+func (in *ClusterUpgradeHistory) DeepCopy() *ClusterUpgradeHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
 	*out = *in
@@ -2068,6 +2654,22 @@ func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprovisionResourceCount) DeepCopyInto(out *DeprovisionResourceCount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprovisionResourceCount.
+func (in *DeprovisionResourceCount) DeepCopy() *DeprovisionResourceCount {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprovisionResourceCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
 	*out = *in
@@ -2085,6 +2687,23 @@ func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionAzureConfig) DeepCopyInto(out *FailedProvisionAzureConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAzureConfig.
+func (in *FailedProvisionAzureConfig) DeepCopy() *FailedProvisionAzureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionAzureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 	*out = *in
@@ -2093,6 +2712,21 @@ func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 		*out = new(FailedProvisionAWSConfig)
 		**out = **in
 	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(FailedProvisionGCPConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(FailedProvisionAzureConfig)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(FailedProvisionPersistentVolumeClaimConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -2106,6 +2740,39 @@ func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionGCPConfig) DeepCopyInto(out *FailedProvisionGCPConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionGCPConfig.
+func (in *FailedProvisionGCPConfig) DeepCopy() *FailedProvisionGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionPersistentVolumeClaimConfig) DeepCopyInto(out *FailedProvisionPersistentVolumeClaimConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionPersistentVolumeClaimConfig.
+func (in *FailedProvisionPersistentVolumeClaimConfig) DeepCopy() *FailedProvisionPersistentVolumeClaimConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionPersistentVolumeClaimConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
 	*out = *in
@@ -2339,6 +3006,22 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 		*out = new(ReleaseImageVerificationConfigMapReference)
 		**out = **in
 	}
+	if in.RedactionRulesConfigMapRef != nil {
+		in, out := &in.RedactionRulesConfigMapRef, &out.RedactionRulesConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ClusterExpiration != nil {
+		in, out := &in.ClusterExpiration, &out.ClusterExpiration
+		*out = new(ClusterExpirationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanedInfraReaper != nil {
+		in, out := &in.OrphanedInfraReaper, &out.OrphanedInfraReaper
+		*out = new(OrphanedInfraReaperConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ArgoCD = in.ArgoCD
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = new(FeatureGateSelection)
@@ -2396,6 +3079,69 @@ func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallPhase) DeepCopyInto(out *InstallPhase) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallPhase.
+func (in *InstallPhase) DeepCopy() *InstallPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallRetryBackoff) DeepCopyInto(out *InstallRetryBackoff) {
+	*out = *in
+	if in.InitialDelay != nil {
+		in, out := &in.InitialDelay, &out.InitialDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallRetryBackoff.
+func (in *InstallRetryBackoff) DeepCopy() *InstallRetryBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallRetryBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryEntry) DeepCopyInto(out *InventoryEntry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryEntry.
+func (in *InventoryEntry) DeepCopy() *InventoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
 	*out = *in
@@ -2878,6 +3624,32 @@ func (in *OpenStackClusterDeprovision) DeepCopy() *OpenStackClusterDeprovision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedInfraReaperConfig) DeepCopyInto(out *OrphanedInfraReaperConfig) {
+	*out = *in
+	if in.ScanInterval != nil {
+		in, out := &in.ScanInterval, &out.ScanInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DeprovisionAfter != nil {
+		in, out := &in.DeprovisionAfter, &out.DeprovisionAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedInfraReaperConfig.
+func (in *OrphanedInfraReaperConfig) DeepCopy() *OrphanedInfraReaperConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedInfraReaperConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OvirtClusterDeprovision) DeepCopyInto(out *OvirtClusterDeprovision) {
 	*out = *in
@@ -2896,6 +3668,22 @@ func (in *OvirtClusterDeprovision) DeepCopy() *OvirtClusterDeprovision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchEntity) DeepCopyInto(out *PatchEntity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchEntity.
+func (in *PatchEntity) DeepCopy() *PatchEntity {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchEntity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
@@ -2973,6 +3761,65 @@ func (in *PlatformStatus) DeepCopy() *PlatformStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreDeprovisionHook) DeepCopyInto(out *PreDeprovisionHook) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreDeprovisionHook.
+func (in *PreDeprovisionHook) DeepCopy() *PreDeprovisionHook {
+	if in == nil {
+		return nil
+	}
+	out := new(PreDeprovisionHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionHook) DeepCopyInto(out *ProvisionHook) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionHook.
+func (in *ProvisionHook) DeepCopy() *ProvisionHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provisioning) DeepCopyInto(out *Provisioning) {
 	*out = *in
@@ -3008,6 +3855,13 @@ func (in *Provisioning) DeepCopyInto(out *Provisioning) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]ProvisionHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -24,8 +24,12 @@ func main() {
 	admissionCmd.RunAdmissionServer(
 		hivevalidatingwebhooks.NewDNSZoneValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterDeploymentValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterDeprovisionValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterPoolValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterImageSetValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewHiveConfigValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterClaimValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterClaimMutatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterProvisionValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewMachinePoolValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSyncSetValidatingAdmissionHook(decoder),
@@ -54,6 +54,7 @@ import (
 	"github.com/openshift/hive/pkg/controller/hibernation"
 	"github.com/openshift/hive/pkg/controller/machinemanagement"
 	"github.com/openshift/hive/pkg/controller/metrics"
+	"github.com/openshift/hive/pkg/controller/orphanedinfra"
 	"github.com/openshift/hive/pkg/controller/remoteingress"
 	"github.com/openshift/hive/pkg/controller/remotemachineset"
 	"github.com/openshift/hive/pkg/controller/syncidentityprovider"
@@ -99,6 +100,7 @@ var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
 	machinemanagement.ControllerName:    machinemanagement.Add,
 	awsprivatelink.ControllerName:       awsprivatelink.Add,
 	argocdregister.ControllerName:       argocdregister.Add,
+	orphanedinfra.ControllerName:        orphanedinfra.Add,
 }
 
 type controllerManagerOptions struct {
@@ -186,6 +188,11 @@ func newRootCommand() *cobra.Command {
 					log.Fatal(err)
 				}
 
+				// Without the configured redaction rules, sensitive text could leak into install logs and conditions.
+				if err := utils.LoadRedactionRules(mgr.GetAPIReader()); err != nil {
+					log.WithError(err).Fatal("error loading redaction rules")
+				}
+
 				// Setup Scheme for all resources
 				if err := apis.AddToScheme(mgr.GetScheme()); err != nil {
 					log.Fatal(err)
@@ -5,10 +5,14 @@ import (
 	"os"
 	"strings"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/installer/pkg/destroy/aws"
+
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/deprovision"
 )
 
 // NewDeprovisionAWSWithTagsCommand is the entrypoint to create the 'aws-tag-deprovision' subcommand
@@ -17,6 +21,7 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 	opt := &aws.ClusterUninstaller{}
 	var credsDir string
 	var logLevel string
+	dryRun := &dryRunOptions{}
 	cmd := &cobra.Command{
 		Use:   "aws-tag-deprovision KEY=VALUE ...",
 		Short: "Deprovision AWS assets (as created by openshift-installer) with the given tag(s)",
@@ -31,6 +36,17 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 				go terminateWhenFilesChange(credsDir)
 			}
 
+			if dryRun.enabled {
+				lister, err := newAWSLister(opt)
+				if err != nil {
+					log.WithError(err).Fatal("Cannot complete command")
+				}
+				if err := dryRun.run(lister, opt.Logger); err != nil {
+					log.WithError(err).Fatal("Runtime error")
+				}
+				return
+			}
+
 			if err := opt.Run(); err != nil {
 				log.WithError(err).Fatal("Runtime error")
 			}
@@ -40,9 +56,35 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 	flags.StringVar(&logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
 	flags.StringVar(&opt.Region, "region", "us-east-1", "AWS region to use")
 	flags.StringVar(&credsDir, "creds-dir", "", "directory of the creds. Changes in the creds will cause the program to terminate")
+	dryRun.addFlags(flags)
 	return cmd
 }
 
+func newAWSLister(o *aws.ClusterUninstaller) (deprovision.Lister, error) {
+	awsClient, err := awsclient.NewClient(nil, "", "", o.Region)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create AWS client")
+	}
+	lister := &deprovision.AWSLister{Client: awsClient, ClusterID: o.ClusterID}
+	if globalRegion := deprovision.AWSGlobalRegion(o.Region); globalRegion != o.Region {
+		lister.GlobalClient, err = awsclient.NewClient(nil, "", "", globalRegion)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create AWS client")
+		}
+	}
+	for _, filter := range o.Filters {
+		lister.Filters = append(lister.Filters, filter)
+		// The infra ID, which names the untaggable resources, is not passed to the command, but can be taken from
+		// the cluster ownership tag.
+		for key, value := range filter {
+			if lister.ClusterID == "" && value == "owned" && strings.HasPrefix(key, "kubernetes.io/cluster/") {
+				lister.ClusterID = strings.TrimPrefix(key, "kubernetes.io/cluster/")
+			}
+		}
+	}
+	return lister, nil
+}
+
 func completeAWSUninstaller(o *aws.ClusterUninstaller, logLevel string, args []string) error {
 
 	for _, arg := range args {
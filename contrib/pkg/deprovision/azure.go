@@ -13,11 +13,14 @@ import (
 	installertypesazure "github.com/openshift/installer/pkg/types/azure"
 
 	azureutils "github.com/openshift/hive/contrib/pkg/utils/azure"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/deprovision"
 )
 
 // NewDeprovisionAzureCommand is the entrypoint to create the azure deprovision subcommand
 func NewDeprovisionAzureCommand() *cobra.Command {
 	var logLevel string
+	dryRun := &dryRunOptions{}
 	cmd := &cobra.Command{
 		Use:   "azure INFRAID",
 		Short: "Deprovision Azure assets (as created by openshift-installer)",
@@ -26,6 +29,12 @@ func NewDeprovisionAzureCommand() *cobra.Command {
 			if err := validate(); err != nil {
 				log.WithError(err).Fatal("Failed validating Azure credentials")
 			}
+			if dryRun.enabled {
+				if err := runAzureDryRun(dryRun, logLevel, args[0]); err != nil {
+					log.WithError(err).Fatal("Runtime error")
+				}
+				return
+			}
 			uninstaller, err := completeAzureUninstaller(logLevel, args)
 			if err != nil {
 				log.WithError(err).Error("Cannot complete command")
@@ -38,9 +47,29 @@ func NewDeprovisionAzureCommand() *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.StringVar(&logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	dryRun.addFlags(flags)
 	return cmd
 }
 
+func runAzureDryRun(dryRun *dryRunOptions, logLevel string, infraID string) error {
+	level, err := log.ParseLevel(logLevel)
+	if err != nil {
+		log.WithError(err).Error("cannot parse log level")
+		return err
+	}
+	log.SetLevel(level)
+
+	creds, err := azureutils.GetCreds("")
+	if err != nil {
+		return errors.Wrap(err, "failed to get Azure credentials")
+	}
+	azureClient, err := azureclient.NewClient(creds)
+	if err != nil {
+		return errors.Wrap(err, "could not create Azure client")
+	}
+	return dryRun.run(&deprovision.AzureLister{Client: azureClient, InfraID: infraID}, log.WithField("infraID", infraID))
+}
+
 func validate() error {
 	_, err := azureutils.GetCreds("")
 	if err != nil {
@@ -0,0 +1,76 @@
+package deprovision
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/deprovision"
+)
+
+// dryRunOptions are the options for listing the resources that a deprovision would delete, without deleting them.
+type dryRunOptions struct {
+	enabled   bool
+	configMap string
+	namespace string
+}
+
+func (o *dryRunOptions) addFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&o.enabled, "dry-run", false, "list the resources that would be deleted, without deleting them")
+	flags.StringVar(&o.configMap, "dry-run-configmap", "", "name of a ConfigMap in which to save the resources listed by a dry run")
+	flags.StringVar(&o.namespace, "namespace", "", "namespace of the dry run ConfigMap. Defaults to the current namespace")
+}
+
+// run lists the resources with the lister, prints them, and saves them to the dry run ConfigMap if one was given.
+func (o *dryRunOptions) run(lister deprovision.Lister, logger log.FieldLogger) error {
+	resources, err := lister.ListResources()
+	if err != nil {
+		return errors.Wrap(err, "could not list resources")
+	}
+	formatted := deprovision.FormatResources(resources)
+	if formatted != "" {
+		fmt.Println(formatted)
+	}
+	logger.WithField("resources", len(resources)).Info("dry run complete, no resources were deleted")
+	if o.configMap == "" {
+		return nil
+	}
+
+	if o.namespace == "" {
+		o.namespace, err = contributils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "could not determine the namespace of the dry run configmap")
+		}
+	}
+	c, err := contributils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not get kube client")
+	}
+	cm := &corev1.ConfigMap{}
+	switch err := c.Get(context.TODO(), client.ObjectKey{Namespace: o.namespace, Name: o.configMap}, cm); {
+	case apierrors.IsNotFound(err):
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: o.namespace,
+				Name:      o.configMap,
+			},
+			Data: map[string]string{deprovision.ResourcesKey: formatted},
+		}
+		logger.WithField("configmap", o.configMap).Info("saving dry run resources")
+		return errors.Wrap(c.Create(context.TODO(), cm), "could not create dry run configmap")
+	case err != nil:
+		return errors.Wrap(err, "could not get dry run configmap")
+	}
+	cm.Data = map[string]string{deprovision.ResourcesKey: formatted}
+	logger.WithField("configmap", o.configMap).Info("updating dry run resources")
+	return errors.Wrap(c.Update(context.TODO(), cm), "could not update dry run configmap")
+}
@@ -13,6 +13,7 @@ import (
 	typesgcp "github.com/openshift/installer/pkg/types/gcp"
 
 	gcputils "github.com/openshift/hive/contrib/pkg/utils/gcp"
+	"github.com/openshift/hive/pkg/deprovision"
 	"github.com/openshift/hive/pkg/gcpclient"
 )
 
@@ -22,6 +23,8 @@ type gcpOptions struct {
 	infraID   string
 	region    string
 	projectID string
+	creds     []byte
+	dryRun    dryRunOptions
 }
 
 // NewDeprovisionGCPCommand is the entrypoint to create the GCP deprovision subcommand
@@ -46,6 +49,7 @@ func NewDeprovisionGCPCommand() *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
 	flags.StringVar(&opt.region, "region", "", "GCP region where the cluster is installed")
+	opt.dryRun.addFlags(flags)
 	return cmd
 }
 
@@ -72,6 +76,7 @@ func (o *gcpOptions) Validate(cmd *cobra.Command) error {
 		return errors.Wrap(err, "could not get GCP project ID")
 	}
 	o.projectID = projectID
+	o.creds = creds
 	return nil
 }
 
@@ -93,6 +98,14 @@ func (o *gcpOptions) Run() error {
 		Level: level,
 	})
 
+	if o.dryRun.enabled {
+		gcpClient, err := gcpclient.NewClient(o.creds)
+		if err != nil {
+			return errors.Wrap(err, "could not create GCP client")
+		}
+		return o.dryRun.run(&deprovision.GCPLister{Client: gcpClient, InfraID: o.infraID, Region: o.region}, logger)
+	}
+
 	metadata := &types.ClusterMetadata{
 		InfraID: o.infraID,
 		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
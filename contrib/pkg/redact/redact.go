@@ -0,0 +1,84 @@
+package redact
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/util/redact"
+)
+
+// Options is the set of options for applying redaction rules to text.
+type Options struct {
+	RulesFile string
+	InputFile string
+}
+
+// NewRedactCommand returns a command that applies the redaction rules from a HiveConfig redaction rules ConfigMap to
+// text, for testing the rules before they are configured.
+func NewRedactCommand() *cobra.Command {
+	opt := &Options{}
+	cmd := &cobra.Command{
+		Use:   "redact [INPUT_FILE]",
+		Short: "Applies redaction rules to text read from a file or stdin",
+		Long: `Applies redaction rules to text read from a file or stdin and prints the result.
+The rules file is either a redaction rules ConfigMap manifest, as referenced by redactionRulesConfigMapRef
+in HiveConfig, or the yaml list of rules from its "rules" key.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if len(args) == 1 {
+				opt.InputFile = args[0]
+			}
+			if err := opt.Run(os.Stdout); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.RulesFile, "rules-file", "", "File containing the redaction rules ConfigMap or rules")
+	cmd.MarkFlagRequired("rules-file")
+	return cmd
+}
+
+// Run applies the redaction rules to the input and writes the result to out.
+func (o *Options) Run(out io.Writer) error {
+	rulesBytes, err := ioutil.ReadFile(o.RulesFile)
+	if err != nil {
+		return errors.Wrap(err, "cannot read rules file")
+	}
+	redactor, err := parseRules(rulesBytes)
+	if err != nil {
+		return err
+	}
+
+	var input []byte
+	if o.InputFile == "" {
+		input, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		input, err = ioutil.ReadFile(o.InputFile)
+	}
+	if err != nil {
+		return errors.Wrap(err, "cannot read input")
+	}
+
+	_, err = fmt.Fprint(out, redactor.Redact(string(input)))
+	return err
+}
+
+// parseRules parses either a redaction rules ConfigMap or a list of rules.
+func parseRules(data []byte) (*redact.Redactor, error) {
+	cm := &corev1.ConfigMap{}
+	if err := yaml.Unmarshal(data, cm); err == nil && cm.Kind == "ConfigMap" {
+		return redact.FromConfigMap(cm)
+	}
+	return redact.Parse(string(data))
+}
@@ -27,12 +27,16 @@ import (
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
@@ -64,6 +68,9 @@ type Client interface {
 	DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
 	DescribeRouteTables(*ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
 	DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+	DescribeInstanceTypes(*ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error)
+	DescribeAddresses(*ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error)
+	DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
 	StopInstances(*ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
 	StartInstances(*ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error)
 	CreateVpcEndpointServiceConfiguration(*ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error)
@@ -105,15 +112,28 @@ type Client interface {
 
 	// STS
 	GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
+
+	// IAM
+	SimulatePrincipalPolicy(input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePolicyResponse, error)
+	ListRolesPages(input *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error
+	GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error)
+	ListUsersPages(input *iam.ListUsersInput, fn func(*iam.ListUsersOutput, bool) bool) error
+	GetUser(input *iam.GetUserInput) (*iam.GetUserOutput, error)
+	GetInstanceProfile(input *iam.GetInstanceProfileInput) (*iam.GetInstanceProfileOutput, error)
+
+	// ServiceQuotas
+	GetServiceQuota(input *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error)
 }
 
 type awsClient struct {
 	ec2Client     ec2iface.EC2API
 	elbClient     elbiface.ELBAPI
 	elbv2Client   elbv2iface.ELBV2API
+	iamClient     iamiface.IAMAPI
 	route53Client route53iface.Route53API
 	s3Client      s3iface.S3API
 	s3Uploader    *s3manager.Uploader
+	sqClient      servicequotasiface.ServiceQuotasAPI
 	stsClient     stsiface.STSAPI
 	tagClient     *resourcegroupstaggingapi.ResourceGroupsTaggingAPI
 }
@@ -138,6 +158,21 @@ func (c *awsClient) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.D
 	return c.ec2Client.DescribeInstances(input)
 }
 
+func (c *awsClient) DescribeInstanceTypes(input *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeInstanceTypes").Inc()
+	return c.ec2Client.DescribeInstanceTypes(input)
+}
+
+func (c *awsClient) DescribeAddresses(input *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeAddresses").Inc()
+	return c.ec2Client.DescribeAddresses(input)
+}
+
+func (c *awsClient) DescribeVpcs(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeVpcs").Inc()
+	return c.ec2Client.DescribeVpcs(input)
+}
+
 func (c *awsClient) StopInstances(input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("StopInstances").Inc()
 	return c.ec2Client.StopInstances(input)
@@ -286,6 +321,41 @@ func (c *awsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.G
 	return c.stsClient.GetCallerIdentity(input)
 }
 
+func (c *awsClient) SimulatePrincipalPolicy(input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePolicyResponse, error) {
+	metricAWSAPICalls.WithLabelValues("SimulatePrincipalPolicy").Inc()
+	return c.iamClient.SimulatePrincipalPolicy(input)
+}
+
+func (c *awsClient) ListRolesPages(input *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error {
+	metricAWSAPICalls.WithLabelValues("ListRolesPages").Inc()
+	return c.iamClient.ListRolesPages(input, fn)
+}
+
+func (c *awsClient) GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetRole").Inc()
+	return c.iamClient.GetRole(input)
+}
+
+func (c *awsClient) ListUsersPages(input *iam.ListUsersInput, fn func(*iam.ListUsersOutput, bool) bool) error {
+	metricAWSAPICalls.WithLabelValues("ListUsersPages").Inc()
+	return c.iamClient.ListUsersPages(input, fn)
+}
+
+func (c *awsClient) GetUser(input *iam.GetUserInput) (*iam.GetUserOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetUser").Inc()
+	return c.iamClient.GetUser(input)
+}
+
+func (c *awsClient) GetInstanceProfile(input *iam.GetInstanceProfileInput) (*iam.GetInstanceProfileOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetInstanceProfile").Inc()
+	return c.iamClient.GetInstanceProfile(input)
+}
+
+func (c *awsClient) GetServiceQuota(input *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetServiceQuota").Inc()
+	return c.sqClient.GetServiceQuota(input)
+}
+
 // Options provides the means to control how a client is created and what
 // configuration values will be loaded.
 //
@@ -477,9 +547,11 @@ func newClientFromSession(s *session.Session, cfgs ...*aws.Config) (Client, erro
 		ec2Client:     ec2.New(s, cfgs...),
 		elbClient:     elb.New(s, cfgs...),
 		elbv2Client:   elbv2.New(s, cfgs...),
+		iamClient:     iam.New(s, cfgs...),
 		s3Client:      s3.New(s, cfgs...),
 		s3Uploader:    s3manager.NewUploader(s),
 		route53Client: route53.New(s, cfgs...),
+		sqClient:      servicequotas.New(s, cfgs...),
 		stsClient:     sts.New(s, cfgs...),
 		tagClient:     resourcegroupstaggingapi.New(s, cfgs...),
 	}, nil
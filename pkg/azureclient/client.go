@@ -3,10 +3,14 @@ package azureclient
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"net/http"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -22,6 +26,9 @@ import (
 type Client interface {
 	ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error)
 
+	// Usage
+	ListUsage(ctx context.Context, location string) (UsagePage, error)
+
 	// Zones
 	CreateOrUpdateZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error)
 	DeleteZone(ctx context.Context, resourceGroupName string, zone string) error
@@ -36,6 +43,12 @@ type Client interface {
 	ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error)
 	DeallocateVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesDeallocateFuture, error)
 	StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error)
+
+	// Blobs
+	UploadBlob(ctx context.Context, storageAccount, container, name string, content []byte) error
+
+	// Resources
+	ListResourcesByResourceGroup(ctx context.Context, resourceGroupName string) (ResourcePage, error)
 }
 
 // ResourceSKUsPage is a page of results from listing resource SKUs.
@@ -45,6 +58,13 @@ type ResourceSKUsPage interface {
 	Values() []compute.ResourceSku
 }
 
+// UsagePage is a page of results from listing compute usage.
+type UsagePage interface {
+	NextWithContext(ctx context.Context) error
+	NotDone() bool
+	Values() []compute.Usage
+}
+
 // RecordSetPage is a page of results from listing record sets.
 type RecordSetPage interface {
 	NextWithContext(ctx context.Context) error
@@ -52,18 +72,37 @@ type RecordSetPage interface {
 	Values() []dns.RecordSet
 }
 
+// ResourcePage is a page of results from listing generic resources.
+type ResourcePage interface {
+	NextWithContext(ctx context.Context) error
+	NotDone() bool
+	Values() []resources.GenericResourceExpanded
+}
+
 type azureClient struct {
 	resourceSKUsClient    *compute.ResourceSkusClient
 	recordSetsClient      *dns.RecordSetsClient
 	zonesClient           *dns.ZonesClient
 	virtualMachinesClient *compute.VirtualMachinesClient
+	usageClient           *compute.UsageClient
+	blobClient            *autorest.Client
+	resourcesClient       *resources.Client
 }
 
+// blobStorageAPIVersion is the version of the blob storage REST API used to upload blobs. Azure AD authorization of
+// blob requests requires version 2017-11-09 or later.
+const blobStorageAPIVersion = "2019-12-12"
+
 func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error) {
 	page, err := c.resourceSKUsClient.List(ctx, filter)
 	return &page, err
 }
 
+func (c *azureClient) ListUsage(ctx context.Context, location string) (UsagePage, error) {
+	page, err := c.usageClient.List(ctx, location)
+	return &page, err
+}
+
 func (c *azureClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error) {
 	return c.zonesClient.CreateOrUpdate(ctx, resourceGroupName, zone, dns.Zone{
 		Location: to.StringPtr("global"),
@@ -112,6 +151,29 @@ func (c *azureClient) StartVirtualMachine(ctx context.Context, resourceGroup, na
 	return c.virtualMachinesClient.Start(ctx, resourceGroup, name)
 }
 
+func (c *azureClient) UploadBlob(ctx context.Context, storageAccount, container, name string, content []byte) error {
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+		autorest.AsPut(),
+		autorest.WithBaseURL(fmt.Sprintf("https://%s.blob.%s/%s/%s", storageAccount, azure.PublicCloud.StorageEndpointSuffix, container, name)),
+		autorest.WithHeader("x-ms-blob-type", "BlockBlob"),
+		autorest.WithHeader("x-ms-version", blobStorageAPIVersion),
+		autorest.WithBytes(&content),
+	)
+	if err != nil {
+		return err
+	}
+	resp, err := c.blobClient.Send(req, autorest.DoRetryForStatusCodes(c.blobClient.RetryAttempts, c.blobClient.RetryDuration, autorest.StatusCodesForRetry...))
+	if err != nil {
+		return err
+	}
+	return autorest.Respond(resp, azure.WithErrorUnlessStatusCode(http.StatusCreated), autorest.ByClosing())
+}
+
+func (c *azureClient) ListResourcesByResourceGroup(ctx context.Context, resourceGroupName string) (ResourcePage, error) {
+	page, err := c.resourcesClient.ListByResourceGroup(ctx, resourceGroupName, "", "", nil)
+	return &page, err
+}
+
 // NewClientFromSecret creates our client wrapper object for interacting with Azure. The Azure creds are read from the
 // specified secret.
 func NewClientFromSecret(secret *corev1.Secret) (Client, error) {
@@ -174,11 +236,29 @@ func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
 	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
 	virtualMachinesClient.Authorizer = authorizer
 
+	usageClient := compute.NewUsageClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	usageClient.Authorizer = authorizer
+
+	resourcesClient := resources.NewClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	resourcesClient.Authorizer = authorizer
+
+	// Blob storage is authorized with a token for the storage resource rather than the resource manager.
+	config.Resource = azure.PublicCloud.ResourceIdentifiers.Storage
+	blobAuthorizer, err := config.Authorizer()
+	if err != nil {
+		return nil, err
+	}
+	blobClient := autorest.NewClientWithUserAgent("openshift.io hive/v1")
+	blobClient.Authorizer = blobAuthorizer
+
 	return &azureClient{
 		resourceSKUsClient:    &resourceSKUsClient,
 		recordSetsClient:      &recordSetsClient,
 		zonesClient:           &zonesClient,
 		virtualMachinesClient: &virtualMachinesClient,
+		usageClient:           &usageClient,
+		blobClient:            &blobClient,
+		resourcesClient:       &resourcesClient,
 	}, nil
 }
 
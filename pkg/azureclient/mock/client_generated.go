@@ -8,6 +8,7 @@ import (
 	context "context"
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	dns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	resources "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 	gomock "github.com/golang/mock/gomock"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 	reflect "reflect"
@@ -51,6 +52,21 @@ func (mr *MockClientMockRecorder) ListResourceSKUs(ctx, filter interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceSKUs", reflect.TypeOf((*MockClient)(nil).ListResourceSKUs), ctx, filter)
 }
 
+// ListUsage mocks base method
+func (m *MockClient) ListUsage(ctx context.Context, location string) (azureclient.UsagePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsage", ctx, location)
+	ret0, _ := ret[0].(azureclient.UsagePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsage indicates an expected call of ListUsage
+func (mr *MockClientMockRecorder) ListUsage(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsage", reflect.TypeOf((*MockClient)(nil).ListUsage), ctx, location)
+}
+
 // CreateOrUpdateZone mocks base method
 func (m *MockClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName, zone string) (dns.Zone, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +200,35 @@ func (mr *MockClientMockRecorder) StartVirtualMachine(ctx, resourceGroup, name i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVirtualMachine", reflect.TypeOf((*MockClient)(nil).StartVirtualMachine), ctx, resourceGroup, name)
 }
 
+// UploadBlob mocks base method
+func (m *MockClient) UploadBlob(ctx context.Context, storageAccount, container, name string, content []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBlob", ctx, storageAccount, container, name, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadBlob indicates an expected call of UploadBlob
+func (mr *MockClientMockRecorder) UploadBlob(ctx, storageAccount, container, name, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBlob", reflect.TypeOf((*MockClient)(nil).UploadBlob), ctx, storageAccount, container, name, content)
+}
+
+// ListResourcesByResourceGroup mocks base method
+func (m *MockClient) ListResourcesByResourceGroup(ctx context.Context, resourceGroupName string) (azureclient.ResourcePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourcesByResourceGroup", ctx, resourceGroupName)
+	ret0, _ := ret[0].(azureclient.ResourcePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourcesByResourceGroup indicates an expected call of ListResourcesByResourceGroup
+func (mr *MockClientMockRecorder) ListResourcesByResourceGroup(ctx, resourceGroupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourcesByResourceGroup", reflect.TypeOf((*MockClient)(nil).ListResourcesByResourceGroup), ctx, resourceGroupName)
+}
+
 // MockResourceSKUsPage is a mock of ResourceSKUsPage interface
 type MockResourceSKUsPage struct {
 	ctrl     *gomock.Controller
@@ -249,6 +294,71 @@ func (mr *MockResourceSKUsPageMockRecorder) Values() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockResourceSKUsPage)(nil).Values))
 }
 
+// MockUsagePage is a mock of UsagePage interface
+type MockUsagePage struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsagePageMockRecorder
+}
+
+// MockUsagePageMockRecorder is the mock recorder for MockUsagePage
+type MockUsagePageMockRecorder struct {
+	mock *MockUsagePage
+}
+
+// NewMockUsagePage creates a new mock instance
+func NewMockUsagePage(ctrl *gomock.Controller) *MockUsagePage {
+	mock := &MockUsagePage{ctrl: ctrl}
+	mock.recorder = &MockUsagePageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUsagePage) EXPECT() *MockUsagePageMockRecorder {
+	return m.recorder
+}
+
+// NextWithContext mocks base method
+func (m *MockUsagePage) NextWithContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWithContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NextWithContext indicates an expected call of NextWithContext
+func (mr *MockUsagePageMockRecorder) NextWithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWithContext", reflect.TypeOf((*MockUsagePage)(nil).NextWithContext), ctx)
+}
+
+// NotDone mocks base method
+func (m *MockUsagePage) NotDone() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotDone")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NotDone indicates an expected call of NotDone
+func (mr *MockUsagePageMockRecorder) NotDone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotDone", reflect.TypeOf((*MockUsagePage)(nil).NotDone))
+}
+
+// Values mocks base method
+func (m *MockUsagePage) Values() []compute.Usage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Values")
+	ret0, _ := ret[0].([]compute.Usage)
+	return ret0
+}
+
+// Values indicates an expected call of Values
+func (mr *MockUsagePageMockRecorder) Values() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockUsagePage)(nil).Values))
+}
+
 // MockRecordSetPage is a mock of RecordSetPage interface
 type MockRecordSetPage struct {
 	ctrl     *gomock.Controller
@@ -313,3 +423,68 @@ func (mr *MockRecordSetPageMockRecorder) Values() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockRecordSetPage)(nil).Values))
 }
+
+// MockResourcePage is a mock of ResourcePage interface
+type MockResourcePage struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourcePageMockRecorder
+}
+
+// MockResourcePageMockRecorder is the mock recorder for MockResourcePage
+type MockResourcePageMockRecorder struct {
+	mock *MockResourcePage
+}
+
+// NewMockResourcePage creates a new mock instance
+func NewMockResourcePage(ctrl *gomock.Controller) *MockResourcePage {
+	mock := &MockResourcePage{ctrl: ctrl}
+	mock.recorder = &MockResourcePageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockResourcePage) EXPECT() *MockResourcePageMockRecorder {
+	return m.recorder
+}
+
+// NextWithContext mocks base method
+func (m *MockResourcePage) NextWithContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWithContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NextWithContext indicates an expected call of NextWithContext
+func (mr *MockResourcePageMockRecorder) NextWithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWithContext", reflect.TypeOf((*MockResourcePage)(nil).NextWithContext), ctx)
+}
+
+// NotDone mocks base method
+func (m *MockResourcePage) NotDone() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotDone")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NotDone indicates an expected call of NotDone
+func (mr *MockResourcePageMockRecorder) NotDone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotDone", reflect.TypeOf((*MockResourcePage)(nil).NotDone))
+}
+
+// Values mocks base method
+func (m *MockResourcePage) Values() []resources.GenericResourceExpanded {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Values")
+	ret0, _ := ret[0].([]resources.GenericResourceExpanded)
+	return ret0
+}
+
+// Values indicates an expected call of Values
+func (mr *MockResourcePageMockRecorder) Values() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockResourcePage)(nil).Values))
+}
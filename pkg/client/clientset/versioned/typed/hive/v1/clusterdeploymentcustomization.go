@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	scheme "github.com/openshift/hive/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterDeploymentCustomizationsGetter has a method to return a ClusterDeploymentCustomizationInterface.
+// A group's client should implement this interface.
+type ClusterDeploymentCustomizationsGetter interface {
+	ClusterDeploymentCustomizations(namespace string) ClusterDeploymentCustomizationInterface
+}
+
+// ClusterDeploymentCustomizationInterface has methods to work with ClusterDeploymentCustomization resources.
+type ClusterDeploymentCustomizationInterface interface {
+	Create(ctx context.Context, clusterDeploymentCustomization *v1.ClusterDeploymentCustomization, opts metav1.CreateOptions) (*v1.ClusterDeploymentCustomization, error)
+	Update(ctx context.Context, clusterDeploymentCustomization *v1.ClusterDeploymentCustomization, opts metav1.UpdateOptions) (*v1.ClusterDeploymentCustomization, error)
+	UpdateStatus(ctx context.Context, clusterDeploymentCustomization *v1.ClusterDeploymentCustomization, opts metav1.UpdateOptions) (*v1.ClusterDeploymentCustomization, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ClusterDeploymentCustomization, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ClusterDeploymentCustomizationList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterDeploymentCustomization, err error)
+	ClusterDeploymentCustomizationExpansion
+}
+
+// clusterDeploymentCustomizations implements ClusterDeploymentCustomizationInterface
+type clusterDeploymentCustomizations struct {
+	client rest.Interface
+	ns     string
+}
+
+// newClusterDeploymentCustomizations returns a ClusterDeploymentCustomizations
+func newClusterDeploymentCustomizations(c *HiveV1Client, namespace string) *clusterDeploymentCustomizations {
+	return &clusterDeploymentCustomizations{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the clusterDeploymentCustomization, and returns the corresponding clusterDeploymentCustomization object, and an error if there is any.
+func (c *clusterDeploymentCustomizations) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ClusterDeploymentCustomization, err error) {
+	result = &v1.ClusterDeploymentCustomization{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("clusterdeploymentcustomizations").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterDeploymentCustomizations that match those selectors.
+func (c *clusterDeploymentCustomizations) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ClusterDeploymentCustomizationList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ClusterDeploymentCustomizationList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("clusterdeploymentcustomizations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterDeploymentCustomizations.
+func (c *clusterDeploymentCustomizations) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("clusterdeploymentcustomizations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterDeploymentCustomization and creates it.  Returns the server's representation of the clusterDeploymentCustomization, and an error, if there is any.
+func (c *clusterDeploymentCustomizations) Create(ctx context.Context, clusterDeploymentCustomization *v1.ClusterDeploymentCustomization, opts metav1.CreateOptions) (result *v1.ClusterDeploymentCustomization, err error) {
+	result = &v1.ClusterDeploymentCustomization{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("clusterdeploymentcustomizations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterDeploymentCustomization).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterDeploymentCustomization and updates it. Returns the server's representation of the clusterDeploymentCustomization, and an error, if there is any.
+func (c *clusterDeploymentCustomizations) Update(ctx context.Context, clusterDeploymentCustomization *v1.ClusterDeploymentCustomization, opts metav1.UpdateOptions) (result *v1.ClusterDeploymentCustomization, err error) {
+	result = &v1.ClusterDeploymentCustomization{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("clusterdeploymentcustomizations").
+		Name(clusterDeploymentCustomization.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterDeploymentCustomization).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *clusterDeploymentCustomizations) UpdateStatus(ctx context.Context, clusterDeploymentCustomization *v1.ClusterDeploymentCustomization, opts metav1.UpdateOptions) (result *v1.ClusterDeploymentCustomization, err error) {
+	result = &v1.ClusterDeploymentCustomization{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("clusterdeploymentcustomizations").
+		Name(clusterDeploymentCustomization.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterDeploymentCustomization).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterDeploymentCustomization and deletes it. Returns an error if one occurs.
+func (c *clusterDeploymentCustomizations) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("clusterdeploymentcustomizations").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterDeploymentCustomizations) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("clusterdeploymentcustomizations").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterDeploymentCustomization.
+func (c *clusterDeploymentCustomizations) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterDeploymentCustomization, err error) {
+	result = &v1.ClusterDeploymentCustomization{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("clusterdeploymentcustomizations").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	versioned "github.com/openshift/hive/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/openshift/hive/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/openshift/hive/pkg/client/listers/hive/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterDeploymentCustomizationInformer provides access to a shared informer and lister for
+// ClusterDeploymentCustomizations.
+type ClusterDeploymentCustomizationInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.ClusterDeploymentCustomizationLister
+}
+
+type clusterDeploymentCustomizationInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewClusterDeploymentCustomizationInformer constructs a new informer for ClusterDeploymentCustomization type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterDeploymentCustomizationInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterDeploymentCustomizationInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterDeploymentCustomizationInformer constructs a new informer for ClusterDeploymentCustomization type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterDeploymentCustomizationInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.HiveV1().ClusterDeploymentCustomizations(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.HiveV1().ClusterDeploymentCustomizations(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&hivev1.ClusterDeploymentCustomization{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterDeploymentCustomizationInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterDeploymentCustomizationInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterDeploymentCustomizationInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&hivev1.ClusterDeploymentCustomization{}, f.defaultInformer)
+}
+
+func (f *clusterDeploymentCustomizationInformer) Lister() v1.ClusterDeploymentCustomizationLister {
+	return v1.NewClusterDeploymentCustomizationLister(f.Informer().GetIndexer())
+}
@@ -45,6 +45,10 @@ const (
 	// ClusterDeprovisionNameLabel is the label that is used to identify a relationship to a given cluster deprovision object.
 	ClusterDeprovisionNameLabel = "hive.openshift.io/cluster-deprovision-name"
 
+	// ClusterDeprovisionNamespaceLabel is the label that is used to identify the namespace of a related cluster deprovision
+	// object, on objects that are kept in another namespace.
+	ClusterDeprovisionNamespaceLabel = "hive.openshift.io/cluster-deprovision-namespace"
+
 	// ClusterProvisionNameLabel is the label that is used to identify a relationship to a given cluster provision object.
 	ClusterProvisionNameLabel = "hive.openshift.io/cluster-provision-name"
 
@@ -77,6 +81,15 @@ const (
 	// JobTypeProvision is used as a value of JobTypeLabel that says the Job is specifically running the provisioner.
 	JobTypeProvision = "provision"
 
+	// JobTypeProvisionHook is used as a value of JobTypeLabel that says the Job is running a provision hook.
+	JobTypeProvisionHook = "provision-hook"
+
+	// JobTypePreDeprovisionHook is used as a value of JobTypeLabel that says the Job is running a pre-deprovision hook.
+	JobTypePreDeprovisionHook = "pre-deprovision-hook"
+
+	// ProvisionHookNameLabel is the label on a provision or pre-deprovision hook Job with the name of the hook it runs.
+	ProvisionHookNameLabel = "hive.openshift.io/provision-hook-name"
+
 	// DNSZoneTypeLabel is the label that is used to identify what a DNSZone is being used for.
 	DNSZoneTypeLabel = "hive.openshift.io/dnszone-type"
 
@@ -110,6 +123,13 @@ const (
 	// GlobalPullSecret is the environment variable for controllers to get the global pull secret
 	GlobalPullSecret = "GLOBAL_PULL_SECRET"
 
+	// RedactionRulesConfigMapEnvVar is the environment variable for controllers to get the name of the ConfigMap
+	// holding the additional redaction rules.
+	RedactionRulesConfigMapEnvVar = "HIVE_REDACTION_RULES_CONFIGMAP"
+
+	// RedactionRulesEnvVar is the environment variable passing the additional redaction rules to install pods.
+	RedactionRulesEnvVar = "HIVE_REDACTION_RULES"
+
 	// DefaultHiveNamespace is the default namespace where core hive components will run. It is used if the environment variable is not defined.
 	DefaultHiveNamespace = "hive"
 
@@ -142,10 +162,42 @@ const (
 	// for the cluster provision to complete by running `openshift-install wait-for install-complete` command.
 	WaitForInstallCompleteExecutionsAnnotation = "hive.openshift.io/wait-for-install-complete-executions"
 
+	// SkipPreflightChecksAnnotation is an annotation used on ClusterDeployments to skip the cloud quota and permission
+	// checks which are run before a ClusterProvision is created. Set to "true".
+	SkipPreflightChecksAnnotation = "hive.openshift.io/skip-preflight-checks"
+
+	// PreflightChecksHashAnnotation is an annotation set on ClusterDeployments by the clusterdeployment controller
+	// to the hash of the install config and platform for which the preflight checks were last run.
+	PreflightChecksHashAnnotation = "hive.openshift.io/preflight-checks-hash"
+
 	// ProtectedDeleteAnnotation is an annotation used on ClusterDeployments to indicate that the ClusterDeployment
 	// cannot be deleted. The annotation must be removed in order to delete the ClusterDeployment.
 	ProtectedDeleteAnnotation = "hive.openshift.io/protected-delete"
 
+	// DeleteAfterAnnotation is an annotation used on ClusterDeployments holding a duration, from the creation of
+	// the ClusterDeployment, after which the cluster is deleted. The Expiration of the spec takes precedence.
+	DeleteAfterAnnotation = "hive.openshift.io/delete-after"
+
+	// OriginalExpirationAnnotation is an annotation set by hive on ClusterDeployments holding the first expiration
+	// of the cluster, in RFC3339 format. The admission webhook caps extensions of the expiration relative to it.
+	OriginalExpirationAnnotation = "hive.openshift.io/original-expiration"
+
+	// ClusterExpirationMaxExtensionEnvVar is the environment variable holding the maximum extension of the
+	// expiration of a ClusterDeployment allowed by the admission webhook.
+	ClusterExpirationMaxExtensionEnvVar = "HIVE_CLUSTER_EXPIRATION_MAX_EXTENSION"
+
+	// OrphanedInfraReaperScanIntervalEnvVar is the environment variable holding the time between searches for
+	// orphaned cloud infrastructure. The search is only run when it is set.
+	OrphanedInfraReaperScanIntervalEnvVar = "HIVE_ORPHANED_INFRA_REAPER_SCAN_INTERVAL"
+
+	// OrphanedInfraReaperDeprovisionAfterEnvVar is the environment variable holding how long infrastructure must
+	// have been orphaned before it is deprovisioned.
+	OrphanedInfraReaperDeprovisionAfterEnvVar = "HIVE_ORPHANED_INFRA_REAPER_DEPROVISION_AFTER"
+
+	// OrphanedInfraLabel is the label set to "true" on ClusterDeprovisions created to delete orphaned
+	// infrastructure, which are run without an owning ClusterDeployment.
+	OrphanedInfraLabel = "hive.openshift.io/orphaned-infra"
+
 	// ProtectedDeleteEnvVar is the name of the environment variable used to tell the controller manager whether
 	// protected delete is enabled.
 	ProtectedDeleteEnvVar = "PROTECTED_DELETE"
@@ -304,6 +356,15 @@ const (
 	// InstallLogsUploadProviderAWS is used to specify that AWS is the cloud provider to upload logs to.
 	InstallLogsUploadProviderAWS = "aws"
 
+	// InstallLogsUploadProviderGCP is used to specify that GCP is the cloud provider to upload logs to.
+	InstallLogsUploadProviderGCP = "gcp"
+
+	// InstallLogsUploadProviderAzure is used to specify that Azure is the cloud provider to upload logs to.
+	InstallLogsUploadProviderAzure = "azure"
+
+	// InstallLogsUploadProviderPVC is used to specify that logs are copied to a persistent volume.
+	InstallLogsUploadProviderPVC = "pvc"
+
 	// InstallLogsCredentialsSecretRefEnvVar is the environment variable specifying what secret to use for storing logs.
 	InstallLogsCredentialsSecretRefEnvVar = "HIVE_INSTALL_LOGS_CREDENTIALS_SECRET"
 
@@ -316,6 +377,21 @@ const (
 	// InstallLogsAWSS3BucketEnvVar is the environment variable specifying the S3 bucket to use.
 	InstallLogsAWSS3BucketEnvVar = "HIVE_INSTALL_LOGS_AWS_S3_BUCKET"
 
+	// InstallLogsGCSBucketEnvVar is the environment variable specifying the GCS bucket to use.
+	InstallLogsGCSBucketEnvVar = "HIVE_INSTALL_LOGS_GCS_BUCKET"
+
+	// InstallLogsAzureStorageAccountEnvVar is the environment variable specifying the Azure storage account to use.
+	InstallLogsAzureStorageAccountEnvVar = "HIVE_INSTALL_LOGS_AZURE_STORAGE_ACCOUNT"
+
+	// InstallLogsAzureContainerEnvVar is the environment variable specifying the Azure Blob storage container to use.
+	InstallLogsAzureContainerEnvVar = "HIVE_INSTALL_LOGS_AZURE_CONTAINER"
+
+	// InstallLogsPVCClaimNameEnvVar is the environment variable specifying the PersistentVolumeClaim to copy logs to.
+	InstallLogsPVCClaimNameEnvVar = "HIVE_INSTALL_LOGS_PVC_CLAIM_NAME"
+
+	// InstallLogsPVCMountPath is the path at which the install logs PersistentVolumeClaim is mounted in install pods.
+	InstallLogsPVCMountPath = "/install-logs"
+
 	// HiveFakeClusterAnnotation can be set to true on a cluster deployment to create a fake cluster that never
 	// provisions resources, and all communication with the cluster will be faked.
 	HiveFakeClusterAnnotation = "hive.openshift.io/fake-cluster"
@@ -333,6 +409,31 @@ const (
 	// from the pool.
 	ClusterClaimRemoveClusterAnnotation = "hive.openshift.io/remove-claimed-cluster-from-pool"
 
+	// ClusterClaimRequesterAnnotation is set by the ClusterClaim admission webhook on claims when they are created. It
+	// holds the name of the user that created the claim, and is used to apply the claim quotas of cluster pools.
+	ClusterClaimRequesterAnnotation = "hive.openshift.io/claim-requester"
+
+	// ClusterClaimRequesterGroupsAnnotation is set by the ClusterClaim admission webhook on claims when they are
+	// created. It holds a JSON list of the groups of the user that created the claim.
+	ClusterClaimRequesterGroupsAnnotation = "hive.openshift.io/claim-requester-groups"
+
+	// ClusterPoolPlatformVariantLabel is a label on ClusterDeployments created from a ClusterPool holding the name of
+	// the platform variant of the pool from which the cluster was created.
+	ClusterPoolPlatformVariantLabel = "hive.openshift.io/cluster-pool-platform-variant"
+
+	// ClusterPoolPriorityLabel is a label on ClusterPools holding the integer priority of the pool when ordering
+	// the pools selected by a ClusterClaim with the Priority ordering policy.
+	ClusterPoolPriorityLabel = "hive.openshift.io/cluster-pool-priority"
+
+	// ClusterPoolSpecHashAnnotation is set by the cluster pool controller on the ClusterDeployments it creates. It
+	// holds a hash of the parts of the ClusterPool spec that determine how the cluster is installed, and is used to
+	// detect unclaimed clusters that were created from an older version of the pool.
+	ClusterPoolSpecHashAnnotation = "hive.openshift.io/cluster-pool-spec-hash"
+
+	// ClusterPoolStaleAnnotation is set to "true" by the cluster pool controller on unclaimed ClusterDeployments that
+	// were created from an older version of the pool spec and are due to be replaced.
+	ClusterPoolStaleAnnotation = "hive.openshift.io/cluster-pool-stale"
+
 	// HiveAWSServiceProviderCredentialsSecretRefEnvVar is the environment variable specifying what secret to use for
 	// assuming the service provider credentials for AWS clusters.
 	HiveAWSServiceProviderCredentialsSecretRefEnvVar = "HIVE_AWS_SERVICE_PROVIDER_CREDENTIALS_SECRET"
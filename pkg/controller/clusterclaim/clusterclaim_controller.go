@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -101,6 +102,22 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterClaim, concurrentRecon
 		return err
 	}
 
+	// Watch for changes to the admin kubeconfig and admin password secrets of claimed clusters
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(requestsForConnectionSecret(r.Client, r.logger)),
+		predicate.NewPredicateFuncs(isClusterConnectionSecret)); err != nil {
+		return err
+	}
+
+	// Watch for changes to the copies of the connection secrets owned by ClusterClaims
+	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &hivev1.ClusterClaim{},
+	}, predicate.NewPredicateFuncs(isClaimOwned)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -294,10 +311,31 @@ func getClaimLifetime(poolLifetime *hivev1.ClusterPoolClaimLifetime, claimLifeti
 
 // clusterPoolLifetimeForClaim returns the default and max lifetimes for the cluster pool the claim belongs to.
 func (r *ReconcileClusterClaim) clusterPoolLifetimeForClaim(claim *hivev1.ClusterClaim, logger log.FieldLogger) (*hivev1.ClusterPoolClaimLifetime, error) {
+	// Claims using a pool selector record the pool from which the cluster was assigned in their status. The status
+	// is written separately from the assignment, so fall back to the pool of the assigned cluster.
+	poolName := claim.Spec.ClusterPoolName
+	if poolName == "" {
+		poolName = claim.Status.ClusterPoolName
+	}
+	if poolName == "" {
+		cd := &hivev1.ClusterDeployment{}
+		switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: claim.Spec.Namespace, Name: claim.Spec.Namespace}, cd); {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster deployment of claim")
+			return nil, errors.Wrap(err, "failed to get the cluster deployment")
+		case cd.Spec.ClusterPoolRef != nil:
+			poolName = cd.Spec.ClusterPoolRef.PoolName
+		}
+	}
+	if poolName == "" {
+		logger.WithField("claim", claim.Name).Debug("pool of claim not yet known")
+		return nil, nil
+	}
 	// Fetch the ClusterPool instance
 	clp := &hivev1.ClusterPool{}
 	// claims exists in the same namespace as the pool
-	key := client.ObjectKey{Namespace: claim.Namespace, Name: claim.Spec.ClusterPoolName}
+	key := client.ObjectKey{Namespace: claim.Namespace, Name: poolName}
 	err := r.Get(context.TODO(), key, clp)
 	if apierrors.IsNotFound(err) {
 		logger.WithField("pool", key).WithField("claim", claim.Name).Info("cluster pool no longer exists")
@@ -426,7 +464,10 @@ func (r *ReconcileClusterClaim) reconcileForExistingAssignment(claim *hivev1.Clu
 	if err := r.createRBAC(claim, cd, logger); err != nil {
 		return reconcile.Result{}, err
 	}
-	var statusChanged bool
+	statusChanged, err := r.syncConnectionDetails(claim, cd, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	var changed bool
 	conds := claim.Status.Conditions
 
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -58,6 +59,7 @@ func TestReconcileClusterClaim(t *testing.T) {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
 	rbacv1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
 
 	poolBuilder := testcp.FullBuilder(claimNamespace, testLeasePoolName, scheme).
 		GenericOptions(
@@ -109,6 +111,10 @@ func TestReconcileClusterClaim(t *testing.T) {
 		expectHibernating                      bool
 		expectDeleted                          bool
 		expectedRequeueAfter                   *time.Duration
+		expectCopiedSecrets                    bool
+		expectedAPIURL                         string
+		expectNoSecrets                        []string
+		expectKeptSecrets                      []string
 	}{
 		{
 			name:  "initialize conditions",
@@ -538,6 +544,81 @@ func TestReconcileClusterClaim(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "claim with pool selector uses lifetime of assigned pool",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithPoolSelector(map[string]string{"test-label": "test-value"}),
+				testclaim.WithStatusPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithDefaultClaimLifetime(1 * time.Hour)),
+			},
+			expectDeleted: true,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimPendingCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ClusterClaimed",
+					Message: "Cluster claimed",
+				},
+				{
+					Type:    hivev1.ClusterRunningCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
+			},
+		},
+		{
+			name: "claim with pool selector uses lifetime of pool of assigned cluster when pool not in status",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithPoolSelector(map[string]string{"test-label": "test-value"}),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, testLeasePoolName, claimName),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithDefaultClaimLifetime(1 * time.Hour)),
+			},
+			expectDeleted: true,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimPendingCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ClusterClaimed",
+					Message: "Cluster claimed",
+				},
+				{
+					Type:    hivev1.ClusterRunningCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
+			},
+		},
 		{
 			name: "claim with elapsed pool lifetime is deleted as set by pool maximum",
 			claim: initializedClaimBuilder.Build(
@@ -730,6 +811,110 @@ func TestReconcileClusterClaim(t *testing.T) {
 			expectRBAC:           true,
 			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(2 * time.Hour),
 		},
+		{
+			name: "existing assignment copies connection secrets",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				testclaim.WithCopyConnectionSecrets(),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionFalse,
+				}),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Status.APIURL = "https://api.test-cluster.example.com:6443"
+					cd.Status.WebConsoleURL = "https://console.test-cluster.example.com"
+				},
+			),
+			existing: []runtime.Object{
+				testSecret(clusterName, kubeconfigSecretName, "kubeconfig", "test-kubeconfig"),
+				testSecret(clusterName, passwordSecretName, "password", "test-password"),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectCopiedSecrets:  true,
+			expectedAPIURL:       "https://api.test-cluster.example.com:6443",
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:   hivev1.ClusterRunningCondition,
+					Status: corev1.ConditionTrue,
+					Reason: "Running",
+				},
+			},
+		},
+		{
+			name: "copied connection secrets are updated",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				testclaim.WithCopyConnectionSecrets(),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionFalse,
+				}),
+			),
+			existing: []runtime.Object{
+				testSecret(clusterName, kubeconfigSecretName, "kubeconfig", "test-kubeconfig"),
+				testSecret(clusterName, passwordSecretName, "password", "test-password"),
+				ownedByClaim(testSecret(claimNamespace, claimName+adminKubeconfigSecretSuffix, "kubeconfig", "old-kubeconfig")),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectCopiedSecrets:  true,
+		},
+		{
+			name: "copied connection secrets are removed when no longer requested",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				func(claim *hivev1.ClusterClaim) {
+					claim.Status.AdminKubeconfigSecretRef = &corev1.LocalObjectReference{Name: claimName + adminKubeconfigSecretSuffix}
+					claim.Status.AdminPasswordSecretRef = &corev1.LocalObjectReference{Name: claimName + adminPasswordSecretSuffix}
+				},
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionFalse,
+				}),
+			),
+			existing: []runtime.Object{
+				ownedByClaim(testSecret(claimNamespace, claimName+adminKubeconfigSecretSuffix, "kubeconfig", "test-kubeconfig")),
+				ownedByClaim(testSecret(claimNamespace, claimName+adminPasswordSecretSuffix, "password", "test-password")),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectNoSecrets:      []string{claimName + adminKubeconfigSecretSuffix, claimName + adminPasswordSecretSuffix},
+		},
+		{
+			name: "secrets not owned by claim are not removed when no longer requested",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				func(claim *hivev1.ClusterClaim) {
+					claim.Status.AdminKubeconfigSecretRef = &corev1.LocalObjectReference{Name: claimName + adminKubeconfigSecretSuffix}
+					claim.Status.AdminPasswordSecretRef = &corev1.LocalObjectReference{Name: claimName + adminPasswordSecretSuffix}
+				},
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionFalse,
+				}),
+			),
+			existing: []runtime.Object{
+				testSecret(claimNamespace, claimName+adminKubeconfigSecretSuffix, "kubeconfig", "test-kubeconfig"),
+				ownedByClaim(testSecret(claimNamespace, claimName+adminPasswordSecretSuffix, "password", "test-password")),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectNoSecrets:      []string{claimName + adminPasswordSecretSuffix},
+			expectKeptSecrets:    []string{claimName + adminKubeconfigSecretSuffix},
+		},
 	}
 
 	for _, test := range tests {
@@ -857,10 +1042,57 @@ func TestReconcileClusterClaim(t *testing.T) {
 				assert.True(t, apierrors.IsNotFound(getRoleError), "expected no role")
 				assert.True(t, apierrors.IsNotFound(getRoleBindingError), "expected no role binding")
 			}
+			assert.Equal(t, test.expectedAPIURL, claim.Status.APIURL, "unexpected API URL")
+			if test.expectCopiedSecrets {
+				for _, copied := range []struct {
+					ref   *corev1.LocalObjectReference
+					key   string
+					value string
+				}{
+					{ref: claim.Status.AdminKubeconfigSecretRef, key: "kubeconfig", value: "test-kubeconfig"},
+					{ref: claim.Status.AdminPasswordSecretRef, key: "password", value: "test-password"},
+				} {
+					if !assert.NotNil(t, copied.ref, "expected reference to copied secret") {
+						continue
+					}
+					secret := &corev1.Secret{}
+					if assert.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: claimNamespace, Name: copied.ref.Name}, secret), "unexpected error getting copied secret") {
+						assert.Equal(t, copied.value, string(secret.Data[copied.key]), "unexpected data in copied secret")
+						assert.True(t, metav1.IsControlledBy(secret, claim), "expected copied secret to be owned by claim")
+					}
+				}
+			} else {
+				assert.Nil(t, claim.Status.AdminKubeconfigSecretRef, "expected no reference to copied kubeconfig secret")
+				assert.Nil(t, claim.Status.AdminPasswordSecretRef, "expected no reference to copied password secret")
+			}
+			for _, name := range test.expectNoSecrets {
+				err := c.Get(context.Background(), client.ObjectKey{Namespace: claimNamespace, Name: name}, &corev1.Secret{})
+				assert.True(t, apierrors.IsNotFound(err), "expected secret %s to be deleted", name)
+			}
+			for _, name := range test.expectKeptSecrets {
+				err := c.Get(context.Background(), client.ObjectKey{Namespace: claimNamespace, Name: name}, &corev1.Secret{})
+				assert.NoError(t, err, "expected secret %s to be kept", name)
+			}
 		})
 	}
 }
 
+func testSecret(namespace, name, key, value string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Data: map[string][]byte{key: []byte(value)},
+	}
+}
+
+func ownedByClaim(secret *corev1.Secret) *corev1.Secret {
+	claim := &hivev1.ClusterClaim{ObjectMeta: metav1.ObjectMeta{Namespace: claimNamespace, Name: claimName}}
+	secret.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(claim, hivev1.SchemeGroupVersion.WithKind("ClusterClaim"))}
+	return secret
+}
+
 func Test_getClaimLifetime(t *testing.T) {
 	cases := []struct {
 		name string
@@ -979,3 +1211,42 @@ func testRoleBinding() *rbacv1.RoleBinding {
 		},
 	}
 }
+
+func Test_connectionSecretPredicates(t *testing.T) {
+	withLabel := func(secret *corev1.Secret, value string) *corev1.Secret {
+		secret.Labels = map[string]string{constants.SecretTypeLabel: value}
+		return secret
+	}
+	cases := []struct {
+		name                   string
+		secret                 *corev1.Secret
+		expectConnectionSecret bool
+		expectClaimOwned       bool
+	}{
+		{
+			name:                   "kubeconfig secret",
+			secret:                 withLabel(testSecret(clusterName, kubeconfigSecretName, "kubeconfig", "test"), constants.SecretTypeKubeConfig),
+			expectConnectionSecret: true,
+		},
+		{
+			name:                   "password secret",
+			secret:                 withLabel(testSecret(clusterName, passwordSecretName, "password", "test"), constants.SecretTypeKubeAdminCreds),
+			expectConnectionSecret: true,
+		},
+		{
+			name:   "other secret",
+			secret: testSecret(clusterName, "other-secret", "key", "test"),
+		},
+		{
+			name:             "copy owned by claim",
+			secret:           ownedByClaim(testSecret(claimNamespace, claimName+adminKubeconfigSecretSuffix, "kubeconfig", "test")),
+			expectClaimOwned: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectConnectionSecret, isClusterConnectionSecret(tc.secret), "unexpected connection secret result")
+			assert.Equal(t, tc.expectClaimOwned, isClaimOwned(tc.secret), "unexpected claim owned result")
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package clusterclaim
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	adminKubeconfigSecretSuffix = "-admin-kubeconfig"
+	adminPasswordSecretSuffix   = "-admin-password"
+)
+
+// isClusterConnectionSecret returns true if the secret is an admin kubeconfig or admin password secret written by the
+// install of a cluster.
+func isClusterConnectionSecret(o client.Object) bool {
+	switch o.GetLabels()[constants.SecretTypeLabel] {
+	case constants.SecretTypeKubeConfig, constants.SecretTypeKubeAdminCreds:
+		return true
+	}
+	return false
+}
+
+// isClaimOwned returns true if the object is controlled by a ClusterClaim.
+func isClaimOwned(o client.Object) bool {
+	ref := metav1.GetControllerOf(o)
+	return ref != nil && ref.Kind == "ClusterClaim" && ref.APIVersion == hivev1.SchemeGroupVersion.String()
+}
+
+// requestsForConnectionSecret enqueues the ClusterClaim that has claimed the cluster whose admin kubeconfig or admin
+// password secret has changed.
+func requestsForConnectionSecret(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		clusterName := o.GetNamespace()
+		cd := &hivev1.ClusterDeployment{}
+		if err := c.Get(context.Background(), client.ObjectKey{Namespace: clusterName, Name: clusterName}, cd); err != nil {
+			if !apierrors.IsNotFound(err) {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get ClusterDeployment for secret")
+			}
+			return nil
+		}
+		if cd.Spec.ClusterMetadata == nil {
+			return nil
+		}
+		if name := o.GetName(); name != cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name &&
+			name != cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name {
+			return nil
+		}
+		claim := claimForClusterDeployment(cd)
+		if claim == nil {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: *claim}}
+	}
+}
+
+// syncConnectionDetails records the URLs of the claimed cluster in the status of the claim and, when requested by the
+// claim, copies the admin kubeconfig and admin password secrets of the cluster into the namespace of the claim.
+// Returns true if the status of the claim was changed.
+func (r *ReconcileClusterClaim) syncConnectionDetails(claim *hivev1.ClusterClaim, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
+	origStatus := claim.Status.DeepCopy()
+	claim.Status.APIURL = cd.Status.APIURL
+	claim.Status.WebConsoleURL = cd.Status.WebConsoleURL
+
+	switch {
+	case claim.Spec.CopyConnectionSecrets && cd.Spec.ClusterMetadata != nil:
+		kubeconfigRef, err := r.copySecret(claim, cd.Namespace, cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name, claim.Name+adminKubeconfigSecretSuffix, logger)
+		if err != nil {
+			return false, err
+		}
+		passwordRef, err := r.copySecret(claim, cd.Namespace, cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name, claim.Name+adminPasswordSecretSuffix, logger)
+		if err != nil {
+			return false, err
+		}
+		claim.Status.AdminKubeconfigSecretRef = kubeconfigRef
+		claim.Status.AdminPasswordSecretRef = passwordRef
+	case claim.Spec.CopyConnectionSecrets:
+		logger.Debug("not copying connection secrets since ClusterDeployment does not have ClusterMetadata")
+	default:
+		// Remove copies made before the claim stopped asking for them.
+		for _, ref := range []*corev1.LocalObjectReference{claim.Status.AdminKubeconfigSecretRef, claim.Status.AdminPasswordSecretRef} {
+			if ref == nil {
+				continue
+			}
+			if err := r.deleteSecretCopy(claim, ref.Name, logger); err != nil {
+				return false, err
+			}
+		}
+		claim.Status.AdminKubeconfigSecretRef = nil
+		claim.Status.AdminPasswordSecretRef = nil
+	}
+
+	return !reflect.DeepEqual(origStatus, &claim.Status), nil
+}
+
+// copySecret creates or updates the secret with the given name in the namespace of the claim as a copy of the source
+// secret. The copy is owned by the claim. A secret with the same name that is not owned by the claim is left alone.
+func (r *ReconcileClusterClaim) copySecret(claim *hivev1.ClusterClaim, srcNamespace, srcName, name string, logger log.FieldLogger) (*corev1.LocalObjectReference, error) {
+	src := &corev1.Secret{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: srcNamespace, Name: srcName}, src); err != nil {
+		logger.WithError(err).WithField("secret", srcName).Log(controllerutils.LogLevel(err), "could not get secret to copy")
+		return nil, errors.Wrap(err, "could not get secret to copy")
+	}
+	ownerRef := metav1.NewControllerRef(claim, hivev1.SchemeGroupVersion.WithKind("ClusterClaim"))
+	desired := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       claim.Namespace,
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{*ownerRef},
+		},
+		Type: src.Type,
+		Data: src.Data,
+	}
+	observed := &corev1.Secret{}
+	notOwned := false
+	update := func() bool {
+		if !metav1.IsControlledBy(observed, claim) {
+			notOwned = true
+			return false
+		}
+		if reflect.DeepEqual(desired.Data, observed.Data) {
+			return false
+		}
+		observed.Data = desired.Data
+		return true
+	}
+	if err := r.applyResource(desired, observed, update, logger); err != nil {
+		return nil, err
+	}
+	if notOwned {
+		logger.WithField("secret", name).Warn("not overwriting secret that is not owned by the ClusterClaim")
+		return nil, errors.Errorf("secret %s already exists and is not owned by the ClusterClaim", name)
+	}
+	return &corev1.LocalObjectReference{Name: name}, nil
+}
+
+// deleteSecretCopy deletes a copy of a connection secret made for the claim. A secret with the name that is not owned
+// by the claim is left alone.
+func (r *ReconcileClusterClaim) deleteSecretCopy(claim *hivev1.ClusterClaim, name string, logger log.FieldLogger) error {
+	logger = logger.WithField("secret", name)
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Namespace: claim.Namespace, Name: name}, secret); {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get copy of connection secret")
+		return errors.Wrap(err, "could not get copy of connection secret")
+	}
+	if !metav1.IsControlledBy(secret, claim) {
+		logger.Warn("not deleting secret that is not owned by the ClusterClaim")
+		return nil
+	}
+	if secret.DeletionTimestamp != nil {
+		return nil
+	}
+	logger.Info("deleting copy of connection secret")
+	if err := r.Delete(context.Background(), secret); err != nil && !apierrors.IsNotFound(err) {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete copy of connection secret")
+		return errors.Wrap(err, "could not delete copy of connection secret")
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package clusterdeployment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/sts"
+	log "github.com/sirupsen/logrus"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	"github.com/openshift/hive/pkg/awsclient"
+)
+
+const (
+	awsDefaultControlPlaneInstanceType = "m5.xlarge"
+	awsDefaultComputeInstanceType      = "m5.large"
+
+	// Service quota codes, see https://docs.aws.amazon.com/general/latest/gr/aws_service_limits.html
+	awsStandardInstanceVCPUQuotaCode = "L-1216C47A"
+	awsElasticIPQuotaCode            = "L-0263D0A3"
+	awsVPCQuotaCode                  = "L-F678F1CE"
+
+	// awsStandardInstanceFamilies are the instance families counted against the standard instance vCPU quota.
+	awsStandardInstanceFamilies = "acdhimrtz"
+)
+
+var (
+	// awsRequiredActions are the IAM actions the installer needs regardless of the install config.
+	awsRequiredActions = []string{
+		"ec2:CreateSecurityGroup",
+		"ec2:CreateTags",
+		"ec2:RunInstances",
+		"elasticloadbalancing:CreateLoadBalancer",
+		"iam:CreateInstanceProfile",
+		"iam:CreateRole",
+		"iam:PassRole",
+		"route53:ChangeResourceRecordSets",
+		"route53:CreateHostedZone",
+		"s3:CreateBucket",
+	}
+	// awsNetworkActions are the IAM actions the installer needs when it creates the VPC.
+	awsNetworkActions = []string{
+		"ec2:AllocateAddress",
+		"ec2:CreateInternetGateway",
+		"ec2:CreateNatGateway",
+		"ec2:CreateSubnet",
+		"ec2:CreateVpc",
+	}
+)
+
+func awsPreflightChecks(awsClient awsclient.Client, ic *installertypes.InstallConfig, result *preflightResult, logger log.FieldLogger) {
+	// The installer only creates a VPC when it is not given existing subnets.
+	createsNetwork := len(ic.Platform.AWS.Subnets) == 0
+
+	preflightCheck("permissions", logger, func() error {
+		actions := awsRequiredActions
+		if createsNetwork {
+			actions = append(append([]string{}, awsRequiredActions...), awsNetworkActions...)
+		}
+		return awsCheckPermissions(awsClient, actions, result)
+	})
+	preflightCheck("vCPU quota", logger, func() error {
+		return awsCheckVCPUQuota(awsClient, ic, result)
+	})
+	if createsNetwork {
+		preflightCheck("elastic IP quota", logger, func() error {
+			return awsCheckElasticIPQuota(awsClient, ic, result)
+		})
+		preflightCheck("VPC quota", logger, func() error {
+			return awsCheckVPCQuota(awsClient, result)
+		})
+	}
+}
+
+func awsInstanceType(ic *installertypes.InstallConfig) func(*installertypes.MachinePool, bool) string {
+	return func(pool *installertypes.MachinePool, controlPlane bool) string {
+		if pool != nil && pool.Platform.AWS != nil && pool.Platform.AWS.InstanceType != "" {
+			return pool.Platform.AWS.InstanceType
+		}
+		if d := ic.Platform.AWS.DefaultMachinePlatform; d != nil && d.InstanceType != "" {
+			return d.InstanceType
+		}
+		if controlPlane {
+			return awsDefaultControlPlaneInstanceType
+		}
+		return awsDefaultComputeInstanceType
+	}
+}
+
+// awsCheckPermissions simulates the required actions against the policies of the caller.
+func awsCheckPermissions(awsClient awsclient.Client, actions []string, result *preflightResult) error {
+	identity, err := awsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return err
+	}
+	principal, err := awsPolicySourceARN(aws.StringValue(identity.Arn))
+	if err != nil {
+		return err
+	}
+	if principal == "" {
+		// The account root user is allowed everything.
+		return nil
+	}
+
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principal),
+		ActionNames:     aws.StringSlice(actions),
+	}
+	for {
+		out, err := awsClient.SimulatePrincipalPolicy(input)
+		if err != nil {
+			return err
+		}
+		for _, r := range out.EvaluationResults {
+			if aws.StringValue(r.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+				result.missingPermissions = append(result.missingPermissions, aws.StringValue(r.EvalActionName))
+			}
+		}
+		if !aws.BoolValue(out.IsTruncated) {
+			return nil
+		}
+		input.Marker = out.Marker
+	}
+}
+
+// awsPolicySourceARN returns the ARN of the IAM principal whose policies apply to the caller. Policies
+// are attached to the role, not to the session assuming it. An empty string is returned for the root user.
+func awsPolicySourceARN(callerARN string) (string, error) {
+	a, err := arn.Parse(callerARN)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case a.Service == "iam" && a.Resource == "root":
+		return "", nil
+	case a.Service == "sts" && strings.HasPrefix(a.Resource, "assumed-role/"):
+		parts := strings.Split(a.Resource, "/")
+		return arn.ARN{
+			Partition: a.Partition,
+			Service:   "iam",
+			AccountID: a.AccountID,
+			Resource:  "role/" + parts[1],
+		}.String(), nil
+	}
+	return callerARN, nil
+}
+
+func awsServiceQuota(awsClient awsclient.Client, serviceCode, quotaCode string) (float64, error) {
+	out, err := awsClient.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(serviceCode),
+		QuotaCode:   aws.String(quotaCode),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if out.Quota == nil || out.Quota.Value == nil {
+		return 0, fmt.Errorf("no value for quota %s", quotaCode)
+	}
+	return *out.Quota.Value, nil
+}
+
+func awsIsStandardInstanceType(instanceType string) bool {
+	return instanceType != "" && strings.ContainsRune(awsStandardInstanceFamilies, rune(instanceType[0]))
+}
+
+func awsCheckVCPUQuota(awsClient awsclient.Client, ic *installertypes.InstallConfig, result *preflightResult) error {
+	machines := installMachines(ic, awsInstanceType(ic))
+	instanceTypes := make([]string, 0, len(machines))
+	for _, m := range machines {
+		if awsIsStandardInstanceType(m.instanceType) {
+			instanceTypes = append(instanceTypes, m.instanceType)
+		}
+	}
+	if len(instanceTypes) == 0 {
+		return nil
+	}
+
+	typesOut, err := awsClient.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: aws.StringSlice(instanceTypes),
+	})
+	if err != nil {
+		return err
+	}
+	vcpus := map[string]int64{}
+	for _, t := range typesOut.InstanceTypes {
+		if t.VCpuInfo != nil {
+			vcpus[aws.StringValue(t.InstanceType)] = aws.Int64Value(t.VCpuInfo.DefaultVCpus)
+		}
+	}
+	var required int64
+	for _, m := range machines {
+		required += m.count * vcpus[m.instanceType]
+	}
+
+	limit, err := awsServiceQuota(awsClient, "ec2", awsStandardInstanceVCPUQuotaCode)
+	if err != nil {
+		return err
+	}
+
+	var used int64
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("instance-state-name"),
+			Values: aws.StringSlice([]string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning}),
+		}},
+	}
+	for {
+		out, err := awsClient.DescribeInstances(input)
+		if err != nil {
+			return err
+		}
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				if !awsIsStandardInstanceType(aws.StringValue(instance.InstanceType)) || instance.CpuOptions == nil {
+					continue
+				}
+				used += aws.Int64Value(instance.CpuOptions.CoreCount) * aws.Int64Value(instance.CpuOptions.ThreadsPerCore)
+			}
+		}
+		if aws.StringValue(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	result.checkQuota("standard instance vCPUs", float64(required), float64(used), limit)
+	return nil
+}
+
+// awsCheckElasticIPQuota checks there are enough elastic IPs for the NAT gateway the installer
+// creates in each availability zone.
+func awsCheckElasticIPQuota(awsClient awsclient.Client, ic *installertypes.InstallConfig, result *preflightResult) error {
+	zones := map[string]bool{}
+	for _, pool := range installMachinePools(ic) {
+		if pool.Platform.AWS != nil {
+			for _, z := range pool.Platform.AWS.Zones {
+				zones[z] = true
+			}
+		}
+	}
+	if d := ic.Platform.AWS.DefaultMachinePlatform; len(zones) == 0 && d != nil {
+		for _, z := range d.Zones {
+			zones[z] = true
+		}
+	}
+	required := len(zones)
+	if required == 0 {
+		out, err := awsClient.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+			Filters: []*ec2.Filter{{
+				Name:   aws.String("state"),
+				Values: aws.StringSlice([]string{ec2.AvailabilityZoneStateAvailable}),
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		required = len(out.AvailabilityZones)
+	}
+
+	limit, err := awsServiceQuota(awsClient, "ec2", awsElasticIPQuotaCode)
+	if err != nil {
+		return err
+	}
+	addresses, err := awsClient.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("domain"),
+			Values: aws.StringSlice([]string{ec2.DomainTypeVpc}),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	result.checkQuota("elastic IPs", float64(required), float64(len(addresses.Addresses)), limit)
+	return nil
+}
+
+func awsCheckVPCQuota(awsClient awsclient.Client, result *preflightResult) error {
+	limit, err := awsServiceQuota(awsClient, "vpc", awsVPCQuotaCode)
+	if err != nil {
+		return err
+	}
+
+	var used int
+	input := &ec2.DescribeVpcsInput{}
+	for {
+		out, err := awsClient.DescribeVpcs(input)
+		if err != nil {
+			return err
+		}
+		used += len(out.Vpcs)
+		if aws.StringValue(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	result.checkQuota("VPCs", 1, float64(used), limit)
+	return nil
+}
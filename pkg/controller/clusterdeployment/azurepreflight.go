@@ -0,0 +1,105 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	log "github.com/sirupsen/logrus"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	"github.com/openshift/hive/pkg/azureclient"
+)
+
+const (
+	azureDefaultControlPlaneInstanceType = "Standard_D8s_v3"
+	azureDefaultComputeInstanceType      = "Standard_D4s_v3"
+
+	// azureRegionalCoresUsage is the usage name of the total regional vCPU quota.
+	azureRegionalCoresUsage = "cores"
+)
+
+// azurePreflightChecks checks the regional and per VM family vCPU quotas. Azure role assignments cannot
+// be evaluated for a set of actions up front, so permissions are not checked.
+func azurePreflightChecks(azureClient azureclient.Client, ic *installertypes.InstallConfig, result *preflightResult, logger log.FieldLogger) {
+	preflightCheck("vCPU quota", logger, func() error {
+		return azureCheckVCPUQuota(azureClient, ic, result)
+	})
+}
+
+func azureInstanceType(ic *installertypes.InstallConfig) func(*installertypes.MachinePool, bool) string {
+	return func(pool *installertypes.MachinePool, controlPlane bool) string {
+		if pool != nil && pool.Platform.Azure != nil && pool.Platform.Azure.InstanceType != "" {
+			return pool.Platform.Azure.InstanceType
+		}
+		if d := ic.Platform.Azure.DefaultMachinePlatform; d != nil && d.InstanceType != "" {
+			return d.InstanceType
+		}
+		if controlPlane {
+			return azureDefaultControlPlaneInstanceType
+		}
+		return azureDefaultComputeInstanceType
+	}
+}
+
+type azureSKU struct {
+	family string
+	vcpus  int64
+}
+
+func azureCheckVCPUQuota(azureClient azureclient.Client, ic *installertypes.InstallConfig, result *preflightResult) error {
+	ctx := context.TODO()
+	region := ic.Platform.Azure.Region
+	machines := installMachines(ic, azureInstanceType(ic))
+
+	skus := map[string]azureSKU{}
+	var skuPage azureclient.ResourceSKUsPage
+	var err error
+	for skuPage, err = azureClient.ListResourceSKUs(ctx, fmt.Sprintf("location eq '%s'", region)); err == nil && skuPage.NotDone(); err = skuPage.NextWithContext(ctx) {
+		for _, sku := range skuPage.Values() {
+			if !strings.EqualFold(to.String(sku.ResourceType), "virtualMachines") || sku.Capabilities == nil {
+				continue
+			}
+			for _, c := range *sku.Capabilities {
+				if to.String(c.Name) != "vCPUs" {
+					continue
+				}
+				vcpus, err := strconv.ParseInt(to.String(c.Value), 10, 64)
+				if err != nil {
+					return err
+				}
+				skus[strings.ToLower(to.String(sku.Name))] = azureSKU{family: to.String(sku.Family), vcpus: vcpus}
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	required := map[string]int64{}
+	for _, m := range machines {
+		sku, ok := skus[strings.ToLower(m.instanceType)]
+		if !ok {
+			return fmt.Errorf("instance type %s not found in region %s", m.instanceType, region)
+		}
+		required[azureRegionalCoresUsage] += m.count * sku.vcpus
+		required[sku.family] += m.count * sku.vcpus
+	}
+
+	var usagePage azureclient.UsagePage
+	for usagePage, err = azureClient.ListUsage(ctx, region); err == nil && usagePage.NotDone(); err = usagePage.NextWithContext(ctx) {
+		for _, u := range usagePage.Values() {
+			if u.Name == nil {
+				continue
+			}
+			name := to.String(u.Name.Value)
+			if r, ok := required[name]; ok {
+				result.checkQuota(fmt.Sprintf("%s vCPUs", name), float64(r), float64(to.Int32(u.CurrentValue)), float64(to.Int64(u.Limit)))
+			}
+		}
+	}
+	return err
+}
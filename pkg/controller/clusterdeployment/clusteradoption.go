@@ -0,0 +1,217 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	adoptionSucceededReason                  = "AdoptionSucceeded"
+	adoptionRemoteClusterUnreachableReason   = "RemoteClusterUnreachable"
+	adoptionClusterMetadataUnavailableReason = "ClusterMetadataUnavailable"
+	adoptionClusterMetadataMismatchReason    = "ClusterMetadataMismatch"
+
+	// adoptionRetryInterval is how long to wait before trying again to read the metadata of a cluster being adopted.
+	adoptionRetryInterval = 2 * time.Minute
+)
+
+// adoptedClusterInfo is the metadata read from a cluster being adopted.
+type adoptedClusterInfo struct {
+	clusterID     string
+	infraID       string
+	platformType  openshiftapiv1.PlatformType
+	region        string
+	clusterDomain string
+}
+
+// reconcileAdoption connects to a cluster being adopted using the admin kubeconfig, fills in the ClusterMetadata,
+// the platform region and the base domain from the ClusterVersion, Infrastructure and DNS objects of the cluster,
+// and then marks the cluster as installed. Failures are reported in the ClusterAdoptionFailed condition.
+func (r *ReconcileClusterDeployment) reconcileAdoption(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
+	cdLog.Debug("reconciling cluster adoption")
+
+	remoteClient, err := r.remoteClusterAPIClientBuilder(cd).Build()
+	if err != nil {
+		cdLog.WithError(err).Info("could not connect to cluster being adopted")
+		return r.setAdoptionFailedCondition(cd, adoptionRemoteClusterUnreachableReason, err, cdLog)
+	}
+
+	info, err := readAdoptedClusterInfo(remoteClient)
+	if err != nil {
+		cdLog.WithError(err).Info("could not read metadata of cluster being adopted")
+		return r.setAdoptionFailedCondition(cd, adoptionClusterMetadataUnavailableReason, err, cdLog)
+	}
+
+	if err := applyAdoptedClusterInfo(cd, info); err != nil {
+		cdLog.WithError(err).Info("cluster being adopted does not match cluster deployment")
+		return r.setAdoptionFailedCondition(cd, adoptionClusterMetadataMismatchReason, err, cdLog)
+	}
+
+	cd.Spec.Installed = true
+	if err := r.Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster deployment with adopted cluster metadata")
+		return reconcile.Result{}, err
+	}
+	cdLog.WithField("infraID", info.infraID).Info("adopted cluster")
+
+	if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterAdoptionFailedCondition); cond != nil && cond.Status != corev1.ConditionFalse {
+		cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+			cd.Status.Conditions,
+			hivev1.ClusterAdoptionFailedCondition,
+			corev1.ConditionFalse,
+			adoptionSucceededReason,
+			"Cluster has been adopted",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileClusterDeployment) setAdoptionFailedCondition(cd *hivev1.ClusterDeployment, reason string, adoptionErr error, cdLog log.FieldLogger) (reconcile.Result, error) {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ClusterAdoptionFailedCondition,
+		corev1.ConditionTrue,
+		reason,
+		controllerutils.ErrorScrub(adoptionErr),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		cd.Status.Conditions = conditions
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: adoptionRetryInterval}, nil
+}
+
+// readAdoptedClusterInfo reads the metadata of a cluster being adopted from its ClusterVersion, Infrastructure and
+// DNS objects.
+func readAdoptedClusterInfo(remoteClient client.Client) (*adoptedClusterInfo, error) {
+	clusterVersion := &openshiftapiv1.ClusterVersion{}
+	if err := remoteClient.Get(context.TODO(), client.ObjectKey{Name: "version"}, clusterVersion); err != nil {
+		return nil, errors.Wrap(err, "could not get ClusterVersion")
+	}
+	infrastructure := &openshiftapiv1.Infrastructure{}
+	if err := remoteClient.Get(context.TODO(), client.ObjectKey{Name: "cluster"}, infrastructure); err != nil {
+		return nil, errors.Wrap(err, "could not get Infrastructure")
+	}
+	dns := &openshiftapiv1.DNS{}
+	if err := remoteClient.Get(context.TODO(), client.ObjectKey{Name: "cluster"}, dns); err != nil {
+		return nil, errors.Wrap(err, "could not get DNS")
+	}
+
+	info := &adoptedClusterInfo{
+		clusterID:     string(clusterVersion.Spec.ClusterID),
+		infraID:       infrastructure.Status.InfrastructureName,
+		platformType:  infrastructure.Status.Platform,
+		clusterDomain: dns.Spec.BaseDomain,
+	}
+	if ps := infrastructure.Status.PlatformStatus; ps != nil {
+		info.platformType = ps.Type
+		switch {
+		case ps.AWS != nil:
+			info.region = ps.AWS.Region
+		case ps.GCP != nil:
+			info.region = ps.GCP.Region
+		}
+	}
+	switch {
+	case info.clusterID == "":
+		return nil, errors.New("ClusterVersion has no cluster ID")
+	case info.infraID == "":
+		return nil, errors.New("Infrastructure has no infrastructure name")
+	case info.clusterDomain == "":
+		return nil, errors.New("DNS has no base domain")
+	}
+	return info, nil
+}
+
+// applyAdoptedClusterInfo fills in the ClusterMetadata of the cluster deployment, and the cluster name, base domain
+// and platform region when they have not been set. An error is returned if the metadata read from the cluster
+// conflicts with the values set on the cluster deployment.
+func applyAdoptedClusterInfo(cd *hivev1.ClusterDeployment, info *adoptedClusterInfo) error {
+	if expected := adoptionPlatformType(cd.Spec.Platform); expected != "" && info.platformType != expected {
+		return fmt.Errorf("cluster platform %q does not match cluster deployment platform %q", info.platformType, expected)
+	}
+
+	// The base domain of the cluster's DNS is made up of the cluster name and the base domain.
+	clusterName := cd.Spec.ClusterName
+	if clusterName == "" {
+		clusterName = strings.SplitN(info.clusterDomain, ".", 2)[0]
+	}
+	baseDomain := strings.TrimPrefix(info.clusterDomain, clusterName+".")
+	if baseDomain == info.clusterDomain {
+		return fmt.Errorf("cluster domain %q does not start with cluster name %q", info.clusterDomain, clusterName)
+	}
+	if cd.Spec.BaseDomain != "" && cd.Spec.BaseDomain != baseDomain {
+		return fmt.Errorf("cluster base domain %q does not match cluster deployment base domain %q", baseDomain, cd.Spec.BaseDomain)
+	}
+
+	var region *string
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		region = &cd.Spec.Platform.AWS.Region
+	case cd.Spec.Platform.GCP != nil:
+		region = &cd.Spec.Platform.GCP.Region
+	}
+	if region != nil && info.region != "" {
+		if *region != "" && *region != info.region {
+			return fmt.Errorf("cluster region %q does not match cluster deployment region %q", info.region, *region)
+		}
+		*region = info.region
+	}
+
+	cd.Spec.ClusterName = clusterName
+	cd.Spec.BaseDomain = baseDomain
+	cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+		ClusterID:                info.clusterID,
+		InfraID:                  info.infraID,
+		AdminKubeconfigSecretRef: cd.Spec.Adoption.AdminKubeconfigSecretRef,
+	}
+	if ref := cd.Spec.Adoption.AdminPasswordSecretRef; ref != nil {
+		cd.Spec.ClusterMetadata.AdminPasswordSecretRef = *ref
+	}
+	return nil
+}
+
+// adoptionPlatformType returns the platform type that a cluster being adopted must report for the platform of the
+// cluster deployment, or an empty string if the platform type is not checked.
+func adoptionPlatformType(platform hivev1.Platform) openshiftapiv1.PlatformType {
+	switch {
+	case platform.AWS != nil:
+		return openshiftapiv1.AWSPlatformType
+	case platform.Azure != nil:
+		return openshiftapiv1.AzurePlatformType
+	case platform.GCP != nil:
+		return openshiftapiv1.GCPPlatformType
+	case platform.OpenStack != nil:
+		return openshiftapiv1.OpenStackPlatformType
+	case platform.VSphere != nil:
+		return openshiftapiv1.VSpherePlatformType
+	case platform.Ovirt != nil:
+		return openshiftapiv1.OvirtPlatformType
+	case platform.BareMetal != nil:
+		return openshiftapiv1.BareMetalPlatformType
+	}
+	return ""
+}
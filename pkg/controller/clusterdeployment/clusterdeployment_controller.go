@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/pointer"
@@ -43,9 +44,12 @@ import (
 	apihelpers "github.com/openshift/hive/apis/helpers"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/azureclient"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/hive/pkg/imageset"
 	"github.com/openshift/hive/pkg/remoteclient"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
@@ -67,6 +71,12 @@ var (
 		hivev1.ProvisionStoppedCondition,
 		hivev1.AuthenticationFailureClusterDeploymentCondition,
 		hivev1.RequirementsMetCondition,
+		hivev1.PreflightChecksPassedCondition,
+		hivev1.ProvisionHookFailedCondition,
+		hivev1.PreDeprovisionHooksCompleteCondition,
+		hivev1.ProvisionDryRunCompleteCondition,
+		hivev1.ClusterExpiringSoonCondition,
+		hivev1.ClusterAdoptionFailedCondition,
 
 		// ClusterInstall conditions copied over to cluster deployment
 		hivev1.ClusterInstallFailedClusterDeploymentCondition,
@@ -102,7 +112,10 @@ const (
 	installOnlyOnceSetReason          = "InstallOnlyOnceSet"
 	provisionNotStoppedReason         = "ProvisionNotStopped"
 
-	deleteAfterAnnotation    = "hive.openshift.io/delete-after" // contains a duration after which the cluster should be cleaned up.
+	dryRunCompleteReason = "DryRunComplete"
+	dryRunDisabledReason = "DryRunDisabled"
+
+	deleteAfterAnnotation    = constants.DeleteAfterAnnotation
 	tryInstallOnceAnnotation = "hive.openshift.io/try-install-once"
 
 	regionUnknown = "unknown"
@@ -128,10 +141,14 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		expectations:                            controllerutils.NewExpectations(logger),
 		watchingClusterInstall:                  map[string]struct{}{},
 		validateCredentialsForClusterDeployment: controllerutils.ValidateCredentialsForClusterDeployment,
+		eventRecorder:                           mgr.GetEventRecorderFor(ControllerName.String()),
 	}
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
 	}
+	r.awsClientBuilder = newAWSClientBuilder(r)
+	r.gcpClientBuilder = newGCPClientBuilder(r)
+	r.azureClientBuilder = newAzureClientBuilder(r)
 
 	protectedDeleteEnvVar := os.Getenv(constants.ProtectedDeleteEnvVar)
 	if protectedDelete, err := strconv.ParseBool(protectedDeleteEnvVar); protectedDelete && err == nil {
@@ -266,10 +283,19 @@ type ReconcileClusterDeployment struct {
 	// that the platform creds are good (used for testing)
 	validateCredentialsForClusterDeployment func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
 
+	// awsClientBuilder, gcpClientBuilder and azureClientBuilder build the cloud clients used for the preflight
+	// checks run before a provision is started. The checks are skipped for a platform without a builder.
+	awsClientBuilder   func(*hivev1.ClusterDeployment) (awsclient.Client, error)
+	gcpClientBuilder   func(*hivev1.ClusterDeployment) (gcpclient.Client, error)
+	azureClientBuilder func(*hivev1.ClusterDeployment) (azureclient.Client, error)
+
 	// releaseImageVerifier, if provided, will be used to check an release image before it is executed.
 	// Any error will prevent a release image from being accessed.
 	releaseImageVerifier verify.Interface
 
+	// eventRecorder records the warning Events emitted before the cluster expires.
+	eventRecorder record.EventRecorder
+
 	protectedDelete bool
 }
 
@@ -513,38 +539,18 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return r.syncDeletedClusterDeployment(cd, cdLog)
 	}
 
-	// Check for the delete-after annotation, and if the cluster has expired, delete it
-	deleteAfter, ok := cd.Annotations[deleteAfterAnnotation]
-	if ok {
-		cdLog.Debugf("found delete after annotation: %s", deleteAfter)
-		dur, err := time.ParseDuration(deleteAfter)
-		if err != nil {
-			cdLog.WithError(err).WithField("deleteAfter", deleteAfter).Infof("error parsing %s as a duration", deleteAfterAnnotation)
-			return reconcile.Result{}, fmt.Errorf("error parsing %s as a duration: %v", deleteAfterAnnotation, err)
-		}
-		if !cd.CreationTimestamp.IsZero() {
-			expiry := cd.CreationTimestamp.Add(dur)
-			cdLog.Debugf("cluster expires at: %s", expiry)
-			if time.Now().After(expiry) {
-				cdLog.WithField("expiry", expiry).Info("cluster has expired, issuing delete")
-				err := r.Delete(context.TODO(), cd)
-				if err != nil {
-					cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting expired cluster")
-				}
-				return reconcile.Result{}, err
-			}
-
-			defer func() {
-				// We have an expiry time but we're not expired yet. Set requeueAfter to the expiry time
-				// so that we requeue cluster for deletion once reconcile has completed
-				result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(
-					time.Until(cd.CreationTimestamp.Add(dur)),
-					result,
-					returnErr,
-				)
-			}()
-
-		}
+	// Check for the expiration of the cluster, and if the cluster has expired, delete it
+	switch deleted, requeueAfter, err := r.reconcileExpiration(cd, cdLog); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case deleted:
+		return reconcile.Result{}, nil
+	case requeueAfter > 0:
+		defer func() {
+			// We have an expiry time but we're not expired yet. Requeue the cluster for the next warning or for
+			// deletion once reconcile has completed.
+			result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(requeueAfter, result, returnErr)
+		}()
 	}
 
 	if !controllerutils.HasFinalizer(cd, hivev1.FinalizerDeprovision) {
@@ -619,6 +625,10 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return *result, err
 	}
 
+	if cd.Spec.Adoption != nil {
+		return r.reconcileAdoption(cd, cdLog)
+	}
+
 	// Sanity check the platform/cloud credentials.
 	validCreds, err := r.validatePlatformCreds(cd, cdLog)
 	if err != nil {
@@ -767,6 +777,32 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 			return reconcile.Result{}, nil
 		}
 
+		// Only check the cloud account right before starting a new provision, the provision may take a while
+		// to complete and will consume the quota we would be checking for.
+		if cd.Status.ProvisionRef == nil {
+			reason, message, failed, recheckAfter, err := r.reconcilePreflightChecks(cd, icSecret.Data["install-config.yaml"], cdLog)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if failed {
+				cdLog.WithField("reason", reason).Info(message)
+				conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+					cd.Status.Conditions,
+					hivev1.RequirementsMetCondition,
+					corev1.ConditionFalse,
+					reason,
+					message,
+					controllerutils.UpdateConditionIfReasonOrMessageChange)
+				if changed {
+					cd.Status.Conditions = conditions
+					if err := r.Status().Update(context.TODO(), cd); err != nil {
+						return reconcile.Result{}, err
+					}
+				}
+				return reconcile.Result{RequeueAfter: recheckAfter}, nil
+			}
+		}
+
 		// If we made it this far, RequirementsMet condition should be True:
 		//
 		// TODO: when https://github.com/openshift/hive/pull/1413 is implemented
@@ -1294,6 +1330,12 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 		return false, err
 	}
 
+	// A dry run with the name of the cluster deployment blocks the real deprovision, which must not be skipped.
+	if existingRequest.Spec.DryRun {
+		cdLog.Warn("deprovision request for cluster deployment is a dry run, delete it to deprovision the cluster")
+		return false, nil
+	}
+
 	authenticationFailureCondition := controllerutils.FindClusterDeprovisionCondition(existingRequest.Status.Conditions, hivev1.AuthenticationFailureClusterDeprovisionCondition)
 	if authenticationFailureCondition != nil {
 		err := r.setDeprovisionLaunchErrorCondition(cd,
@@ -1338,6 +1380,11 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 		return reconcile.Result{}, nil
 	}
 
+	// Run the pre-deprovision hooks while the cluster, and the DNS used to reach it, are still around
+	if hooksDone, requeueAfter, err := r.reconcilePreDeprovisionHooks(cd, cdLog); err != nil || !hooksDone {
+		return reconcile.Result{RequeueAfter: requeueAfter}, err
+	}
+
 	dnsZoneGone, err := r.ensureManagedDNSZoneDeleted(cd, cdLog)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -1849,15 +1896,27 @@ func (r *ReconcileClusterDeployment) updatePullSecretInfo(pullSecret string, cd
 	return true, nil
 }
 
-func calculateNextProvisionTime(failureTime time.Time, retries int, cdLog log.FieldLogger) time.Time {
-	// (2^currentRetries) * 60 seconds up to a max of 24 hours.
-	const sleepCap = 24 * time.Hour
-	const retryCap = 11 // log_2_(24*60)
+func calculateNextProvisionTime(failureTime time.Time, retries int, backoff *hivev1.InstallRetryBackoff, cdLog log.FieldLogger) time.Time {
+	// (2^currentRetries) * initial delay up to the max delay, by default 60 seconds up to a max of 24 hours.
+	initialDelay := time.Minute
+	sleepCap := 24 * time.Hour
+	if backoff != nil {
+		if backoff.InitialDelay != nil && backoff.InitialDelay.Duration > 0 {
+			initialDelay = backoff.InitialDelay.Duration
+		}
+		if backoff.MaxDelay != nil && backoff.MaxDelay.Duration > 0 {
+			sleepCap = backoff.MaxDelay.Duration
+		}
+	}
 
-	if retries >= retryCap {
-		return failureTime.Add(sleepCap)
+	delay := initialDelay
+	for i := 0; i < retries && delay < sleepCap; i++ {
+		delay *= 2
+	}
+	if delay > sleepCap {
+		delay = sleepCap
 	}
-	return failureTime.Add((1 << uint(retries)) * time.Minute)
+	return failureTime.Add(delay)
 }
 
 func (r *ReconcileClusterDeployment) existingProvisions(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]*hivev1.ClusterProvision, error) {
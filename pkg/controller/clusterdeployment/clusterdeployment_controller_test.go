@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +43,8 @@ import (
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/baremetal"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
@@ -142,6 +146,9 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 		validate                      func(client.Client, *testing.T)
 		reconcilerSetup               func(*ReconcileClusterDeployment)
 		platformCredentialsValidation func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
+		setupAWSClient                func(*mockaws.MockClient)
+		setupRemoteClientBuilder      func(*remoteclientmock.MockBuilder)
+		expectedEventReasons          []string
 	}{
 		{
 			name: "Initialize conditions",
@@ -181,6 +188,250 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Len(t, provisions, 1, "expected provision to exist")
 			},
 		},
+		{
+			name: "Create pre-install hook job before provision",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithHooks(testClusterDeployment(), testProvisionHook("pre", hivev1.PreInstallProvisionHookPhase, hivev1.FailProvisionHookFailurePolicy)))),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision while pre-install hook is running")
+				job := getJob(c, testProvisionHookJobName("pre"))
+				if assert.NotNil(t, job, "expected pre-install hook job") {
+					assert.Equal(t, constants.JobTypeProvisionHook, job.Labels[constants.JobTypeLabel], "unexpected job type label")
+					assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy, "unexpected restart policy")
+					assert.Contains(t, job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "CLUSTER_DEPLOYMENT_NAME", Value: testName}, "expected cluster deployment name env var")
+					assert.Empty(t, job.Spec.Template.Spec.Volumes, "unexpected volumes on pre-install hook job")
+				}
+			},
+		},
+		{
+			name: "Create provision after pre-install hook succeeded",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithHooks(testClusterDeployment(), testProvisionHook("pre", hivev1.PreInstallProvisionHookPhase, hivev1.FailProvisionHookFailurePolicy)))),
+				testProvisionHookJob("pre", batchv1.JobComplete),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "Stop provision after pre-install hook failed",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithHooks(testClusterDeployment(), testProvisionHook("pre", hivev1.PreInstallProvisionHookPhase, hivev1.FailProvisionHookFailurePolicy)))),
+				testProvisionHookJob("pre", batchv1.JobFailed),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision after pre-install hook failed")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.ProvisionHookFailedCondition)
+				if assert.NotNil(t, cond, "expected ProvisionHookFailed condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, "PreInstallHookFailed", cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Create provision after ignored pre-install hook failure",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithHooks(testClusterDeployment(), testProvisionHook("pre", hivev1.PreInstallProvisionHookPhase, hivev1.IgnoreProvisionHookFailurePolicy)))),
+				testProvisionHookJob("pre", batchv1.JobFailed),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+				testassert.AssertConditionStatus(t, getCD(c), hivev1.ProvisionHookFailedCondition, corev1.ConditionUnknown)
+			},
+		},
+		{
+			name: "Run pre-install hooks in order",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithHooks(testClusterDeployment(),
+					testProvisionHook("first", hivev1.PreInstallProvisionHookPhase, hivev1.FailProvisionHookFailurePolicy),
+					testProvisionHook("second", hivev1.PreInstallProvisionHookPhase, hivev1.FailProvisionHookFailurePolicy),
+				))),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getJob(c, testProvisionHookJobName("first")), "expected first hook job")
+				assert.Nil(t, getJob(c, testProvisionHookJobName("second")), "unexpected second hook job")
+			},
+		},
+		{
+			name: "Create provision when preflight checks pass",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			setupAWSClient: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 100)
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Len(t, provisions, 1, "expected provision to exist")
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				testassert.AssertConditionStatus(t, cd, hivev1.RequirementsMetCondition, corev1.ConditionTrue)
+			},
+		},
+		{
+			name: "Provision not created with insufficient quota",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			setupAWSClient: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 16)
+			},
+			expectedRequeueAfter: preflightRecheckInterval,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Empty(t, provisions, "expected provision to not exist")
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				testassert.AssertConditions(t, cd, []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.RequirementsMetCondition,
+					Status: corev1.ConditionFalse,
+					Reason: insufficientQuotaReason,
+				}})
+			},
+		},
+		{
+			name: "Provision not created with missing permissions",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			setupAWSClient: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 100, "ec2:RunInstances")
+			},
+			expectedRequeueAfter: preflightRecheckInterval,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Empty(t, provisions, "expected provision to not exist")
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				testassert.AssertConditions(t, cd, []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.RequirementsMetCondition,
+					Status: corev1.ConditionFalse,
+					Reason: missingPermissionsReason,
+				}})
+			},
+		},
+		{
+			name: "Preflight checks skipped by annotation",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment()))
+					cd.Annotations = map[string]string{constants.SkipPreflightChecksAnnotation: "true"}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			setupAWSClient:        func(client *mockaws.MockClient) {},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Len(t, provisions, 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "Recent preflight check failure used without running checks",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				withPreflightResult(
+					testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+					corev1.ConditionFalse, insufficientQuotaReason, time.Now().Add(-5*time.Minute), true,
+				),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			setupAWSClient:       func(client *mockaws.MockClient) {},
+			expectedRequeueAfter: 5 * time.Minute,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Empty(t, provisions, "expected provision to not exist")
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				testassert.AssertConditions(t, cd, []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.RequirementsMetCondition,
+					Status: corev1.ConditionFalse,
+					Reason: insufficientQuotaReason,
+				}})
+			},
+		},
+		{
+			name: "Preflight checks run again when result is old",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				withPreflightResult(
+					testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+					corev1.ConditionFalse, insufficientQuotaReason, time.Now().Add(-20*time.Minute), true,
+				),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			setupAWSClient: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 100)
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Len(t, provisions, 1, "expected provision to exist")
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				testassert.AssertConditionStatus(t, cd, hivev1.PreflightChecksPassedCondition, corev1.ConditionTrue)
+				testassert.AssertConditionStatus(t, cd, hivev1.RequirementsMetCondition, corev1.ConditionTrue)
+			},
+		},
+		{
+			name: "Preflight checks run again when install config changes",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				withPreflightResult(
+					testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+					corev1.ConditionFalse, insufficientQuotaReason, time.Now().Add(-5*time.Minute), false,
+				),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			setupAWSClient: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 100)
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Len(t, provisions, 1, "expected provision to exist")
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				testassert.AssertConditionStatus(t, cd, hivev1.PreflightChecksPassedCondition, corev1.ConditionTrue)
+				hash, err := preflightChecksHash(cd, []byte(testAWSIC))
+				require.NoError(t, err, "unexpected error calculating preflight checks hash")
+				assert.Equal(t, hash, cd.Annotations[constants.PreflightChecksHashAnnotation], "unexpected preflight checks hash")
+			},
+		},
 		{
 			name: "Provision not created when pending create",
 			existing: []runtime.Object{
@@ -312,6 +563,306 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Adopt cluster",
+			existing: []runtime.Object{
+				testClusterDeploymentWithInitializedConditions(testAdoptionClusterDeployment()),
+			},
+			setupRemoteClientBuilder: func(builder *remoteclientmock.MockBuilder) {
+				builder.EXPECT().Build().Return(testAdoptedRemoteClusterAPIClient(&openshiftapiv1.PlatformStatus{
+					Type: openshiftapiv1.AWSPlatformType,
+					AWS:  &openshiftapiv1.AWSPlatformStatus{Region: "us-west-2"},
+				}), nil)
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.True(t, cd.Spec.Installed, "expected cluster to be installed")
+					assert.Equal(t, &hivev1.ClusterMetadata{
+						ClusterID:                testClusterID,
+						InfraID:                  testInfraID,
+						AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigSecret},
+						AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: adminPasswordSecret},
+					}, cd.Spec.ClusterMetadata, "unexpected cluster metadata")
+					assert.Equal(t, "example.com", cd.Spec.BaseDomain, "unexpected base domain")
+					assert.Equal(t, "us-west-2", cd.Spec.Platform.AWS.Region, "unexpected region")
+					testassert.AssertConditionStatus(t, cd, hivev1.ClusterAdoptionFailedCondition, corev1.ConditionFalse)
+				}
+			},
+		},
+		{
+			name: "Adopt cluster after previous failure",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testAdoptionClusterDeployment())
+					cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+						cd.Status.Conditions,
+						hivev1.ClusterAdoptionFailedCondition,
+						corev1.ConditionTrue,
+						adoptionRemoteClusterUnreachableReason,
+						"",
+						controllerutils.UpdateConditionAlways)
+					return cd
+				}(),
+			},
+			setupRemoteClientBuilder: func(builder *remoteclientmock.MockBuilder) {
+				builder.EXPECT().Build().Return(testAdoptedRemoteClusterAPIClient(&openshiftapiv1.PlatformStatus{
+					Type: openshiftapiv1.AWSPlatformType,
+					AWS:  &openshiftapiv1.AWSPlatformStatus{Region: "us-west-2"},
+				}), nil)
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.True(t, cd.Spec.Installed, "expected cluster to be installed")
+					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterAdoptionFailedCondition)
+					if assert.NotNil(t, cond, "expected ClusterAdoptionFailed condition") {
+						assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+						assert.Equal(t, adoptionSucceededReason, cond.Reason, "unexpected condition reason")
+					}
+				}
+			},
+		},
+		{
+			name: "Adoption fails when cluster is unreachable",
+			existing: []runtime.Object{
+				testClusterDeploymentWithInitializedConditions(testAdoptionClusterDeployment()),
+			},
+			setupRemoteClientBuilder: func(builder *remoteclientmock.MockBuilder) {
+				builder.EXPECT().Build().Return(nil, fmt.Errorf("connection refused"))
+			},
+			expectedRequeueAfter: adoptionRetryInterval,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster not to be installed")
+					assert.Nil(t, cd.Spec.ClusterMetadata, "unexpected cluster metadata")
+					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterAdoptionFailedCondition)
+					if assert.NotNil(t, cond, "expected ClusterAdoptionFailed condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+						assert.Equal(t, adoptionRemoteClusterUnreachableReason, cond.Reason, "unexpected condition reason")
+					}
+				}
+			},
+		},
+		{
+			name: "Adoption fails on platform mismatch",
+			existing: []runtime.Object{
+				testClusterDeploymentWithInitializedConditions(testAdoptionClusterDeployment()),
+			},
+			setupRemoteClientBuilder: func(builder *remoteclientmock.MockBuilder) {
+				builder.EXPECT().Build().Return(testAdoptedRemoteClusterAPIClient(&openshiftapiv1.PlatformStatus{
+					Type: openshiftapiv1.GCPPlatformType,
+					GCP:  &openshiftapiv1.GCPPlatformStatus{Region: "us-east1"},
+				}), nil)
+			},
+			expectedRequeueAfter: adoptionRetryInterval,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster not to be installed")
+					assert.Empty(t, cd.Spec.Platform.AWS.Region, "unexpected region")
+					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterAdoptionFailedCondition)
+					if assert.NotNil(t, cond, "expected ClusterAdoptionFailed condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+						assert.Equal(t, adoptionClusterMetadataMismatchReason, cond.Reason, "unexpected condition reason")
+					}
+				}
+			},
+		},
+		{
+			name: "Create dry run provision without running pre-install hooks",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testDryRunClusterDeployment(testClusterDeploymentWithHooks(testClusterDeployment(), testProvisionHook("pre", hivev1.PreInstallProvisionHookPhase, hivev1.FailProvisionHookFailurePolicy))))),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 1, "expected provision to exist") {
+					assert.True(t, provisions[0].Spec.DryRun, "expected dry run provision")
+				}
+				assert.Nil(t, getJob(c, testProvisionHookJobName("pre")), "unexpected pre-install hook job")
+			},
+		},
+		{
+			name: "Completed dry run provision",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithInitializedConditions(testDryRunClusterDeployment(testClusterDeploymentWithProvision())),
+				testCompletedDryRunProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster not to be installed by dry run")
+					assert.NotNil(t, cd.Status.ProvisionRef, "expected dry run provision to remain referenced")
+					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionDryRunCompleteCondition)
+					if assert.NotNil(t, cond, "expected ProvisionDryRunComplete condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+						assert.Equal(t, dryRunCompleteReason, cond.Reason, "unexpected condition reason")
+						assert.Contains(t, cond.Message, provisionName+"-dry-run", "expected condition message to name result ConfigMap")
+					}
+				}
+			},
+		},
+		{
+			name: "Release completed dry run provision when dry run disabled",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+						cd.Status.Conditions,
+						hivev1.ProvisionDryRunCompleteCondition,
+						corev1.ConditionTrue,
+						dryRunCompleteReason,
+						"",
+						controllerutils.UpdateConditionAlways)
+					return cd
+				}(),
+				testCompletedDryRunProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected dry run provision to be released")
+					assert.Zero(t, cd.Status.InstallRestarts, "expected dry run not to count as an install attempt")
+					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionDryRunCompleteCondition)
+					if assert.NotNil(t, cond, "expected ProvisionDryRunComplete condition") {
+						assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+						assert.Equal(t, dryRunDisabledReason, cond.Reason, "unexpected condition reason")
+					}
+				}
+			},
+		},
+		{
+			name: "Do not adopt completed dry run provision when dry run disabled",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testCompletedDryRunProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "unexpected adoption of dry run provision")
+				}
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 2, "expected new provision to be created") {
+					for _, p := range provisions {
+						if p.Name != provisionName {
+							assert.False(t, p.Spec.DryRun, "expected new provision not to be a dry run")
+						}
+					}
+				}
+			},
+		},
+		{
+			name: "Completed provision waits for post-install hook",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+						ClusterID:                testClusterID,
+						InfraID:                  testInfraID,
+						AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigSecret},
+						AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: adminPasswordSecret},
+					}
+					return testClusterDeploymentWithHooks(cd, testProvisionHook("post", hivev1.PostInstallProvisionHookPhase, hivev1.FailProvisionHookFailurePolicy))
+				}(),
+				testSuccessfulProvision(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster not to be installed while post-install hook is running")
+				}
+				job := getJob(c, testProvisionHookJobName("post"))
+				if assert.NotNil(t, job, "expected post-install hook job") {
+					podSpec := job.Spec.Template.Spec
+					if assert.Len(t, podSpec.Volumes, 1, "expected kubeconfig volume") {
+						assert.Equal(t, adminKubeconfigSecret, podSpec.Volumes[0].Secret.SecretName, "unexpected kubeconfig secret")
+					}
+					assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "KUBECONFIG", Value: "/etc/kubeconfig/kubeconfig"}, "expected KUBECONFIG env var")
+				}
+			},
+		},
+		{
+			name: "Completed provision after post-install hook succeeded",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+						ClusterID:                testClusterID,
+						InfraID:                  testInfraID,
+						AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigSecret},
+						AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: adminPasswordSecret},
+					}
+					return testClusterDeploymentWithHooks(cd, testProvisionHook("post", hivev1.PostInstallProvisionHookPhase, hivev1.FailProvisionHookFailurePolicy))
+				}(),
+				testSuccessfulProvision(),
+				testMetadataConfigMap(),
+				testProvisionHookJob("post", batchv1.JobComplete),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.True(t, cd.Spec.Installed, "expected cluster to be installed")
+				}
+			},
+		},
+		{
+			name: "Completed provision after post-install hook failed",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+						ClusterID:                testClusterID,
+						InfraID:                  testInfraID,
+						AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigSecret},
+						AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: adminPasswordSecret},
+					}
+					return testClusterDeploymentWithHooks(cd, testProvisionHook("post", hivev1.PostInstallProvisionHookPhase, hivev1.FailProvisionHookFailurePolicy))
+				}(),
+				testSuccessfulProvision(),
+				testMetadataConfigMap(),
+				testProvisionHookJob("post", batchv1.JobFailed),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster not to be installed after post-install hook failed")
+					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionHookFailedCondition)
+					if assert.NotNil(t, cond, "expected ProvisionHookFailed condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+						assert.Equal(t, "PostInstallHookFailed", cond.Reason, "unexpected condition reason")
+					}
+				}
+			},
+		},
 		{
 			name: "Completed provision with protected delete",
 			existing: []runtime.Object{
@@ -1013,6 +1564,256 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.NotNil(t, deprovision, "expected deprovision request to be created")
 			},
 		},
+		{
+			name: "Create pre-deprovision hook job before deprovision",
+			existing: []runtime.Object{
+				testDeletedClusterDeploymentWithPreDeprovisionHooks(
+					testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy),
+					testPreDeprovisionHook("deregister", hivev1.FailProvisionHookFailurePolicy),
+				),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: defaultPreDeprovisionHookTimeout,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getDeprovision(c), "expected no deprovision request while pre-deprovision hook is running")
+				job := getJob(c, testPreDeprovisionHookJobName("drain"))
+				if assert.NotNil(t, job, "expected pre-deprovision hook job") {
+					assert.Equal(t, constants.JobTypePreDeprovisionHook, job.Labels[constants.JobTypeLabel], "unexpected job type label")
+					assert.Contains(t, job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "KUBECONFIG", Value: "/etc/kubeconfig/kubeconfig"}, "expected kubeconfig env var")
+					assert.Len(t, job.Spec.Template.Spec.Volumes, 1, "expected kubeconfig volume")
+				}
+				assert.Nil(t, getJob(c, testPreDeprovisionHookJobName("deregister")), "expected second hook to wait for the first")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition)
+				if assert.NotNil(t, cond, "expected PreDeprovisionHooksComplete condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+					assert.Equal(t, preDeprovisionHookRunningReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Wait for running pre-deprovision hook",
+			existing: []runtime.Object{
+				testDeletedClusterDeploymentWithPreDeprovisionHooks(
+					testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy),
+					testPreDeprovisionHook("deregister", hivev1.FailProvisionHookFailurePolicy),
+				),
+				testPreDeprovisionHookJob("drain", batchv1.JobComplete),
+				testPreDeprovisionHookJob("deregister", ""),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: defaultPreDeprovisionHookTimeout,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getDeprovision(c), "expected no deprovision request while pre-deprovision hook is running")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition)
+				if assert.NotNil(t, cond, "expected PreDeprovisionHooksComplete condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+					assert.Contains(t, cond.Message, "deregister", "expected running hook in condition message")
+				}
+			},
+		},
+		{
+			name: "Deprovision after pre-deprovision hooks succeeded",
+			existing: []runtime.Object{
+				testDeletedClusterDeploymentWithPreDeprovisionHooks(
+					testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy),
+					testPreDeprovisionHook("deregister", hivev1.FailProvisionHookFailurePolicy),
+				),
+				testPreDeprovisionHookJob("drain", batchv1.JobComplete),
+				testPreDeprovisionHookJob("deregister", batchv1.JobComplete),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition)
+				if assert.NotNil(t, cond, "expected PreDeprovisionHooksComplete condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, preDeprovisionHooksSucceededReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Hold deprovision after pre-deprovision hook failed",
+			existing: []runtime.Object{
+				testDeletedClusterDeploymentWithPreDeprovisionHooks(testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy)),
+				testPreDeprovisionHookJob("drain", batchv1.JobFailed),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getDeprovision(c), "expected no deprovision request after pre-deprovision hook failed")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition)
+				if assert.NotNil(t, cond, "expected PreDeprovisionHooksComplete condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+					assert.Equal(t, preDeprovisionHookFailedReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Deprovision after ignored pre-deprovision hook failure",
+			existing: []runtime.Object{
+				testDeletedClusterDeploymentWithPreDeprovisionHooks(testPreDeprovisionHook("drain", hivev1.IgnoreProvisionHookFailurePolicy)),
+				testPreDeprovisionHookJob("drain", batchv1.JobFailed),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+			},
+		},
+		{
+			name: "Skip pre-deprovision hooks for hibernating cluster",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedClusterDeploymentWithPreDeprovisionHooks(testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy))
+					cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getJob(c, testPreDeprovisionHookJobName("drain")), "unexpected pre-deprovision hook job")
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition)
+				if assert.NotNil(t, cond, "expected PreDeprovisionHooksComplete condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, preDeprovisionHooksSkippedReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Skip pre-deprovision hooks for unreachable cluster",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedClusterDeploymentWithPreDeprovisionHooks(testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy))
+					cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition,
+						corev1.ConditionTrue, "Unreachable", "cluster is unreachable", controllerutils.UpdateConditionIfReasonOrMessageChange)
+					return cd
+				}(),
+				testPreDeprovisionHookJob("drain", ""),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition)
+				if assert.NotNil(t, cond, "expected PreDeprovisionHooksComplete condition") {
+					assert.Equal(t, preDeprovisionHooksSkippedReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Deprovision after pre-deprovision hook timed out",
+			existing: []runtime.Object{
+				testDeletedClusterDeploymentWithPreDeprovisionHooks(
+					testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy),
+					testPreDeprovisionHook("deregister", hivev1.FailProvisionHookFailurePolicy),
+				),
+				func() *batchv1.Job {
+					job := testPreDeprovisionHookJob("drain", "")
+					job.CreationTimestamp = metav1.NewTime(time.Now().Add(-defaultPreDeprovisionHookTimeout - time.Minute))
+					return job
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+				assert.Nil(t, getJob(c, testPreDeprovisionHookJobName("deregister")), "unexpected job for hook after timed out hook")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition)
+				if assert.NotNil(t, cond, "expected PreDeprovisionHooksComplete condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, preDeprovisionHooksTimedOutReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Wait for running pre-deprovision hook with timeout",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					hook := testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy)
+					hook.Timeout = &metav1.Duration{Duration: 6 * time.Hour}
+					return testDeletedClusterDeploymentWithPreDeprovisionHooks(hook)
+				}(),
+				func() *batchv1.Job {
+					job := testPreDeprovisionHookJob("drain", "")
+					job.CreationTimestamp = metav1.NewTime(time.Now().Add(-4 * time.Hour))
+					return job
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: 2 * time.Hour,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getDeprovision(c), "expected no deprovision request while pre-deprovision hook is running")
+			},
+		},
+		{
+			name: "Hold deprovision after pre-deprovision hook failed past timeout",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedClusterDeploymentWithPreDeprovisionHooks(testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy))
+					cd.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-defaultPreDeprovisionHookTimeout - time.Minute)}
+					return cd
+				}(),
+				func() *batchv1.Job {
+					job := testPreDeprovisionHookJob("drain", batchv1.JobFailed)
+					job.CreationTimestamp = metav1.NewTime(time.Now().Add(-defaultPreDeprovisionHookTimeout - time.Minute))
+					return job
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getDeprovision(c), "expected no deprovision request after pre-deprovision hook failed")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition)
+				if assert.NotNil(t, cond, "expected PreDeprovisionHooksComplete condition") {
+					assert.Equal(t, preDeprovisionHookFailedReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Skip pre-deprovision hooks when namespace is being deleted",
+			existing: []runtime.Object{
+				testDeletedClusterDeploymentWithPreDeprovisionHooks(testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy)),
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              testNamespace,
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+					},
+				},
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getJob(c, testPreDeprovisionHookJobName("drain")), "unexpected pre-deprovision hook job")
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+				cond := controllerutils.FindClusterDeploymentCondition(getCD(c).Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition)
+				if assert.NotNil(t, cond, "expected PreDeprovisionHooksComplete condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, preDeprovisionHooksSkippedReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Skip pre-deprovision hooks for uninstalled cluster",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedClusterDeploymentWithPreDeprovisionHooks(testPreDeprovisionHook("drain", hivev1.FailProvisionHookFailurePolicy))
+					cd.Spec.Installed = false
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getJob(c, testPreDeprovisionHookJobName("drain")), "unexpected pre-deprovision hook job")
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+			},
+		},
 		{
 			name: "Delete old provisions",
 			existing: []runtime.Object{
@@ -1094,7 +1895,120 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
-			expectedRequeueAfter: 8 * time.Hour,
+			// Requeued for the warning at the default lead time of one hour before the cluster expires.
+			expectedRequeueAfter: 7 * time.Hour,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd.Status.ExpirationTimestamp, "expected expiration timestamp") {
+					assert.WithinDuration(t, time.Now().Add(8*time.Hour), cd.Status.ExpirationTimestamp.Time, 10*time.Second, "unexpected expiration timestamp")
+					assert.Equal(t, cd.Status.ExpirationTimestamp.UTC().Format(time.RFC3339), cd.Annotations[constants.OriginalExpirationAnnotation], "unexpected original expiration")
+				}
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterExpiringSoonCondition)
+				if assert.NotNil(t, cond, "expected ExpiringSoon condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected ExpiringSoon status")
+				}
+			},
+			expectedEventReasons: []string{expiringSoonReason},
+		},
+		{
+			name: "Expiration warning lead time",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.CreationTimestamp = metav1.Now()
+					cd.Spec.Expiration = &hivev1.ClusterExpiration{
+						DeleteAfter:      &metav1.Duration{Duration: 30 * time.Minute},
+						WarningLeadTimes: []metav1.Duration{{Duration: time.Hour}, {Duration: 10 * time.Minute}},
+					}
+					return cd
+				}(),
+				testProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: 20 * time.Minute,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterExpiringSoonCondition)
+				if assert.NotNil(t, cond, "expected ExpiringSoon condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected ExpiringSoon status")
+					assert.Equal(t, expiringSoonReason, cond.Reason, "unexpected ExpiringSoon reason")
+					assert.Contains(t, cond.Message, "in less than 1h0m0s", "unexpected ExpiringSoon message")
+				}
+			},
+			expectedEventReasons: []string{expiringSoonReason},
+		},
+		{
+			name: "Expiration warning not repeated",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.CreationTimestamp = metav1.Now()
+					deleteAt := metav1.NewTime(cd.CreationTimestamp.Add(30 * time.Minute).Truncate(time.Second))
+					cd.Spec.Expiration = &hivev1.ClusterExpiration{DeleteAt: &deleteAt}
+					cd.Status.ExpirationTimestamp = &deleteAt
+					cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+						cd.Status.Conditions,
+						hivev1.ClusterExpiringSoonCondition,
+						corev1.ConditionTrue,
+						expiringSoonReason,
+						fmt.Sprintf("Cluster will be deleted at %s, in less than 1h0m0s", deleteAt.UTC().Format(time.RFC3339)),
+						controllerutils.UpdateConditionAlways)
+					return cd
+				}(),
+				testProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: 30 * time.Minute,
+		},
+		{
+			name: "Expiration warning cleared when expiration is extended",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.CreationTimestamp = metav1.Now()
+					cd.Spec.Expiration = &hivev1.ClusterExpiration{DeleteAfter: &metav1.Duration{Duration: 48 * time.Hour}}
+					cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+						cd.Status.Conditions,
+						hivev1.ClusterExpiringSoonCondition,
+						corev1.ConditionTrue,
+						expiringSoonReason,
+						"",
+						controllerutils.UpdateConditionAlways)
+					return cd
+				}(),
+				testProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: 24 * time.Hour,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterExpiringSoonCondition)
+				if assert.NotNil(t, cond, "expected ExpiringSoon condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected ExpiringSoon status")
+					assert.Equal(t, notExpiringSoonReason, cond.Reason, "unexpected ExpiringSoon reason")
+				}
+			},
+		},
+		{
+			name: "Delete cluster deployment past expiration",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeployment())
+					deleteAt := metav1.NewTime(time.Now().Add(-time.Minute))
+					cd.Spec.Expiration = &hivev1.ClusterExpiration{DeleteAt: &deleteAt}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getCD(c), "expected cluster deployment to be deleted")
+			},
 		},
 		{
 			name: "Wait after failed provision",
@@ -1116,6 +2030,95 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			expectedRequeueAfter: 1 * time.Minute,
+			expectedEventReasons: []string{expiringSoonReason},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					if assert.NotNil(t, cd.Status.ProvisionRef, "missing provision ref") {
+						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision ref name")
+					}
+				}
+			},
+		},
+		{
+			name: "Retry failed provision immediately",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision()),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now())
+					provision.Status.RetryPolicy = hivev1.RetryInstallFailureRetryPolicy
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected empty provision ref")
+					assert.Equal(t, 1, cd.Status.InstallRestarts, "expected incremented install restart count")
+				}
+			},
+		},
+		{
+			name: "Stop after non-retryable provision failure",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision()),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now().Add(-time.Hour))
+					provision.Status.Conditions[0].Reason = "InvalidCredentials"
+					provision.Status.Conditions[0].Message = "Credentials are invalid"
+					provision.Status.RetryPolicy = hivev1.StopInstallFailureRetryPolicy
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				if assert.NotNil(t, cd.Status.ProvisionRef, "missing provision ref") {
+					assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision ref name")
+				}
+				assert.Equal(t, 0, cd.Status.InstallRestarts, "expected install restart count to be unchanged")
+				testassert.AssertConditions(t, cd, []hivev1.ClusterDeploymentCondition{
+					{
+						Type:   hivev1.ProvisionFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "InvalidCredentials",
+					},
+					{
+						Type:   hivev1.ProvisionStoppedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "InvalidCredentials",
+					},
+				})
+			},
+		},
+		{
+			name: "Wait with custom backoff after failed provision",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.Spec.InstallRetryBackoff = &hivev1.InstallRetryBackoff{
+						InitialDelay: &metav1.Duration{Duration: 10 * time.Minute},
+					}
+					return cd
+				}(),
+				testFailedProvisionTime(time.Now()),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: 10 * time.Minute,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
 				if assert.NotNil(t, cd, "missing clusterdeployment") {
@@ -2118,6 +3121,13 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					(schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "FakeClusterInstall"}).String(): {},
 				},
 				releaseImageVerifier: test.riVerifier,
+				eventRecorder:        record.NewFakeRecorder(10),
+			}
+
+			if test.setupAWSClient != nil {
+				mockAWSClient := mockaws.NewMockClient(mockCtrl)
+				test.setupAWSClient(mockAWSClient)
+				rcd.awsClientBuilder = func(*hivev1.ClusterDeployment) (awsclient.Client, error) { return mockAWSClient, nil }
 			}
 
 			if test.reconcilerSetup != nil {
@@ -2139,6 +3149,10 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				mockRemoteClientBuilder.EXPECT().Build().Return(testRemoteClusterAPIClient(), nil)
 			}
 
+			if test.setupRemoteClientBuilder != nil {
+				test.setupRemoteClientBuilder(mockRemoteClientBuilder)
+			}
+
 			result, err := rcd.Reconcile(context.TODO(), reconcileRequest)
 
 			if test.validate != nil {
@@ -2160,6 +3174,14 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 
 			actualPendingCreation := !controllerExpectations.SatisfiedExpectations(reconcileRequest.String())
 			assert.Equal(t, test.expectPendingCreation, actualPendingCreation, "unexpected pending creation")
+
+			events := rcd.eventRecorder.(*record.FakeRecorder).Events
+			close(events)
+			var eventReasons []string
+			for event := range events {
+				eventReasons = append(eventReasons, strings.Fields(event)[1])
+			}
+			assert.Equal(t, test.expectedEventReasons, eventReasons, "unexpected events")
 		})
 	}
 }
@@ -2216,6 +3238,7 @@ func TestCalculateNextProvisionTime(t *testing.T) {
 		name             string
 		failureTime      time.Time
 		attempt          int
+		backoff          *hivev1.InstallRetryBackoff
 		expectedNextTime time.Time
 	}{
 		{
@@ -2260,10 +3283,38 @@ func TestCalculateNextProvisionTime(t *testing.T) {
 			attempt:          999999,
 			expectedNextTime: time.Date(2019, time.July, 17, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name:        "custom initial delay",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     2,
+			backoff: &hivev1.InstallRetryBackoff{
+				InitialDelay: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 0, 20, 0, 0, time.UTC),
+		},
+		{
+			name:        "custom max delay",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     10,
+			backoff: &hivev1.InstallRetryBackoff{
+				MaxDelay: &metav1.Duration{Duration: time.Hour},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "custom backoff millionth attempt",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     999999,
+			backoff: &hivev1.InstallRetryBackoff{
+				InitialDelay: &metav1.Duration{Duration: 10 * time.Minute},
+				MaxDelay:     &metav1.Duration{Duration: 6 * time.Hour},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 6, 0, 0, 0, time.UTC),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actualNextTime := calculateNextProvisionTime(tc.failureTime, tc.attempt, log.WithField("controller", "clusterDeployment"))
+			actualNextTime := calculateNextProvisionTime(tc.failureTime, tc.attempt, tc.backoff, log.WithField("controller", "clusterDeployment"))
 			assert.Equal(t, tc.expectedNextTime.String(), actualNextTime.String(), "unexpected next provision time")
 		})
 	}
@@ -2597,6 +3648,28 @@ func testEmptyClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+// withPreflightResult records the result of preflight checks run at the given time on the ClusterDeployment. The
+// checks are recorded as run for the current install config when current is true.
+func withPreflightResult(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason string, ran time.Time, current bool) *hivev1.ClusterDeployment {
+	hash := "old-hash"
+	if current {
+		hash, _ = preflightChecksHash(cd, []byte(testAWSIC))
+	}
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.PreflightChecksHashAnnotation] = hash
+	cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+		cd.Status.Conditions,
+		hivev1.PreflightChecksPassedCondition,
+		status,
+		reason,
+		"test message",
+		controllerutils.UpdateConditionAlways)
+	controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.PreflightChecksPassedCondition).LastProbeTime = metav1.NewTime(ran)
+	return cd
+}
+
 func testInstallConfigSecret() *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2846,6 +3919,14 @@ func testSuccessfulProvision() *hivev1.ClusterProvision {
 	return provision
 }
 
+func testCompletedDryRunProvision() *hivev1.ClusterProvision {
+	provision := testProvision()
+	provision.Spec.DryRun = true
+	provision.Spec.Stage = hivev1.ClusterProvisionStageComplete
+	provision.Status.DryRunResultRef = &corev1.LocalObjectReference{Name: provisionName + "-dry-run"}
+	return provision
+}
+
 func testFailedProvisionAttempt(attempt int) *hivev1.ClusterProvision {
 	provision := testProvision()
 	provision.Name = fmt.Sprintf("%s-%02d", provision.Name, attempt)
@@ -2931,6 +4012,39 @@ func testRemoteClusterAPIClient() client.Client {
 	return fake.NewFakeClient(remoteClusterRouteObject)
 }
 
+func testAdoptionClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.BaseDomain = ""
+	cd.Spec.Platform.AWS.Region = ""
+	cd.Spec.Provisioning = nil
+	cd.Spec.ClusterMetadata = nil
+	cd.Spec.Adoption = &hivev1.ClusterAdoption{
+		AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigSecret},
+		AdminPasswordSecretRef:   &corev1.LocalObjectReference{Name: adminPasswordSecret},
+	}
+	delete(cd.Labels, hivev1.HiveClusterRegionLabel)
+	return cd
+}
+
+func testAdoptedRemoteClusterAPIClient(platformStatus *openshiftapiv1.PlatformStatus) client.Client {
+	clusterVersion := &openshiftapiv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "version"},
+		Spec:       openshiftapiv1.ClusterVersionSpec{ClusterID: testClusterID},
+	}
+	infrastructure := &openshiftapiv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Status: openshiftapiv1.InfrastructureStatus{
+			InfrastructureName: testInfraID,
+			PlatformStatus:     platformStatus,
+		},
+	}
+	dns := &openshiftapiv1.DNS{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       openshiftapiv1.DNSSpec{BaseDomain: testClusterName + ".example.com"},
+	}
+	return fake.NewFakeClient(clusterVersion, infrastructure, dns)
+}
+
 func testClusterImageSet() *hivev1.ClusterImageSet {
 	cis := &hivev1.ClusterImageSet{}
 	cis.Name = testClusterImageSetName
@@ -3368,6 +4482,75 @@ func TestCopyInstallLogSecret(t *testing.T) {
 	}
 }
 
+func TestSkipMissingInstallLogsPVC(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	pvcEnvVars := []corev1.EnvVar{
+		{
+			Name:  constants.InstallLogsUploadProviderEnvVar,
+			Value: constants.InstallLogsUploadProviderPVC,
+		},
+		{
+			Name:  constants.InstallLogsPVCClaimNameEnvVar,
+			Value: "install-logs",
+		},
+	}
+
+	tests := []struct {
+		name            string
+		existingObjs    []runtime.Object
+		envVars         []corev1.EnvVar
+		expectedEnvVars []corev1.EnvVar
+	}{
+		{
+			name: "claim exists",
+			existingObjs: []runtime.Object{
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      "install-logs",
+					},
+				},
+			},
+			envVars:         pvcEnvVars,
+			expectedEnvVars: pvcEnvVars,
+		},
+		{
+			name:    "claim missing",
+			envVars: pvcEnvVars,
+		},
+		{
+			name: "no claim configured",
+			envVars: []corev1.EnvVar{
+				{
+					Name:  constants.InstallLogsUploadProviderEnvVar,
+					Value: constants.InstallLogsUploadProviderAWS,
+				},
+			},
+			expectedEnvVars: []corev1.EnvVar{
+				{
+					Name:  constants.InstallLogsUploadProviderEnvVar,
+					Value: constants.InstallLogsUploadProviderAWS,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rcd := &ReconcileClusterDeployment{
+				Client: fake.NewFakeClient(test.existingObjs...),
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", "clusterDeployment"),
+			}
+
+			envVars, err := rcd.skipMissingInstallLogsPVC(testNamespace, test.envVars, rcd.logger)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedEnvVars, envVars, "unexpected install log environment variables")
+		})
+	}
+}
+
 func TestEnsureManagedDNSZone(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 
@@ -3550,6 +4733,89 @@ func testCompletedFailedImageSetJob() *batchv1.Job {
 	}
 }
 
+func testDryRunClusterDeployment(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeployment {
+	cd.Spec.Provisioning.DryRun = true
+	return cd
+}
+
+func testClusterDeploymentWithHooks(cd *hivev1.ClusterDeployment, hooks ...hivev1.ProvisionHook) *hivev1.ClusterDeployment {
+	cd.Spec.Provisioning.Hooks = hooks
+	return cd
+}
+
+func testProvisionHook(name string, phase hivev1.ProvisionHookPhase, failurePolicy hivev1.ProvisionHookFailurePolicy) hivev1.ProvisionHook {
+	return hivev1.ProvisionHook{
+		Name:          name,
+		Phase:         phase,
+		FailurePolicy: failurePolicy,
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "hook", Image: "hook-image"}},
+			},
+		},
+	}
+}
+
+func testDeletedClusterDeploymentWithPreDeprovisionHooks(hooks ...hivev1.PreDeprovisionHook) *hivev1.ClusterDeployment {
+	cd := testClusterDeploymentWithInitializedConditions(testDeletedClusterDeployment())
+	cd.Spec.Installed = true
+	cd.Spec.PreDeprovisionHooks = hooks
+	return cd
+}
+
+func testPreDeprovisionHook(name string, failurePolicy hivev1.ProvisionHookFailurePolicy) hivev1.PreDeprovisionHook {
+	return hivev1.PreDeprovisionHook{
+		Name:          name,
+		FailurePolicy: failurePolicy,
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "hook", Image: "hook-image"}},
+			},
+		},
+	}
+}
+
+func testPreDeprovisionHookJobName(hookName string) string {
+	return GetPreDeprovisionHookJobName(testClusterDeployment(), hookName)
+}
+
+// testPreDeprovisionHookJob returns a pre-deprovision hook Job with the given condition, or a running Job when the
+// condition type is empty.
+func testPreDeprovisionHookJob(hookName string, conditionType batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testPreDeprovisionHookJobName(hookName),
+			Namespace: testNamespace,
+		},
+	}
+	if conditionType != "" {
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:   conditionType,
+			Status: corev1.ConditionTrue,
+		}}
+	}
+	return job
+}
+
+func testProvisionHookJobName(hookName string) string {
+	return GetProvisionHookJobName(testClusterDeployment(), hookName)
+}
+
+func testProvisionHookJob(hookName string, conditionType batchv1.JobConditionType) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testProvisionHookJobName(hookName),
+			Namespace: testNamespace,
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{
+				Type:   conditionType,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+	}
+}
+
 func dnsZoneBase() testdnszone.Option {
 	return func(dnsZone *hivev1.DNSZone) {
 		dnsZone.Name = controllerutils.DNSZoneName(testName)
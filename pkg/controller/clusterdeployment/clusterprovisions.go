@@ -45,6 +45,10 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	}
 
 	for _, provision := range existingProvisions {
+		// A completed dry run must not be adopted once dry run has been disabled, or the real install would never start.
+		if provision.Spec.DryRun && provision.Spec.Stage == hivev1.ClusterProvisionStageComplete && !isDryRun(cd) {
+			continue
+		}
 		if provision.Spec.Stage != hivev1.ClusterProvisionStageFailed {
 			return reconcile.Result{}, r.adoptProvision(cd, provision, logger)
 		}
@@ -108,6 +112,13 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		return reconcile.Result{}, nil
 	}
 
+	// Pre-install hooks prepare for a real install, so they are not run for a dry run.
+	if !isDryRun(cd) {
+		if done, err := r.reconcileProvisionHooks(cd, hivev1.PreInstallProvisionHookPhase, logger); !done || err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	if err := controllerutils.SetupClusterInstallServiceAccount(r, cd.Namespace, logger); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "error setting up service account and role")
 		return reconcile.Result{}, err
@@ -121,8 +132,17 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	}
 	labels[constants.ClusterDeploymentNameLabel] = cd.Name
 
-	extraEnvVars := getInstallLogEnvVars(cd.Name)
+	extraEnvVars, err := r.skipMissingInstallLogsPVC(cd.Namespace, getInstallLogEnvVars(cd.Name), logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	extraEnvVars = append(extraEnvVars, getAWSServiceProviderEnvVars(cd, cd.Name)...)
+	if rules := controllerutils.GetRedactor().Data(); rules != "" {
+		extraEnvVars = append(extraEnvVars, corev1.EnvVar{
+			Name:  constants.RedactionRulesEnvVar,
+			Value: rules,
+		})
+	}
 
 	podSpec, err := install.InstallerPodSpec(
 		cd,
@@ -152,6 +172,7 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 			PodSpec: *podSpec,
 			Attempt: cd.Status.InstallRestarts,
 			Stage:   hivev1.ClusterProvisionStageInitializing,
+			DryRun:  isDryRun(cd),
 		},
 	}
 
@@ -248,6 +269,12 @@ func (r *ReconcileClusterDeployment) reconcileExistingProvision(cd *hivev1.Clust
 		}
 	}
 
+	if provision.Spec.DryRun {
+		if result, err := r.reconcileDryRunProvision(cd, provision, logger); result != nil || err != nil {
+			return *result, err
+		}
+	}
+
 	switch provision.Spec.Stage {
 	case hivev1.ClusterProvisionStageInitializing:
 		return r.reconcileInitializingProvision(cd, provision, logger)
@@ -263,6 +290,69 @@ func (r *ReconcileClusterDeployment) reconcileExistingProvision(cd *hivev1.Clust
 	}
 }
 
+// reconcileDryRunProvision handles a provision which only renders the install assets. When the dry run is complete,
+// the ProvisionDryRunComplete condition is set. When dry run has been disabled on the cluster deployment, the
+// finished dry run provision is released so that a real install can be started. A nil result is returned when the
+// provision should be reconciled as any other provision.
+func (r *ReconcileClusterDeployment) reconcileDryRunProvision(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, logger log.FieldLogger) (*reconcile.Result, error) {
+	finished := provision.Spec.Stage == hivev1.ClusterProvisionStageComplete ||
+		provision.Spec.Stage == hivev1.ClusterProvisionStageFailed
+
+	if !isDryRun(cd) {
+		if !finished {
+			return nil, nil
+		}
+		logger.Info("dry run has been disabled, releasing dry run provision")
+		if controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionDryRunCompleteCondition) != nil {
+			cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+				cd.Status.Conditions,
+				hivev1.ProvisionDryRunCompleteCondition,
+				corev1.ConditionFalse,
+				dryRunDisabledReason,
+				"Dry run has been disabled",
+				controllerutils.UpdateConditionIfReasonOrMessageChange,
+			)
+		}
+		// The dry run was not an install attempt, so the install restarts are left alone.
+		cd.Status.ProvisionRef = nil
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not release dry run provision")
+			return &reconcile.Result{}, err
+		}
+		return &reconcile.Result{}, nil
+	}
+
+	if provision.Spec.Stage != hivev1.ClusterProvisionStageComplete {
+		return nil, nil
+	}
+	message := "Dry run has rendered the install assets"
+	if provision.Status.DryRunResultRef != nil {
+		message = fmt.Sprintf("Dry run has rendered the install assets, see ConfigMap %s", provision.Status.DryRunResultRef.Name)
+	}
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ProvisionDryRunCompleteCondition,
+		corev1.ConditionTrue,
+		dryRunCompleteReason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		cd.Status.Conditions = conditions
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return &reconcile.Result{}, err
+		}
+		logger.Info("dry run provision complete")
+	}
+	return &reconcile.Result{}, nil
+}
+
+// isDryRun returns true if the cluster deployment is set to only render the install assets.
+func isDryRun(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Provisioning != nil && cd.Spec.Provisioning.DryRun
+}
+
 func (r *ReconcileClusterDeployment) stopProvisioning(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*reconcile.Result, error) {
 	if cd.Status.ProvisionRef == nil {
 		return nil, nil
@@ -315,9 +405,19 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	reason := "MissingCondition"
 	message := fmt.Sprintf("Provision %s failed. Next provision will begin soon.", provision.Name)
 
+	retryPolicy := hivev1.RetryWithBackoffInstallFailureRetryPolicy
+
 	failedCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
 	if failedCond != nil && failedCond.Status == corev1.ConditionTrue {
-		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cdLog)
+		if provision.Status.RetryPolicy != "" {
+			retryPolicy = provision.Status.RetryPolicy
+		}
+		switch retryPolicy {
+		case hivev1.RetryInstallFailureRetryPolicy:
+			nextProvisionTime = failedCond.LastTransitionTime.Time
+		default:
+			nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cd.Spec.InstallRetryBackoff, cdLog)
+		}
 		reason = failedCond.Reason
 		message = failedCond.Message
 	} else {
@@ -334,6 +434,26 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	)
 	cd.Status.Conditions = newConditions
 
+	// The failed provision is kept as the current provision, so that no further attempts are made.
+	if retryPolicy == hivev1.StopInstallFailureRetryPolicy {
+		cdLog.WithField("reason", reason).Info("not retrying provision since the failure is not retryable")
+		newConditions, stoppedChange := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ProvisionStoppedCondition,
+			corev1.ConditionTrue,
+			reason,
+			fmt.Sprintf("Provision %s failed and will not be retried: %s", provision.Name, message),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		cd.Status.Conditions = newConditions
+		if condChange || stoppedChange {
+			if err := r.statusUpdate(cd, cdLog); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
 	timeUntilNextProvision := time.Until(nextProvisionTime)
 	if timeUntilNextProvision.Seconds() > 0 {
 		cdLog.WithField("nextProvision", nextProvisionTime).Info("waiting to start a new provision after failure")
@@ -381,6 +501,11 @@ func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.Clus
 		return reconcile.Result{}, nil
 	}
 
+	// The cluster is not marked as installed until the post-install hooks have succeeded.
+	if done, err := r.reconcileProvisionHooks(cd, hivev1.PostInstallProvisionHookPhase, cdLog); !done || err != nil {
+		return reconcile.Result{}, err
+	}
+
 	cd.Spec.Installed = true
 
 	if r.protectedDelete {
@@ -434,6 +559,40 @@ func (r *ReconcileClusterDeployment) clearOutCurrentProvision(cd *hivev1.Cluster
 	return reconcile.Result{}, nil
 }
 
+// skipMissingInstallLogsPVC drops the PersistentVolumeClaim logs of failed installs are copied to from the install log
+// environment variables when the claim does not exist in the namespace, as the install pod could not start with the
+// claim mounted. The logs of failed installs in the namespace are then not kept.
+func (r *ReconcileClusterDeployment) skipMissingInstallLogsPVC(namespace string, extraEnvVars []corev1.EnvVar, logger log.FieldLogger) ([]corev1.EnvVar, error) {
+	var claimName string
+	for _, envVar := range extraEnvVars {
+		if envVar.Name == constants.InstallLogsPVCClaimNameEnvVar {
+			claimName = envVar.Value
+		}
+	}
+	if claimName == "" {
+		return extraEnvVars, nil
+	}
+	pvcLog := logger.WithField("pvc", claimName)
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: claimName}, &corev1.PersistentVolumeClaim{}); {
+	case apierrors.IsNotFound(err):
+		pvcLog.Warn("install logs PersistentVolumeClaim not found in namespace, logs of failed installs will not be kept")
+	case err != nil:
+		pvcLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting install logs PersistentVolumeClaim")
+		return nil, err
+	default:
+		return extraEnvVars, nil
+	}
+	var remaining []corev1.EnvVar
+	for _, envVar := range extraEnvVars {
+		switch envVar.Name {
+		case constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsPVCClaimNameEnvVar:
+		default:
+			remaining = append(remaining, envVar)
+		}
+	}
+	return remaining, nil
+}
+
 func (r *ReconcileClusterDeployment) copyInstallLogSecret(destNamespace string, extraEnvVars []corev1.EnvVar) error {
 	hiveNS := controllerutils.GetHiveNamespace()
 
@@ -475,7 +634,7 @@ func getInstallLogEnvVars(secretPrefix string) []corev1.EnvVar {
 		Value: cloudProvider,
 	})
 
-	if cloudProvider == constants.InstallLogsUploadProviderAWS {
+	if cloudProvider != constants.InstallLogsUploadProviderPVC {
 		secretName, foundSrc := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
 		if foundSrc {
 			extraEnvVars = append(extraEnvVars, corev1.EnvVar{
@@ -483,10 +642,20 @@ func getInstallLogEnvVars(secretPrefix string) []corev1.EnvVar {
 				Value: secretPrefix + "-" + secretName,
 			})
 		}
+	}
 
+	switch cloudProvider {
+	case constants.InstallLogsUploadProviderAWS:
 		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAWSRegionEnvVar, extraEnvVars)
 		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAWSServiceEndpointEnvVar, extraEnvVars)
 		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAWSS3BucketEnvVar, extraEnvVars)
+	case constants.InstallLogsUploadProviderGCP:
+		extraEnvVars = addEnvVarIfFound(constants.InstallLogsGCSBucketEnvVar, extraEnvVars)
+	case constants.InstallLogsUploadProviderAzure:
+		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAzureStorageAccountEnvVar, extraEnvVars)
+		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAzureContainerEnvVar, extraEnvVars)
+	case constants.InstallLogsUploadProviderPVC:
+		extraEnvVars = addEnvVarIfFound(constants.InstallLogsPVCClaimNameEnvVar, extraEnvVars)
 	}
 
 	return extraEnvVars
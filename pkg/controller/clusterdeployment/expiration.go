@@ -0,0 +1,140 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	expiringSoonReason    = "ExpiringSoon"
+	notExpiringSoonReason = "NotExpiringSoon"
+)
+
+// reconcileExpiration deletes the cluster deployment once it has expired. Until then, the time at which the cluster
+// will be deleted is kept in the status, the first expiration of the cluster is recorded in the original-expiration
+// annotation to cap later extensions, and the ExpiringSoon condition is updated, with a warning Event, each time
+// the cluster comes within one of the warning lead times of its expiration. The returned requeueAfter is the time
+// until the next lead time or the expiration, or zero if the cluster does not expire.
+func (r *ReconcileClusterDeployment) reconcileExpiration(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (deleted bool, requeueAfter time.Duration, returnErr error) {
+	expiry, err := controllerutils.ExpirationTime(cd)
+	if err != nil {
+		cdLog.WithError(err).Info("error computing cluster expiration")
+		return false, 0, err
+	}
+
+	if expiry == nil {
+		if cd.Status.ExpirationTimestamp == nil {
+			return false, 0, nil
+		}
+		cdLog.Info("cluster no longer expires")
+		cd.Status.ExpirationTimestamp = nil
+		if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterExpiringSoonCondition); cond != nil {
+			cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+				cd.Status.Conditions,
+				hivev1.ClusterExpiringSoonCondition,
+				corev1.ConditionFalse,
+				notExpiringSoonReason,
+				"Cluster does not expire",
+				controllerutils.UpdateConditionIfReasonOrMessageChange,
+			)
+		}
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not clear cluster expiration")
+			return false, 0, err
+		}
+		return false, 0, nil
+	}
+
+	cdLog.Debugf("cluster expires at: %s", expiry)
+	if time.Now().After(*expiry) {
+		cdLog.WithField("expiry", expiry).Info("cluster has expired, issuing delete")
+		if err := r.Delete(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting expired cluster")
+			return false, 0, err
+		}
+		return true, 0, nil
+	}
+
+	if _, ok := cd.Annotations[constants.OriginalExpirationAnnotation]; !ok {
+		cdLog.WithField("expiry", expiry).Info("recording original cluster expiration")
+		if cd.Annotations == nil {
+			cd.Annotations = map[string]string{}
+		}
+		cd.Annotations[constants.OriginalExpirationAnnotation] = expiry.UTC().Format(time.RFC3339)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not record original cluster expiration")
+			return false, 0, err
+		}
+	}
+
+	statusChanged := false
+	if cd.Status.ExpirationTimestamp == nil || !cd.Status.ExpirationTimestamp.Time.Equal(*expiry) {
+		cd.Status.ExpirationTimestamp = &metav1.Time{Time: *expiry}
+		statusChanged = true
+	}
+
+	// Find the smallest lead time that the cluster is within, and the time until the cluster comes within the next.
+	remaining := time.Until(*expiry)
+	requeueAfter = remaining
+	var warningLeadTime time.Duration
+	for _, leadTime := range controllerutils.ExpirationWarningLeadTimes(cd) {
+		if remaining <= leadTime {
+			warningLeadTime = leadTime
+		} else if remaining-leadTime < requeueAfter {
+			requeueAfter = remaining - leadTime
+		}
+	}
+
+	var warning string
+	if warningLeadTime > 0 {
+		message := fmt.Sprintf("Cluster will be deleted at %s, in less than %s", expiry.UTC().Format(time.RFC3339), warningLeadTime)
+		conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ClusterExpiringSoonCondition,
+			corev1.ConditionTrue,
+			expiringSoonReason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if changed {
+			cd.Status.Conditions = conditions
+			statusChanged = true
+			warning = message
+		}
+	} else if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterExpiringSoonCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+			cd.Status.Conditions,
+			hivev1.ClusterExpiringSoonCondition,
+			corev1.ConditionFalse,
+			notExpiringSoonReason,
+			fmt.Sprintf("Cluster will be deleted at %s", expiry.UTC().Format(time.RFC3339)),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		statusChanged = true
+	}
+
+	if statusChanged {
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster expiration")
+			return false, 0, err
+		}
+	}
+	// The Event is only emitted once the condition has been saved, so that it is not repeated.
+	if warning != "" {
+		cdLog.WithField("expiry", expiry).Info("cluster is expiring soon")
+		if r.eventRecorder != nil {
+			r.eventRecorder.Event(cd, corev1.EventTypeWarning, expiringSoonReason, warning)
+		}
+	}
+	return false, requeueAfter, nil
+}
@@ -0,0 +1,124 @@
+package clusterdeployment
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+const (
+	gcpDefaultInstanceType = "n1-standard-4"
+
+	gcpCPUsQuotaMetric     = "CPUS"
+	gcpNetworksQuotaMetric = "NETWORKS"
+)
+
+var (
+	// gcpRequiredPermissions are the project permissions the installer needs regardless of the install config.
+	gcpRequiredPermissions = []string{
+		"compute.addresses.create",
+		"compute.firewalls.create",
+		"compute.forwardingRules.create",
+		"compute.instances.create",
+		"dns.managedZones.create",
+		"iam.serviceAccounts.create",
+		"resourcemanager.projects.setIamPolicy",
+		"storage.buckets.create",
+	}
+	// gcpNetworkPermissions are the project permissions the installer needs when it creates the network.
+	gcpNetworkPermissions = []string{
+		"compute.networks.create",
+		"compute.routers.create",
+		"compute.subnetworks.create",
+	}
+)
+
+func gcpPreflightChecks(gcpClient gcpclient.Client, ic *installertypes.InstallConfig, result *preflightResult, logger log.FieldLogger) {
+	// The installer only creates a network when it is not given an existing one.
+	createsNetwork := ic.Platform.GCP.Network == ""
+
+	preflightCheck("permissions", logger, func() error {
+		permissions := gcpRequiredPermissions
+		if createsNetwork {
+			permissions = append(append([]string{}, gcpRequiredPermissions...), gcpNetworkPermissions...)
+		}
+		return gcpCheckPermissions(gcpClient, permissions, result)
+	})
+	preflightCheck("CPU quota", logger, func() error {
+		return gcpCheckCPUQuota(gcpClient, ic, result)
+	})
+	if createsNetwork {
+		preflightCheck("network quota", logger, func() error {
+			project, err := gcpClient.GetProject()
+			if err != nil {
+				return err
+			}
+			return gcpCheckQuota(project.Quotas, gcpNetworksQuotaMetric, "networks", 1, result)
+		})
+	}
+}
+
+func gcpInstanceType(ic *installertypes.InstallConfig) func(*installertypes.MachinePool, bool) string {
+	return func(pool *installertypes.MachinePool, controlPlane bool) string {
+		if pool != nil && pool.Platform.GCP != nil && pool.Platform.GCP.InstanceType != "" {
+			return pool.Platform.GCP.InstanceType
+		}
+		if d := ic.Platform.GCP.DefaultMachinePlatform; d != nil && d.InstanceType != "" {
+			return d.InstanceType
+		}
+		return gcpDefaultInstanceType
+	}
+}
+
+func gcpCheckPermissions(gcpClient gcpclient.Client, permissions []string, result *preflightResult) error {
+	granted, err := gcpClient.TestIamPermissions(permissions)
+	if err != nil {
+		return err
+	}
+	result.missingPermissions = append(result.missingPermissions, sets.NewString(permissions...).Delete(granted...).List()...)
+	return nil
+}
+
+func gcpCheckCPUQuota(gcpClient gcpclient.Client, ic *installertypes.InstallConfig, result *preflightResult) error {
+	zones, err := gcpClient.ListComputeZones(gcpclient.ListComputeZonesOptions{
+		Filter: fmt.Sprintf("(region eq '.*%s.*') (status eq UP)", ic.Platform.GCP.Region),
+	})
+	if err != nil {
+		return err
+	}
+	if len(zones.Items) == 0 {
+		return fmt.Errorf("no zones available in region %s", ic.Platform.GCP.Region)
+	}
+	zone := zones.Items[0].Name
+
+	var required int64
+	for _, m := range installMachines(ic, gcpInstanceType(ic)) {
+		machineType, err := gcpClient.GetMachineType(zone, m.instanceType)
+		if err != nil {
+			return err
+		}
+		required += m.count * machineType.GuestCpus
+	}
+
+	region, err := gcpClient.GetRegion(ic.Platform.GCP.Region)
+	if err != nil {
+		return err
+	}
+	return gcpCheckQuota(region.Quotas, gcpCPUsQuotaMetric, "CPUs", float64(required), result)
+}
+
+func gcpCheckQuota(quotas []*compute.Quota, metric, name string, required float64, result *preflightResult) error {
+	for _, q := range quotas {
+		if q.Metric == metric {
+			result.checkQuota(name, required, q.Usage, q.Limit)
+			return nil
+		}
+	}
+	return fmt.Errorf("no quota found for metric %s", metric)
+}
@@ -0,0 +1,183 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	preDeprovisionHookRunningReason    = "PreDeprovisionHookRunning"
+	preDeprovisionHookFailedReason     = "PreDeprovisionHookFailed"
+	preDeprovisionHooksSucceededReason = "PreDeprovisionHooksSucceeded"
+	preDeprovisionHooksSkippedReason   = "PreDeprovisionHooksSkipped"
+	preDeprovisionHooksTimedOutReason  = "PreDeprovisionHooksTimedOut"
+
+	// defaultPreDeprovisionHookTimeout is how long the deprovision waits for a running pre-deprovision hook without a
+	// timeout of its own.
+	defaultPreDeprovisionHookTimeout = 2 * time.Hour
+)
+
+// GetPreDeprovisionHookJobName returns the name of the Job running the named pre-deprovision hook of a
+// ClusterDeployment.
+func GetPreDeprovisionHookJobName(cd *hivev1.ClusterDeployment, hookName string) string {
+	return apihelpers.GetResourceName(cd.Name, "predeprovision-hook-"+hookName)
+}
+
+// reconcilePreDeprovisionHooks runs the pre-deprovision hooks of a deleted ClusterDeployment one at a time, in the
+// order they are listed. It returns true once all of the hooks have finished, when there are no hooks to run, when the
+// hooks cannot run against the cluster because it is hibernating or unreachable or because the namespace is being
+// deleted, or when a running hook has reached its timeout. It returns false while a hook is running, and when a hook
+// with the Fail failure policy has failed, until the failed hook Job is deleted. The Job watch requeues the
+// ClusterDeployment when a hook Job finishes, and the returned requeueAfter is the time left until the running hook
+// times out.
+func (r *ReconcileClusterDeployment) reconcilePreDeprovisionHooks(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (done bool, requeueAfter time.Duration, returnErr error) {
+	if len(cd.Spec.PreDeprovisionHooks) == 0 {
+		return true, 0, nil
+	}
+	// The hooks need a running cluster, and are not run when the cluster is not going to be deprovisioned.
+	if !cd.Spec.Installed || cd.Spec.ClusterMetadata == nil || cd.Spec.PreserveOnDelete {
+		logger.Debug("skipping pre-deprovision hooks")
+		return true, 0, nil
+	}
+	if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.PreDeprovisionHooksCompleteCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		return true, 0, nil
+	}
+	reason, err := r.preDeprovisionHooksSkipReason(cd)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error checking whether pre-deprovision hooks can run")
+		return false, 0, err
+	}
+	if reason != "" {
+		logger.WithField("reason", reason).Warn("skipping pre-deprovision hooks")
+		return true, 0, r.setPreDeprovisionHooksCompleteCondition(
+			cd,
+			corev1.ConditionTrue,
+			preDeprovisionHooksSkippedReason,
+			fmt.Sprintf("Pre-deprovision hooks skipped: %s", reason),
+			logger,
+		)
+	}
+
+	hooks := make([]hookJob, len(cd.Spec.PreDeprovisionHooks))
+	for i := range cd.Spec.PreDeprovisionHooks {
+		hook := &cd.Spec.PreDeprovisionHooks[i]
+		hooks[i] = hookJob{
+			name:          hook.Name,
+			jobName:       GetPreDeprovisionHookJobName(cd, hook.Name),
+			failurePolicy: hook.FailurePolicy,
+			generate:      func() (*batchv1.Job, error) { return generatePreDeprovisionHookJob(cd, hook) },
+		}
+	}
+	running, failed, err := r.runHookJobs(cd, hooks, "pre-deprovision", logger)
+	switch {
+	case err != nil:
+		return false, 0, err
+	case running != nil:
+		timeout := defaultPreDeprovisionHookTimeout
+		for _, hook := range cd.Spec.PreDeprovisionHooks {
+			if hook.Name == running.name && hook.Timeout != nil {
+				timeout = hook.Timeout.Duration
+			}
+		}
+		timeLeft := timeout
+		if created := running.job.CreationTimestamp; !created.IsZero() {
+			timeLeft -= time.Since(created.Time)
+		}
+		if timeLeft <= 0 {
+			logger.WithField("hook", running.name).Warn("pre-deprovision hook timed out, proceeding with deprovision")
+			return true, 0, r.setPreDeprovisionHooksCompleteCondition(
+				cd,
+				corev1.ConditionTrue,
+				preDeprovisionHooksTimedOutReason,
+				fmt.Sprintf("Pre-deprovision hook %s did not finish within %s", running.name, timeout),
+				logger,
+			)
+		}
+		return false, timeLeft, r.setPreDeprovisionHooksCompleteCondition(
+			cd,
+			corev1.ConditionFalse,
+			preDeprovisionHookRunningReason,
+			fmt.Sprintf("Running pre-deprovision hook %s", running.name),
+			logger,
+		)
+	case failed != nil:
+		logger.WithField("hook", failed.name).Error("pre-deprovision hook failed, not proceeding with deprovision")
+		return false, 0, r.setPreDeprovisionHooksCompleteCondition(
+			cd,
+			corev1.ConditionFalse,
+			preDeprovisionHookFailedReason,
+			fmt.Sprintf("Pre-deprovision hook %s failed. Delete job %s to run the hook again.", failed.name, failed.jobName),
+			logger,
+		)
+	}
+	logger.Info("pre-deprovision hooks finished")
+	return true, 0, r.setPreDeprovisionHooksCompleteCondition(cd, corev1.ConditionTrue, preDeprovisionHooksSucceededReason, "Pre-deprovision hooks finished", logger)
+}
+
+// preDeprovisionHooksSkipReason returns why the pre-deprovision hooks cannot run, or "" if they can.
+func (r *ReconcileClusterDeployment) preDeprovisionHooksSkipReason(cd *hivev1.ClusterDeployment) (string, error) {
+	if cd.Spec.PowerState == hivev1.HibernatingClusterPowerState {
+		return "cluster is hibernating", nil
+	}
+	if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		return "cluster is hibernating", nil
+	}
+	if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		return "cluster is unreachable", nil
+	}
+	// The hook Jobs can never run once the namespace is being deleted.
+	ns := &corev1.Namespace{}
+	switch err := r.Get(context.TODO(), client.ObjectKey{Name: cd.Namespace}, ns); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return "", err
+	case ns.DeletionTimestamp != nil:
+		return "namespace is being deleted", nil
+	}
+	return "", nil
+}
+
+func (r *ReconcileClusterDeployment) setPreDeprovisionHooksCompleteCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.PreDeprovisionHooksCompleteCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	logger.Debugf("setting PreDeprovisionHooksCompleteCondition to %v", status)
+	return r.statusUpdate(cd, logger)
+}
+
+// generatePreDeprovisionHookJob returns the Job running a pre-deprovision hook, with the admin kubeconfig of the
+// cluster mounted into each of its containers.
+func generatePreDeprovisionHookJob(cd *hivev1.ClusterDeployment, hook *hivev1.PreDeprovisionHook) (*batchv1.Job, error) {
+	job, err := generateHookJob(cd, hook.Name, hook.Template, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not generate pre-deprovision hook %s", hook.Name)
+	}
+	job.Name = GetPreDeprovisionHookJobName(cd, hook.Name)
+	job.Spec.BackoffLimit = hook.BackoffLimit
+	job.Spec.ActiveDeadlineSeconds = hook.ActiveDeadlineSeconds
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.JobTypeLabel, constants.JobTypePreDeprovisionHook)
+	return job, nil
+}
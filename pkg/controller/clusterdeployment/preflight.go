@@ -0,0 +1,261 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+const (
+	insufficientQuotaReason  = "InsufficientQuota"
+	missingPermissionsReason = "MissingPermissions"
+	preflightPassedReason    = "PreflightChecksPassed"
+
+	// preflightRecheckInterval is how long the result of the preflight checks is used before the checks are run
+	// again. Quota is often freed up by other clusters being deprovisioned, so failed checks are retried periodically.
+	preflightRecheckInterval = 10 * time.Minute
+)
+
+// preflightResult collects the requirements of an install which the cloud account does not meet.
+type preflightResult struct {
+	missingPermissions []string
+	insufficientQuotas []string
+}
+
+// failure returns the reason and message for the RequirementsMet condition if any of the
+// preflight checks failed. Missing permissions take precedence over insufficient quota.
+func (p *preflightResult) failure() (reason string, message string, failed bool) {
+	switch {
+	case len(p.missingPermissions) > 0:
+		sort.Strings(p.missingPermissions)
+		return missingPermissionsReason,
+			fmt.Sprintf("Credentials are missing permissions required to install: %s", strings.Join(p.missingPermissions, ", ")),
+			true
+	case len(p.insufficientQuotas) > 0:
+		return insufficientQuotaReason,
+			fmt.Sprintf("Insufficient quota to install: %s", strings.Join(p.insufficientQuotas, "; ")),
+			true
+	}
+	return "", "", false
+}
+
+func (p *preflightResult) checkQuota(name string, required, used, limit float64) {
+	if used+required > limit {
+		p.insufficientQuotas = append(p.insufficientQuotas,
+			fmt.Sprintf("%s requires %v but only %v of %v is available", name, required, limit-used, limit))
+	}
+}
+
+// machineRequirement is the number of machines of an instance type the installer creates.
+type machineRequirement struct {
+	instanceType string
+	count        int64
+}
+
+// installMachinePools returns the control plane and compute pools of the install config.
+func installMachinePools(ic *installertypes.InstallConfig) []*installertypes.MachinePool {
+	pools := []*installertypes.MachinePool{}
+	if ic.ControlPlane != nil {
+		pools = append(pools, ic.ControlPlane)
+	}
+	for i := range ic.Compute {
+		pools = append(pools, &ic.Compute[i])
+	}
+	return pools
+}
+
+// installMachines returns the machines the installer creates for the install config, including the
+// bootstrap machine which is counted as a control plane machine. instanceType returns the instance type
+// for a pool, falling back on the platform defaults.
+func installMachines(ic *installertypes.InstallConfig, instanceType func(pool *installertypes.MachinePool, controlPlane bool) string) []machineRequirement {
+	counts := map[string]int64{}
+	replicas := func(pool *installertypes.MachinePool) int64 {
+		if pool == nil || pool.Replicas == nil {
+			return 3
+		}
+		return *pool.Replicas
+	}
+	counts[instanceType(ic.ControlPlane, true)] += replicas(ic.ControlPlane) + 1
+	for i := range ic.Compute {
+		counts[instanceType(&ic.Compute[i], false)] += replicas(&ic.Compute[i])
+	}
+
+	machines := make([]machineRequirement, 0, len(counts))
+	for t, c := range counts {
+		if c > 0 {
+			machines = append(machines, machineRequirement{instanceType: t, count: c})
+		}
+	}
+	sort.Slice(machines, func(i, j int) bool { return machines[i].instanceType < machines[j].instanceType })
+	return machines
+}
+
+// reconcilePreflightChecks returns the result of the preflight checks for the install config, running the checks only
+// when the result recorded in the PreflightChecksPassed condition is older than preflightRecheckInterval or was for
+// a different install config or platform. The returned duration is when the checks are next due to be run.
+func (r *ReconcileClusterDeployment) reconcilePreflightChecks(cd *hivev1.ClusterDeployment, installConfig []byte, logger log.FieldLogger) (reason, message string, failed bool, recheckAfter time.Duration, err error) {
+	hash, err := preflightChecksHash(cd, installConfig)
+	if err != nil {
+		logger.WithError(err).Error("could not calculate preflight checks hash")
+		return "", "", false, 0, err
+	}
+
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.PreflightChecksPassedCondition)
+	if cond != nil && cond.Status != corev1.ConditionUnknown && cd.Annotations[constants.PreflightChecksHashAnnotation] == hash {
+		if age := time.Since(cond.LastProbeTime.Time); age < preflightRecheckInterval {
+			logger.WithField("age", age).Debug("using result of preflight checks last run")
+			return cond.Reason, cond.Message, cond.Status == corev1.ConditionFalse, preflightRecheckInterval - age, nil
+		}
+	}
+
+	status := corev1.ConditionTrue
+	reason, message, failed = r.runPreflightChecks(cd, installConfig, logger).failure()
+	if failed {
+		status = corev1.ConditionFalse
+	} else {
+		reason, message = preflightPassedReason, "Cloud account has the quota and permissions needed to install"
+	}
+
+	if cd.Annotations[constants.PreflightChecksHashAnnotation] != hash {
+		if cd.Annotations == nil {
+			cd.Annotations = map[string]string{}
+		}
+		cd.Annotations[constants.PreflightChecksHashAnnotation] = hash
+		if err := r.Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not record preflight checks hash")
+			return "", "", false, 0, err
+		}
+	}
+	// The condition is always updated, so that its LastProbeTime records when the checks were run.
+	cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+		cd.Status.Conditions,
+		hivev1.PreflightChecksPassedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionAlways)
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not record result of preflight checks")
+		return "", "", false, 0, err
+	}
+	return reason, message, failed, preflightRecheckInterval, nil
+}
+
+// preflightChecksHash returns the hash of the inputs to the preflight checks, which are run again when it changes.
+func preflightChecksHash(cd *hivev1.ClusterDeployment, installConfig []byte) (string, error) {
+	return controllerutils.GetChecksumOfObjects(
+		string(installConfig),
+		cd.Spec.Platform,
+		cd.Annotations[constants.SkipPreflightChecksAnnotation],
+	)
+}
+
+// runPreflightChecks verifies that the cloud account has the quota and permissions needed by the install
+// before a ClusterProvision is created. The checks are best effort: any check which cannot be completed,
+// for example because the credentials cannot read quotas, is skipped and the install is allowed to proceed.
+func (r *ReconcileClusterDeployment) runPreflightChecks(cd *hivev1.ClusterDeployment, installConfig []byte, logger log.FieldLogger) *preflightResult {
+	result := &preflightResult{}
+	if controllerutils.IsFakeCluster(cd) {
+		return result
+	}
+	if skip, err := strconv.ParseBool(cd.Annotations[constants.SkipPreflightChecksAnnotation]); err == nil && skip {
+		logger.Debug("skipping preflight checks due to annotation")
+		return result
+	}
+
+	ic := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(installConfig, ic); err != nil {
+		logger.WithError(err).Warn("skipping preflight checks, could not unmarshal InstallConfig")
+		return result
+	}
+
+	var err error
+	switch platform := cd.Spec.Platform; {
+	case platform.AWS != nil && ic.Platform.AWS != nil && r.awsClientBuilder != nil:
+		var awsClient awsclient.Client
+		if awsClient, err = r.awsClientBuilder(cd); err == nil {
+			awsPreflightChecks(awsClient, ic, result, logger)
+		}
+	case platform.GCP != nil && ic.Platform.GCP != nil && r.gcpClientBuilder != nil:
+		var gcpClient gcpclient.Client
+		if gcpClient, err = r.gcpClientBuilder(cd); err == nil {
+			gcpPreflightChecks(gcpClient, ic, result, logger)
+		}
+	case platform.Azure != nil && ic.Platform.Azure != nil && r.azureClientBuilder != nil:
+		var azureClient azureclient.Client
+		if azureClient, err = r.azureClientBuilder(cd); err == nil {
+			azurePreflightChecks(azureClient, ic, result, logger)
+		}
+	}
+	if err != nil {
+		logger.WithError(err).Warn("skipping preflight checks, could not create cloud client")
+	}
+	return result
+}
+
+// preflightCheck runs a single check, logging and skipping it if it could not be completed.
+func preflightCheck(name string, logger log.FieldLogger, check func() error) {
+	if err := check(); err != nil {
+		logger.WithError(err).WithField("check", name).Warn("skipping preflight check which could not be completed")
+	}
+}
+
+func newAWSClientBuilder(r *ReconcileClusterDeployment) func(*hivev1.ClusterDeployment) (awsclient.Client, error) {
+	return func(cd *hivev1.ClusterDeployment) (awsclient.Client, error) {
+		return awsclient.New(r.Client, awsclient.Options{
+			Region: cd.Spec.Platform.AWS.Region,
+			CredentialsSource: awsclient.CredentialsSource{
+				Secret: &awsclient.SecretCredentialsSource{
+					Namespace: cd.Namespace,
+					Ref:       &cd.Spec.Platform.AWS.CredentialsSecretRef,
+				},
+				AssumeRole: &awsclient.AssumeRoleCredentialsSource{
+					SecretRef: corev1.SecretReference{
+						Name:      os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar),
+						Namespace: controllerutils.GetHiveNamespace(),
+					},
+					Role: cd.Spec.Platform.AWS.CredentialsAssumeRole,
+				},
+			},
+		})
+	}
+}
+
+func newGCPClientBuilder(r *ReconcileClusterDeployment) func(*hivev1.ClusterDeployment) (gcpclient.Client, error) {
+	return func(cd *hivev1.ClusterDeployment) (gcpclient.Client, error) {
+		secret := &corev1.Secret{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Platform.GCP.CredentialsSecretRef.Name}, secret); err != nil {
+			return nil, err
+		}
+		return gcpclient.NewClientFromSecret(secret)
+	}
+}
+
+func newAzureClientBuilder(r *ReconcileClusterDeployment) func(*hivev1.ClusterDeployment) (azureclient.Client, error) {
+	return func(cd *hivev1.ClusterDeployment) (azureclient.Client, error) {
+		secret := &corev1.Secret{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Platform.Azure.CredentialsSecretRef.Name}, secret); err != nil {
+			return nil, err
+		}
+		return azureclient.NewClientFromSecret(secret)
+	}
+}
@@ -0,0 +1,376 @@
+package clusterdeployment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	gcpcompute "google.golang.org/api/compute/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
+	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+)
+
+const testAWSBYOSubnetsIC = `apiVersion: v1
+metadata:
+  name: testcluster
+baseDomain: example.com
+compute:
+- name: worker
+  platform:
+    aws:
+      type: m4.xlarge
+  replicas: 3
+controlPlane:
+  name: master
+  platform:
+    aws:
+      type: m4.xlarge
+  replicas: 3
+platform:
+  aws:
+    region: us-east-1
+    subnets:
+    - subnet-1
+pullSecret: ""
+`
+
+// mockAWSPreflight sets up the AWS calls made by the preflight checks for testAWSIC, which needs 28 vCPUs
+// for 7 m4.xlarge instances, 3 elastic IPs and 1 VPC. All quotas are set to quota and the given actions are
+// denied.
+func mockAWSPreflight(client *mockaws.MockClient, quota float64, denied ...string) {
+	client.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+		Arn: aws.String("arn:aws:sts::123456789012:assumed-role/installer/session"),
+	}, nil).AnyTimes()
+	client.EXPECT().SimulatePrincipalPolicy(gomock.Any()).DoAndReturn(
+		func(input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePolicyResponse, error) {
+			out := &iam.SimulatePolicyResponse{}
+			for _, action := range aws.StringValueSlice(input.ActionNames) {
+				decision := iam.PolicyEvaluationDecisionTypeAllowed
+				for _, d := range denied {
+					if d == action {
+						decision = iam.PolicyEvaluationDecisionTypeImplicitDeny
+					}
+				}
+				out.EvaluationResults = append(out.EvaluationResults, &iam.EvaluationResult{
+					EvalActionName: aws.String(action),
+					EvalDecision:   aws.String(decision),
+				})
+			}
+			return out, nil
+		}).AnyTimes()
+	client.EXPECT().DescribeInstanceTypes(gomock.Any()).Return(&ec2.DescribeInstanceTypesOutput{
+		InstanceTypes: []*ec2.InstanceTypeInfo{{
+			InstanceType: aws.String("m4.xlarge"),
+			VCpuInfo:     &ec2.VCpuInfo{DefaultVCpus: aws.Int64(4)},
+		}},
+	}, nil).AnyTimes()
+	client.EXPECT().GetServiceQuota(gomock.Any()).Return(&servicequotas.GetServiceQuotaOutput{
+		Quota: &servicequotas.ServiceQuota{Value: aws.Float64(quota)},
+	}, nil).AnyTimes()
+	client.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{}, nil).AnyTimes()
+	client.EXPECT().DescribeAvailabilityZones(gomock.Any()).Return(&ec2.DescribeAvailabilityZonesOutput{
+		AvailabilityZones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1a")},
+			{ZoneName: aws.String("us-east-1b")},
+			{ZoneName: aws.String("us-east-1c")},
+		},
+	}, nil).AnyTimes()
+	client.EXPECT().DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{}, nil).AnyTimes()
+	client.EXPECT().DescribeVpcs(gomock.Any()).Return(&ec2.DescribeVpcsOutput{}, nil).AnyTimes()
+}
+
+func testPreflightInstallConfig(t *testing.T, data string) *installertypes.InstallConfig {
+	ic := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal([]byte(data), ic); err != nil {
+		t.Fatalf("could not unmarshal install config: %v", err)
+	}
+	return ic
+}
+
+func TestAWSPreflightChecks(t *testing.T) {
+	cases := []struct {
+		name                       string
+		installConfig              string
+		setup                      func(*mockaws.MockClient)
+		expectedReason             string
+		expectedMessage            string
+		expectedInsufficientQuotas int
+	}{
+		{
+			name:          "all requirements met",
+			installConfig: testAWSIC,
+			setup: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 100)
+			},
+		},
+		{
+			name:          "exactly enough quota",
+			installConfig: testAWSIC,
+			setup: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 28)
+			},
+		},
+		{
+			name:          "insufficient vCPU quota",
+			installConfig: testAWSIC,
+			setup: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 27)
+			},
+			expectedReason:             insufficientQuotaReason,
+			expectedMessage:            "Insufficient quota to install: standard instance vCPUs requires 28 but only 27 of 27 is available",
+			expectedInsufficientQuotas: 1,
+		},
+		{
+			name:          "insufficient quota for everything",
+			installConfig: testAWSIC,
+			setup: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 0)
+			},
+			expectedReason:             insufficientQuotaReason,
+			expectedInsufficientQuotas: 3,
+		},
+		{
+			name:          "missing permissions",
+			installConfig: testAWSIC,
+			setup: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 100, "iam:PassRole", "ec2:CreateVpc")
+			},
+			expectedReason:  missingPermissionsReason,
+			expectedMessage: "Credentials are missing permissions required to install: ec2:CreateVpc, iam:PassRole",
+		},
+		{
+			name:          "network not checked with existing subnets",
+			installConfig: testAWSBYOSubnetsIC,
+			setup: func(client *mockaws.MockClient) {
+				mockAWSPreflight(client, 28, "ec2:CreateVpc")
+				client.EXPECT().DescribeVpcs(gomock.Any()).Times(0)
+				client.EXPECT().DescribeAddresses(gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:          "checks which cannot be completed are skipped",
+			installConfig: testAWSIC,
+			setup: func(client *mockaws.MockClient) {
+				client.EXPECT().GetCallerIdentity(gomock.Any()).Return(nil, errors.New("access denied"))
+				client.EXPECT().DescribeInstanceTypes(gomock.Any()).Return(nil, errors.New("access denied"))
+				client.EXPECT().DescribeAvailabilityZones(gomock.Any()).Return(nil, errors.New("access denied"))
+				client.EXPECT().GetServiceQuota(gomock.Any()).Return(nil, errors.New("access denied"))
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			client := mockaws.NewMockClient(mockCtrl)
+			tc.setup(client)
+
+			result := &preflightResult{}
+			awsPreflightChecks(client, testPreflightInstallConfig(t, tc.installConfig), result, log.WithField("test", tc.name))
+
+			reason, message, failed := result.failure()
+			assert.Equal(t, tc.expectedReason != "", failed, "unexpected failure")
+			assert.Equal(t, tc.expectedReason, reason, "unexpected reason")
+			if tc.expectedMessage != "" {
+				assert.Equal(t, tc.expectedMessage, message, "unexpected message")
+			}
+			if tc.expectedInsufficientQuotas > 0 {
+				assert.Len(t, result.insufficientQuotas, tc.expectedInsufficientQuotas, "unexpected number of insufficient quotas")
+			}
+		})
+	}
+}
+
+func TestAWSPolicySourceARN(t *testing.T) {
+	cases := []struct {
+		callerARN string
+		expected  string
+	}{
+		{
+			callerARN: "arn:aws:iam::123456789012:user/installer",
+			expected:  "arn:aws:iam::123456789012:user/installer",
+		},
+		{
+			callerARN: "arn:aws:sts::123456789012:assumed-role/installer/session",
+			expected:  "arn:aws:iam::123456789012:role/installer",
+		},
+		{
+			callerARN: "arn:aws-cn:sts::123456789012:assumed-role/installer/session",
+			expected:  "arn:aws-cn:iam::123456789012:role/installer",
+		},
+		{
+			callerARN: "arn:aws:iam::123456789012:root",
+			expected:  "",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.callerARN, func(t *testing.T) {
+			actual, err := awsPolicySourceARN(tc.callerARN)
+			if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, tc.expected, actual, "unexpected policy source ARN")
+			}
+		})
+	}
+}
+
+func TestGCPPreflightChecks(t *testing.T) {
+	cases := []struct {
+		name            string
+		granted         []string
+		cpuLimit        float64
+		cpuUsage        float64
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:     "all requirements met",
+			granted:  append(append([]string{}, gcpRequiredPermissions...), gcpNetworkPermissions...),
+			cpuLimit: 100,
+		},
+		{
+			name:            "missing permissions",
+			granted:         gcpRequiredPermissions[1:],
+			cpuLimit:        100,
+			expectedReason:  missingPermissionsReason,
+			expectedMessage: "Credentials are missing permissions required to install: compute.addresses.create, compute.networks.create, compute.routers.create, compute.subnetworks.create",
+		},
+		{
+			name:            "insufficient CPU quota",
+			granted:         append(append([]string{}, gcpRequiredPermissions...), gcpNetworkPermissions...),
+			cpuLimit:        100,
+			cpuUsage:        80,
+			expectedReason:  insufficientQuotaReason,
+			expectedMessage: "Insufficient quota to install: CPUs requires 28 but only 20 of 100 is available",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			client := mockgcp.NewMockClient(mockCtrl)
+			client.EXPECT().TestIamPermissions(gomock.Any()).Return(tc.granted, nil)
+			client.EXPECT().ListComputeZones(gomock.Any()).Return(&gcpcompute.ZoneList{
+				Items: []*gcpcompute.Zone{{Name: "us-east1-b"}},
+			}, nil)
+			client.EXPECT().GetMachineType("us-east1-b", "n1-standard-4").Return(&gcpcompute.MachineType{GuestCpus: 4}, nil)
+			client.EXPECT().GetRegion("us-east1").Return(&gcpcompute.Region{
+				Quotas: []*gcpcompute.Quota{{Metric: gcpCPUsQuotaMetric, Limit: tc.cpuLimit, Usage: tc.cpuUsage}},
+			}, nil)
+			client.EXPECT().GetProject().Return(&gcpcompute.Project{
+				Quotas: []*gcpcompute.Quota{{Metric: gcpNetworksQuotaMetric, Limit: 5, Usage: 1}},
+			}, nil)
+
+			result := &preflightResult{}
+			gcpPreflightChecks(client, testPreflightInstallConfig(t, testGCPIC), result, log.WithField("test", tc.name))
+
+			reason, message, failed := result.failure()
+			assert.Equal(t, tc.expectedReason != "", failed, "unexpected failure")
+			assert.Equal(t, tc.expectedReason, reason, "unexpected reason")
+			assert.Equal(t, tc.expectedMessage, message, "unexpected message")
+		})
+	}
+}
+
+func TestAzurePreflightChecks(t *testing.T) {
+	cases := []struct {
+		name            string
+		usage           []compute.Usage
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name: "all requirements met",
+			usage: []compute.Usage{
+				testAzureUsage("cores", 10, 100),
+				testAzureUsage("standardDSv3Family", 10, 100),
+			},
+		},
+		{
+			name: "insufficient family quota",
+			usage: []compute.Usage{
+				testAzureUsage("cores", 10, 100),
+				testAzureUsage("standardDSv3Family", 10, 50),
+			},
+			expectedReason:  insufficientQuotaReason,
+			expectedMessage: "Insufficient quota to install: standardDSv3Family vCPUs requires 44 but only 40 of 50 is available",
+		},
+		{
+			name: "insufficient regional quota",
+			usage: []compute.Usage{
+				testAzureUsage("cores", 90, 100),
+				testAzureUsage("standardDSv3Family", 10, 100),
+			},
+			expectedReason:  insufficientQuotaReason,
+			expectedMessage: "Insufficient quota to install: cores vCPUs requires 44 but only 10 of 100 is available",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			client := mockazure.NewMockClient(mockCtrl)
+
+			skuPage := mockazure.NewMockResourceSKUsPage(mockCtrl)
+			client.EXPECT().ListResourceSKUs(gomock.Any(), "location eq 'centralus'").Return(skuPage, nil)
+			gomock.InOrder(
+				skuPage.EXPECT().NotDone().Return(true),
+				skuPage.EXPECT().NotDone().Return(false),
+			)
+			skuPage.EXPECT().NextWithContext(gomock.Any()).Return(nil)
+			skuPage.EXPECT().Values().Return([]compute.ResourceSku{
+				testAzureSKU("Standard_D8s_v3", "standardDSv3Family", "8"),
+				testAzureSKU("Standard_D4s_v3", "standardDSv3Family", "4"),
+			})
+
+			usagePage := mockazure.NewMockUsagePage(mockCtrl)
+			client.EXPECT().ListUsage(gomock.Any(), "centralus").Return(usagePage, nil)
+			gomock.InOrder(
+				usagePage.EXPECT().NotDone().Return(true),
+				usagePage.EXPECT().NotDone().Return(false),
+			)
+			usagePage.EXPECT().NextWithContext(gomock.Any()).Return(nil)
+			usagePage.EXPECT().Values().Return(tc.usage)
+
+			result := &preflightResult{}
+			azurePreflightChecks(client, testPreflightInstallConfig(t, testAzureIC), result, log.WithField("test", tc.name))
+
+			reason, message, failed := result.failure()
+			assert.Equal(t, tc.expectedReason != "", failed, "unexpected failure")
+			assert.Equal(t, tc.expectedReason, reason, "unexpected reason")
+			assert.Equal(t, tc.expectedMessage, message, "unexpected message")
+		})
+	}
+}
+
+func testAzureSKU(name, family, vcpus string) compute.ResourceSku {
+	return compute.ResourceSku{
+		ResourceType: pointer.StringPtr("virtualMachines"),
+		Name:         pointer.StringPtr(name),
+		Family:       pointer.StringPtr(family),
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: pointer.StringPtr("vCPUs"), Value: pointer.StringPtr(vcpus)},
+		},
+	}
+}
+
+func testAzureUsage(name string, current int32, limit int64) compute.Usage {
+	return compute.Usage{
+		Name:         &compute.UsageName{Value: pointer.StringPtr(name)},
+		CurrentValue: pointer.Int32Ptr(current),
+		Limit:        pointer.Int64Ptr(limit),
+	}
+}
@@ -0,0 +1,228 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	provisionHooksSucceededReason = "ProvisionHooksSucceeded"
+
+	provisionHookKubeconfigVolume = "kubeconfig"
+	provisionHookKubeconfigDir    = "/etc/kubeconfig"
+
+	provisionHookClusterDeploymentNameEnvVar      = "CLUSTER_DEPLOYMENT_NAME"
+	provisionHookClusterDeploymentNamespaceEnvVar = "CLUSTER_DEPLOYMENT_NAMESPACE"
+)
+
+// GetProvisionHookJobName returns the name of the Job running the named provision hook of a ClusterDeployment.
+func GetProvisionHookJobName(cd *hivev1.ClusterDeployment, hookName string) string {
+	return apihelpers.GetResourceName(cd.Name, "hook-"+hookName)
+}
+
+// reconcileProvisionHooks runs the provision hooks of a phase one at a time, in the order they are listed. It returns
+// true once all of the hooks have finished, and false while a hook is running or a hook with the Fail failure policy
+// has failed. The Job watch requeues the ClusterDeployment when a hook Job finishes.
+func (r *ReconcileClusterDeployment) reconcileProvisionHooks(cd *hivev1.ClusterDeployment, phase hivev1.ProvisionHookPhase, logger log.FieldLogger) (bool, error) {
+	if cd.Spec.Provisioning == nil {
+		return true, nil
+	}
+	var hooks []hookJob
+	for i := range cd.Spec.Provisioning.Hooks {
+		hook := &cd.Spec.Provisioning.Hooks[i]
+		if hook.Phase != phase {
+			continue
+		}
+		hooks = append(hooks, hookJob{
+			name:          hook.Name,
+			jobName:       GetProvisionHookJobName(cd, hook.Name),
+			failurePolicy: hook.FailurePolicy,
+			generate:      func() (*batchv1.Job, error) { return generateProvisionHookJob(cd, hook) },
+		})
+	}
+	running, failed, err := r.runHookJobs(cd, hooks, "provision", logger)
+	switch {
+	case err != nil, running != nil:
+		return false, err
+	case failed != nil:
+		logger.WithField("hook", failed.name).Error("provision hook failed, not proceeding with install")
+		return false, r.setProvisionHookFailedCondition(
+			cd,
+			corev1.ConditionTrue,
+			fmt.Sprintf("%sHookFailed", phase),
+			fmt.Sprintf("%s hook %s failed. Delete job %s to run the hook again.", phase, failed.name, failed.jobName),
+			logger,
+		)
+	}
+	return true, r.setProvisionHookFailedCondition(cd, corev1.ConditionFalse, provisionHooksSucceededReason, "Provision hooks succeeded", logger)
+}
+
+// hookJob is a provision or pre-deprovision hook run in a Job.
+type hookJob struct {
+	name          string
+	jobName       string
+	failurePolicy hivev1.ProvisionHookFailurePolicy
+	generate      func() (*batchv1.Job, error)
+	// job is the Job of the hook, set by runHookJobs for the hook it returns.
+	job *batchv1.Job
+}
+
+// runHookJobs runs hooks one at a time, in order, creating the Job of the first hook which has not run yet. It returns
+// the hook which is running, or the hook with the Fail failure policy which has failed, and neither once all of the
+// hooks have finished.
+func (r *ReconcileClusterDeployment) runHookJobs(cd *hivev1.ClusterDeployment, hooks []hookJob, kind string, logger log.FieldLogger) (running, failed *hookJob, returnErr error) {
+	for i := range hooks {
+		hook := &hooks[i]
+		hookLog := logger.WithField("hook", hook.name)
+
+		job := &batchv1.Job{}
+		switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: hook.jobName}, job); {
+		case apierrors.IsNotFound(err):
+			job, err = hook.generate()
+			if err != nil {
+				hookLog.WithError(err).Errorf("error generating %s hook job", kind)
+				return nil, nil, err
+			}
+			if err := controllerutil.SetControllerReference(cd, job, r.scheme); err != nil {
+				hookLog.WithError(err).Error("error setting controller reference on job")
+				return nil, nil, err
+			}
+			hookLog.WithField("job", job.Name).Infof("creating %s hook job", kind)
+			if err := r.Create(context.TODO(), job); err != nil {
+				hookLog.WithError(err).Log(controllerutils.LogLevel(err), fmt.Sprintf("error creating %s hook job", kind))
+				return nil, nil, err
+			}
+			hook.job = job
+			return hook, nil, nil
+		case err != nil:
+			hookLog.WithError(err).Log(controllerutils.LogLevel(err), fmt.Sprintf("error getting %s hook job", kind))
+			return nil, nil, err
+		}
+
+		hook.job = job
+		switch {
+		case controllerutils.IsSuccessful(job):
+			continue
+		case controllerutils.IsFailed(job):
+			if hook.failurePolicy == hivev1.IgnoreProvisionHookFailurePolicy {
+				hookLog.Warnf("%s hook failed, ignoring due to failure policy", kind)
+				continue
+			}
+			return nil, hook, nil
+		default:
+			hookLog.Debugf("waiting for %s hook to finish", kind)
+			return hook, nil, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// setProvisionHookFailedCondition sets the ProvisionHookFailed condition. The condition is only set to False once a
+// hook that had failed succeeds, so it is left Unknown on ClusterDeployments without failed hooks.
+func (r *ReconcileClusterDeployment) setProvisionHookFailedCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	if status == corev1.ConditionFalse {
+		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionHookFailedCondition)
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			return nil
+		}
+	}
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ProvisionHookFailedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	logger.Debugf("setting ProvisionHookFailedCondition to %v", status)
+	return r.statusUpdate(cd, logger)
+}
+
+// generateProvisionHookJob returns the Job running a provision hook. Post-install hooks get the admin kubeconfig of
+// the cluster mounted into each of their containers.
+func generateProvisionHookJob(cd *hivev1.ClusterDeployment, hook *hivev1.ProvisionHook) (*batchv1.Job, error) {
+	job, err := generateHookJob(cd, hook.Name, hook.Template, hook.Phase == hivev1.PostInstallProvisionHookPhase)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not generate %s hook %s", hook.Phase, hook.Name)
+	}
+	job.Name = GetProvisionHookJobName(cd, hook.Name)
+	job.Spec.BackoffLimit = hook.BackoffLimit
+	job.Spec.ActiveDeadlineSeconds = hook.ActiveDeadlineSeconds
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.JobTypeLabel, constants.JobTypeProvisionHook)
+	return job, nil
+}
+
+// generateHookJob returns a Job running the pod template of a hook, with the ClusterDeployment name and namespace set
+// in the environment of each container. When mountKubeconfig is set, the admin kubeconfig of the cluster is also
+// mounted into each container.
+func generateHookJob(cd *hivev1.ClusterDeployment, hookName string, podTemplate corev1.PodTemplateSpec, mountKubeconfig bool) (*batchv1.Job, error) {
+	template := podTemplate.DeepCopy()
+	if template.Spec.RestartPolicy == "" {
+		template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	env := []corev1.EnvVar{
+		{Name: provisionHookClusterDeploymentNameEnvVar, Value: cd.Name},
+		{Name: provisionHookClusterDeploymentNamespaceEnvVar, Value: cd.Namespace},
+	}
+	var volumeMounts []corev1.VolumeMount
+	if mountKubeconfig {
+		if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name == "" {
+			return nil, errors.New("cluster has no admin kubeconfig")
+		}
+		template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+			Name: provisionHookKubeconfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      provisionHookKubeconfigVolume,
+			MountPath: provisionHookKubeconfigDir,
+			ReadOnly:  true,
+		})
+		env = append(env, corev1.EnvVar{
+			Name:  "KUBECONFIG",
+			Value: filepath.Join(provisionHookKubeconfigDir, constants.KubeconfigSecretKey),
+		})
+	}
+	for i := range template.Spec.Containers {
+		c := &template.Spec.Containers[i]
+		c.Env = append(c.Env, env...)
+		c.VolumeMounts = append(c.VolumeMounts, volumeMounts...)
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cd.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Template: *template,
+		},
+	}
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.ProvisionHookNameLabel, hookName)
+	return job, nil
+}
@@ -2,6 +2,7 @@ package clusterdeprovision
 
 import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/deprovision"
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -13,4 +14,7 @@ type Actuator interface {
 
 	// TestCredentials returns nil if the credential check succeeds. Otherwise returns the error.
 	TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error
+
+	// ListResources returns the cloud resources of the cluster that are still present.
+	ListResources(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]deprovision.Resource, error)
 }
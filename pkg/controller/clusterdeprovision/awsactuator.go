@@ -1,6 +1,7 @@
 package clusterdeprovision
 
 import (
+	"fmt"
 	"os"
 
 	log "github.com/sirupsen/logrus"
@@ -13,6 +14,7 @@ import (
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/deprovision"
 )
 
 func init() {
@@ -51,6 +53,30 @@ func (a *awsActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovis
 	return nil
 }
 
+// ListResources lists the resources that the uninstall job would delete, using the same tags as the uninstall job.
+func (a *awsActuator) ListResources(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]deprovision.Resource, error) {
+	awsClient, err := a.awsClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []map[string]string{
+		{fmt.Sprintf("kubernetes.io/cluster/%s", clusterDeprovision.Spec.InfraID): "owned"},
+	}
+	if clusterDeprovision.Spec.ClusterID != "" {
+		filters = append(filters, map[string]string{"openshiftClusterID": clusterDeprovision.Spec.ClusterID})
+	}
+	lister := &deprovision.AWSLister{Client: awsClient, ClusterID: clusterDeprovision.Spec.InfraID, Filters: filters}
+	if globalRegion := deprovision.AWSGlobalRegion(clusterDeprovision.Spec.Platform.AWS.Region); globalRegion != clusterDeprovision.Spec.Platform.AWS.Region {
+		global := clusterDeprovision.DeepCopy()
+		global.Spec.Platform.AWS.Region = globalRegion
+		if lister.GlobalClient, err = a.awsClientFn(global, c, logger); err != nil {
+			return nil, err
+		}
+	}
+	return lister.ListResources()
+}
+
 func getAWSClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
 	options := awsclient.Options{
 		Region: cd.Spec.Platform.AWS.Region,
@@ -0,0 +1,58 @@
+package clusterdeprovision
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/deprovision"
+)
+
+func init() {
+	registerActuator(&azureActuator{azureClientFn: getAzureClient})
+}
+
+// Ensure azureActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &azureActuator{}
+
+type azureActuator struct {
+	// azureClientFn is the function to build an Azure client, here for testing
+	azureClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (azureclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *azureActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.Azure != nil
+}
+
+// TestCredentials ensures that an Azure client can be built from the credentials.
+func (a *azureActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	_, err := a.azureClientFn(clusterDeprovision, c, logger)
+	return err
+}
+
+// ListResources lists the resources in the resource group of the cluster.
+func (a *azureActuator) ListResources(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]deprovision.Resource, error) {
+	azureClient, err := a.azureClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return nil, err
+	}
+	lister := &deprovision.AzureLister{Client: azureClient, InfraID: clusterDeprovision.Spec.InfraID}
+	return lister.ListResources()
+}
+
+func getAzureClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (azureclient.Client, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Azure.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to fetch Azure credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch Azure credentials secret")
+	}
+	return azureclient.NewClientFromSecret(secret)
+}
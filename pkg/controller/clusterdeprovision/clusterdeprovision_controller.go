@@ -16,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 
@@ -31,6 +32,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	"github.com/openshift/hive/pkg/controller/orphanedinfra"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/install"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
@@ -52,6 +54,14 @@ var (
 		},
 	)
 
+	metricLeakedResources = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_cluster_deprovision_leaked_resources_total",
+			Help: "Counter incremented by the number of cloud resources found remaining after a successful deprovision.",
+		},
+		[]string{"platform"},
+	)
+
 	// actuators is a list of available actuators for this controller
 	// It is populated via the registerActuator function
 	actuators []Actuator
@@ -66,6 +76,7 @@ func registerActuator(a Actuator) {
 
 func init() {
 	metrics.Registry.MustRegister(metricUninstallJobDuration)
+	metrics.Registry.MustRegister(metricLeakedResources)
 }
 
 // Add creates a new ClusterDeprovision Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -99,6 +110,7 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (re
 	return &ReconcileClusterDeprovision{
 		Client:               controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme:               mgr.GetScheme(),
+		eventRecorder:        mgr.GetEventRecorderFor(ControllerName.String()),
 		deprovisionsDisabled: deprovisionsDisabled,
 	}, nil
 }
@@ -142,6 +154,7 @@ var _ reconcile.Reconciler = &ReconcileClusterDeprovision{}
 type ReconcileClusterDeprovision struct {
 	client.Client
 	scheme               *runtime.Scheme
+	eventRecorder        record.EventRecorder
 	deprovisionsDisabled bool
 }
 
@@ -176,6 +189,12 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, err
 	}
 
+	// A completed deprovision is scanned for leaked resources, even when it is being deleted along with its
+	// ClusterDeployment.
+	if instance.Status.Completed && controllerutils.HasFinalizer(instance, leakScanFinalizer) {
+		return r.reconcileLeakScan(instance, rLog)
+	}
+
 	if !instance.DeletionTimestamp.IsZero() {
 		rLog.Debug("clusterdeprovision being deleted, skipping")
 		return reconcile.Result{}, nil
@@ -186,38 +205,61 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, nil
 	}
 
-	// Check if there is a ClusterDeployment owning this Deprovision, if so look it up and
-	// make sure it has a deletion timestamp. Otherwise bail out as a safety check.
-	oRef := metav1.GetControllerOf(instance)
-	if oRef == nil {
-		// TODO: this was once supported to cleanup self-managed "preserveOnDelete" clusters,
-		// but the feature was killed off. For now we'd rather not open the door to dangling
-		// ClusterDeprovisions with no associated cluster.
-		rLog.Warn("ClusterDeprovision does not have an owning ClusterDeployment")
-		return reconcile.Result{}, nil
-	}
-	if oRef.Kind != "ClusterDeployment" || !strings.HasPrefix(oRef.APIVersion, "hive.openshift.io") {
-		rLog.Warnf("ClusterDeprovision has a non-ClusterDeployment owner: %v", oRef)
-		return reconcile.Result{}, nil
-	}
-	cd := &hivev1.ClusterDeployment{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: oRef.Name}, cd); err != nil {
-		rLog.Error("error looking up ClusterDeployment that owns ClusterDeprovision")
-		return reconcile.Result{}, fmt.Errorf("error looking up ClusterDeployment that owns ClusterDeprovision")
-	}
-	if cd.DeletionTimestamp == nil {
-		rLog.Error("ClusterDeprovision created for ClusterDeployment that has not been deleted")
-		return reconcile.Result{}, nil
-	}
-	if controllerutils.IsDeleteProtected(cd) {
-		rLog.Error("deprovision blocked for ClusterDeployment with protected delete on")
-		return reconcile.Result{}, nil
-	}
+	if instance.Spec.DryRun {
+		// A dry run deletes nothing, so it does not need a deleted ClusterDeployment, and is run even when
+		// deprovisions are disabled.
+		if !install.DryRunDeprovisionSupported(instance) {
+			rLog.Warn("dry run deprovisions are not supported for this platform")
+			return reconcile.Result{}, r.setDryRunNotSupportedCondition(instance, rLog)
+		}
+	} else {
+		// Check if there is a ClusterDeployment owning this Deprovision, if so look it up and
+		// make sure it has a deletion timestamp. Otherwise bail out as a safety check.
+		oRef := metav1.GetControllerOf(instance)
+		switch {
+		case oRef == nil && instance.Labels[constants.OrphanedInfraLabel] == "true":
+			// Created by the orphaned infrastructure reaper, for infrastructure which no longer has a cluster. The
+			// label alone is not trusted, as anyone able to create a ClusterDeprovision could set it.
+			created, err := orphanedinfra.IsOrphanedInfraDeprovision(r.Client, instance)
+			if err != nil {
+				rLog.WithError(err).Log(controllerutils.LogLevel(err), "error checking for orphaned infrastructure reaper record")
+				return reconcile.Result{}, err
+			}
+			if !created {
+				rLog.Warn("ClusterDeprovision has the orphaned infrastructure label but was not created by the orphaned infrastructure reaper")
+				return reconcile.Result{}, nil
+			}
+			rLog.Info("deprovisioning orphaned infrastructure")
+		case oRef == nil:
+			// TODO: this was once supported to cleanup self-managed "preserveOnDelete" clusters,
+			// but the feature was killed off. For now we'd rather not open the door to dangling
+			// ClusterDeprovisions with no associated cluster.
+			rLog.Warn("ClusterDeprovision does not have an owning ClusterDeployment")
+			return reconcile.Result{}, nil
+		case oRef.Kind != "ClusterDeployment" || !strings.HasPrefix(oRef.APIVersion, "hive.openshift.io"):
+			rLog.Warnf("ClusterDeprovision has a non-ClusterDeployment owner: %v", oRef)
+			return reconcile.Result{}, nil
+		default:
+			cd := &hivev1.ClusterDeployment{}
+			if err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: oRef.Name}, cd); err != nil {
+				rLog.Error("error looking up ClusterDeployment that owns ClusterDeprovision")
+				return reconcile.Result{}, fmt.Errorf("error looking up ClusterDeployment that owns ClusterDeprovision")
+			}
+			if cd.DeletionTimestamp == nil {
+				rLog.Error("ClusterDeprovision created for ClusterDeployment that has not been deleted")
+				return reconcile.Result{}, nil
+			}
+			if controllerutils.IsDeleteProtected(cd) {
+				rLog.Error("deprovision blocked for ClusterDeployment with protected delete on")
+				return reconcile.Result{}, nil
+			}
+		}
 
-	// Check if deprovisions are currently disabled: (originates in HiveConfig in real world)
-	if r.deprovisionsDisabled {
-		rLog.Warn("deprovisions are currently disabled in HiveConfig, skipping")
-		return reconcile.Result{}, nil
+		// Check if deprovisions are currently disabled: (originates in HiveConfig in real world)
+		if r.deprovisionsDisabled {
+			rLog.Warn("deprovisions are currently disabled in HiveConfig, skipping")
+			return reconcile.Result{}, nil
+		}
 	}
 
 	actuator := r.getActuator(instance)
@@ -282,7 +324,7 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		}
 	}
 
-	if err := controllerutils.SetupClusterUninstallServiceAccount(r, cd.Namespace, rLog); err != nil {
+	if err := controllerutils.SetupClusterUninstallServiceAccount(r, instance.Namespace, rLog); err != nil {
 		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting up service account and role")
 		return reconcile.Result{}, err
 	}
@@ -339,7 +381,19 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 
 	// Uninstall job exists, check its status and if successful, set the deprovision request status to complete
 	if controllerutils.IsSuccessful(existingJob) {
+		if instance.Spec.DryRun {
+			return reconcile.Result{}, r.completeDryRun(instance, rLog)
+		}
 		rLog.Infof("uninstall job successful, setting completed status")
+		if actuator != nil {
+			if err := r.copyLeakScanCredentials(instance, rLog); err != nil {
+				return reconcile.Result{}, err
+			}
+			if err := r.addLeakScanFinalizer(instance, rLog); err != nil {
+				return reconcile.Result{}, err
+			}
+			setLeakScanPending(instance)
+		}
 		conditions, _ := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
 			instance.Status.Conditions,
 			hivev1.DeprovisionFailedClusterDeprovisionCondition,
@@ -360,6 +414,9 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 			return reconcile.Result{}, err
 		}
 		metricUninstallJobDuration.Observe(float64(jobDuration.Seconds()))
+		if actuator != nil {
+			return reconcile.Result{RequeueAfter: leakScanDelay}, nil
+		}
 		return reconcile.Result{}, nil
 	}
 
@@ -3,10 +3,18 @@ package clusterdeprovision
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +37,7 @@ import (
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/deprovision"
 	"github.com/openshift/hive/pkg/install"
 )
 
@@ -53,6 +63,7 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 		validate                       func(t *testing.T, c client.Client)
 		expectErr                      bool
 		deprovisionsDisabled           bool
+		hiveNamespace                  string
 	}{
 		{
 			name: "no-op deleting",
@@ -122,6 +133,57 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateNoJobExists(t, c)
 			},
 		},
+		{
+			name:        "no-op without owning cluster deployment",
+			deprovision: testClusterDeprovision(),
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+			},
+		},
+		{
+			name:                  "create uninstall job for orphaned infrastructure",
+			deprovision:           testOrphanedInfraClusterDeprovision(),
+			hiveNamespace:         testNamespace,
+			existing:              []runtime.Object{testOrphanedInfraStateConfigMap(true)},
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+			},
+		},
+		{
+			name:                 "do not create uninstall job for orphaned infrastructure when deprovisions are disabled",
+			deprovision:          testOrphanedInfraClusterDeprovision(),
+			hiveNamespace:        testNamespace,
+			existing:             []runtime.Object{testOrphanedInfraStateConfigMap(true)},
+			deprovisionsDisabled: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+			},
+		},
+		{
+			name:        "do not create uninstall job for orphaned infrastructure outside the hive namespace",
+			deprovision: testOrphanedInfraClusterDeprovision(),
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+			},
+		},
+		{
+			name:          "do not create uninstall job for orphaned infrastructure not recorded by the reaper",
+			deprovision:   testOrphanedInfraClusterDeprovision(),
+			hiveNamespace: testNamespace,
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+			},
+		},
+		{
+			name:          "do not create uninstall job for orphaned infrastructure not known to be managed",
+			deprovision:   testOrphanedInfraClusterDeprovision(),
+			hiveNamespace: testNamespace,
+			existing:      []runtime.Object{testOrphanedInfraStateConfigMap(false)},
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+			},
+		},
 		{
 			name:        "no-op when job in progress",
 			deprovision: testClusterDeprovision(),
@@ -152,10 +214,24 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					job.Status.StartTime = &now
 					return job
 				}(),
+				testCredentialsSecret(),
 			},
 			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
 				validateCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.ResourcesLeakedClusterDeprovisionCondition,
+						Reason: leakScanPendingReason,
+						Status: corev1.ConditionUnknown,
+					},
+				})
+				req := &hivev1.ClusterDeprovision{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req))
+				assert.Contains(t, req.Finalizers, leakScanFinalizer, "expected leak scan finalizer")
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(context.TODO(), leakScanCredentialsName(), secret), "expected copy of credentials for leak scan")
+				assert.Equal(t, testCredentialsSecret().Data, secret.Data, "unexpected copy of credentials for leak scan")
 			},
 		},
 		{
@@ -285,6 +361,102 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateNoJobExists(t, c)
 			},
 		},
+		{
+			name:                  "create dry run uninstall job for cluster deployment not deleted",
+			deprovision:           testDryRunClusterDeprovision(),
+			deployment:            testClusterDeployment(),
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				job := validateJobExists(t, c)
+				assert.Contains(t, job.Spec.Template.Spec.Containers[0].Args, "--dry-run", "expected dry run job")
+			},
+		},
+		{
+			name:                  "create dry run uninstall job when deprovisions are disabled",
+			deprovision:           testDryRunClusterDeprovision(),
+			deployment:            testDeletedClusterDeployment(),
+			deprovisionsDisabled:  true,
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+			},
+		},
+		{
+			name:        "dry run completed when job is successful",
+			deprovision: testDryRunClusterDeprovision(),
+			deployment:  testClusterDeployment(),
+			existing: []runtime.Object{
+				func() runtime.Object {
+					job := testDryRunUninstallJob()
+					job.Status.Conditions = []batchv1.JobCondition{
+						{
+							Type:   batchv1.JobComplete,
+							Status: corev1.ConditionTrue,
+						},
+					}
+					return job
+				}(),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      testName + "-dry-run",
+					},
+					Data: map[string]string{
+						"resources": "ec2:instance arn:aws:ec2:us-east-1:123456789012:instance/i-1\n" +
+							"ec2:instance arn:aws:ec2:us-east-1:123456789012:instance/i-2\n" +
+							"s3 arn:aws:s3:::test-bucket",
+					},
+				},
+			},
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.DryRunCompleteClusterDeprovisionCondition,
+						Reason: dryRunSucceededReason,
+						Status: corev1.ConditionTrue,
+					},
+				})
+				req := &hivev1.ClusterDeprovision{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req))
+				if assert.NotNil(t, req.Status.DryRunResultRef, "expected dry run result reference") {
+					assert.Equal(t, testName+"-dry-run", req.Status.DryRunResultRef.Name, "unexpected dry run result reference")
+				}
+				assert.Equal(t,
+					[]hivev1.DeprovisionResourceCount{{Type: "ec2:instance", Count: 2}, {Type: "s3", Count: 1}},
+					req.Status.DryRunResources,
+					"unexpected dry run resources",
+				)
+				cm := &corev1.ConfigMap{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-dry-run"}, cm))
+				if owner := metav1.GetControllerOf(cm); assert.NotNil(t, owner, "expected dry run configmap to be owned") {
+					assert.Equal(t, testName, owner.Name, "unexpected dry run configmap owner")
+				}
+			},
+		},
+		{
+			name: "dry run not supported for platform",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testDryRunClusterDeprovision()
+				req.Spec.Platform = hivev1.ClusterDeprovisionPlatform{
+					VSphere: &hivev1.VSphereClusterDeprovision{},
+				}
+				return req
+			}(),
+			deployment: testClusterDeployment(),
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateNotCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.DeprovisionFailedClusterDeprovisionCondition,
+						Reason: dryRunNotSupportedReason,
+						Status: corev1.ConditionTrue,
+					},
+				})
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -298,7 +470,15 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					return
 				}
 			}
-			existing := append(test.existing, test.deprovision, test.deployment)
+			existing := append(test.existing, test.deprovision)
+			if test.deployment != nil {
+				existing = append(existing, test.deployment)
+			}
+
+			if test.hiveNamespace != "" {
+				os.Setenv(constants.HiveNamespaceEnvVar, test.hiveNamespace)
+				defer os.Unsetenv(constants.HiveNamespaceEnvVar)
+			}
 
 			mocks := setupDefaultMocks(t, existing...)
 
@@ -310,10 +490,10 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					GetCallerIdentity(gomock.Any()).
 					Return(nil, test.expectedGetCallerIdentityError)
 			}
-
 			r := &ReconcileClusterDeprovision{
 				Client:               mocks.fakeKubeClient,
 				scheme:               scheme.Scheme,
+				eventRecorder:        record.NewFakeRecorder(10),
 				deprovisionsDisabled: test.deprovisionsDisabled,
 			}
 
@@ -344,6 +524,303 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 	}
 }
 
+func TestClusterDeprovisionLeakScan(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	leakedARNs := []string{
+		"arn:aws:s3:::test-infra-id-image-registry",
+		"arn:aws:route53:::hostedzone/Z1234567890",
+	}
+	leakedCMName := types.NamespacedName{
+		Namespace: controllerutils.GetHiveNamespace(),
+		Name:      deprovision.LeakedResourcesConfigMapName(testNamespace, testName),
+	}
+
+	tests := []struct {
+		name                  string
+		deprovision           *hivev1.ClusterDeprovision
+		existing              []runtime.Object
+		mockLeakScan          bool
+		leakedResourceARNs    []string
+		leakScanError         error
+		expectErr             bool
+		expectRequeue         bool
+		expectFinalizer       bool
+		expectScanCredentials types.NamespacedName
+		expectedCondition     *hivev1.ClusterDeprovisionCondition
+		expectedLeaked        []hivev1.DeprovisionResourceCount
+		expectedMetric        float64
+		expectedEvent         string
+		expectCredentialsKept bool
+	}{
+		{
+			name:              "waits for the delay",
+			deprovision:       testLeakScanClusterDeprovision(time.Minute),
+			expectRequeue:     true,
+			expectFinalizer:   true,
+			expectedCondition: &hivev1.ClusterDeprovisionCondition{Status: corev1.ConditionUnknown, Reason: leakScanPendingReason},
+		},
+		{
+			name:              "no leaked resources",
+			deprovision:       testLeakScanClusterDeprovision(leakScanDelay),
+			mockLeakScan:      true,
+			expectedCondition: &hivev1.ClusterDeprovisionCondition{Status: corev1.ConditionFalse, Reason: noResourcesLeakedReason},
+			expectedEvent:     corev1.EventTypeNormal + " " + noResourcesLeakedReason,
+		},
+		{
+			name:               "leaked resources",
+			deprovision:        testLeakScanClusterDeprovision(leakScanDelay),
+			mockLeakScan:       true,
+			leakedResourceARNs: leakedARNs,
+			expectedCondition:  &hivev1.ClusterDeprovisionCondition{Status: corev1.ConditionTrue, Reason: resourcesLeakedReason},
+			expectedLeaked:     []hivev1.DeprovisionResourceCount{{Type: "route53:hostedzone", Count: 1}, {Type: "s3", Count: 1}},
+			expectedMetric:     2,
+			expectedEvent:      corev1.EventTypeWarning + " " + resourcesLeakedReason,
+		},
+		{
+			name: "leaked resources when being deleted",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testLeakScanClusterDeprovision(leakScanDelay)
+				now := metav1.Now()
+				req.DeletionTimestamp = &now
+				return req
+			}(),
+			mockLeakScan:       true,
+			leakedResourceARNs: leakedARNs,
+			expectedCondition:  &hivev1.ClusterDeprovisionCondition{Status: corev1.ConditionTrue, Reason: resourcesLeakedReason},
+			expectedLeaked:     []hivev1.DeprovisionResourceCount{{Type: "route53:hostedzone", Count: 1}, {Type: "s3", Count: 1}},
+			expectedMetric:     2,
+			expectedEvent:      corev1.EventTypeWarning + " " + resourcesLeakedReason,
+		},
+		{
+			name:                  "scans with copied credentials when namespace is being deleted",
+			deprovision:           testLeakScanClusterDeprovision(leakScanDelay),
+			existing:              []runtime.Object{testLeakScanCredentialsSecret(), testDeletedNamespace()},
+			mockLeakScan:          true,
+			leakedResourceARNs:    leakedARNs,
+			expectScanCredentials: leakScanCredentialsName(),
+			expectedCondition:     &hivev1.ClusterDeprovisionCondition{Status: corev1.ConditionTrue, Reason: resourcesLeakedReason},
+			expectedLeaked:        []hivev1.DeprovisionResourceCount{{Type: "route53:hostedzone", Count: 1}, {Type: "s3", Count: 1}},
+			expectedMetric:        2,
+			expectedEvent:         corev1.EventTypeWarning + " " + resourcesLeakedReason,
+		},
+		{
+			name:              "skipped without copied credentials when namespace is being deleted",
+			deprovision:       testLeakScanClusterDeprovision(leakScanDelay),
+			existing:          []runtime.Object{testDeletedNamespace()},
+			expectedCondition: &hivev1.ClusterDeprovisionCondition{Status: corev1.ConditionUnknown, Reason: leakScanSkippedReason},
+		},
+		{
+			name:                  "scan fails",
+			deprovision:           testLeakScanClusterDeprovision(leakScanDelay),
+			existing:              []runtime.Object{testLeakScanCredentialsSecret()},
+			expectScanCredentials: leakScanCredentialsName(),
+			expectCredentialsKept: true,
+			mockLeakScan:          true,
+			leakScanError:         awserr.New("AccessDenied", "", fmt.Errorf("")),
+			expectErr:             true,
+			expectFinalizer:       true,
+			expectedCondition:     &hivev1.ClusterDeprovisionCondition{Status: corev1.ConditionUnknown, Reason: leakScanPendingReason},
+		},
+		{
+			name:              "scan fails after timeout",
+			deprovision:       testLeakScanClusterDeprovision(leakScanDelay + leakScanTimeout),
+			mockLeakScan:      true,
+			leakScanError:     awserr.New("AccessDenied", "", fmt.Errorf("")),
+			expectedCondition: &hivev1.ClusterDeprovisionCondition{Status: corev1.ConditionUnknown, Reason: leakScanFailedReason},
+		},
+		{
+			name: "already scanned",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testLeakScanClusterDeprovision(leakScanDelay)
+				req.Status.Conditions[0].Status = corev1.ConditionTrue
+				req.Status.Conditions[0].Reason = resourcesLeakedReason
+				return req
+			}(),
+			expectedCondition: &hivev1.ClusterDeprovisionCondition{Status: corev1.ConditionTrue, Reason: resourcesLeakedReason},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t, append(test.existing, test.deprovision)...)
+			defer mocks.mockCtrl.Finish()
+
+			if test.mockLeakScan {
+				mockLeakScan(mocks, test.leakedResourceARNs, test.leakScanError)
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileClusterDeprovision{
+				Client:        mocks.fakeKubeClient,
+				scheme:        scheme.Scheme,
+				eventRecorder: recorder,
+			}
+
+			actuatorsSaved := actuators
+			defer func() { actuators = actuatorsSaved }()
+			actuators = []Actuator{&awsActuator{awsClientFn: func(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
+				expected := test.expectScanCredentials
+				if expected.Name == "" {
+					expected = types.NamespacedName{Namespace: testNamespace, Name: "aws-creds"}
+				}
+				assert.Equal(t, expected, types.NamespacedName{
+					Namespace: clusterDeprovision.Namespace,
+					Name:      clusterDeprovision.Spec.Platform.AWS.CredentialsSecretRef.Name,
+				}, "unexpected credentials for leak scan")
+				return mocks.mockAWSClient, nil
+			}}}
+
+			metricBefore := testutil.ToFloat64(metricLeakedResources.WithLabelValues(constants.PlatformAWS))
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      testName,
+					Namespace: testNamespace,
+				},
+			})
+			if test.expectErr {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+			assert.Equal(t, test.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			req := &hivev1.ClusterDeprovision{}
+			require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req))
+			assert.Equal(t, test.expectFinalizer, controllerutils.HasFinalizer(req, leakScanFinalizer), "unexpected leak scan finalizer")
+			cond := controllerutils.FindClusterDeprovisionCondition(req.Status.Conditions, hivev1.ResourcesLeakedClusterDeprovisionCondition)
+			if assert.NotNil(t, cond, "expected ResourcesLeaked condition") {
+				assert.Equal(t, test.expectedCondition.Status, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectedCondition.Reason, cond.Reason, "unexpected condition reason")
+			}
+			assert.Equal(t, test.expectedLeaked, req.Status.LeakedResources, "unexpected leaked resources")
+
+			assert.Equal(t, test.expectedMetric,
+				testutil.ToFloat64(metricLeakedResources.WithLabelValues(constants.PlatformAWS))-metricBefore,
+				"unexpected leaked resources metric")
+
+			cm := &corev1.ConfigMap{}
+			err = mocks.fakeKubeClient.Get(context.TODO(), leakedCMName, cm)
+			if len(test.leakedResourceARNs) > 0 && test.leakScanError == nil {
+				if assert.NoError(t, err, "expected leaked resources configmap") {
+					resources, err := deprovision.ParseResources(cm)
+					require.NoError(t, err, "unexpected error parsing leaked resources configmap")
+					assert.Len(t, resources, len(test.leakedResourceARNs), "unexpected leaked resources in configmap")
+					assert.Empty(t, cm.OwnerReferences, "leaked resources configmap must not be owned")
+				}
+			} else {
+				assert.True(t, errors.IsNotFound(err), "expected no leaked resources configmap")
+			}
+
+			err = mocks.fakeKubeClient.Get(context.TODO(), leakScanCredentialsName(), &corev1.Secret{})
+			if test.expectCredentialsKept {
+				assert.NoError(t, err, "expected copy of credentials for leak scan")
+			} else {
+				assert.True(t, errors.IsNotFound(err), "expected no copy of credentials for leak scan")
+			}
+
+			select {
+			case event := <-recorder.Events:
+				assert.True(t, strings.HasPrefix(event, test.expectedEvent), "unexpected event %q", event)
+				assert.NotEmpty(t, test.expectedEvent, "unexpected event %q", event)
+			default:
+				assert.Empty(t, test.expectedEvent, "expected event")
+			}
+		})
+	}
+}
+
+func mockLeakScan(mocks *mocks, leakedResourceARNs []string, leakScanError error) {
+	mocks.mockAWSClient.EXPECT().
+		GetResourcesPages(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+			if leakScanError != nil {
+				return leakScanError
+			}
+			output := &resourcegroupstaggingapi.GetResourcesOutput{}
+			for _, arn := range leakedResourceARNs {
+				output.ResourceTagMappingList = append(output.ResourceTagMappingList, &resourcegroupstaggingapi.ResourceTagMapping{
+					ResourceARN: aws.String(arn),
+				})
+			}
+			fn(output, true)
+			return nil
+		}).
+		MinTimes(1)
+	mocks.mockAWSClient.EXPECT().ListRolesPages(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mocks.mockAWSClient.EXPECT().ListUsersPages(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mocks.mockAWSClient.EXPECT().GetInstanceProfile(gomock.Any()).
+		Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil)).
+		AnyTimes()
+	mocks.mockAWSClient.EXPECT().ListResourceRecordSets(gomock.Any()).
+		Return(&route53.ListResourceRecordSetsOutput{}, nil).
+		AnyTimes()
+	mocks.mockAWSClient.EXPECT().GetHostedZone(gomock.Any()).
+		Return(&route53.GetHostedZoneOutput{HostedZone: &route53.HostedZone{}}, nil).
+		AnyTimes()
+}
+
+// testLeakScanClusterDeprovision returns a completed ClusterDeprovision that has been waiting for the given time to be
+// scanned for leaked resources.
+func testLeakScanClusterDeprovision(waited time.Duration) *hivev1.ClusterDeprovision {
+	req := testClusterDeprovision()
+	req.Finalizers = []string{leakScanFinalizer}
+	req.Status.Completed = true
+	pendingSince := metav1.NewTime(time.Now().Add(-waited))
+	req.Status.Conditions = []hivev1.ClusterDeprovisionCondition{
+		{
+			Type:               hivev1.ResourcesLeakedClusterDeprovisionCondition,
+			Status:             corev1.ConditionUnknown,
+			Reason:             leakScanPendingReason,
+			LastTransitionTime: pendingSince,
+			LastProbeTime:      pendingSince,
+		},
+	}
+	return req
+}
+
+// leakScanCredentialsName returns the name of the copy of the credentials made for the leak scan of the test
+// ClusterDeprovision.
+func leakScanCredentialsName() types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: controllerutils.GetHiveNamespace(),
+		Name:      leakScanCredentialsSecretName(testNamespace, testName),
+	}
+}
+
+func testCredentialsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "aws-creds",
+		},
+		Data: map[string][]byte{
+			constants.AWSAccessKeyIDSecretKey:     []byte("access-key-id"),
+			constants.AWSSecretAccessKeySecretKey: []byte("secret-access-key"),
+		},
+	}
+}
+
+func testLeakScanCredentialsSecret() *corev1.Secret {
+	secret := testCredentialsSecret()
+	name := leakScanCredentialsName()
+	secret.Namespace = name.Namespace
+	secret.Name = name.Name
+	return secret
+}
+
+func testDeletedNamespace() *corev1.Namespace {
+	now := metav1.Now()
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              testNamespace,
+			DeletionTimestamp: &now,
+			Finalizers:        []string{"kubernetes"},
+		},
+	}
+}
+
 func testClusterDeprovision() *hivev1.ClusterDeprovision {
 	return &hivev1.ClusterDeprovision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -365,6 +842,33 @@ func testClusterDeprovision() *hivev1.ClusterDeprovision {
 	}
 }
 
+func testDryRunClusterDeprovision() *hivev1.ClusterDeprovision {
+	req := testClusterDeprovision()
+	req.Spec.DryRun = true
+	return req
+}
+
+func testOrphanedInfraClusterDeprovision() *hivev1.ClusterDeprovision {
+	req := testClusterDeprovision()
+	req.Labels = map[string]string{constants.OrphanedInfraLabel: "true"}
+	req.Spec.ClusterID = ""
+	return req
+}
+
+// testOrphanedInfraStateConfigMap returns the state of the orphaned infrastructure reaper, recording that it created
+// the orphaned infrastructure ClusterDeprovision, in the test namespace.
+func testOrphanedInfraStateConfigMap(managed bool) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "orphaned-infra",
+		},
+		Data: map[string]string{
+			"orphans": fmt.Sprintf(`[{"infraID": "test-infra-id", "managed": %t, "clusterDeprovision": %q}]`, managed, testName),
+		},
+	}
+}
+
 func testDeletedClusterDeployment() *hivev1.ClusterDeployment {
 	now := metav1.Now()
 	cd := testClusterDeployment()
@@ -384,8 +888,30 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 	}
 }
 
+func testSuccessfulUninstallJob() *batchv1.Job {
+	job := testUninstallJob()
+	job.Status.Conditions = []batchv1.JobCondition{
+		{
+			Type:   batchv1.JobComplete,
+			Status: corev1.ConditionTrue,
+		},
+	}
+	now := metav1.Now()
+	job.Status.CompletionTime = &now
+	job.Status.StartTime = &now
+	return job
+}
+
 func testUninstallJob() *batchv1.Job {
-	uninstallJob, _ := install.GenerateUninstallerJobForDeprovision(testClusterDeprovision(),
+	return testUninstallJobForDeprovision(testClusterDeprovision())
+}
+
+func testDryRunUninstallJob() *batchv1.Job {
+	return testUninstallJobForDeprovision(testDryRunClusterDeprovision())
+}
+
+func testUninstallJobForDeprovision(req *hivev1.ClusterDeprovision) *batchv1.Job {
+	uninstallJob, _ := install.GenerateUninstallerJobForDeprovision(req,
 		"someserviceaccount", "", "", "", nil)
 	hash, err := controllerutils.CalculateJobSpecHash(uninstallJob)
 	if err != nil {
@@ -408,7 +934,7 @@ func validateNoJobExists(t *testing.T, c client.Client) {
 	}
 }
 
-func validateJobExists(t *testing.T, c client.Client) {
+func validateJobExists(t *testing.T, c client.Client) *batchv1.Job {
 	job := &batchv1.Job{}
 	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-uninstall"}, job)
 	if err != nil {
@@ -418,6 +944,7 @@ func validateJobExists(t *testing.T, c client.Client) {
 	require.NotNil(t, job, "expected job")
 	assert.Equal(t, testClusterDeprovision().Name, job.Labels[constants.ClusterDeprovisionNameLabel], "incorrect cluster deprovision name label")
 	assert.Equal(t, constants.JobTypeDeprovision, job.Labels[constants.JobTypeLabel], "incorrect job type label")
+	return job
 }
 
 func validateNotCompleted(t *testing.T, c client.Client) {
@@ -0,0 +1,87 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/deprovision"
+)
+
+const (
+	dryRunNotSupportedReason = "DryRunNotSupported"
+	dryRunSucceededReason    = "DryRunSucceeded"
+)
+
+// completeDryRun records the resources listed by a successful dry run uninstall job in the status of the
+// ClusterDeprovision, and marks it completed. The full list of resources is left in the dry run ConfigMap, which is
+// made to be owned by the ClusterDeprovision so that it is deleted along with it.
+func (r *ReconcileClusterDeprovision) completeDryRun(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) error {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: deprovision.DryRunConfigMapName(instance.Name)}, cm); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting dry run configmap")
+		return err
+	}
+	resources, err := deprovision.ParseResources(cm)
+	if err != nil {
+		rLog.WithError(err).Error("error parsing dry run configmap")
+		return err
+	}
+	if metav1.GetControllerOf(cm) == nil {
+		if err := controllerutil.SetControllerReference(instance, cm, r.scheme); err != nil {
+			rLog.WithError(err).Error("error setting controller reference on dry run configmap")
+			return err
+		}
+		if err := r.Update(context.TODO(), cm); err != nil {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating dry run configmap")
+			return err
+		}
+	}
+
+	rLog.WithField("resources", len(resources)).Info("dry run uninstall job successful, setting completed status")
+	instance.Status.DryRunResultRef = &corev1.LocalObjectReference{Name: cm.Name}
+	instance.Status.DryRunResources = deprovision.Summarize(resources)
+	instance.Status.Conditions = controllerutils.SetClusterDeprovisionCondition(
+		instance.Status.Conditions,
+		hivev1.DryRunCompleteClusterDeprovisionCondition,
+		corev1.ConditionTrue,
+		dryRunSucceededReason,
+		fmt.Sprintf("Dry run found %d resources that would be deleted", len(resources)),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	instance.Status.Completed = true
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
+		return err
+	}
+	return nil
+}
+
+// setDryRunNotSupportedCondition marks the ClusterDeprovision as failed, as the resources of the platform cannot be
+// listed.
+func (r *ReconcileClusterDeprovision) setDryRunNotSupportedCondition(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		instance.Status.Conditions,
+		hivev1.DeprovisionFailedClusterDeprovisionCondition,
+		corev1.ConditionTrue,
+		dryRunNotSupportedReason,
+		"Dry run deprovisions are not supported for this platform",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	instance.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package clusterdeprovision
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/deprovision"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+func init() {
+	registerActuator(&gcpActuator{gcpClientFn: getGCPClient})
+}
+
+// Ensure gcpActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &gcpActuator{}
+
+type gcpActuator struct {
+	// gcpClientFn is the function to build a GCP client, here for testing
+	gcpClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (gcpclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *gcpActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.GCP != nil
+}
+
+// TestCredentials ensures that a GCP client can be built from the credentials.
+func (a *gcpActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	_, err := a.gcpClientFn(clusterDeprovision, c, logger)
+	return err
+}
+
+// ListResources lists the resources that the uninstall job would delete.
+func (a *gcpActuator) ListResources(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]deprovision.Resource, error) {
+	gcpClient, err := a.gcpClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return nil, err
+	}
+	lister := &deprovision.GCPLister{
+		Client:  gcpClient,
+		InfraID: clusterDeprovision.Spec.InfraID,
+		Region:  clusterDeprovision.Spec.Platform.GCP.Region,
+	}
+	return lister.ListResources()
+}
+
+func getGCPClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (gcpclient.Client, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.GCP.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to fetch GCP credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch GCP credentials secret")
+	}
+	return gcpclient.NewClientFromSecret(secret)
+}
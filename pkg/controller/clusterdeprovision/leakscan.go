@@ -0,0 +1,371 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/deprovision"
+)
+
+const (
+	resourcesLeakedReason   = "ResourcesLeaked"
+	noResourcesLeakedReason = "NoResourcesLeaked"
+	leakScanPendingReason   = "LeakScanPending"
+	leakScanFailedReason    = "LeakScanFailed"
+	leakScanSkippedReason   = "LeakScanSkipped"
+
+	// leakScanFinalizer keeps a completed ClusterDeprovision around, after its ClusterDeployment is gone, until it has
+	// been scanned for leaked resources.
+	leakScanFinalizer = "hive.openshift.io/leak-scan"
+
+	// leakScanDelay is how long to wait after the uninstall job succeeds before scanning for leaked resources. Cloud
+	// APIs are eventually consistent, and some resources are still being torn down when the destroyer returns, so an
+	// immediate scan reports resources that are about to disappear.
+	leakScanDelay = 10 * time.Minute
+
+	// leakScanTimeout is how long after leakScanDelay a failing scan is retried before it is given up on.
+	leakScanTimeout = time.Hour
+)
+
+// leakScanCredentialsSecretName returns the name of the copy, in the hive namespace, of the credentials secret used to
+// scan the named ClusterDeprovision for leaked resources.
+func leakScanCredentialsSecretName(clusterDeprovisionNamespace, clusterDeprovisionName string) string {
+	return fmt.Sprintf("%s-%s-leak-scan-creds", clusterDeprovisionNamespace, clusterDeprovisionName)
+}
+
+// getCredentialsSecretRef returns the credentials secret of a ClusterDeprovision on a platform that can be scanned for
+// leaked resources, or nil if it has none.
+func getCredentialsSecretRef(instance *hivev1.ClusterDeprovision) *corev1.LocalObjectReference {
+	var ref *corev1.LocalObjectReference
+	switch {
+	case instance.Spec.Platform.AWS != nil:
+		ref = instance.Spec.Platform.AWS.CredentialsSecretRef
+	case instance.Spec.Platform.Azure != nil:
+		ref = instance.Spec.Platform.Azure.CredentialsSecretRef
+	case instance.Spec.Platform.GCP != nil:
+		ref = instance.Spec.Platform.GCP.CredentialsSecretRef
+	}
+	if ref == nil || ref.Name == "" {
+		return nil
+	}
+	return ref
+}
+
+// copyLeakScanCredentials copies the credentials secret of a ClusterDeprovision whose uninstall job has succeeded to
+// the hive namespace. The namespace of a ClusterDeprovision, such as that of a cluster pool cluster, is often deleted
+// along with its ClusterDeployment before the scan runs, taking the credentials with it.
+func (r *ReconcileClusterDeprovision) copyLeakScanCredentials(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) error {
+	ref := getCredentialsSecretRef(instance)
+	if ref == nil {
+		return nil
+	}
+	source := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: ref.Name}, source); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting credentials secret")
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controllerutils.GetHiveNamespace(),
+			Name:      leakScanCredentialsSecretName(instance.Namespace, instance.Name),
+			Labels: map[string]string{
+				constants.ClusterDeprovisionNameLabel:      instance.Name,
+				constants.ClusterDeprovisionNamespaceLabel: instance.Namespace,
+			},
+		},
+		Type: source.Type,
+		Data: source.Data,
+	}
+	switch err := r.Create(context.TODO(), secret); {
+	case apierrors.IsAlreadyExists(err):
+		return nil
+	case err != nil:
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error copying credentials secret for leak scan")
+		return err
+	}
+	rLog.WithField("secret", secret.Name).Debug("copied credentials secret for leak scan")
+	return nil
+}
+
+// deleteLeakScanCredentials removes the copy of the credentials secret made for the leak scan of a ClusterDeprovision.
+func (r *ReconcileClusterDeprovision) deleteLeakScanCredentials(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controllerutils.GetHiveNamespace(),
+			Name:      leakScanCredentialsSecretName(instance.Namespace, instance.Name),
+		},
+	}
+	if err := r.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting credentials secret for leak scan")
+		return err
+	}
+	return nil
+}
+
+// getLeakScanTarget returns the ClusterDeprovision to hand to the actuator for the leak scan, pointed at the copy of its
+// credentials in the hive namespace. A ClusterDeprovision completed without a copy is scanned with the credentials in
+// its own namespace, unless that namespace is being deleted, in which case nil is returned and the scan is skipped.
+func (r *ReconcileClusterDeprovision) getLeakScanTarget(instance *hivev1.ClusterDeprovision) (*hivev1.ClusterDeprovision, error) {
+	ref := getCredentialsSecretRef(instance)
+	if ref == nil {
+		return instance, nil
+	}
+	hiveNS := controllerutils.GetHiveNamespace()
+	name := leakScanCredentialsSecretName(instance.Namespace, instance.Name)
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: hiveNS, Name: name}, &corev1.Secret{})
+	switch {
+	case err == nil:
+		target := instance.DeepCopy()
+		target.Namespace = hiveNS
+		getCredentialsSecretRef(target).Name = name
+		return target, nil
+	case !apierrors.IsNotFound(err):
+		return nil, err
+	}
+
+	ns := &corev1.Namespace{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Name: instance.Namespace}, ns); {
+	case apierrors.IsNotFound(err):
+		return instance, nil
+	case err != nil:
+		return nil, err
+	case !ns.DeletionTimestamp.IsZero():
+		return nil, nil
+	}
+	return instance, nil
+}
+
+// addLeakScanFinalizer adds the leak scan finalizer to a ClusterDeprovision whose uninstall job has succeeded. It must
+// be called before any changes to the status, as the update replaces the in-memory object.
+func (r *ReconcileClusterDeprovision) addLeakScanFinalizer(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) error {
+	if controllerutils.HasFinalizer(instance, leakScanFinalizer) {
+		return nil
+	}
+	controllerutils.AddFinalizer(instance, leakScanFinalizer)
+	if err := r.Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error adding leak scan finalizer")
+		return err
+	}
+	return nil
+}
+
+// setLeakScanPending marks the ClusterDeprovision as waiting to be scanned for leaked resources. The transition time
+// of the condition is when the wait started. The status is not saved.
+func setLeakScanPending(instance *hivev1.ClusterDeprovision) {
+	now := metav1.Now()
+	cond := controllerutils.FindClusterDeprovisionCondition(instance.Status.Conditions, hivev1.ResourcesLeakedClusterDeprovisionCondition)
+	if cond == nil {
+		instance.Status.Conditions = append(instance.Status.Conditions, hivev1.ClusterDeprovisionCondition{
+			Type: hivev1.ResourcesLeakedClusterDeprovisionCondition,
+		})
+		cond = &instance.Status.Conditions[len(instance.Status.Conditions)-1]
+	}
+	cond.Status = corev1.ConditionUnknown
+	cond.Reason = leakScanPendingReason
+	cond.Message = fmt.Sprintf("Resources remaining after deprovision will be scanned for after %s", leakScanDelay)
+	cond.LastTransitionTime = now
+	cond.LastProbeTime = now
+}
+
+// reconcileLeakScan scans a completed ClusterDeprovision for leaked resources once leakScanDelay has passed, and then
+// removes the leak scan finalizer. The scan is only run while the ResourcesLeaked condition is pending, and the status
+// is saved before the finalizer is removed, so a retry after a failed update does not scan and count the resources
+// again.
+func (r *ReconcileClusterDeprovision) reconcileLeakScan(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) (reconcile.Result, error) {
+	cond := controllerutils.FindClusterDeprovisionCondition(instance.Status.Conditions, hivev1.ResourcesLeakedClusterDeprovisionCondition)
+	if cond != nil && cond.Reason == leakScanPendingReason {
+		waited := time.Since(cond.LastTransitionTime.Time)
+		if waited < leakScanDelay {
+			rLog.WithField("remaining", leakScanDelay-waited).Debug("waiting to scan for resources remaining after deprovision")
+			return reconcile.Result{RequeueAfter: leakScanDelay - waited}, nil
+		}
+		if actuator := r.getActuator(instance); actuator != nil {
+			target, err := r.getLeakScanTarget(instance)
+			if err != nil {
+				rLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting credentials for leak scan")
+				return reconcile.Result{}, err
+			}
+			if target == nil {
+				if err := r.skipLeakScan(instance, rLog); err != nil {
+					return reconcile.Result{}, err
+				}
+			} else if err := r.scanForLeakedResources(instance, target, actuator, waited, rLog); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	if err := r.deleteLeakScanCredentials(instance, rLog); err != nil {
+		return reconcile.Result{}, err
+	}
+	rLog.Debug("removing leak scan finalizer")
+	controllerutils.DeleteFinalizer(instance, leakScanFinalizer)
+	if err := r.Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error removing leak scan finalizer")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// skipLeakScan records that a ClusterDeprovision could not be scanned for leaked resources, because its namespace, and
+// the credentials in it, are being deleted.
+func (r *ReconcileClusterDeprovision) skipLeakScan(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) error {
+	rLog.Warn("skipping scan for resources remaining after deprovision, namespace is being deleted")
+	instance.Status.Conditions = controllerutils.SetClusterDeprovisionCondition(
+		instance.Status.Conditions,
+		hivev1.ResourcesLeakedClusterDeprovisionCondition,
+		corev1.ConditionUnknown,
+		leakScanSkippedReason,
+		"Resources were not scanned for after deprovision as the credentials were deleted along with the namespace",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	return r.updateLeakScanStatus(instance, rLog)
+}
+
+// scanForLeakedResources looks for resources of the cluster that are still present after the deprovision, using the
+// credentials of the given scan target, and saves the result in the status of the ClusterDeprovision. Resources that are found are also kept in a ConfigMap in the hive
+// namespace, which outlives the ClusterDeprovision, and are reported in an event and in the leaked resources metric
+// once the status has been saved. A failing scan is retried until leakScanTimeout has passed.
+func (r *ReconcileClusterDeprovision) scanForLeakedResources(instance, target *hivev1.ClusterDeprovision, actuator Actuator, waited time.Duration, rLog log.FieldLogger) error {
+	resources, err := actuator.ListResources(target, r.Client, rLog)
+	if err != nil {
+		if waited < leakScanDelay+leakScanTimeout {
+			rLog.WithError(err).Warn("could not scan for resources remaining after deprovision, will retry")
+			return err
+		}
+		rLog.WithError(err).Error("giving up scanning for resources remaining after deprovision")
+		instance.Status.Conditions = controllerutils.SetClusterDeprovisionCondition(
+			instance.Status.Conditions,
+			hivev1.ResourcesLeakedClusterDeprovisionCondition,
+			corev1.ConditionUnknown,
+			leakScanFailedReason,
+			fmt.Sprintf("Could not scan for resources after deprovision: %v", err),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		return r.updateLeakScanStatus(instance, rLog)
+	}
+
+	if len(resources) == 0 {
+		rLog.Info("no resources remaining after deprovision")
+		instance.Status.Conditions = controllerutils.SetClusterDeprovisionCondition(
+			instance.Status.Conditions,
+			hivev1.ResourcesLeakedClusterDeprovisionCondition,
+			corev1.ConditionFalse,
+			noResourcesLeakedReason,
+			"No resources were found after deprovision",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if err := r.updateLeakScanStatus(instance, rLog); err != nil {
+			return err
+		}
+		r.eventRecorder.Event(instance, corev1.EventTypeNormal, noResourcesLeakedReason, "No resources were found after deprovision")
+		return nil
+	}
+
+	for _, resource := range resources {
+		rLog.WithField("type", resource.Type).WithField("id", resource.ID).Warn("resource remains after deprovision")
+	}
+	cmName, err := r.saveLeakedResources(instance, resources, rLog)
+	if err != nil {
+		return err
+	}
+	instance.Status.LeakedResources = deprovision.Summarize(resources)
+	counts := make([]string, len(instance.Status.LeakedResources))
+	for i, c := range instance.Status.LeakedResources {
+		counts[i] = fmt.Sprintf("%s (%d)", c.Type, c.Count)
+	}
+	message := fmt.Sprintf("Found %d resources after deprovision: %s. The resources are listed in ConfigMap %s/%s",
+		len(resources), strings.Join(counts, ", "), controllerutils.GetHiveNamespace(), cmName)
+	instance.Status.Conditions = controllerutils.SetClusterDeprovisionCondition(
+		instance.Status.Conditions,
+		hivev1.ResourcesLeakedClusterDeprovisionCondition,
+		corev1.ConditionTrue,
+		resourcesLeakedReason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.updateLeakScanStatus(instance, rLog); err != nil {
+		return err
+	}
+	metricLeakedResources.WithLabelValues(getClusterDeprovisionPlatform(instance)).Add(float64(len(resources)))
+	r.eventRecorder.Event(instance, corev1.EventTypeWarning, resourcesLeakedReason, message)
+	return nil
+}
+
+// saveLeakedResources writes the leaked resources of a ClusterDeprovision to a ConfigMap in the hive namespace, and
+// returns its name. The ConfigMap is not owned by the ClusterDeprovision, so it remains for an administrator to
+// clean up after the ClusterDeprovision and its ClusterDeployment are gone.
+func (r *ReconcileClusterDeprovision) saveLeakedResources(instance *hivev1.ClusterDeprovision, resources []deprovision.Resource, rLog log.FieldLogger) (string, error) {
+	hiveNS := controllerutils.GetHiveNamespace()
+	name := deprovision.LeakedResourcesConfigMapName(instance.Namespace, instance.Name)
+	data := map[string]string{deprovision.ResourcesKey: deprovision.FormatResources(resources)}
+
+	cm := &corev1.ConfigMap{}
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: hiveNS, Name: name}, cm)
+	switch {
+	case apierrors.IsNotFound(err):
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: hiveNS,
+				Name:      name,
+				Labels: map[string]string{
+					constants.ClusterDeprovisionNameLabel:      instance.Name,
+					constants.ClusterDeprovisionNamespaceLabel: instance.Namespace,
+				},
+			},
+			Data: data,
+		}
+		if err := r.Create(context.TODO(), cm); err != nil {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating leaked resources configmap")
+			return "", err
+		}
+	case err != nil:
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting leaked resources configmap")
+		return "", err
+	default:
+		cm.Data = data
+		if err := r.Update(context.TODO(), cm); err != nil {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating leaked resources configmap")
+			return "", err
+		}
+	}
+	return name, nil
+}
+
+// updateLeakScanStatus saves the status of a ClusterDeprovision after a leak scan.
+func (r *ReconcileClusterDeprovision) updateLeakScanStatus(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) error {
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
+		return err
+	}
+	return nil
+}
+
+// getClusterDeprovisionPlatform returns the platform of a given ClusterDeprovision
+func getClusterDeprovisionPlatform(instance *hivev1.ClusterDeprovision) string {
+	switch {
+	case instance.Spec.Platform.AWS != nil:
+		return constants.PlatformAWS
+	case instance.Spec.Platform.Azure != nil:
+		return constants.PlatformAzure
+	case instance.Spec.Platform.GCP != nil:
+		return constants.PlatformGCP
+	case instance.Spec.Platform.OpenStack != nil:
+		return constants.PlatformOpenStack
+	case instance.Spec.Platform.VSphere != nil:
+		return constants.PlatformVSphere
+	}
+	return constants.PlatformUnknown
+}
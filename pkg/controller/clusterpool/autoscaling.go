@@ -0,0 +1,109 @@
+package clusterpool
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	defaultAutoscalingWindow = time.Hour
+	// defaultProvisionTime is the time assumed for a cluster to provision until a cluster of the pool has installed.
+	defaultProvisionTime = 45 * time.Minute
+	// claimCountIntervals is the number of intervals into which the autoscaling window is divided to count the claims
+	// assigned clusters from a pool, which bounds the size of the counts recorded in the status of the pool.
+	claimCountIntervals = 12
+)
+
+func autoscalingWindow(pool *hivev1.ClusterPool) time.Duration {
+	if w := pool.Spec.Autoscaling.Window; w != nil && w.Duration > 0 {
+		return w.Duration
+	}
+	return defaultAutoscalingWindow
+}
+
+// autoscale chooses the size of a pool with autoscaling enabled from all of its clusters, claimed or not. The pool is
+// sized to hold the clusters expected to be claimed while a new cluster provisions, given the rate at which clusters
+// were claimed over the autoscaling window. Returns nil if autoscaling is not enabled, along with the time at which
+// the oldest recent claims leave the window, when the target size is next due to change.
+func autoscale(pool *hivev1.ClusterPool, clusters []*hivev1.ClusterDeployment, now time.Time) (*hivev1.ClusterPoolAutoscalingStatus, time.Time) {
+	if pool.Spec.Autoscaling == nil {
+		return nil, time.Time{}
+	}
+	window := autoscalingWindow(pool)
+	status := &hivev1.ClusterPoolAutoscalingStatus{}
+	if pool.Status.Autoscaling != nil {
+		for _, c := range pool.Status.Autoscaling.RecentClaims {
+			if now.Sub(c.Start.Time) < window {
+				status.RecentClaims = append(status.RecentClaims, c)
+			}
+		}
+	}
+	var nextChange time.Time
+	if len(status.RecentClaims) > 0 {
+		nextChange = status.RecentClaims[0].Start.Add(window)
+	}
+	recentClaims := recentClaimCount(status)
+
+	provisionTime := averageProvisionTime(clusters)
+	provisionTimeSource := "measured"
+	if provisionTime == 0 {
+		provisionTime = defaultProvisionTime
+		provisionTimeSource = "assumed"
+	} else {
+		status.ProvisionTime = &metav1.Duration{Duration: provisionTime.Round(time.Second)}
+	}
+
+	expected := float64(recentClaims) * provisionTime.Seconds() / window.Seconds()
+	target := int32(math.Ceil(expected))
+	if target < pool.Spec.Autoscaling.MinSize {
+		target = pool.Spec.Autoscaling.MinSize
+	}
+	if target > pool.Spec.Autoscaling.MaxSize {
+		target = pool.Spec.Autoscaling.MaxSize
+	}
+	status.TargetSize = target
+	status.Message = fmt.Sprintf(
+		"%d clusters claimed in the last %s and %s provision time of %s: %.1f claims expected while a cluster provisions, target size %d within %d to %d",
+		recentClaims, window, provisionTimeSource, provisionTime.Round(time.Second), expected,
+		target, pool.Spec.Autoscaling.MinSize, pool.Spec.Autoscaling.MaxSize,
+	)
+	return status, nextChange
+}
+
+// recentClaimCount returns the number of claims recently assigned clusters from the pool.
+func recentClaimCount(status *hivev1.ClusterPoolAutoscalingStatus) int {
+	count := 0
+	for _, c := range status.RecentClaims {
+		count += int(c.Count)
+	}
+	return count
+}
+
+// recordClaimAssignment counts a claim assigned a cluster from a pool with autoscaling enabled at the given time, from
+// which the rate at which clusters are claimed is measured. The claim is counted in the status of the pool, which is
+// left for the caller to update.
+func recordClaimAssignment(pool *hivev1.ClusterPool, now time.Time) {
+	if pool.Spec.Autoscaling == nil {
+		return
+	}
+	if pool.Status.Autoscaling == nil {
+		pool.Status.Autoscaling = &hivev1.ClusterPoolAutoscalingStatus{}
+	}
+	start := metav1.NewTime(now.Truncate(autoscalingWindow(pool) / claimCountIntervals))
+	counts := pool.Status.Autoscaling.RecentClaims
+	for i := range counts {
+		if counts[i].Start.Equal(&start) {
+			counts[i].Count++
+			return
+		}
+	}
+	counts = append(counts, hivev1.ClusterPoolClaimCount{Start: start, Count: 1})
+	sort.SliceStable(counts, func(i, j int) bool { return counts[i].Start.Before(&counts[j].Start) })
+	pool.Status.Autoscaling.RecentClaims = counts
+}
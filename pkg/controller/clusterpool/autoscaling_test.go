@@ -0,0 +1,138 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestAutoscale(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	installedCD := func(took time.Duration) *hivev1.ClusterDeployment {
+		cd := testcd.BasicBuilder().
+			GenericOptions(testgeneric.WithCreationTimestamp(now.Add(-3 * time.Hour))).
+			Build(testcd.Installed())
+		cd.Status.InstalledTimestamp = &metav1.Time{Time: now.Add(-3 * time.Hour).Add(took)}
+		return cd
+	}
+	claimsAgo := func(agos ...time.Duration) testcp.Option {
+		var times []time.Time
+		for _, ago := range agos {
+			times = append(times, now.Add(-ago))
+		}
+		return testcp.WithRecentClaims(times...)
+	}
+
+	cases := []struct {
+		name                  string
+		pool                  *hivev1.ClusterPool
+		clusters              []*hivev1.ClusterDeployment
+		expectedTarget        int32
+		expectedRecentClaims  int
+		expectedProvisionTime *time.Duration
+		expectedNextChange    time.Time
+	}{
+		{
+			name:           "no claims",
+			pool:           testcp.BasicBuilder().Build(testcp.WithAutoscaling(2, 10, time.Hour)),
+			expectedTarget: 2,
+		},
+		{
+			name: "assumed provision time",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithAutoscaling(0, 10, time.Hour),
+				claimsAgo(50*time.Minute, 30*time.Minute, 10*time.Minute, 5*time.Minute),
+			),
+			expectedTarget:       3,
+			expectedRecentClaims: 4,
+			expectedNextChange:   now.Add(10 * time.Minute),
+		},
+		{
+			name: "measured provision time",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithAutoscaling(0, 10, 2*time.Hour),
+				claimsAgo(90*time.Minute, 60*time.Minute, 50*time.Minute, 30*time.Minute, 10*time.Minute, 5*time.Minute, time.Minute, 0),
+			),
+			clusters:              []*hivev1.ClusterDeployment{installedCD(20 * time.Minute), installedCD(40 * time.Minute)},
+			expectedTarget:        2,
+			expectedRecentClaims:  8,
+			expectedProvisionTime: durationPtr(30 * time.Minute),
+			expectedNextChange:    now.Add(30 * time.Minute),
+		},
+		{
+			name: "claims outside of the window",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithAutoscaling(0, 10, time.Hour),
+				claimsAgo(3*time.Hour, 2*time.Hour, time.Hour, 30*time.Minute),
+			),
+			expectedTarget:       1,
+			expectedRecentClaims: 1,
+			expectedNextChange:   now.Add(30 * time.Minute),
+		},
+		{
+			name: "max size",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithAutoscaling(0, 1, time.Hour),
+				claimsAgo(30*time.Minute, 20*time.Minute, 10*time.Minute),
+			),
+			expectedTarget:       1,
+			expectedRecentClaims: 3,
+			expectedNextChange:   now.Add(30 * time.Minute),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, nextChange := autoscale(tc.pool, tc.clusters, now)
+			if !assert.NotNil(t, status, "expected autoscaling status") {
+				return
+			}
+			assert.Equal(t, tc.expectedTarget, status.TargetSize, "unexpected target size")
+			assert.Equal(t, tc.expectedRecentClaims, recentClaimCount(status), "unexpected number of recent claims")
+			if tc.expectedProvisionTime == nil {
+				assert.Nil(t, status.ProvisionTime, "expected no provision time")
+			} else if assert.NotNil(t, status.ProvisionTime, "expected provision time") {
+				assert.Equal(t, *tc.expectedProvisionTime, status.ProvisionTime.Duration, "unexpected provision time")
+			}
+			assert.NotEmpty(t, status.Message, "expected message")
+			assert.Equal(t, tc.expectedNextChange, nextChange, "unexpected next change")
+		})
+	}
+
+	t.Run("autoscaling disabled", func(t *testing.T) {
+		status, _ := autoscale(testcp.BasicBuilder().Build(), nil, now)
+		assert.Nil(t, status, "expected no autoscaling status")
+	})
+}
+
+func TestRecordClaimAssignment(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	pool := testcp.BasicBuilder().Build(
+		testcp.WithAutoscaling(0, 10, time.Hour),
+		testcp.WithRecentClaims(now.Add(-20*time.Minute)),
+	)
+	for _, at := range []time.Time{now.Add(-time.Minute), now, now.Add(time.Minute), now.Add(5 * time.Minute), now.Add(-10 * time.Minute)} {
+		recordClaimAssignment(pool, at)
+	}
+	expected := []hivev1.ClusterPoolClaimCount{
+		{Start: metav1.NewTime(now.Add(-20 * time.Minute)), Count: 1},
+		{Start: metav1.NewTime(now.Add(-10 * time.Minute)), Count: 1},
+		{Start: metav1.NewTime(now.Add(-5 * time.Minute)), Count: 1},
+		{Start: metav1.NewTime(now), Count: 2},
+		{Start: metav1.NewTime(now.Add(5 * time.Minute)), Count: 1},
+	}
+	assert.Equal(t, expected, pool.Status.Autoscaling.RecentClaims, "unexpected recent claims")
+
+	t.Run("autoscaling disabled", func(t *testing.T) {
+		pool := testcp.BasicBuilder().Build()
+		recordClaimAssignment(pool, now)
+		assert.Nil(t, pool.Status.Autoscaling, "expected no autoscaling status")
+	})
+}
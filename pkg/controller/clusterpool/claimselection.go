@@ -0,0 +1,130 @@
+package clusterpool
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// requestsForClaim enqueues the ClusterPool named by a ClusterClaim or, for claims using a pool selector, all of the
+// ClusterPools selected by the claim.
+func requestsForClaim(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		claim, ok := o.(*hivev1.ClusterClaim)
+		if !ok {
+			return nil
+		}
+		if claim.Spec.ClusterPoolName != "" || claim.Spec.ClusterPoolSelector == nil {
+			return []reconcile.Request{{
+				NamespacedName: client.ObjectKey{Namespace: claim.Namespace, Name: claim.Spec.ClusterPoolName},
+			}}
+		}
+		cpList := &hivev1.ClusterPoolList{}
+		if err := c.List(context.Background(), cpList, client.InNamespace(claim.Namespace)); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list cluster pools for ClusterClaim")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cp := range poolsSelectedByClaim(claim, cpList.Items, logger) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: cp.Namespace, Name: cp.Name}})
+		}
+		return requests
+	}
+}
+
+// poolsSelectedByClaim returns the pools, not being deleted, that are selected by the pool selector of the claim.
+func poolsSelectedByClaim(claim *hivev1.ClusterClaim, pools []hivev1.ClusterPool, logger log.FieldLogger) []*hivev1.ClusterPool {
+	selector, err := metav1.LabelSelectorAsSelector(claim.Spec.ClusterPoolSelector)
+	if err != nil {
+		logger.WithError(err).WithField("claim", claim.Name).Warn("invalid cluster pool selector on ClusterClaim")
+		return nil
+	}
+	var selected []*hivev1.ClusterPool
+	for i, cp := range pools {
+		if cp.DeletionTimestamp != nil || !selector.Matches(labels.Set(cp.Labels)) {
+			continue
+		}
+		selected = append(selected, &pools[i])
+	}
+	return selected
+}
+
+// sortPoolsForClaim orders the pools according to the pool ordering policy of the claim. Ties are broken by pool
+// name so that every pool arrives at the same order.
+func sortPoolsForClaim(claim *hivev1.ClusterClaim, pools []*hivev1.ClusterPool) {
+	sort.SliceStable(pools, func(i, j int) bool {
+		if claim.Spec.ClusterPoolOrdering == hivev1.PriorityClusterPoolOrdering {
+			if pi, pj := poolPriority(pools[i]), poolPriority(pools[j]); pi != pj {
+				return pi > pj
+			}
+		}
+		if pools[i].Status.Ready != pools[j].Status.Ready {
+			return pools[i].Status.Ready > pools[j].Status.Ready
+		}
+		return pools[i].Name < pools[j].Name
+	})
+}
+
+// poolPriority returns the priority of the pool from its priority label. Pools without a valid priority label have a
+// priority of 0.
+func poolPriority(pool *hivev1.ClusterPool) int {
+	priority, err := strconv.Atoi(pool.Labels[constants.ClusterPoolPriorityLabel])
+	if err != nil {
+		return 0
+	}
+	return priority
+}
+
+// choosePoolsForClaims chooses the pool that is to satisfy each pending claim using a pool selector. The claims must
+// be in the order in which they are to be assigned clusters. The result maps the name of each claim to the name of
+// the chosen pool.
+//
+// Every pool in the namespace makes the same choice given the same claims and pools, so that a claim is only
+// assigned a cluster by the one pool chosen for it. Pending claims naming a pool are served first, since they have
+// no other choice. The remaining ready clusters of each pool are then handed out to the claims using a selector in
+// order. A claim goes to the first pool in its order that still has a ready cluster or, when none of them do, to the
+// first pool in its order.
+func choosePoolsForClaims(claims []*hivev1.ClusterClaim, pools []hivev1.ClusterPool, logger log.FieldLogger) map[string]string {
+	available := map[string]int{}
+	for _, cp := range pools {
+		available[cp.Name] = int(cp.Status.Ready)
+	}
+	for _, claim := range claims {
+		if claim.Spec.ClusterPoolName != "" {
+			available[claim.Spec.ClusterPoolName]--
+		}
+	}
+	chosen := map[string]string{}
+	for _, claim := range claims {
+		if claim.Spec.ClusterPoolName != "" || claim.Spec.ClusterPoolSelector == nil {
+			continue
+		}
+		selected := poolsSelectedByClaim(claim, pools, logger)
+		if len(selected) == 0 {
+			continue
+		}
+		sortPoolsForClaim(claim, selected)
+		choice := selected[0].Name
+		for _, cp := range selected {
+			if available[cp.Name] > 0 {
+				choice = cp.Name
+				break
+			}
+		}
+		available[choice]--
+		chosen[claim.Name] = choice
+	}
+	return chosen
+}
@@ -2,10 +2,13 @@ package clusterpool
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math"
 	"reflect"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -19,6 +22,7 @@ import (
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -32,6 +36,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	k8sannotations "github.com/openshift/hive/pkg/util/annotations"
 )
 
 const (
@@ -53,6 +58,8 @@ var (
 	clusterPoolConditions = []hivev1.ClusterPoolConditionType{
 		hivev1.ClusterPoolMissingDependenciesCondition,
 		hivev1.ClusterPoolCapacityAvailableCondition,
+		hivev1.ClusterPoolInventoryValidCondition,
+		hivev1.ClusterPoolScheduleActiveCondition,
 	}
 )
 
@@ -102,24 +109,20 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconc
 	}
 
 	// Watch for changes to ClusterClaims
-	enqueuePoolForClaim := handler.EnqueueRequestsFromMapFunc(
-		func(o client.Object) []reconcile.Request {
-			claim, ok := o.(*hivev1.ClusterClaim)
-			if !ok {
-				return nil
-			}
-			return []reconcile.Request{{
-				NamespacedName: types.NamespacedName{
-					Namespace: claim.Namespace,
-					Name:      claim.Spec.ClusterPoolName,
-				},
-			}}
-		},
-	)
+	enqueuePoolForClaim := handler.EnqueueRequestsFromMapFunc(requestsForClaim(r.Client, r.logger))
 	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterClaim{}}, enqueuePoolForClaim); err != nil {
 		return err
 	}
 
+	// Watch for changes to ClusterDeploymentCustomizations in pool inventories
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.ClusterDeploymentCustomization{}},
+		handler.EnqueueRequestsFromMapFunc(
+			requestsForCustomization(r.Client, r.logger)),
+	); err != nil {
+		return err
+	}
+
 	// Watch for changes to the hive cluster pool admin RoleBindings
 	if err := c.Watch(
 		&source.Kind{Type: &rbacv1.RoleBinding{}},
@@ -291,14 +294,82 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		"ready":      len(readyCDs),
 	}).Debug("found clusters for ClusterPool")
 
+	sizing, scheduleErr := activeSizing(clp, time.Now())
+	if scheduleErr != nil {
+		logger.WithError(scheduleErr).Warn("ignoring invalid schedules")
+	}
+
+	allCDs := make([]*hivev1.ClusterDeployment, 0, len(claimedCDs)+len(unClaminedCDs))
+	allCDs = append(append(allCDs, claimedCDs...), unClaminedCDs...)
+
+	// An active schedule takes precedence over the size chosen by autoscaling.
+	autoscaling, autoscalingChange := autoscale(clp, allCDs, time.Now())
+	if autoscaling != nil {
+		if sizing.schedule == nil {
+			sizing.size = autoscaling.TargetSize
+		}
+		sizing.updateNextChange(autoscalingChange)
+	}
+
+	// Recycle clusters that are broken or too old, so that they are replaced.
+	recycleAvailable := math.MaxInt32
+	if sizing.maxConcurrent != nil {
+		recycleAvailable = int(*sizing.maxConcurrent) - len(installingCDs) - numberOfDeletingCDs - numberOfDeletingClaimedCDs
+		if recycleAvailable < 0 {
+			recycleAvailable = 0
+		}
+	}
+	var recycled map[hivev1.ClusterPoolRecycleReason]int
+	installingCDs, readyCDs, recycled, err = r.recycleClusters(clp, installingCDs, readyCDs, recycleAvailable, time.Now(), logger)
+	if err != nil {
+		logger.WithError(err).Error("error recycling clusters")
+		return reconcile.Result{}, err
+	}
+	for _, count := range recycled {
+		numberOfDeletingCDs += count
+	}
+
+	numberOfRunningCDs := 0
+	for _, cd := range readyCDs {
+		if isRunning(cd) {
+			numberOfRunningCDs++
+		}
+	}
+
+	poolSpecHash, err := r.poolSpecHash(clp, logger)
+	if err != nil {
+		// Without the hash no cluster can be determined to be stale. The error will be surfaced by the
+		// MissingDependencies condition when clusters are next added.
+		logger.WithError(err).Warn("could not calculate pool spec hash")
+	}
+	numberOfStaleCDs := 0
+	for _, clusters := range [][]*hivev1.ClusterDeployment{installingCDs, readyCDs} {
+		for _, cd := range clusters {
+			stale := isStale(cd, poolSpecHash)
+			if stale {
+				numberOfStaleCDs++
+			}
+			if err := r.setStaleAnnotation(cd, stale, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	variants := newPlatformVariants(clp, installingCDs, readyCDs, time.Now())
+
 	origStatus := clp.Status.DeepCopy()
+	addRecycledCounts(&clp.Status, recycled)
+	clp.Status.PlatformVariants = variants.status()
 	clp.Status.Size = int32(len(installingCDs) + len(readyCDs))
 	clp.Status.Ready = int32(len(readyCDs))
-	if !reflect.DeepEqual(origStatus, &clp.Status) {
-		if err := r.Status().Update(context.Background(), clp); err != nil {
-			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
-			return reconcile.Result{}, errors.Wrap(err, "could not update ClusterPool status")
-		}
+	clp.Status.Running = int32(numberOfRunningCDs)
+	clp.Status.Hibernating = int32(len(readyCDs) - numberOfRunningCDs)
+	clp.Status.Stale = int32(numberOfStaleCDs)
+	clp.Status.Autoscaling = autoscaling
+
+	if err := r.setScheduleActiveCondition(clp, sizing, scheduleErr, logger); err != nil {
+		logger.WithError(err).Error("error setting ScheduleActive condition")
+		return reconcile.Result{}, err
 	}
 
 	availableCapacity := math.MaxInt32
@@ -317,23 +388,60 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	inventory, err := r.reconcileInventory(clp, logger)
+	if err != nil {
+		log.WithError(err).Error("error reconciling inventory")
+		return reconcile.Result{}, err
+	}
+
 	pendingClaims, err := r.getAllPendingClusterClaims(clp, logger)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	logger.WithField("count", len(pendingClaims)).Debug("found pending claims for ClusterPool")
 
+	// Claims that would exceed a claim quota are left pending, and do not count towards the clusters needed.
+	quotaUsages, err := r.claimQuotaUsages(clp, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, usage := range quotaUsages {
+		metricClaimQuotaUsed.WithLabelValues(clp.Namespace, clp.Name, controllerutils.ClusterPoolClaimQuotaName(usage.quota)).Set(float64(usage.used))
+	}
+	pendingClaims, overQuotaClaims := filterClaimsByQuota(pendingClaims, quotaUsages)
+	metricClaimsQuotaExceeded.WithLabelValues(clp.Namespace, clp.Name).Set(float64(len(overQuotaClaims)))
+	if err := r.setQuotaExceededClaims(overQuotaClaims, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// reserveSize is the number of clusters that the pool currently has in reserve
 	reserveSize := len(installingCDs) + len(readyCDs) - len(pendingClaims)
 
-	readyCDs, err = r.assignClustersToClaims(pendingClaims, readyCDs, logger)
-	if err != nil {
+	// Assign the clusters that are already running first, so that claims are fulfilled as quickly as possible.
+	sortClustersForRunning(readyCDs)
+	estimator := newWaitEstimator(allCDs, installingCDs, sizing.maxConcurrent, time.Now())
+	readyCDs, assignErr := r.assignClustersToClaims(clp, pendingClaims, readyCDs, estimator, logger)
+
+	// The status is updated once the claims have been assigned, even if not all of them could be, so that the claims
+	// assigned are counted for autoscaling.
+	if !reflect.DeepEqual(origStatus, &clp.Status) {
+		if err := r.Status().Update(context.Background(), clp); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
+			return reconcile.Result{}, errors.Wrap(err, "could not update ClusterPool status")
+		}
+	}
+	if assignErr != nil {
+		return reconcile.Result{}, assignErr
+	}
+
+	if err := r.setPowerStates(clp, installingCDs, readyCDs, logger); err != nil {
+		logger.WithError(err).Error("error setting power state of clusters")
 		return reconcile.Result{}, err
 	}
 
 	availableCurrent := math.MaxInt32
-	if clp.Spec.MaxConcurrent != nil {
-		availableCurrent = int(*clp.Spec.MaxConcurrent) - len(installingCDs) - numberOfDeletingCDs - numberOfDeletingClaimedCDs
+	if sizing.maxConcurrent != nil {
+		availableCurrent = int(*sizing.maxConcurrent) - len(installingCDs) - numberOfDeletingCDs - numberOfDeletingClaimedCDs
 		if availableCurrent < 0 {
 			availableCurrent = 0
 		}
@@ -351,11 +459,11 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	}
 	availableCurrent -= toDel
 
-	switch drift := reserveSize - int(clp.Spec.Size); {
+	switch drift := reserveSize - int(sizing.size); {
 	// activity quota exceeded, so no action
 	case availableCurrent <= 0:
 		logger.WithFields(log.Fields{
-			"MaxConcurrent": *clp.Spec.MaxConcurrent,
+			"MaxConcurrent": *sizing.maxConcurrent,
 			"Available":     availableCurrent,
 		}).Info("Cannot create/delete clusters as max concurrent quota exceeded.")
 	// If too many, delete some.
@@ -364,13 +472,31 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		if err := r.deleteExcessClusters(installingCDs, readyCDs, toDel, logger); err != nil {
 			return reconcile.Result{}, err
 		}
+	// If the pool is at its desired size, replace stale clusters.
+	case drift == 0 && numberOfStaleCDs > 0:
+		if err := r.replaceStaleClusters(clp, installingCDs, readyCDs, poolSpecHash, numberOfDeletingCDs, availableCurrent, logger); err != nil {
+			return reconcile.Result{}, err
+		}
 	// If too few, create new InstallConfig and ClusterDeployment.
 	case drift < 0:
 		if availableCapacity <= 0 {
 			break
 		}
 		toAdd := minIntVarible(-drift, availableCapacity, availableCurrent)
-		if err := r.addClusters(clp, toAdd, logger); err != nil {
+		if len(clp.Spec.Inventory) > 0 {
+			if len(inventory) < toAdd {
+				logger.WithFields(log.Fields{
+					"needed":    toAdd,
+					"available": len(inventory),
+				}).Info("Cannot add all needed clusters because inventory is exhausted.")
+				toAdd = len(inventory)
+			}
+			if toAdd == 0 {
+				break
+			}
+			inventory = inventory[:toAdd]
+		}
+		if err := r.addClusters(clp, toAdd, inventory, variants, logger); err != nil {
 			log.WithError(err).Error("error adding clusters")
 			return reconcile.Result{}, err
 		}
@@ -381,6 +507,15 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	// Requeue for the next time a schedule starts or ends, a claim leaves the autoscaling window, or a failing
+	// platform variant is retried.
+	nextChange := sizing.nextChange
+	if retry := variants.nextRetry(); !retry.IsZero() && (nextChange.IsZero() || retry.Before(nextChange)) {
+		nextChange = retry
+	}
+	if !nextChange.IsZero() {
+		return reconcile.Result{RequeueAfter: time.Until(nextChange)}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -495,6 +630,8 @@ func (r *ReconcileClusterPool) applyRoleBinding(desired, observed *rbacv1.RoleBi
 func (r *ReconcileClusterPool) addClusters(
 	clp *hivev1.ClusterPool,
 	newClusterCount int,
+	inventory []*hivev1.ClusterDeploymentCustomization,
+	variants *platformVariants,
 	logger log.FieldLogger,
 ) error {
 	logger.WithField("count", newClusterCount).Info("Adding new clusters")
@@ -517,9 +654,25 @@ func (r *ReconcileClusterPool) addClusters(
 		errs = append(errs, fmt.Errorf("%s: %w", icSecretDependent, err))
 	}
 
-	cloudBuilder, err := r.createCloudBuilder(clp, logger)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("%s: %w", credentialsSecretDependent, err))
+	// Choose the platform variant of each new cluster, and create a cloud builder for each variant used.
+	clusterVariants := make([]*hivev1.ClusterPoolPlatformVariant, newClusterCount)
+	cloudBuilders := map[string]clusterresource.CloudBuilder{}
+	for i := range clusterVariants {
+		var variantName string
+		if variants != nil {
+			clusterVariants[i] = variants.next()
+		}
+		if clusterVariants[i] != nil {
+			variantName = clusterVariants[i].Name
+		}
+		if _, ok := cloudBuilders[variantName]; ok {
+			continue
+		}
+		cloudBuilder, err := r.createCloudBuilder(clp, clusterVariants[i], logger)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", credentialsSecretDependent, err))
+		}
+		cloudBuilders[variantName] = cloudBuilder
 	}
 
 	dependenciesError := utilerrors.NewAggregate(errs)
@@ -532,8 +685,19 @@ func (r *ReconcileClusterPool) addClusters(
 		return dependenciesError
 	}
 
+	poolSpecHash := calculatePoolSpecHash(clp, installConfigTemplate)
+
 	for i := 0; i < newClusterCount; i++ {
-		if err := r.createCluster(clp, cloudBuilder, pullSecret, installConfigTemplate, logger); err != nil {
+		var cdc *hivev1.ClusterDeploymentCustomization
+		if i < len(inventory) {
+			cdc = inventory[i]
+		}
+		variant := clusterVariants[i]
+		var variantName string
+		if variant != nil {
+			variantName = variant.Name
+		}
+		if err := r.createCluster(clp, cloudBuilders[variantName], pullSecret, installConfigTemplate, poolSpecHash, cdc, variant, logger); err != nil {
 			return err
 		}
 	}
@@ -546,6 +710,9 @@ func (r *ReconcileClusterPool) createCluster(
 	cloudBuilder clusterresource.CloudBuilder,
 	pullSecret string,
 	installConfigTemplate string,
+	poolSpecHash string,
+	cdc *hivev1.ClusterDeploymentCustomization,
+	variant *hivev1.ClusterPoolPlatformVariant,
 	logger log.FieldLogger,
 ) error {
 	ns, err := r.createRandomNamespace(clp)
@@ -580,6 +747,12 @@ func (r *ReconcileClusterPool) createCluster(
 		return errors.Wrap(err, "error building resources")
 	}
 
+	if cdc != nil {
+		if err := applyCustomization(objs, cdc); err != nil {
+			return err
+		}
+	}
+
 	poolKey := types.NamespacedName{Namespace: clp.Namespace, Name: clp.Name}.String()
 	r.expectations.ExpectCreations(poolKey, 1)
 	// Add the ClusterPoolRef to the ClusterDeployment, and move it to the end of the slice.
@@ -589,8 +762,26 @@ func (r *ReconcileClusterPool) createCluster(
 			continue
 		}
 		poolRef := poolReference(clp)
+		poolRef.CustomizationRef = customizationReference(cdc)
 		cd.Spec.ClusterPoolRef = &poolRef
 		cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+		// The builder hands the annotations of the pool spec to the ClusterDeployment as is, so they are copied before
+		// the hash is added to them.
+		annotations := make(map[string]string, len(cd.Annotations)+1)
+		for k, v := range cd.Annotations {
+			annotations[k] = v
+		}
+		annotations[constants.ClusterPoolSpecHashAnnotation] = poolSpecHash
+		cd.Annotations = annotations
+		if variant != nil {
+			// The labels of the pool spec are shared in the same way.
+			labels := make(map[string]string, len(cd.Labels)+1)
+			for k, v := range cd.Labels {
+				labels[k] = v
+			}
+			labels[constants.ClusterPoolPlatformVariantLabel] = variant.Name
+			cd.Labels = labels
+		}
 		lastIndex := len(objs) - 1
 		objs[i], objs[lastIndex] = objs[lastIndex], objs[i]
 	}
@@ -602,6 +793,14 @@ func (r *ReconcileClusterPool) createCluster(
 		}
 	}
 
+	// Record the use of the customization now rather than waiting for the ClusterDeployment to be observed.
+	if cdc != nil {
+		cd := objs[len(objs)-1].(*hivev1.ClusterDeployment)
+		if err := r.syncCustomizationStatus(clp, cdc, cd, logger.WithField("customization", cdc.Name)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -640,7 +839,9 @@ func (r *ReconcileClusterPool) deleteExcessClusters(
 		clustersToDelete = append(clustersToDelete, installingClusters...)
 		deletionsOfInstalledClustersNeeded := deletionsNeeded - len(installingClusters)
 		if deletionsOfInstalledClustersNeeded <= len(readyClusters) {
-			clustersToDelete = append(clustersToDelete, readyClusters[:deletionsOfInstalledClustersNeeded]...)
+			// The ready clusters are ordered with those that are running first, so delete from the end to keep the
+			// running clusters.
+			clustersToDelete = append(clustersToDelete, readyClusters[len(readyClusters)-deletionsOfInstalledClustersNeeded:]...)
 		} else {
 			logger.WithField("deletionsNeeded", deletionsNeeded).
 				WithField("installingClusters", len(installingClusters)).
@@ -661,6 +862,197 @@ func (r *ReconcileClusterPool) deleteExcessClusters(
 	return nil
 }
 
+// setPowerStates sets the power state of the unclaimed clusters in the pool so that RunningCount of them are kept
+// running and the rest are hibernating. Installed clusters are preferred over those still installing, and clusters
+// that are already running are preferred over those that are not.
+func (r *ReconcileClusterPool) setPowerStates(
+	clp *hivev1.ClusterPool,
+	installingClusters []*hivev1.ClusterDeployment,
+	readyClusters []*hivev1.ClusterDeployment,
+	logger log.FieldLogger,
+) error {
+	sortClustersForRunning(readyClusters)
+	sortClustersForRunning(installingClusters)
+	clusters := make([]*hivev1.ClusterDeployment, 0, len(readyClusters)+len(installingClusters))
+	clusters = append(clusters, readyClusters...)
+	clusters = append(clusters, installingClusters...)
+	for i, cd := range clusters {
+		powerState := hivev1.HibernatingClusterPowerState
+		if i < int(clp.Spec.RunningCount) {
+			powerState = hivev1.RunningClusterPowerState
+		}
+		if cd.Spec.PowerState == powerState {
+			continue
+		}
+		cdLog := logger.WithField("cluster", cd.Name)
+		cdLog.WithField("powerState", powerState).Info("changing power state of unclaimed cluster")
+		cd.Spec.PowerState = powerState
+		if err := r.Update(context.Background(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update power state of cluster")
+			return err
+		}
+	}
+	return nil
+}
+
+// sortClustersForRunning orders the clusters by how soon they can be used: clusters that are running, then clusters
+// that have been asked to run, then the rest. Ties are broken by age, oldest first, and then by name.
+func sortClustersForRunning(clusters []*hivev1.ClusterDeployment) {
+	rank := func(cd *hivev1.ClusterDeployment) int {
+		switch {
+		case isRunning(cd):
+			return 0
+		case cd.Spec.PowerState != hivev1.HibernatingClusterPowerState:
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if ri, rj := rank(clusters[i]), rank(clusters[j]); ri != rj {
+			return ri < rj
+		}
+		if !clusters[i].CreationTimestamp.Equal(&clusters[j].CreationTimestamp) {
+			return clusters[i].CreationTimestamp.Before(&clusters[j].CreationTimestamp)
+		}
+		return clusters[i].Name < clusters[j].Name
+	})
+}
+
+// isRunning returns true if the cluster is installed, should be running, and is not hibernating or resuming.
+func isRunning(cd *hivev1.ClusterDeployment) bool {
+	if !cd.Spec.Installed || cd.Spec.PowerState == hivev1.HibernatingClusterPowerState {
+		return false
+	}
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	return cond != nil && cond.Status == corev1.ConditionFalse
+}
+
+// replaceStaleClusters deletes stale clusters so that they are replaced with clusters created from the current pool
+// spec. The number of replacements in progress, counted as the clusters from the current spec that are still
+// installing together with the clusters being deleted, is kept within the MaxStaleReplacements of the pool.
+func (r *ReconcileClusterPool) replaceStaleClusters(
+	clp *hivev1.ClusterPool,
+	installingClusters []*hivev1.ClusterDeployment,
+	readyClusters []*hivev1.ClusterDeployment,
+	poolSpecHash string,
+	numberOfDeletingClusters int,
+	availableCurrent int,
+	logger log.FieldLogger,
+) error {
+	maxReplacements := 1
+	if clp.Spec.MaxStaleReplacements != nil {
+		maxReplacements = int(*clp.Spec.MaxStaleReplacements)
+	}
+	inProgress := numberOfDeletingClusters
+	// Delete stale clusters that are still installing before those that are ready, as they do not contribute to the
+	// capacity of the pool.
+	var staleClusters []*hivev1.ClusterDeployment
+	for _, cd := range installingClusters {
+		if isStale(cd, poolSpecHash) {
+			staleClusters = append(staleClusters, cd)
+		} else {
+			inProgress++
+		}
+	}
+	// The ready clusters are ordered with those that are running first, so take from the end to keep the running
+	// clusters for longest.
+	for i := len(readyClusters) - 1; i >= 0; i-- {
+		if isStale(readyClusters[i], poolSpecHash) {
+			staleClusters = append(staleClusters, readyClusters[i])
+		}
+	}
+	toDelete := minIntVarible(len(staleClusters), maxReplacements-inProgress, availableCurrent)
+	if toDelete <= 0 {
+		logger.WithFields(log.Fields{
+			"stale":           len(staleClusters),
+			"inProgress":      inProgress,
+			"maxReplacements": maxReplacements,
+		}).Debug("not replacing stale clusters as replacement limit reached")
+		return nil
+	}
+	for _, cd := range staleClusters[:toDelete] {
+		cdLog := logger.WithField("cluster", cd.Name)
+		cdLog.Info("deleting stale cluster deployment")
+		if err := r.Client.Delete(context.Background(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting stale cluster deployment")
+			return err
+		}
+		metricStaleClusterDeploymentsDeleted.WithLabelValues(clp.Namespace, clp.Name).Inc()
+	}
+	return nil
+}
+
+// poolSpecHash returns the hash of the current pool spec to be recorded on new clusters.
+func (r *ReconcileClusterPool) poolSpecHash(clp *hivev1.ClusterPool, logger log.FieldLogger) (string, error) {
+	installConfigTemplate, err := r.getInstallConfigTemplate(clp, logger)
+	if err != nil {
+		return "", err
+	}
+	return calculatePoolSpecHash(clp, installConfigTemplate), nil
+}
+
+// calculatePoolSpecHash hashes the parts of the pool spec that determine how a cluster in the pool is installed.
+// The weights of the platform variants are left out, as they only decide how new clusters are spread across the
+// variants.
+func calculatePoolSpecHash(clp *hivev1.ClusterPool, installConfigTemplate string) string {
+	type hashedVariant struct {
+		Name                 string
+		Region               string
+		CredentialsSecretRef *corev1.LocalObjectReference
+	}
+	var variants []hashedVariant
+	for _, v := range clp.Spec.PlatformVariants {
+		variants = append(variants, hashedVariant{Name: v.Name, Region: v.Region, CredentialsSecretRef: v.CredentialsSecretRef})
+	}
+	hashed := struct {
+		Platform              hivev1.Platform
+		PlatformVariants      []hashedVariant `json:",omitempty"`
+		BaseDomain            string
+		ImageSetRef           hivev1.ClusterImageSetReference
+		PullSecretRef         *corev1.LocalObjectReference
+		InstallConfigTemplate string
+		SkipMachinePools      bool
+	}{
+		Platform:              clp.Spec.Platform,
+		PlatformVariants:      variants,
+		BaseDomain:            clp.Spec.BaseDomain,
+		ImageSetRef:           clp.Spec.ImageSetRef,
+		PullSecretRef:         clp.Spec.PullSecretRef,
+		InstallConfigTemplate: installConfigTemplate,
+		SkipMachinePools:      clp.Spec.SkipMachinePools,
+	}
+	// Marshalling a struct of plain types cannot fail.
+	b, _ := json.Marshal(hashed)
+	h := fnv.New64a()
+	h.Write(b)
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+// isStale returns true if the cluster was created from a different version of the pool spec. Clusters created
+// before the spec hash was recorded are not considered stale.
+func isStale(cd *hivev1.ClusterDeployment, poolSpecHash string) bool {
+	cdHash, ok := cd.Annotations[constants.ClusterPoolSpecHashAnnotation]
+	return ok && poolSpecHash != "" && cdHash != poolSpecHash
+}
+
+// setStaleAnnotation marks an unclaimed cluster as stale, or removes the mark once it is no longer stale.
+func (r *ReconcileClusterPool) setStaleAnnotation(cd *hivev1.ClusterDeployment, stale bool, logger log.FieldLogger) error {
+	if _, marked := cd.Annotations[constants.ClusterPoolStaleAnnotation]; marked == stale {
+		return nil
+	}
+	if stale {
+		cd.Annotations = k8sannotations.AddAnnotation(cd.Annotations, constants.ClusterPoolStaleAnnotation, "true")
+	} else {
+		delete(cd.Annotations, constants.ClusterPoolStaleAnnotation)
+	}
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).WithField("cluster", cd.Name).Log(controllerutils.LogLevel(err), "could not update stale annotation of cluster deployment")
+		return errors.Wrap(err, "could not update stale annotation of cluster deployment")
+	}
+	return nil
+}
+
 func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, logger log.FieldLogger) error {
 	if !controllerutils.HasFinalizer(pool, finalizer) {
 		return nil
@@ -678,6 +1070,10 @@ func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, lo
 			return errors.Wrap(err, "could not delete ClusterDeployment")
 		}
 	}
+	for i := range pool.Spec.ClaimQuotas {
+		metricClaimQuotaUsed.DeleteLabelValues(pool.Namespace, pool.Name, controllerutils.ClusterPoolClaimQuotaName(&pool.Spec.ClaimQuotas[i]))
+	}
+	metricClaimsQuotaExceeded.DeleteLabelValues(pool.Namespace, pool.Name)
 	controllerutils.DeleteFinalizer(pool, finalizer)
 	if err := r.Update(context.Background(), pool); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove finalizer from ClusterPool")
@@ -837,8 +1233,30 @@ func (r *ReconcileClusterPool) getPullSecret(pool *hivev1.ClusterPool, logger lo
 	return string(pullSecret), nil
 }
 
-func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logger log.FieldLogger) (clusterresource.CloudBuilder, error) {
-	switch platform := pool.Spec.Platform; {
+// createCloudBuilder creates the cloud builder for the platform of the pool. When a platform variant is given, the
+// region and credentials of the variant override those of the platform.
+func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, variant *hivev1.ClusterPoolPlatformVariant, logger log.FieldLogger) (clusterresource.CloudBuilder, error) {
+	platform := pool.Spec.Platform.DeepCopy()
+	if variant != nil {
+		var region *string
+		var credsSecretRef *corev1.LocalObjectReference
+		switch {
+		case platform.AWS != nil:
+			region, credsSecretRef = &platform.AWS.Region, &platform.AWS.CredentialsSecretRef
+		case platform.GCP != nil:
+			region, credsSecretRef = &platform.GCP.Region, &platform.GCP.CredentialsSecretRef
+		case platform.Azure != nil:
+			region, credsSecretRef = &platform.Azure.Region, &platform.Azure.CredentialsSecretRef
+		default:
+			logger.Info("platform variants not supported for platform")
+			return nil, errors.New("platform variants not supported for platform")
+		}
+		*region = variant.Region
+		if variant.CredentialsSecretRef != nil {
+			*credsSecretRef = *variant.CredentialsSecretRef
+		}
+	}
+	switch {
 	case platform.AWS != nil:
 		var cloudBuilder *clusterresource.AWSCloudBuilder
 		switch {
@@ -883,35 +1301,67 @@ func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logg
 }
 
 // getAllPendingClusterClaims returns all of the ClusterClaims that are requesting clusters from the specified pool.
-// The claims are returned in order of creation time, from oldest to youngest.
+// The claims are returned in the order in which they are to be assigned clusters: highest priority first, and from
+// oldest to youngest within a priority.
 func (r *ReconcileClusterPool) getAllPendingClusterClaims(pool *hivev1.ClusterPool, logger log.FieldLogger) ([]*hivev1.ClusterClaim, error) {
 	claimsList := &hivev1.ClusterClaimList{}
 	if err := r.Client.List(context.Background(), claimsList, client.InNamespace(pool.Namespace)); err != nil {
 		logger.WithError(err).Error("error listing ClusterClaims")
 		return nil, err
 	}
-	var pendingClaims []*hivev1.ClusterClaim
+	var allPendingClaims []*hivev1.ClusterClaim
+	var selectorClaims bool
 	for i, claim := range claimsList.Items {
-		// skip claims for other pools
-		if claim.Spec.ClusterPoolName != pool.Name {
-			continue
-		}
 		// skip claims that have been assigned already
 		if claim.Spec.Namespace != "" {
 			continue
 		}
-		pendingClaims = append(pendingClaims, &claimsList.Items[i])
+		if claim.Spec.ClusterPoolName == "" && claim.Spec.ClusterPoolSelector != nil {
+			selectorClaims = true
+		}
+		allPendingClaims = append(allPendingClaims, &claimsList.Items[i])
+	}
+	sortPendingClaims(allPendingClaims)
+
+	// Claims using a pool selector are pending for this pool only if this pool is chosen to satisfy them.
+	var chosenPools map[string]string
+	if selectorClaims {
+		poolList := &hivev1.ClusterPoolList{}
+		if err := r.Client.List(context.Background(), poolList, client.InNamespace(pool.Namespace)); err != nil {
+			logger.WithError(err).Error("error listing ClusterPools")
+			return nil, err
+		}
+		// Use the latest status of this pool rather than the cached one.
+		for i := range poolList.Items {
+			if poolList.Items[i].Name == pool.Name {
+				poolList.Items[i] = *pool
+			}
+		}
+		chosenPools = choosePoolsForClaims(allPendingClaims, poolList.Items, logger)
+	}
+
+	var pendingClaims []*hivev1.ClusterClaim
+	for _, claim := range allPendingClaims {
+		poolName := claim.Spec.ClusterPoolName
+		if poolName == "" {
+			poolName = chosenPools[claim.Name]
+		}
+		// skip claims for other pools
+		if poolName != pool.Name {
+			continue
+		}
+		pendingClaims = append(pendingClaims, claim)
 	}
-	sort.Slice(
-		pendingClaims,
-		func(i, j int) bool {
-			return pendingClaims[i].CreationTimestamp.Before(&pendingClaims[j].CreationTimestamp)
-		},
-	)
 	return pendingClaims, nil
 }
 
-func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterClaim, cds []*hivev1.ClusterDeployment, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
+// assignClustersToClaims assigns the clusters, in order, to the claims. The pool from which the cluster was assigned is
+// recorded in the status of each claim. A claim that is assigned concurrently by another pool, which can happen for
+// claims using a pool selector, fails to update with a conflict, so that a claim is never assigned two clusters.
+// The claims left waiting for a cluster have their queue position and estimated wait recorded in their status. The
+// claims assigned are counted in the status of the pool for autoscaling, which is left for the caller to update.
+func (r *ReconcileClusterPool) assignClustersToClaims(pool *hivev1.ClusterPool, claims []*hivev1.ClusterClaim, cds []*hivev1.ClusterDeployment, estimator *waitEstimator, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
+	position := 0
 	for _, claim := range claims {
 		logger := logger.WithField("claim", claim.Name)
 		var conds []hivev1.ClusterClaimCondition
@@ -924,6 +1374,7 @@ func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterCl
 				logger.WithError(err).Log(controllerutils.LogLevel(err), "could not assign cluster to claim")
 				return cds, err
 			}
+			recordClaimAssignment(pool, time.Now())
 			conds = controllerutils.SetClusterClaimCondition(
 				claim.Status.Conditions,
 				hivev1.ClusterClaimPendingCondition,
@@ -932,6 +1383,9 @@ func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterCl
 				"Cluster assigned to ClusterClaim, awaiting claim",
 				controllerutils.UpdateConditionIfReasonOrMessageChange,
 			)
+			claim.Status.ClusterPoolName = pool.Name
+			claim.Status.QueuePosition = nil
+			claim.Status.EstimatedWait = nil
 			statusChanged = true
 		} else {
 			logger.Debug("no clusters ready to assign to claim")
@@ -943,6 +1397,14 @@ func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterCl
 				"No clusters in pool are ready to be claimed",
 				controllerutils.UpdateConditionIfReasonOrMessageChange,
 			)
+			position++
+			queuePosition := pointer.Int32Ptr(int32(position))
+			estimatedWait := estimator.estimate(position)
+			if !equalInt32s(claim.Status.QueuePosition, queuePosition) || !equalDurations(claim.Status.EstimatedWait, estimatedWait) {
+				claim.Status.QueuePosition = queuePosition
+				claim.Status.EstimatedWait = estimatedWait
+				statusChanged = true
+			}
 		}
 		if statusChanged {
 			claim.Status.Conditions = conds
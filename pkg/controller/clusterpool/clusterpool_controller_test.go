@@ -17,6 +17,8 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -26,6 +28,7 @@ import (
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcdc "github.com/openshift/hive/pkg/test/clusterdeploymentcustomization"
 	testcp "github.com/openshift/hive/pkg/test/clusterpool"
 	"github.com/openshift/hive/pkg/test/generic"
 	testgeneric "github.com/openshift/hive/pkg/test/generic"
@@ -62,7 +65,21 @@ func TestReconcileClusterPool(t *testing.T) {
 			Status: corev1.ConditionUnknown,
 			Type:   hivev1.ClusterPoolCapacityAvailableCondition,
 		}),
+		testcp.WithCondition(hivev1.ClusterPoolCondition{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.ClusterPoolInventoryValidCondition,
+		}),
+		testcp.WithCondition(hivev1.ClusterPoolCondition{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.ClusterPoolScheduleActiveCondition,
+		}),
 	)
+	nowish := time.Now()
+	cdcBuilder := func(name string) testcdc.Builder {
+		return testcdc.FullBuilder(testNamespace, name, scheme).Options(
+			testcdc.WithInstallConfigPatch("replace", "/metadata/name", name),
+		)
+	}
 	cdBuilder := func(name string) testcd.Builder {
 		return testcd.FullBuilder(name, name, scheme).Options(
 			testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
@@ -74,6 +91,18 @@ func TestReconcileClusterPool(t *testing.T) {
 		)
 	}
 
+	poolSpecHash := calculatePoolSpecHash(initializedPoolBuilder.Build(), "")
+	staleCDBuilder := func(name string) testcd.Builder {
+		return unclaimedCDBuilder(name).GenericOptions(
+			testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, "stale-hash"),
+		)
+	}
+	currentCDBuilder := func(name string) testcd.Builder {
+		return unclaimedCDBuilder(name).GenericOptions(
+			testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, poolSpecHash),
+		)
+	}
+
 	tests := []struct {
 		name                               string
 		existing                           []runtime.Object
@@ -83,6 +112,11 @@ func TestReconcileClusterPool(t *testing.T) {
 		expectedTotalClusters              int
 		expectedObservedSize               int32
 		expectedObservedReady              int32
+		expectedObservedRunning            int32
+		expectedObservedStale              int32
+		expectedStaleClusters              []string
+		expectPoolSpecHash                 bool // Tested on all clusters, so will not work if your test has pre-existing cds in the pool.
+		expectedRunning                    []string
 		expectedDeletedClusters            []string
 		expectFinalizerRemoved             bool
 		expectedMissingDependenciesStatus  corev1.ConditionStatus
@@ -90,7 +124,21 @@ func TestReconcileClusterPool(t *testing.T) {
 		expectedMissingDependenciesMessage string
 		expectedAssignedClaims             int
 		expectedUnassignedClaims           int
+		expectedClaimedClusters            map[string]string
+		expectedClaimPools                 map[string]string
+		expectedQueuePositions             map[string]int // 0 for no queue position
+		expectedClaimPendingReasons        map[string]string
 		expectedLabels                     map[string]string // Tested on all clusters, so will not work if your test has pre-existing cds in the pool.
+		expectedInventoryValidStatus       corev1.ConditionStatus
+		expectedCustomizationsInUse        []string
+		expectedScheduleActiveStatus       corev1.ConditionStatus
+		expectedActiveSchedule             string
+		expectedRecycled                   []hivev1.ClusterPoolRecycledClusters
+		expectedVariantClusters            map[string]int
+		expectedVariantRegions             map[string]string
+		expectedPlatformVariants           []hivev1.ClusterPoolPlatformVariantStatus
+		expectedAutoscalingTarget          *int32
+		expectedRecentClaims               int
 	}{
 		{
 			name: "initialize conditions",
@@ -627,6 +675,132 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedAssignedClaims:   2,
 			expectedUnassignedClaims: 1,
 		},
+		{
+			name: "assign to claims in order of priority",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-3 * time.Minute))).
+					Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-2 * time.Minute))).
+					Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-1*time.Minute))).
+					Build(testclaim.WithPool(testLeasePoolName), testclaim.WithPriority(10)),
+				testclaim.FullBuilder(testNamespace, "test-claim-4", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish)).
+					Build(testclaim.WithPool(testLeasePoolName), testclaim.WithPriority(-1)),
+			},
+			expectedTotalClusters:    7,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedUnassignedClaims: 2,
+			expectedClaimPools: map[string]string{
+				"test-claim-1": testLeasePoolName,
+				"test-claim-3": testLeasePoolName,
+			},
+			expectedQueuePositions: map[string]int{
+				"test-claim-1": 0,
+				"test-claim-2": 1,
+				"test-claim-3": 0,
+				"test-claim-4": 2,
+			},
+		},
+		{
+			name: "hold back claims exceeding group quota",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithGroupClaimQuota("team-a", 1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-0", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("c0"),
+					testclaim.WithRequester("user-0", "team-a"),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithRequester("user-1", "team-a"),
+				),
+				// The subjects of a claim do not determine the quotas that apply to it.
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithRequester("user-2", "team-b"),
+					testclaim.WithSubjects([]rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "team-a"}}),
+				),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedUnassignedClaims: 1,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-1": "QuotaExceeded",
+				"test-claim-2": "ClusterAssigned",
+			},
+			expectedQueuePositions: map[string]int{"test-claim-1": 0},
+		},
+		{
+			name: "hold back claims exceeding namespace quota",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithNamespaceClaimQuota(testNamespace, 2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-2 * time.Minute))).
+					Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-1 * time.Minute))).
+					Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish)).
+					Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    5,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedUnassignedClaims: 1,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-1": "ClusterAssigned",
+				"test-claim-2": "ClusterAssigned",
+				"test-claim-3": "QuotaExceeded",
+			},
+		},
+		{
+			name: "hold back claims exceeding user quota",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithUserClaimQuota("user-1", 1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-2*time.Minute))).
+					Build(testclaim.WithPool(testLeasePoolName), testclaim.WithRequester("user-1")),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-1*time.Minute))).
+					Build(testclaim.WithPool(testLeasePoolName), testclaim.WithRequester("user-1")),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish)).
+					Build(testclaim.WithPool(testLeasePoolName), testclaim.WithRequester("user-2")),
+			},
+			expectedTotalClusters:    5,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedUnassignedClaims: 1,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-1": "ClusterAssigned",
+				"test-claim-2": "QuotaExceeded",
+				"test-claim-3": "ClusterAssigned",
+			},
+		},
 		{
 			name: "do not assign to claims for other pools",
 			existing: []runtime.Object{
@@ -657,6 +831,142 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedAssignedClaims:   0,
 			expectedUnassignedClaims: 1,
 		},
+		{
+			name: "assign to claim selecting pool",
+			existing: []runtime.Object{
+				initializedPoolBuilder.GenericOptions(testgeneric.WithLabel("group", "test")).Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPoolSelector(map[string]string{"group": "test"}),
+				),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 0,
+			expectedClaimPools:       map[string]string{"test-claim": testLeasePoolName},
+		},
+		{
+			name: "do not assign to claim selecting other pools",
+			existing: []runtime.Object{
+				initializedPoolBuilder.GenericOptions(testgeneric.WithLabel("group", "test")).Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPoolSelector(map[string]string{"group": "other"}),
+				),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "do not assign to claim selecting pool with more ready clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.GenericOptions(testgeneric.WithLabel("group", "test")).Build(testcp.WithSize(3)),
+				testcp.FullBuilder(testNamespace, "other-pool", scheme).
+					GenericOptions(testgeneric.WithLabel("group", "test")).
+					Build(testcp.WithReady(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPoolSelector(map[string]string{"group": "test"}),
+				),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "assign to claim selecting pool with highest priority",
+			existing: []runtime.Object{
+				initializedPoolBuilder.GenericOptions(
+					testgeneric.WithLabel("group", "test"),
+					testgeneric.WithLabel(constants.ClusterPoolPriorityLabel, "2"),
+				).Build(testcp.WithSize(3)),
+				testcp.FullBuilder(testNamespace, "other-pool", scheme).
+					GenericOptions(
+						testgeneric.WithLabel("group", "test"),
+						testgeneric.WithLabel(constants.ClusterPoolPriorityLabel, "1"),
+					).
+					Build(testcp.WithReady(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPoolSelector(map[string]string{"group": "test"}),
+					testclaim.WithPoolOrdering(hivev1.PriorityClusterPoolOrdering),
+				),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 0,
+			expectedClaimPools:       map[string]string{"test-claim": testLeasePoolName},
+		},
+		{
+			name: "share claims selecting pools with other pools",
+			existing: []runtime.Object{
+				initializedPoolBuilder.GenericOptions(testgeneric.WithLabel("group", "test")).Build(testcp.WithSize(3)),
+				testcp.FullBuilder(testNamespace, "other-pool", scheme).
+					GenericOptions(testgeneric.WithLabel("group", "test")).
+					Build(testcp.WithReady(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(
+					testclaim.WithPoolSelector(map[string]string{"group": "test"}),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(
+					testclaim.WithPoolSelector(map[string]string{"group": "test"}),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).Build(
+					testclaim.WithPoolSelector(map[string]string{"group": "test"}),
+				),
+			},
+			expectedTotalClusters:    5,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "claims naming pool take precedence over claims selecting pool",
+			existing: []runtime.Object{
+				initializedPoolBuilder.GenericOptions(testgeneric.WithLabel("group", "test")).Build(testcp.WithSize(3)),
+				testcp.FullBuilder(testNamespace, "other-pool", scheme).
+					GenericOptions(testgeneric.WithLabel("group", "test")).
+					Build(testcp.WithReady(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(
+					testclaim.WithPoolSelector(map[string]string{"group": "test"}),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    5,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedUnassignedClaims: 1,
+			expectedClaimPools: map[string]string{
+				"test-claim-1": "",
+				"test-claim-2": testLeasePoolName,
+				"test-claim-3": testLeasePoolName,
+			},
+		},
 		{
 			name: "do not delete previously claimed clusters",
 			existing: []runtime.Object{
@@ -884,6 +1194,676 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady:   2,
 			expectedDeletedClusters: []string{"c4"},
 		},
+		{
+			name: "create clusters from inventory",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2), testcp.WithInventory("cdc1", "cdc2", "cdc3")),
+				cdcBuilder("cdc1").Build(),
+				cdcBuilder("cdc2").Build(),
+				cdcBuilder("cdc3").Build(),
+			},
+			expectedTotalClusters:        2,
+			expectedInventoryValidStatus: corev1.ConditionTrue,
+			expectedCustomizationsInUse:  []string{"cdc1", "cdc2"},
+		},
+		{
+			name: "create clusters from inventory skips entries in use",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2), testcp.WithInventory("cdc1", "cdc2", "cdc3")),
+				cdcBuilder("cdc1").Build(),
+				cdcBuilder("cdc2").Build(),
+				cdcBuilder("cdc3").Build(),
+				unclaimedCDBuilder("c1").Build(testcd.WithCustomizationRef("cdc1")),
+			},
+			expectedTotalClusters:        2,
+			expectedObservedSize:         1,
+			expectedInventoryValidStatus: corev1.ConditionTrue,
+			expectedCustomizationsInUse:  []string{"cdc1", "cdc2"},
+		},
+		{
+			name: "inventory in use by claimed cluster",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithInventory("cdc1", "cdc2")),
+				cdcBuilder("cdc1").Build(),
+				cdcBuilder("cdc2").Build(),
+				cdBuilder("c1").Build(
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+					testcd.WithCustomizationRef("cdc1"),
+				),
+			},
+			expectedTotalClusters:        2,
+			expectedInventoryValidStatus: corev1.ConditionTrue,
+			expectedCustomizationsInUse:  []string{"cdc1", "cdc2"},
+		},
+		{
+			name: "inventory exhausted",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithInventory("cdc1", "cdc2")),
+				cdcBuilder("cdc1").Build(),
+				cdcBuilder("cdc2").Build(),
+				unclaimedCDBuilder("c1").Build(testcd.WithCustomizationRef("cdc1")),
+			},
+			expectedTotalClusters:        2,
+			expectedObservedSize:         1,
+			expectedInventoryValidStatus: corev1.ConditionTrue,
+			expectedCustomizationsInUse:  []string{"cdc1", "cdc2"},
+		},
+		{
+			name: "inventory entry released by deleted cluster",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithInventory("cdc1")),
+				cdcBuilder("cdc1").Build(testcdc.WithClusterDeploymentRef(testLeasePoolName, "c1")),
+			},
+			expectedTotalClusters:        1,
+			expectedInventoryValidStatus: corev1.ConditionTrue,
+			expectedCustomizationsInUse:  []string{"cdc1"},
+		},
+		{
+			name: "missing inventory entry",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2), testcp.WithInventory("cdc1", "cdc2")),
+				cdcBuilder("cdc2").Build(),
+			},
+			expectedTotalClusters:        1,
+			expectedInventoryValidStatus: corev1.ConditionFalse,
+			expectedCustomizationsInUse:  []string{"cdc2"},
+		},
+		{
+			name: "scale up for active schedule",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithSchedule("always", "* * * * *", time.Hour, 3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:        3,
+			expectedObservedSize:         1,
+			expectedObservedReady:        1,
+			expectedScheduleActiveStatus: corev1.ConditionTrue,
+			expectedActiveSchedule:       "always",
+		},
+		{
+			name: "scale down for active schedule",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithSchedule("always", "* * * * *", time.Hour, 0)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:        0,
+			expectedObservedSize:         2,
+			expectedObservedReady:        2,
+			expectedScheduleActiveStatus: corev1.ConditionTrue,
+			expectedActiveSchedule:       "always",
+		},
+		{
+			name: "first active schedule takes precedence",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithSchedule("never", "0 0 30 2 *", time.Hour, 5),
+					testcp.WithSchedule("first", "* * * * *", time.Hour, 2),
+					testcp.WithSchedule("second", "* * * * *", time.Hour, 4),
+				),
+			},
+			expectedTotalClusters:        2,
+			expectedScheduleActiveStatus: corev1.ConditionTrue,
+			expectedActiveSchedule:       "first",
+		},
+		{
+			name: "no active schedule",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithSchedule("never", "0 0 30 2 *", time.Hour, 5)),
+			},
+			expectedTotalClusters:        1,
+			expectedScheduleActiveStatus: corev1.ConditionFalse,
+		},
+		{
+			name: "invalid schedule",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithSchedule("invalid", "not a schedule", time.Hour, 5)),
+			},
+			expectedTotalClusters:        1,
+			expectedScheduleActiveStatus: corev1.ConditionFalse,
+		},
+		{
+			name: "running count starts oldest ready clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(2)),
+				unclaimedCDBuilder("c1").GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-time.Hour))).Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-2 * time.Hour))).Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").GenericOptions(testgeneric.WithCreationTimestamp(nowish)).Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 3,
+			expectedObservedSize:  3,
+			expectedObservedReady: 3,
+			expectedRunning:       []string{"c1", "c2"},
+		},
+		{
+			name: "running count keeps running clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2), testcp.WithRunningCount(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(
+					testcd.Installed(),
+					testcd.WithPowerState(hivev1.RunningClusterPowerState),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ClusterHibernatingCondition,
+						Status: corev1.ConditionFalse,
+						Reason: hivev1.RunningHibernationReason,
+					}),
+				),
+			},
+			expectedTotalClusters:   2,
+			expectedObservedSize:    2,
+			expectedObservedReady:   2,
+			expectedObservedRunning: 1,
+			expectedRunning:         []string{"c2"},
+		},
+		{
+			name: "running count prefers installed clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2), testcp.WithRunningCount(1)),
+				unclaimedCDBuilder("c1").Build(),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 1,
+			expectedRunning:       []string{"c2"},
+		},
+		{
+			name: "running count includes installing clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2), testcp.WithRunningCount(5)),
+				unclaimedCDBuilder("c1").Build(),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 1,
+			expectedRunning:       []string{"c1", "c2"},
+		},
+		{
+			name: "reduced running count hibernates clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 2,
+		},
+		{
+			name: "running clusters assigned to claims first",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2), testcp.WithRunningCount(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(
+					testcd.Installed(),
+					testcd.WithPowerState(hivev1.RunningClusterPowerState),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ClusterHibernatingCondition,
+						Status: corev1.ConditionFalse,
+						Reason: hivev1.RunningHibernationReason,
+					}),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:   3,
+			expectedObservedSize:    2,
+			expectedObservedReady:   2,
+			expectedObservedRunning: 1,
+			expectedAssignedClaims:  1,
+			expectedClaimedClusters: map[string]string{"test-claim": "c2"},
+			// c2 is left running for the claim to take, and c1 is started to replace it.
+			expectedRunning: []string{"c1", "c2"},
+		},
+		{
+			name: "new clusters record pool spec hash",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+			},
+			expectedTotalClusters: 2,
+			expectPoolSpecHash:    true,
+		},
+		{
+			name: "clusters without pool spec hash are not stale",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+		},
+		{
+			name: "recycle clusters older than max unclaimed age",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithMaxUnclaimedAge(24*time.Hour)),
+				unclaimedCDBuilder("c1").
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-48 * time.Hour))).
+					Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish)).
+					Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish)).
+					Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   3,
+			expectedObservedSize:    2,
+			expectedObservedReady:   2,
+			expectedDeletedClusters: []string{"c1"},
+			expectedRecycled: []hivev1.ClusterPoolRecycledClusters{
+				{Reason: hivev1.MaxUnclaimedAgeExceededRecycleReason, Count: 1},
+			},
+		},
+		{
+			name: "recycle oldest clusters within max concurrent",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithMaxConcurrent(1), testcp.WithMaxUnclaimedAge(24*time.Hour)),
+				unclaimedCDBuilder("c1").
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-48 * time.Hour))).
+					Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-72 * time.Hour))).
+					Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish)).
+					Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   2,
+			expectedObservedSize:    2,
+			expectedObservedReady:   2,
+			expectedDeletedClusters: []string{"c2"},
+			expectedRecycled: []hivev1.ClusterPoolRecycledClusters{
+				{Reason: hivev1.MaxUnclaimedAgeExceededRecycleReason, Count: 1},
+			},
+		},
+		{
+			name: "recycle broken clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithBrokenClusterThreshold(time.Hour)),
+				unclaimedCDBuilder("c1").Build(
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:               hivev1.ProvisionFailedCondition,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(nowish.Add(-2 * time.Hour)),
+					}),
+				),
+				unclaimedCDBuilder("c2").Build(
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:               hivev1.ProvisionFailedCondition,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(nowish.Add(-10 * time.Minute)),
+					}),
+				),
+				unclaimedCDBuilder("c3").Build(
+					testcd.Installed(),
+					testcd.WithPowerState(hivev1.RunningClusterPowerState),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ClusterHibernatingCondition,
+						Status: corev1.ConditionFalse,
+						Reason: hivev1.RunningHibernationReason,
+					}),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:               hivev1.UnreachableCondition,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(nowish.Add(-2 * time.Hour)),
+					}),
+				),
+				unclaimedCDBuilder("c4").Build(
+					testcd.Installed(),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ClusterHibernatingCondition,
+						Status: corev1.ConditionTrue,
+						Reason: hivev1.HibernatingHibernationReason,
+					}),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:               hivev1.UnreachableCondition,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(nowish.Add(-2 * time.Hour)),
+					}),
+				),
+			},
+			expectedTotalClusters:   3,
+			expectedObservedSize:    2,
+			expectedObservedReady:   1,
+			expectedDeletedClusters: []string{"c1", "c3"},
+			expectedRecycled: []hivev1.ClusterPoolRecycledClusters{
+				{Reason: hivev1.ProvisionFailedRecycleReason, Count: 1},
+				{Reason: hivev1.UnreachableRecycleReason, Count: 1},
+			},
+		},
+		{
+			name: "recycle broken clusters within max concurrent",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithMaxConcurrent(2), testcp.WithBrokenClusterThreshold(time.Hour)),
+				unclaimedCDBuilder("c1").Build(
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:               hivev1.ProvisionStoppedCondition,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(nowish.Add(-2 * time.Hour)),
+					}),
+				),
+				unclaimedCDBuilder("c2").Build(
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:               hivev1.ProvisionStoppedCondition,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(nowish.Add(-2 * time.Hour)),
+					}),
+				),
+				unclaimedCDBuilder("c3").
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-2*time.Hour))).
+					Build(
+						testcd.Installed(),
+						testcd.WithPowerState(hivev1.RunningClusterPowerState),
+						testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+							Type:               hivev1.UnreachableCondition,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(nowish.Add(-2 * time.Hour)),
+						}),
+					),
+			},
+			expectedTotalClusters:   1,
+			expectedObservedSize:    1,
+			expectedObservedReady:   1,
+			expectedDeletedClusters: []string{"c1", "c2"},
+			expectedRecycled: []hivev1.ClusterPoolRecycledClusters{
+				{Reason: hivev1.ProvisionStoppedRecycleReason, Count: 2},
+			},
+		},
+		{
+			name: "add to recycled clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithMaxUnclaimedAge(24*time.Hour),
+					func(pool *hivev1.ClusterPool) {
+						pool.Status.Recycled = []hivev1.ClusterPoolRecycledClusters{
+							{Reason: hivev1.MaxUnclaimedAgeExceededRecycleReason, Count: 3},
+						}
+					},
+				),
+				unclaimedCDBuilder("c1").
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-48 * time.Hour))).
+					Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   1,
+			expectedObservedSize:    0,
+			expectedObservedReady:   0,
+			expectedDeletedClusters: []string{"c1"},
+			expectedRecycled: []hivev1.ClusterPoolRecycledClusters{
+				{Reason: hivev1.MaxUnclaimedAgeExceededRecycleReason, Count: 4},
+			},
+		},
+		{
+			name: "spread new clusters across platform variants",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(3),
+					testcp.WithPlatformVariant("a", 2, "us-west-1", "creds-a"),
+					testcp.WithPlatformVariant("b", 1, "us-east-2", ""),
+				),
+				testsecret.FullBuilder(testNamespace, "creds-a", scheme).
+					Build(testsecret.WithDataKeyValue("dummykey", []byte("dummyval"))),
+			},
+			expectedTotalClusters:   3,
+			expectedVariantClusters: map[string]int{"a": 2, "b": 1},
+			expectedVariantRegions:  map[string]string{"a": "us-west-1", "b": "us-east-2"},
+			expectedPlatformVariants: []hivev1.ClusterPoolPlatformVariantStatus{
+				{Name: "a"},
+				{Name: "b"},
+			},
+		},
+		{
+			name: "skip failing platform variant",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(3),
+					testcp.WithPlatformVariant("a", 1, "us-west-1", ""),
+					testcp.WithPlatformVariant("b", 1, "us-east-2", ""),
+				),
+				unclaimedCDBuilder("c1").Build(
+					testcd.WithLabel(constants.ClusterPoolPlatformVariantLabel, "a"),
+					testcd.InstallRestarts(2),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ProvisionFailedCondition,
+						Status: corev1.ConditionTrue,
+					}),
+				),
+				unclaimedCDBuilder("c2").Build(
+					testcd.Installed(),
+					testcd.WithLabel(constants.ClusterPoolPlatformVariantLabel, "b"),
+				),
+			},
+			expectedTotalClusters:   3,
+			expectedObservedSize:    2,
+			expectedObservedReady:   1,
+			expectedVariantClusters: map[string]int{"a": 1, "b": 2},
+			expectedPlatformVariants: []hivev1.ClusterPoolPlatformVariantStatus{
+				{Name: "a", Size: 1, Failing: true, ConsecutiveFailures: 1},
+				{Name: "b", Size: 1, Ready: 1},
+			},
+		},
+		{
+			name: "skip platform variant with recorded failure",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(2),
+					testcp.WithPlatformVariant("a", 1, "us-west-1", ""),
+					testcp.WithPlatformVariant("b", 1, "us-east-2", ""),
+					func(pool *hivev1.ClusterPool) {
+						pool.Status.PlatformVariants = []hivev1.ClusterPoolPlatformVariantStatus{{
+							Name:                "a",
+							Failing:             true,
+							ConsecutiveFailures: 1,
+							LastFailureTime:     &metav1.Time{Time: nowish.Add(-time.Minute)},
+							RetryTime:           &metav1.Time{Time: nowish.Add(time.Hour)},
+						}}
+					},
+				),
+			},
+			expectedTotalClusters:   2,
+			expectedVariantClusters: map[string]int{"b": 2},
+			expectedPlatformVariants: []hivev1.ClusterPoolPlatformVariantStatus{
+				{Name: "a", Failing: true, ConsecutiveFailures: 1},
+				{Name: "b"},
+			},
+		},
+		{
+			name: "missing platform variant credentials",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithPlatformVariant("a", 1, "us-west-1", "creds-a"),
+				),
+			},
+			expectError:                        true,
+			expectedMissingDependenciesStatus:  corev1.ConditionTrue,
+			expectedMissingDependenciesMessage: `credentials secret: secrets "creds-a" not found`,
+			expectedPlatformVariants: []hivev1.ClusterPoolPlatformVariantStatus{
+				{Name: "a"},
+			},
+		},
+		{
+			name: "autoscaling sizes pool from recent claims",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithAutoscaling(1, 10, time.Hour),
+					testcp.WithRecentClaims(
+						nowish.Add(-2*time.Hour), // outside of the window
+						nowish.Add(-50*time.Minute),
+						nowish.Add(-40*time.Minute),
+						nowish.Add(-30*time.Minute),
+						nowish.Add(-20*time.Minute),
+						nowish.Add(-10*time.Minute),
+						nowish.Add(-5*time.Minute),
+					),
+				),
+			},
+			// 6 claims in the hour and the default provision time of 45 minutes.
+			expectedTotalClusters:     5,
+			expectedAutoscalingTarget: pointer.Int32Ptr(5),
+			expectedRecentClaims:      6,
+		},
+		{
+			name: "autoscaling limited to max size",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithAutoscaling(1, 2, time.Hour),
+					testcp.WithRecentClaims(nowish.Add(-30*time.Minute), nowish.Add(-20*time.Minute), nowish.Add(-10*time.Minute)),
+				),
+			},
+			expectedTotalClusters:     2,
+			expectedAutoscalingTarget: pointer.Int32Ptr(2),
+			expectedRecentClaims:      3,
+		},
+		{
+			name: "schedule takes precedence over autoscaling",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithAutoscaling(1, 10, time.Hour),
+					testcp.WithSchedule("always", "* * * * *", time.Hour, 3),
+				),
+			},
+			expectedTotalClusters:        3,
+			expectedAutoscalingTarget:    pointer.Int32Ptr(1),
+			expectedScheduleActiveStatus: corev1.ConditionTrue,
+			expectedActiveSchedule:       "always",
+		},
+		{
+			name: "autoscaling records assigned claims",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithAutoscaling(1, 10, time.Hour),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				// The claim is counted when it is assigned a cluster, not when it was created.
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-2 * time.Hour))).
+					Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:     2,
+			expectedObservedSize:      1,
+			expectedObservedReady:     1,
+			expectedAssignedClaims:    1,
+			expectedAutoscalingTarget: pointer.Int32Ptr(1),
+			expectedRecentClaims:      1,
+		},
+		{
+			name: "stale cluster replaced",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				currentCDBuilder("c1").Build(testcd.Installed()),
+				staleCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   1,
+			expectedObservedSize:    2,
+			expectedObservedReady:   2,
+			expectedObservedStale:   1,
+			expectedDeletedClusters: []string{"c2"},
+		},
+		{
+			name: "stale clusters replaced within budget",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithMaxStaleReplacements(2)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+				staleCDBuilder("c2").Build(testcd.Installed()),
+				staleCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   1,
+			expectedObservedSize:    3,
+			expectedObservedReady:   3,
+			expectedObservedStale:   3,
+			expectedDeletedClusters: []string{"c2", "c3"},
+			expectedStaleClusters:   []string{"c1"},
+		},
+		{
+			name: "stale cluster not replaced while replacement installing",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				currentCDBuilder("c1").Build(),
+				staleCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 1,
+			expectedObservedStale: 1,
+			expectedStaleClusters: []string{"c2"},
+		},
+		{
+			name: "stale installing cluster replaced first",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+				staleCDBuilder("c2").Build(),
+			},
+			expectedTotalClusters:   1,
+			expectedObservedSize:    2,
+			expectedObservedReady:   1,
+			expectedObservedStale:   2,
+			expectedDeletedClusters: []string{"c2"},
+			expectedStaleClusters:   []string{"c1"},
+		},
+		{
+			name: "stale cluster not replaced while pool is short",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 3,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+			expectedObservedStale: 1,
+			expectedStaleClusters: []string{"c1"},
+		},
+		{
+			name: "stale cluster replacement disabled",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithMaxStaleReplacements(0)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+			expectedObservedStale: 1,
+			expectedStaleClusters: []string{"c1"},
+		},
+		{
+			name: "clusters stale when platform variants change",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithMaxStaleReplacements(0),
+					testcp.WithPlatformVariant("east", 1, "us-east-1", ""),
+				),
+				currentCDBuilder("c1").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+			expectedObservedStale: 1,
+			expectedStaleClusters: []string{"c1"},
+			expectedPlatformVariants: []hivev1.ClusterPoolPlatformVariantStatus{
+				{Name: "east"},
+			},
+		},
+		{
+			name: "stale annotation removed when cluster no longer stale",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1)),
+				currentCDBuilder("c1").GenericOptions(
+					testgeneric.WithAnnotation(constants.ClusterPoolStaleAnnotation, "true"),
+				).Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+		},
 	}
 
 	for _, test := range tests {
@@ -939,8 +1919,18 @@ func TestReconcileClusterPool(t *testing.T) {
 				}
 			}
 
+			expectedRunning := sets.NewString(test.expectedRunning...)
 			for _, cd := range cds.Items {
-				assert.Equal(t, hivev1.HibernatingClusterPowerState, cd.Spec.PowerState, "expected cluster to be hibernating")
+				if expectedRunning.Has(cd.Name) {
+					assert.Equal(t, hivev1.RunningClusterPowerState, cd.Spec.PowerState, "expected cluster to be running")
+				} else {
+					assert.Equal(t, hivev1.HibernatingClusterPowerState, cd.Spec.PowerState, "expected cluster to be hibernating")
+				}
+				if test.expectPoolSpecHash {
+					assert.Equal(t, poolSpecHash, cd.Annotations[constants.ClusterPoolSpecHashAnnotation], "unexpected pool spec hash")
+				}
+				_, stale := cd.Annotations[constants.ClusterPoolStaleAnnotation]
+				assert.Equal(t, sets.NewString(test.expectedStaleClusters...).Has(cd.Name), stale, "unexpected stale annotation on cluster %s", cd.Name)
 				if test.expectedLabels != nil {
 					for k, v := range test.expectedLabels {
 						assert.Equal(t, v, cd.Labels[k])
@@ -948,6 +1938,40 @@ func TestReconcileClusterPool(t *testing.T) {
 				}
 			}
 
+			if test.expectedVariantClusters != nil {
+				actualVariantClusters := map[string]int{}
+				for _, cd := range cds.Items {
+					if variant, ok := cd.Labels[constants.ClusterPoolPlatformVariantLabel]; ok {
+						actualVariantClusters[variant]++
+						if region, ok := test.expectedVariantRegions[variant]; ok {
+							assert.Equal(t, region, cd.Spec.Platform.AWS.Region, "unexpected region for platform variant")
+						}
+					}
+				}
+				assert.Equal(t, test.expectedVariantClusters, actualVariantClusters, "unexpected clusters for platform variants")
+			}
+
+			var actualCustomizationsInUse []string
+			for _, cd := range cds.Items {
+				if cd.Spec.ClusterPoolRef == nil || cd.Spec.ClusterPoolRef.CustomizationRef == nil {
+					continue
+				}
+				cdcName := cd.Spec.ClusterPoolRef.CustomizationRef.Name
+				actualCustomizationsInUse = append(actualCustomizationsInUse, cdcName)
+				cdc := &hivev1.ClusterDeploymentCustomization{}
+				err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: cdcName}, cdc)
+				if assert.NoError(t, err, "unexpected error getting ClusterDeploymentCustomization") &&
+					assert.NotNil(t, cdc.Status.ClusterDeploymentRef, "expected ClusterDeploymentRef in ClusterDeploymentCustomization status") {
+					assert.Equal(t, cd.Name, cdc.Status.ClusterDeploymentRef.Name, "unexpected ClusterDeploymentRef in ClusterDeploymentCustomization status")
+				}
+				icSecret := &corev1.Secret{}
+				if err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name + "-install-config"}, icSecret); err == nil {
+					assert.Contains(t, icSecret.StringData["install-config.yaml"], "name: "+cdcName, "expected customization to be applied to install-config")
+				}
+			}
+			sort.Strings(actualCustomizationsInUse)
+			assert.Equal(t, test.expectedCustomizationsInUse, actualCustomizationsInUse, "unexpected customizations in use")
+
 			pool := &hivev1.ClusterPool{}
 			err = fakeClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testLeasePoolName}, pool)
 			assert.NoError(t, err, "unexpected error getting clusterpool")
@@ -958,6 +1982,27 @@ func TestReconcileClusterPool(t *testing.T) {
 				assert.Contains(t, pool.Finalizers, finalizer, "expect finalizer on clusterpool")
 				assert.Equal(t, test.expectedObservedSize, pool.Status.Size, "unexpected observed size")
 				assert.Equal(t, test.expectedObservedReady, pool.Status.Ready, "unexpected observed ready count")
+				assert.Equal(t, test.expectedObservedRunning, pool.Status.Running, "unexpected observed running count")
+				assert.Equal(t, test.expectedObservedStale, pool.Status.Stale, "unexpected observed stale count")
+				assert.Equal(t, test.expectedRecycled, pool.Status.Recycled, "unexpected recycled clusters")
+				// The failure times of variants are relative to the time of the reconcile, so are only checked for presence.
+				for i := range pool.Status.PlatformVariants {
+					variant := &pool.Status.PlatformVariants[i]
+					if variant.ConsecutiveFailures > 0 {
+						assert.NotNil(t, variant.LastFailureTime, "missing last failure time for platform variant %s", variant.Name)
+						assert.NotNil(t, variant.RetryTime, "missing retry time for platform variant %s", variant.Name)
+					}
+					variant.LastFailureTime = nil
+					variant.RetryTime = nil
+				}
+				assert.Equal(t, test.expectedPlatformVariants, pool.Status.PlatformVariants, "unexpected platform variant status")
+				if test.expectedAutoscalingTarget == nil {
+					assert.Nil(t, pool.Status.Autoscaling, "expected no autoscaling status")
+				} else if assert.NotNil(t, pool.Status.Autoscaling, "expected autoscaling status") {
+					assert.Equal(t, *test.expectedAutoscalingTarget, pool.Status.Autoscaling.TargetSize, "unexpected autoscaling target size")
+					assert.Equal(t, test.expectedRecentClaims, recentClaimCount(pool.Status.Autoscaling), "unexpected number of recent claims")
+				}
+				assert.Equal(t, test.expectedObservedReady-test.expectedObservedRunning, pool.Status.Hibernating, "unexpected observed hibernating count")
 			}
 
 			if test.expectedMissingDependenciesStatus != "" {
@@ -979,6 +2024,23 @@ func TestReconcileClusterPool(t *testing.T) {
 				}
 			}
 
+			if test.expectedScheduleActiveStatus != "" {
+				scheduleActiveCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolScheduleActiveCondition)
+				if assert.NotNil(t, scheduleActiveCondition, "did not find ScheduleActive condition") {
+					assert.Equal(t, test.expectedScheduleActiveStatus, scheduleActiveCondition.Status,
+						"unexpected ScheduleActive conditon status")
+				}
+			}
+			assert.Equal(t, test.expectedActiveSchedule, pool.Status.ActiveSchedule, "unexpected active schedule")
+
+			if test.expectedInventoryValidStatus != "" {
+				inventoryValidCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolInventoryValidCondition)
+				if assert.NotNil(t, inventoryValidCondition, "did not find InventoryValid condition") {
+					assert.Equal(t, test.expectedInventoryValidStatus, inventoryValidCondition.Status,
+						"unexpected InventoryValid conditon status")
+				}
+			}
+
 			claims := &hivev1.ClusterClaimList{}
 			err = fakeClient.List(context.Background(), claims)
 			require.NoError(t, err)
@@ -992,6 +2054,27 @@ func TestReconcileClusterPool(t *testing.T) {
 					actualAssignedClaims++
 				}
 			}
+			for _, claim := range claims.Items {
+				if expected, ok := test.expectedClaimedClusters[claim.Name]; ok {
+					assert.Equal(t, expected, claim.Spec.Namespace, "unexpected cluster assigned to claim")
+				}
+				if expected, ok := test.expectedClaimPools[claim.Name]; ok {
+					assert.Equal(t, expected, claim.Status.ClusterPoolName, "unexpected pool recorded for claim")
+				}
+				if expected, ok := test.expectedClaimPendingReasons[claim.Name]; ok {
+					cond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
+					if assert.NotNil(t, cond, "missing Pending condition for claim") {
+						assert.Equal(t, expected, cond.Reason, "unexpected Pending reason for claim")
+					}
+				}
+				if expected, ok := test.expectedQueuePositions[claim.Name]; ok {
+					if expected == 0 {
+						assert.Nil(t, claim.Status.QueuePosition, "unexpected queue position for claim")
+					} else if assert.NotNil(t, claim.Status.QueuePosition, "missing queue position for claim") {
+						assert.Equal(t, int32(expected), *claim.Status.QueuePosition, "unexpected queue position for claim")
+					}
+				}
+			}
 			assert.Equal(t, test.expectedAssignedClaims, actualAssignedClaims, "unexpected number of assigned claims")
 			assert.Equal(t, test.expectedUnassignedClaims, actualUnassignedClaims, "unexpected number of unassigned claims")
 		})
@@ -1749,3 +2832,59 @@ func TestReconcileRBAC(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculatePoolSpecHash(t *testing.T) {
+	poolBuilder := testcp.BasicBuilder().Options(
+		testcp.ForAWS(credsSecretName, "us-east-1"),
+		testcp.WithBaseDomain("test-domain"),
+		testcp.WithImageSet(imageSetName),
+		testcp.WithSize(1),
+	)
+	baseHash := calculatePoolSpecHash(poolBuilder.Build(), "")
+
+	tests := []struct {
+		name                  string
+		pool                  *hivev1.ClusterPool
+		installConfigTemplate string
+		expectChange          bool
+	}{
+		{
+			name: "unchanged",
+			pool: poolBuilder.Build(),
+		},
+		{
+			name: "size changed",
+			pool: poolBuilder.Build(testcp.WithSize(5), testcp.WithRunningCount(2)),
+		},
+		{
+			name: "labels changed",
+			pool: poolBuilder.Build(testcp.WithClusterDeploymentLabels(map[string]string{"foo": "bar"})),
+		},
+		{
+			name:         "image set changed",
+			pool:         poolBuilder.Build(testcp.WithImageSet("other-image-set")),
+			expectChange: true,
+		},
+		{
+			name:         "platform changed",
+			pool:         poolBuilder.Build(testcp.ForAWS(credsSecretName, "us-west-2")),
+			expectChange: true,
+		},
+		{
+			name:                  "install config template changed",
+			pool:                  poolBuilder.Build(),
+			installConfigTemplate: "apiVersion: v1\n",
+			expectChange:          true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hash := calculatePoolSpecHash(test.pool, test.installConfigTemplate)
+			if test.expectChange {
+				assert.NotEqual(t, baseHash, hash, "expected hash to change")
+			} else {
+				assert.Equal(t, baseHash, hash, "expected hash to be unchanged")
+			}
+		})
+	}
+}
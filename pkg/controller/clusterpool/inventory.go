@@ -0,0 +1,246 @@
+package clusterpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const installConfigKey = "install-config.yaml"
+
+// requestsForCustomization enqueues the ClusterPools whose inventory refers to a ClusterDeploymentCustomization.
+func requestsForCustomization(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		cpList := &hivev1.ClusterPoolList{}
+		if err := c.List(context.Background(), cpList, client.InNamespace(o.GetNamespace())); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list cluster pools for ClusterDeploymentCustomization")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cp := range cpList.Items {
+			for _, entry := range cp.Spec.Inventory {
+				if entry.Name != o.GetName() {
+					continue
+				}
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: cp.Namespace, Name: cp.Name}})
+				break
+			}
+		}
+		return requests
+	}
+}
+
+// reconcileInventory returns the ClusterDeploymentCustomizations from the pool inventory that are not in use by any
+// ClusterDeployment, in inventory order. The status of each customization in the inventory is updated to reflect
+// the ClusterDeployment using it, if any, and the InventoryValid condition is set on the pool.
+func (r *ReconcileClusterPool) reconcileInventory(pool *hivev1.ClusterPool, logger log.FieldLogger) ([]*hivev1.ClusterDeploymentCustomization, error) {
+	if len(pool.Spec.Inventory) == 0 {
+		return nil, nil
+	}
+
+	// Customizations may be shared by pools in the same namespace, so consider all ClusterDeployments from any pool
+	// in the namespace when determining which customizations are in use.
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.Client.List(context.Background(), cdList); err != nil {
+		logger.WithError(err).Error("error listing ClusterDeployments")
+		return nil, err
+	}
+	inUse := map[string]*hivev1.ClusterDeployment{}
+	for i, cd := range cdList.Items {
+		poolRef := cd.Spec.ClusterPoolRef
+		if poolRef == nil || poolRef.Namespace != pool.Namespace || poolRef.CustomizationRef == nil {
+			continue
+		}
+		inUse[poolRef.CustomizationRef.Name] = &cdList.Items[i]
+	}
+
+	var available []*hivev1.ClusterDeploymentCustomization
+	var errs []error
+	for _, entry := range pool.Spec.Inventory {
+		entryLog := logger.WithField("customization", entry.Name)
+		if entry.Kind != "" && entry.Kind != hivev1.ClusterDeploymentCustomizationInventoryEntry {
+			errs = append(errs, fmt.Errorf("inventory entry %s has unsupported kind %s", entry.Name, entry.Kind))
+			continue
+		}
+		cdc := &hivev1.ClusterDeploymentCustomization{}
+		if err := r.Get(context.Background(), client.ObjectKey{Namespace: pool.Namespace, Name: entry.Name}, cdc); err != nil {
+			if apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("inventory entry %s: %w", entry.Name, err))
+				continue
+			}
+			entryLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting ClusterDeploymentCustomization")
+			return nil, err
+		}
+		cd := inUse[entry.Name]
+		if err := r.syncCustomizationStatus(pool, cdc, cd, entryLog); err != nil {
+			return nil, err
+		}
+		if cd == nil {
+			available = append(available, cdc)
+		}
+	}
+
+	if err := r.setInventoryValidCondition(pool, utilerrors.NewAggregate(errs), logger); err != nil {
+		return nil, err
+	}
+	return available, nil
+}
+
+// syncCustomizationStatus records the ClusterDeployment using the customization, if any, in the customization status.
+func (r *ReconcileClusterPool) syncCustomizationStatus(pool *hivev1.ClusterPool, cdc *hivev1.ClusterDeploymentCustomization, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	status := hivev1.ClusterDeploymentCustomizationStatus{
+		LastAppliedTime: cdc.Status.LastAppliedTime,
+	}
+	if cd != nil {
+		status.ClusterDeploymentRef = &corev1.LocalObjectReference{Name: cd.Name}
+		status.ClusterPoolRef = &corev1.LocalObjectReference{Name: cd.Spec.ClusterPoolRef.PoolName}
+		if cdc.Status.ClusterDeploymentRef == nil || cdc.Status.ClusterDeploymentRef.Name != cd.Name {
+			status.LastAppliedTime = &cd.CreationTimestamp
+		}
+	} else if cdc.Status.ClusterPoolRef != nil && cdc.Status.ClusterPoolRef.Name != pool.Name {
+		// The customization was last used by a different pool. Leave it for that pool to release.
+		return nil
+	}
+	if equalCustomizationStatus(cdc.Status, status) {
+		return nil
+	}
+	if cd == nil {
+		logger.Info("releasing ClusterDeploymentCustomization")
+	} else {
+		logger.WithField("cluster", cd.Name).Info("ClusterDeploymentCustomization in use")
+	}
+	cdc.Status = status
+	if err := r.Status().Update(context.Background(), cdc); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterDeploymentCustomization status")
+		return errors.Wrap(err, "could not update ClusterDeploymentCustomization status")
+	}
+	return nil
+}
+
+func equalCustomizationStatus(a, b hivev1.ClusterDeploymentCustomizationStatus) bool {
+	refName := func(ref *corev1.LocalObjectReference) string {
+		if ref == nil {
+			return ""
+		}
+		return ref.Name
+	}
+	timeEqual := (a.LastAppliedTime == nil) == (b.LastAppliedTime == nil) &&
+		(a.LastAppliedTime == nil || a.LastAppliedTime.Equal(b.LastAppliedTime))
+	return refName(a.ClusterDeploymentRef) == refName(b.ClusterDeploymentRef) &&
+		refName(a.ClusterPoolRef) == refName(b.ClusterPoolRef) &&
+		timeEqual
+}
+
+func (r *ReconcileClusterPool) setInventoryValidCondition(pool *hivev1.ClusterPool, err error, logger log.FieldLogger) error {
+	status := corev1.ConditionTrue
+	reason := "Valid"
+	message := "Inventory is valid"
+	if err != nil {
+		status = corev1.ConditionFalse
+		reason = "Invalid"
+		message = err.Error()
+	}
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolInventoryValidCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+	}
+	return nil
+}
+
+// applyCustomization patches the install-config Secret among the objects built for a new cluster with the
+// install-config patches of the customization.
+func applyCustomization(objs []runtime.Object, cdc *hivev1.ClusterDeploymentCustomization) error {
+	for _, obj := range objs {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			continue
+		}
+		installConfig, ok := secret.StringData[installConfigKey]
+		if !ok {
+			continue
+		}
+		patched, err := patchInstallConfig(installConfig, cdc.Spec.InstallConfigPatches)
+		if err != nil {
+			return errors.Wrapf(err, "could not apply ClusterDeploymentCustomization %s", cdc.Name)
+		}
+		secret.StringData[installConfigKey] = patched
+		return nil
+	}
+	return errors.New("could not find install-config secret to customize")
+}
+
+// patchInstallConfig applies the patches, as a JSON patch, to the YAML install-config.
+func patchInstallConfig(installConfig string, patches []hivev1.PatchEntity) (string, error) {
+	if len(patches) == 0 {
+		return installConfig, nil
+	}
+	ops := make([]map[string]interface{}, len(patches))
+	for i, p := range patches {
+		op := map[string]interface{}{
+			"op":   p.Op,
+			"path": p.Path,
+		}
+		switch p.Op {
+		case "add", "replace", "test":
+			op["value"] = p.Value
+		case "move", "copy":
+			op["from"] = p.From
+		}
+		ops[i] = op
+	}
+	patchJSON, err := json.Marshal(ops)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid install-config patch")
+	}
+	patch, err := jsonpatch.DecodePatch(patchJSON)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid install-config patch")
+	}
+	icJSON, err := yaml.YAMLToJSON([]byte(installConfig))
+	if err != nil {
+		return "", errors.Wrap(err, "could not parse install-config")
+	}
+	patchedJSON, err := patch.Apply(icJSON)
+	if err != nil {
+		return "", errors.Wrap(err, "could not patch install-config")
+	}
+	patchedYAML, err := yaml.JSONToYAML(patchedJSON)
+	if err != nil {
+		return "", err
+	}
+	return string(patchedYAML), nil
+}
+
+func customizationReference(cdc *hivev1.ClusterDeploymentCustomization) *corev1.LocalObjectReference {
+	if cdc == nil {
+		return nil
+	}
+	return &corev1.LocalObjectReference{Name: cdc.Name}
+}
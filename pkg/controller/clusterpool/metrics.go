@@ -0,0 +1,41 @@
+package clusterpool
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	metricStaleClusterDeploymentsDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_clusterpool_stale_clusterdeployments_deleted",
+		Help: "Counter incremented every time a stale unclaimed cluster is deleted to be replaced from the current pool spec.",
+	},
+		[]string{"clusterpool_namespace", "clusterpool_name"},
+	)
+	metricClustersRecycled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_clusterpool_clusters_recycled",
+		Help: "Counter incremented every time an unclaimed cluster is deleted to be replaced because it was too old or broken.",
+	},
+		[]string{"clusterpool_namespace", "clusterpool_name", "reason"},
+	)
+	metricClaimQuotaUsed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_claim_quota_used",
+		Help: "Number of clusters claimed from the pool under each claim quota.",
+	},
+		[]string{"clusterpool_namespace", "clusterpool_name", "quota"},
+	)
+	metricClaimsQuotaExceeded = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_claims_quota_exceeded",
+		Help: "Number of pending claims for the pool that are held back because they would exceed a claim quota.",
+	},
+		[]string{"clusterpool_namespace", "clusterpool_name"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricStaleClusterDeploymentsDeleted)
+	metrics.Registry.MustRegister(metricClustersRecycled)
+	metrics.Registry.MustRegister(metricClaimQuotaUsed)
+	metrics.Registry.MustRegister(metricClaimsQuotaExceeded)
+}
@@ -0,0 +1,107 @@
+package clusterpool
+
+import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// sortPendingClaims orders pending claims in the order in which they are to be assigned clusters: highest priority
+// first, and from oldest to youngest within a priority.
+func sortPendingClaims(claims []*hivev1.ClusterClaim) {
+	sort.SliceStable(claims, func(i, j int) bool {
+		if claims[i].Spec.Priority != claims[j].Spec.Priority {
+			return claims[i].Spec.Priority > claims[j].Spec.Priority
+		}
+		return claims[i].CreationTimestamp.Before(&claims[j].CreationTimestamp)
+	})
+}
+
+// waitEstimator estimates how long the pending claims of a pool will wait for a cluster.
+type waitEstimator struct {
+	// provisionTime is the average time taken by the clusters of the pool to install. Zero if no cluster of the pool
+	// has installed.
+	provisionTime time.Duration
+	// remaining is the estimated time remaining for each installing cluster to finish installing, shortest first.
+	remaining []time.Duration
+	// maxConcurrent is the number of clusters the pool may install at once. Zero if unlimited.
+	maxConcurrent int
+}
+
+// newWaitEstimator creates a waitEstimator from all of the clusters of the pool, claimed or not, and the unclaimed
+// clusters that are installing.
+func newWaitEstimator(clusters, installing []*hivev1.ClusterDeployment, maxConcurrent *int32, now time.Time) *waitEstimator {
+	e := &waitEstimator{}
+	if maxConcurrent != nil {
+		e.maxConcurrent = int(*maxConcurrent)
+	}
+	e.provisionTime = averageProvisionTime(clusters)
+	if e.provisionTime == 0 {
+		return e
+	}
+	for _, cd := range installing {
+		remaining := e.provisionTime - now.Sub(cd.CreationTimestamp.Time)
+		if remaining < 0 {
+			remaining = 0
+		}
+		e.remaining = append(e.remaining, remaining)
+	}
+	sort.Slice(e.remaining, func(i, j int) bool { return e.remaining[i] < e.remaining[j] })
+	return e
+}
+
+// averageProvisionTime returns the average time taken by the clusters that have installed to install. Returns zero if
+// none of the clusters has installed.
+func averageProvisionTime(clusters []*hivev1.ClusterDeployment) time.Duration {
+	var total time.Duration
+	var installed int
+	for _, cd := range clusters {
+		if cd.Status.InstalledTimestamp == nil {
+			continue
+		}
+		total += cd.Status.InstalledTimestamp.Sub(cd.CreationTimestamp.Time)
+		installed++
+	}
+	if installed == 0 {
+		return 0
+	}
+	return total / time.Duration(installed)
+}
+
+// estimate returns the estimated wait for the claim at the given position, starting at 1, in the queue of claims
+// waiting for a cluster. The claims at the front of the queue get the installing clusters as they finish. The claims
+// beyond those wait for new clusters to be installed, in batches of maxConcurrent clusters. Returns nil when there is
+// nothing to estimate from. The estimate is rounded to the minute.
+func (e *waitEstimator) estimate(position int) *metav1.Duration {
+	if e.provisionTime == 0 || position < 1 {
+		return nil
+	}
+	var wait time.Duration
+	if position <= len(e.remaining) {
+		wait = e.remaining[position-1]
+	} else {
+		batches := 1
+		if e.maxConcurrent > 0 {
+			batches = (position-len(e.remaining)-1)/e.maxConcurrent + 1
+		}
+		wait = time.Duration(batches) * e.provisionTime
+	}
+	return &metav1.Duration{Duration: wait.Round(time.Minute)}
+}
+
+func equalDurations(a, b *metav1.Duration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Duration == b.Duration
+}
+
+func equalInt32s(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
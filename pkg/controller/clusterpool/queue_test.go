@@ -0,0 +1,106 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestWaitEstimator(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	installedCD := func(name string, took time.Duration) *hivev1.ClusterDeployment {
+		cd := testcd.BasicBuilder().
+			GenericOptions(
+				testgeneric.WithName(name),
+				testgeneric.WithNamespace(name),
+				testgeneric.WithCreationTimestamp(now.Add(-2*time.Hour)),
+			).
+			Build(testcd.Installed())
+		cd.Status.InstalledTimestamp = &metav1.Time{Time: now.Add(-2 * time.Hour).Add(took)}
+		return cd
+	}
+	installingCD := func(name string, age time.Duration) *hivev1.ClusterDeployment {
+		return testcd.BasicBuilder().
+			GenericOptions(
+				testgeneric.WithName(name),
+				testgeneric.WithNamespace(name),
+				testgeneric.WithCreationTimestamp(now.Add(-age)),
+			).
+			Build()
+	}
+
+	cases := []struct {
+		name          string
+		clusters      []*hivev1.ClusterDeployment
+		installing    []*hivev1.ClusterDeployment
+		maxConcurrent *int32
+		expected      map[int]*time.Duration
+	}{
+		{
+			name:       "no installed clusters",
+			installing: []*hivev1.ClusterDeployment{installingCD("c1", 10*time.Minute)},
+			expected:   map[int]*time.Duration{1: nil, 2: nil},
+		},
+		{
+			name: "installing clusters",
+			clusters: []*hivev1.ClusterDeployment{
+				installedCD("c1", 30*time.Minute),
+				installedCD("c2", 50*time.Minute),
+			},
+			installing: []*hivev1.ClusterDeployment{
+				installingCD("c3", 10*time.Minute),
+				installingCD("c4", 30*time.Minute),
+				installingCD("c5", time.Hour),
+			},
+			expected: map[int]*time.Duration{
+				1: durationPtr(0),
+				2: durationPtr(10 * time.Minute),
+				3: durationPtr(30 * time.Minute),
+				4: durationPtr(40 * time.Minute),
+				5: durationPtr(40 * time.Minute),
+			},
+		},
+		{
+			name: "batches of max concurrent",
+			clusters: []*hivev1.ClusterDeployment{
+				installedCD("c1", 40*time.Minute),
+			},
+			installing: []*hivev1.ClusterDeployment{
+				installingCD("c2", 10*time.Minute),
+			},
+			maxConcurrent: pointer.Int32Ptr(2),
+			expected: map[int]*time.Duration{
+				1: durationPtr(30 * time.Minute),
+				2: durationPtr(40 * time.Minute),
+				3: durationPtr(40 * time.Minute),
+				4: durationPtr(80 * time.Minute),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusters := append(tc.clusters, tc.installing...)
+			e := newWaitEstimator(clusters, tc.installing, tc.maxConcurrent, now)
+			for position, expected := range tc.expected {
+				actual := e.estimate(position)
+				if expected == nil {
+					assert.Nil(t, actual, "unexpected estimate for position %d", position)
+				} else if assert.NotNil(t, actual, "missing estimate for position %d", position) {
+					assert.Equal(t, *expected, actual.Duration, "unexpected estimate for position %d", position)
+				}
+			}
+		})
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
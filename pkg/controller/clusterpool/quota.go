@@ -0,0 +1,105 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// claimQuotaUsage is the number of clusters claimed from a pool under a claim quota.
+type claimQuotaUsage struct {
+	quota *hivev1.ClusterPoolClaimQuota
+	used  int
+}
+
+// claimQuotaUsages returns the usage of each claim quota of the pool by the claims that have been assigned a cluster
+// from the pool.
+func (r *ReconcileClusterPool) claimQuotaUsages(pool *hivev1.ClusterPool, logger log.FieldLogger) ([]*claimQuotaUsage, error) {
+	if len(pool.Spec.ClaimQuotas) == 0 {
+		return nil, nil
+	}
+	claimsList := &hivev1.ClusterClaimList{}
+	if err := r.Client.List(context.Background(), claimsList, client.InNamespace(pool.Namespace)); err != nil {
+		logger.WithError(err).Error("error listing ClusterClaims")
+		return nil, err
+	}
+	usages := make([]*claimQuotaUsage, len(pool.Spec.ClaimQuotas))
+	for i := range pool.Spec.ClaimQuotas {
+		usages[i] = &claimQuotaUsage{quota: &pool.Spec.ClaimQuotas[i]}
+	}
+	for i := range claimsList.Items {
+		claim := &claimsList.Items[i]
+		if claim.Spec.Namespace == "" {
+			continue
+		}
+		if controllerutils.ClusterClaimPoolName(claim) != pool.Name {
+			continue
+		}
+		for _, usage := range usages {
+			if controllerutils.ClusterClaimMatchesQuota(claim, usage.quota) {
+				usage.used++
+			}
+		}
+	}
+	return usages, nil
+}
+
+// filterClaimsByQuota splits the pending claims, in the order in which they are to be assigned clusters, into those
+// that can be assigned a cluster within the claim quotas of the pool and those that would exceed a quota. The
+// usage of the quotas is updated as though each claim within quota has been assigned a cluster.
+func filterClaimsByQuota(claims []*hivev1.ClusterClaim, usages []*claimQuotaUsage) (withinQuota []*hivev1.ClusterClaim, overQuota map[*hivev1.ClusterClaim]*hivev1.ClusterPoolClaimQuota) {
+	if len(usages) == 0 {
+		return claims, nil
+	}
+	overQuota = map[*hivev1.ClusterClaim]*hivev1.ClusterPoolClaimQuota{}
+claims:
+	for _, claim := range claims {
+		for _, usage := range usages {
+			if controllerutils.ClusterClaimMatchesQuota(claim, usage.quota) && usage.used >= int(usage.quota.MaxClaimed) {
+				overQuota[claim] = usage.quota
+				continue claims
+			}
+		}
+		for _, usage := range usages {
+			if controllerutils.ClusterClaimMatchesQuota(claim, usage.quota) {
+				usage.used++
+			}
+		}
+		withinQuota = append(withinQuota, claim)
+	}
+	return withinQuota, overQuota
+}
+
+// setQuotaExceededClaims marks the claims that would exceed a claim quota as pending for that reason.
+func (r *ReconcileClusterPool) setQuotaExceededClaims(overQuota map[*hivev1.ClusterClaim]*hivev1.ClusterPoolClaimQuota, logger log.FieldLogger) error {
+	for claim, quota := range overQuota {
+		logger := logger.WithField("claim", claim.Name).WithField("quota", controllerutils.ClusterPoolClaimQuotaName(quota))
+		conds, changed := controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimPendingCondition,
+			corev1.ConditionTrue,
+			"QuotaExceeded",
+			fmt.Sprintf("Claim quota %s of %d clusters is in use", controllerutils.ClusterPoolClaimQuotaName(quota), quota.MaxClaimed),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if !changed && claim.Status.QueuePosition == nil && claim.Status.EstimatedWait == nil {
+			continue
+		}
+		logger.Debug("claim exceeds quota")
+		claim.Status.Conditions = conds
+		claim.Status.QueuePosition = nil
+		claim.Status.EstimatedWait = nil
+		if err := r.Status().Update(context.Background(), claim); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package clusterpool
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// brokenReason returns the reason for which an unclaimed cluster is broken according to the broken cluster policy of
+// the pool, or an empty reason if the cluster is not broken.
+func brokenReason(pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, now time.Time) hivev1.ClusterPoolRecycleReason {
+	if pool.Spec.BrokenClusterPolicy == nil {
+		return ""
+	}
+	threshold := pool.Spec.BrokenClusterPolicy.Threshold.Duration
+	failingFor := func(condType hivev1.ClusterDeploymentConditionType) bool {
+		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, condType)
+		return cond != nil && cond.Status == corev1.ConditionTrue && now.Sub(cond.LastTransitionTime.Time) >= threshold
+	}
+	switch {
+	case failingFor(hivev1.ProvisionStoppedCondition):
+		return hivev1.ProvisionStoppedRecycleReason
+	case failingFor(hivev1.ProvisionFailedCondition):
+		return hivev1.ProvisionFailedRecycleReason
+	// Hibernating clusters are expected to be unreachable.
+	case isRunning(cd) && failingFor(hivev1.UnreachableCondition):
+		return hivev1.UnreachableRecycleReason
+	}
+	return ""
+}
+
+// tooOld returns true if the unclaimed cluster is older than the max unclaimed age of the pool.
+func tooOld(pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, now time.Time) bool {
+	return pool.Spec.MaxUnclaimedAge != nil && now.Sub(cd.CreationTimestamp.Time) >= pool.Spec.MaxUnclaimedAge.Duration
+}
+
+// recycleClusters deletes the unclaimed clusters that are broken or too old, so that they are replaced. No more than
+// availableCurrent clusters are deleted at a time, broken clusters first and then those that are too old, oldest
+// first. Broken clusters that are still installing are always deleted, as they already count against availableCurrent.
+// The clusters that remain are returned, along with the number of clusters deleted for each reason.
+func (r *ReconcileClusterPool) recycleClusters(
+	clp *hivev1.ClusterPool,
+	installingClusters []*hivev1.ClusterDeployment,
+	readyClusters []*hivev1.ClusterDeployment,
+	availableCurrent int,
+	now time.Time,
+	logger log.FieldLogger,
+) (remainingInstalling, remainingReady []*hivev1.ClusterDeployment, recycled map[hivev1.ClusterPoolRecycleReason]int, err error) {
+	if clp.Spec.BrokenClusterPolicy == nil && clp.Spec.MaxUnclaimedAge == nil {
+		return installingClusters, readyClusters, nil, nil
+	}
+	toRecycle := map[*hivev1.ClusterDeployment]hivev1.ClusterPoolRecycleReason{}
+	for _, cd := range installingClusters {
+		if reason := brokenReason(clp, cd, now); reason != "" {
+			toRecycle[cd] = reason
+		}
+	}
+	var broken, old []*hivev1.ClusterDeployment
+	for _, clusters := range [][]*hivev1.ClusterDeployment{installingClusters, readyClusters} {
+		for _, cd := range clusters {
+			if _, ok := toRecycle[cd]; ok {
+				continue
+			}
+			if brokenReason(clp, cd, now) != "" {
+				broken = append(broken, cd)
+			} else if tooOld(clp, cd, now) {
+				old = append(old, cd)
+			}
+		}
+	}
+	byAge := func(clusters []*hivev1.ClusterDeployment) {
+		sort.SliceStable(clusters, func(i, j int) bool {
+			return clusters[i].CreationTimestamp.Before(&clusters[j].CreationTimestamp)
+		})
+	}
+	byAge(broken)
+	byAge(old)
+	candidates := append(broken, old...)
+	if len(candidates) > availableCurrent {
+		logger.WithFields(log.Fields{
+			"broken":    len(broken),
+			"old":       len(old),
+			"available": availableCurrent,
+		}).Info("not recycling all broken and old clusters as max concurrent quota exceeded")
+		candidates = candidates[:availableCurrent]
+	}
+	for _, cd := range candidates {
+		if reason := brokenReason(clp, cd, now); reason != "" {
+			toRecycle[cd] = reason
+		} else {
+			toRecycle[cd] = hivev1.MaxUnclaimedAgeExceededRecycleReason
+		}
+	}
+
+	recycled = map[hivev1.ClusterPoolRecycleReason]int{}
+	remove := func(clusters []*hivev1.ClusterDeployment) ([]*hivev1.ClusterDeployment, error) {
+		var remaining []*hivev1.ClusterDeployment
+		for _, cd := range clusters {
+			reason, ok := toRecycle[cd]
+			if !ok {
+				remaining = append(remaining, cd)
+				continue
+			}
+			cdLog := logger.WithField("cluster", cd.Name).WithField("reason", reason)
+			cdLog.Info("recycling cluster deployment")
+			if err := r.Client.Delete(context.Background(), cd); err != nil {
+				cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting cluster deployment")
+				return nil, err
+			}
+			metricClustersRecycled.WithLabelValues(clp.Namespace, clp.Name, string(reason)).Inc()
+			recycled[reason]++
+		}
+		return remaining, nil
+	}
+	if remainingInstalling, err = remove(installingClusters); err != nil {
+		return nil, nil, recycled, err
+	}
+	if remainingReady, err = remove(readyClusters); err != nil {
+		return nil, nil, recycled, err
+	}
+	return remainingInstalling, remainingReady, recycled, nil
+}
+
+// addRecycledCounts adds the number of clusters recycled for each reason to the status of the pool.
+func addRecycledCounts(status *hivev1.ClusterPoolStatus, recycled map[hivev1.ClusterPoolRecycleReason]int) {
+	for reason, count := range recycled {
+		found := false
+		for i := range status.Recycled {
+			if status.Recycled[i].Reason == reason {
+				status.Recycled[i].Count += int32(count)
+				found = true
+				break
+			}
+		}
+		if !found {
+			status.Recycled = append(status.Recycled, hivev1.ClusterPoolRecycledClusters{Reason: reason, Count: int32(count)})
+		}
+	}
+	sort.Slice(status.Recycled, func(i, j int) bool {
+		return status.Recycled[i].Reason < status.Recycled[j].Reason
+	})
+}
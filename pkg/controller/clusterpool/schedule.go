@@ -0,0 +1,112 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/util/schedule"
+)
+
+// poolSizing is the size and max concurrent in effect for a pool.
+type poolSizing struct {
+	size          int32
+	maxConcurrent *int32
+	// schedule is the active schedule, if any.
+	schedule *hivev1.ClusterPoolSchedule
+	// until is the time at which the active schedule ends.
+	until time.Time
+	// nextChange is the next time at which the sizing may change. Zero if the sizing will not change.
+	nextChange time.Time
+}
+
+// activeSizing determines the size and max concurrent of the pool at the given time, taking into account its
+// schedules. Schedules that cannot be parsed are ignored and returned in the error.
+func activeSizing(pool *hivev1.ClusterPool, now time.Time) (*poolSizing, error) {
+	sizing := &poolSizing{
+		size:          pool.Spec.Size,
+		maxConcurrent: pool.Spec.MaxConcurrent,
+	}
+	var errs []string
+	for i := range pool.Spec.Schedules {
+		s := &pool.Spec.Schedules[i]
+		sched, err := schedule.Parse(s.Start)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", s.Name, err))
+			continue
+		}
+		// The schedule is active if it started within the last Duration.
+		if start := sched.Next(now.Add(-s.Duration.Duration)); !start.IsZero() && !start.After(now) {
+			end := start.Add(s.Duration.Duration)
+			sizing.updateNextChange(end)
+			if sizing.schedule == nil {
+				sizing.schedule = s
+				sizing.until = end
+				sizing.size = s.Size
+				if s.MaxConcurrent != nil {
+					sizing.maxConcurrent = s.MaxConcurrent
+				}
+			}
+		}
+		sizing.updateNextChange(sched.Next(now))
+	}
+	if len(errs) > 0 {
+		return sizing, fmt.Errorf("invalid schedules: %v", errs)
+	}
+	return sizing, nil
+}
+
+func (s *poolSizing) updateNextChange(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	if s.nextChange.IsZero() || t.Before(s.nextChange) {
+		s.nextChange = t
+	}
+}
+
+// setScheduleActiveCondition records the active schedule in the status of the pool.
+func (r *ReconcileClusterPool) setScheduleActiveCondition(pool *hivev1.ClusterPool, sizing *poolSizing, scheduleErr error, logger log.FieldLogger) error {
+	status := corev1.ConditionFalse
+	reason := "NoScheduleActive"
+	message := "No schedule is active"
+	activeSchedule := ""
+	switch {
+	case sizing.schedule != nil:
+		status = corev1.ConditionTrue
+		reason = "ScheduleActive"
+		message = fmt.Sprintf("Schedule %s is active with size %d until %s",
+			sizing.schedule.Name, sizing.size, sizing.until.UTC().Format(time.RFC3339))
+		activeSchedule = sizing.schedule.Name
+	case scheduleErr != nil:
+		reason = "InvalidSchedule"
+		message = scheduleErr.Error()
+	}
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolScheduleActiveCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed || pool.Status.ActiveSchedule != activeSchedule {
+		if sizing.schedule != nil && pool.Status.ActiveSchedule != activeSchedule {
+			logger.WithField("schedule", activeSchedule).Info("schedule is now active")
+		}
+		pool.Status.Conditions = conds
+		pool.Status.ActiveSchedule = activeSchedule
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+)
+
+func TestActiveSizing(t *testing.T) {
+	// Monday
+	now := time.Date(2021, time.March, 1, 9, 30, 0, 0, time.UTC)
+	pointer := func(i int32) *int32 { return &i }
+
+	tests := []struct {
+		name                  string
+		pool                  *hivev1.ClusterPool
+		expectError           bool
+		expectedSize          int32
+		expectedMaxConcurrent *int32
+		expectedSchedule      string
+		expectedNextChange    time.Time
+	}{
+		{
+			name:         "no schedules",
+			pool:         testcp.Build(testcp.WithSize(2)),
+			expectedSize: 2,
+		},
+		{
+			name: "business hours active",
+			pool: testcp.Build(
+				testcp.WithSize(0),
+				testcp.WithMaxConcurrent(1),
+				testcp.WithSchedule("business-hours", "0 8 * * 1-5", 10*time.Hour, 10),
+			),
+			expectedSize:          10,
+			expectedMaxConcurrent: pointer(1),
+			expectedSchedule:      "business-hours",
+			expectedNextChange:    time.Date(2021, time.March, 1, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "business hours not yet active",
+			pool: testcp.Build(
+				testcp.WithSize(0),
+				testcp.WithSchedule("business-hours", "0 10 * * 1-5", 8*time.Hour, 10),
+			),
+			expectedSize:       0,
+			expectedNextChange: time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekend schedule not active",
+			pool: testcp.Build(
+				testcp.WithSize(3),
+				testcp.WithSchedule("weekend", "0 0 * * 6", 48*time.Hour, 0),
+			),
+			expectedSize:       3,
+			expectedNextChange: time.Date(2021, time.March, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "schedule overrides max concurrent",
+			pool: func() *hivev1.ClusterPool {
+				pool := testcp.Build(
+					testcp.WithSize(0),
+					testcp.WithMaxConcurrent(1),
+					testcp.WithSchedule("business-hours", "0 8 * * 1-5", 10*time.Hour, 10),
+				)
+				pool.Spec.Schedules[0].MaxConcurrent = pointer(5)
+				return pool
+			}(),
+			expectedSize:          10,
+			expectedMaxConcurrent: pointer(5),
+			expectedSchedule:      "business-hours",
+			expectedNextChange:    time.Date(2021, time.March, 1, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone",
+			pool: testcp.Build(
+				testcp.WithSize(0),
+				testcp.WithSchedule("business-hours", "CRON_TZ=America/New_York 0 8 * * 1-5", 10*time.Hour, 10),
+			),
+			expectedSize:       0,
+			expectedNextChange: time.Date(2021, time.March, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "invalid schedule ignored",
+			pool: testcp.Build(
+				testcp.WithSize(0),
+				testcp.WithSchedule("invalid", "0 8 * *", time.Hour, 5),
+				testcp.WithSchedule("business-hours", "0 8 * * 1-5", 10*time.Hour, 10),
+			),
+			expectError:        true,
+			expectedSize:       10,
+			expectedSchedule:   "business-hours",
+			expectedNextChange: time.Date(2021, time.March, 1, 18, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sizing, err := activeSizing(test.pool, now)
+			if test.expectError {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+			assert.Equal(t, test.expectedSize, sizing.size, "unexpected size")
+			assert.Equal(t, test.expectedMaxConcurrent, sizing.maxConcurrent, "unexpected max concurrent")
+			if test.expectedSchedule == "" {
+				assert.Nil(t, sizing.schedule, "expected no active schedule")
+			} else if assert.NotNil(t, sizing.schedule, "expected active schedule") {
+				assert.Equal(t, test.expectedSchedule, sizing.schedule.Name, "unexpected active schedule")
+			}
+			assert.True(t, test.expectedNextChange.Equal(sizing.nextChange), "unexpected next change: %v", sizing.nextChange)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package clusterpool
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// failingVariantInstallRestarts is the number of times an installing cluster must have restarted its install,
+	// with its ProvisionFailed condition true, for its platform variant to be considered failing.
+	failingVariantInstallRestarts = 2
+
+	// failingVariantBackoff is the time for which a platform variant is skipped after it is first found failing. The
+	// time doubles with each consecutive failure, up to failingVariantMaxBackoff.
+	failingVariantBackoff    = 15 * time.Minute
+	failingVariantMaxBackoff = 8 * time.Hour
+)
+
+// platformVariants tracks the unclaimed clusters of a pool created from each of its platform variants, and the
+// failures of each variant, in order to choose the variant for new clusters.
+type platformVariants struct {
+	variants []hivev1.ClusterPoolPlatformVariant
+	size     map[string]int
+	ready    map[string]int
+	failures map[string]*hivev1.ClusterPoolPlatformVariantStatus
+	now      time.Time
+}
+
+// newPlatformVariants creates a platformVariants from the unclaimed clusters of the pool that are installing and
+// ready, and the failures of the variants recorded in the status of the pool. Returns nil if the pool has no platform
+// variants.
+func newPlatformVariants(pool *hivev1.ClusterPool, installing, ready []*hivev1.ClusterDeployment, now time.Time) *platformVariants {
+	if len(pool.Spec.PlatformVariants) == 0 {
+		return nil
+	}
+	v := &platformVariants{
+		variants: pool.Spec.PlatformVariants,
+		size:     map[string]int{},
+		ready:    map[string]int{},
+		failures: map[string]*hivev1.ClusterPoolPlatformVariantStatus{},
+		now:      now,
+	}
+	for i := range pool.Status.PlatformVariants {
+		status := pool.Status.PlatformVariants[i]
+		v.failures[status.Name] = &hivev1.ClusterPoolPlatformVariantStatus{
+			ConsecutiveFailures: status.ConsecutiveFailures,
+			LastFailureTime:     status.LastFailureTime,
+			RetryTime:           status.RetryTime,
+		}
+	}
+	for _, variant := range v.variants {
+		if v.failures[variant.Name] == nil {
+			v.failures[variant.Name] = &hivev1.ClusterPoolPlatformVariantStatus{}
+		}
+	}
+
+	failingProvisions := map[string]bool{}
+	for _, cd := range installing {
+		name := cd.Labels[constants.ClusterPoolPlatformVariantLabel]
+		v.size[name]++
+		if isFailingProvision(cd) {
+			failingProvisions[name] = true
+		}
+	}
+	installedSinceFailure := map[string]bool{}
+	for _, cd := range ready {
+		name := cd.Labels[constants.ClusterPoolPlatformVariantLabel]
+		v.size[name]++
+		v.ready[name]++
+		if f := v.failures[name]; f != nil && f.LastFailureTime != nil && cd.Status.InstalledTimestamp != nil &&
+			cd.Status.InstalledTimestamp.After(f.LastFailureTime.Time) {
+			installedSinceFailure[name] = true
+		}
+	}
+
+	for _, variant := range v.variants {
+		f := v.failures[variant.Name]
+		switch {
+		// While clusters of the variant keep failing, a further failure is only counted once the variant is due to be
+		// retried, so that the backoff grows once per retry.
+		case failingProvisions[variant.Name] && (f.RetryTime == nil || !now.Before(f.RetryTime.Time)):
+			f.ConsecutiveFailures++
+			f.LastFailureTime = &metav1.Time{Time: now}
+			f.RetryTime = &metav1.Time{Time: now.Add(variantBackoff(f.ConsecutiveFailures))}
+		case !failingProvisions[variant.Name] && installedSinceFailure[variant.Name]:
+			f.ConsecutiveFailures = 0
+			f.LastFailureTime = nil
+			f.RetryTime = nil
+		}
+	}
+	return v
+}
+
+// isFailingProvision returns true if the installing cluster keeps failing to provision.
+func isFailingProvision(cd *hivev1.ClusterDeployment) bool {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionFailedCondition)
+	return cond != nil && cond.Status == corev1.ConditionTrue && cd.Status.InstallRestarts >= failingVariantInstallRestarts
+}
+
+// variantBackoff returns the time for which a variant is skipped after the given number of consecutive failures.
+func variantBackoff(failures int32) time.Duration {
+	backoff := failingVariantBackoff
+	for i := int32(1); i < failures && backoff < failingVariantMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > failingVariantMaxBackoff {
+		backoff = failingVariantMaxBackoff
+	}
+	return backoff
+}
+
+func variantWeight(variant *hivev1.ClusterPoolPlatformVariant) int {
+	if variant.Weight == nil {
+		return 1
+	}
+	return int(*variant.Weight)
+}
+
+// failing returns true if the variant is skipped because of its failures.
+func (v *platformVariants) failing(name string) bool {
+	f := v.failures[name]
+	return f != nil && f.RetryTime != nil && v.now.Before(f.RetryTime.Time)
+}
+
+// next chooses the variant for a new cluster, and counts the new cluster against it. The variant chosen is the one
+// holding the smallest share of the clusters relative to its weight, skipping variants of weight 0 and failing
+// variants unless all of the variants of non-zero weight are failing. Ties go to the variant listed first. Returns nil
+// if every variant has weight 0.
+func (v *platformVariants) next() *hivev1.ClusterPoolPlatformVariant {
+	allFailing := true
+	for i := range v.variants {
+		if variantWeight(&v.variants[i]) > 0 && !v.failing(v.variants[i].Name) {
+			allFailing = false
+			break
+		}
+	}
+	var chosen *hivev1.ClusterPoolPlatformVariant
+	for i := range v.variants {
+		variant := &v.variants[i]
+		if variantWeight(variant) == 0 || v.failing(variant.Name) && !allFailing {
+			continue
+		}
+		// Compare size/weight without dividing.
+		if chosen == nil || v.size[variant.Name]*variantWeight(chosen) < v.size[chosen.Name]*variantWeight(variant) {
+			chosen = variant
+		}
+	}
+	if chosen != nil {
+		v.size[chosen.Name]++
+	}
+	return chosen
+}
+
+// nextRetry returns the earliest time at which a failing variant is used again, or the zero time if no variant is
+// failing.
+func (v *platformVariants) nextRetry() time.Time {
+	var next time.Time
+	if v == nil {
+		return next
+	}
+	for _, variant := range v.variants {
+		if !v.failing(variant.Name) {
+			continue
+		}
+		if retry := v.failures[variant.Name].RetryTime.Time; next.IsZero() || retry.Before(next) {
+			next = retry
+		}
+	}
+	return next
+}
+
+// status returns the status of each of the variants.
+func (v *platformVariants) status() []hivev1.ClusterPoolPlatformVariantStatus {
+	if v == nil {
+		return nil
+	}
+	status := make([]hivev1.ClusterPoolPlatformVariantStatus, len(v.variants))
+	for i, variant := range v.variants {
+		f := v.failures[variant.Name]
+		status[i] = hivev1.ClusterPoolPlatformVariantStatus{
+			Name:                variant.Name,
+			Size:                int32(v.size[variant.Name]),
+			Ready:               int32(v.ready[variant.Name]),
+			Failing:             v.failing(variant.Name),
+			ConsecutiveFailures: f.ConsecutiveFailures,
+			LastFailureTime:     f.LastFailureTime,
+			RetryTime:           f.RetryTime,
+		}
+	}
+	return status
+}
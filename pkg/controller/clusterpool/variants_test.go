@@ -0,0 +1,281 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+)
+
+func variantCD(variant string, opts ...testcd.Option) *hivev1.ClusterDeployment {
+	return testcd.BasicBuilder().
+		Options(testcd.WithLabel(constants.ClusterPoolPlatformVariantLabel, variant)).
+		Build(opts...)
+}
+
+func failingVariantCD(variant string) *hivev1.ClusterDeployment {
+	return variantCD(variant,
+		testcd.InstallRestarts(failingVariantInstallRestarts),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.ProvisionFailedCondition,
+			Status: corev1.ConditionTrue,
+		}),
+	)
+}
+
+func installedVariantCD(variant string, installed time.Time) *hivev1.ClusterDeployment {
+	cd := variantCD(variant, testcd.Installed())
+	cd.Status.InstalledTimestamp = &metav1.Time{Time: installed}
+	return cd
+}
+
+// withVariantFailures records failures of the variant in the status of the pool.
+func withVariantFailures(name string, failures int, lastFailure, retry time.Time) testcp.Option {
+	return func(pool *hivev1.ClusterPool) {
+		pool.Status.PlatformVariants = append(pool.Status.PlatformVariants, hivev1.ClusterPoolPlatformVariantStatus{
+			Name:                name,
+			ConsecutiveFailures: int32(failures),
+			LastFailureTime:     &metav1.Time{Time: lastFailure},
+			RetryTime:           &metav1.Time{Time: retry},
+		})
+	}
+}
+
+func TestPlatformVariantsNext(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		pool       *hivev1.ClusterPool
+		installing []*hivev1.ClusterDeployment
+		ready      []*hivev1.ClusterDeployment
+		count      int
+		expected   []string
+	}{
+		{
+			name: "no variants",
+			pool: testcp.BasicBuilder().Build(),
+		},
+		{
+			name: "equal weights",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				testcp.WithPlatformVariant("b", 1, "r2", ""),
+			),
+			count:    4,
+			expected: []string{"a", "b", "a", "b"},
+		},
+		{
+			name: "default weight",
+			pool: func() *hivev1.ClusterPool {
+				pool := testcp.BasicBuilder().Build(
+					testcp.WithPlatformVariant("a", 1, "r1", ""),
+					testcp.WithPlatformVariant("b", 2, "r2", ""),
+				)
+				pool.Spec.PlatformVariants[0].Weight = nil
+				return pool
+			}(),
+			count:    3,
+			expected: []string{"a", "b", "b"},
+		},
+		{
+			name: "zero weight",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 0, "r1", ""),
+				testcp.WithPlatformVariant("b", 1, "r2", ""),
+			),
+			count:    3,
+			expected: []string{"b", "b", "b"},
+		},
+		{
+			name: "existing clusters",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				testcp.WithPlatformVariant("b", 1, "r2", ""),
+			),
+			installing: []*hivev1.ClusterDeployment{variantCD("a")},
+			ready:      []*hivev1.ClusterDeployment{variantCD("a"), variantCD("")},
+			count:      3,
+			expected:   []string{"b", "b", "a"},
+		},
+		{
+			name: "failing variant skipped",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				testcp.WithPlatformVariant("b", 1, "r2", ""),
+			),
+			installing: []*hivev1.ClusterDeployment{failingVariantCD("b")},
+			count:      2,
+			expected:   []string{"a", "a"},
+		},
+		{
+			name: "variant skipped until retry time after failing clusters are gone",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				testcp.WithPlatformVariant("b", 1, "r2", ""),
+				withVariantFailures("b", 1, now.Add(-time.Minute), now.Add(10*time.Minute)),
+			),
+			count:    2,
+			expected: []string{"a", "a"},
+		},
+		{
+			name: "variant retried after retry time",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				testcp.WithPlatformVariant("b", 1, "r2", ""),
+				withVariantFailures("b", 1, now.Add(-20*time.Minute), now.Add(-5*time.Minute)),
+			),
+			count:    2,
+			expected: []string{"a", "b"},
+		},
+		{
+			name: "all variants failing",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				testcp.WithPlatformVariant("b", 1, "r2", ""),
+			),
+			installing: []*hivev1.ClusterDeployment{failingVariantCD("a"), failingVariantCD("b"), failingVariantCD("b")},
+			count:      2,
+			expected:   []string{"a", "a"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			variants := newPlatformVariants(tc.pool, tc.installing, tc.ready, now)
+			if tc.expected == nil {
+				assert.Nil(t, variants, "expected no platform variants")
+				return
+			}
+			var actual []string
+			for i := 0; i < tc.count; i++ {
+				actual = append(actual, variants.next().Name)
+			}
+			assert.Equal(t, tc.expected, actual, "unexpected variants chosen")
+		})
+	}
+}
+
+func TestPlatformVariantsFailures(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name                string
+		pool                *hivev1.ClusterPool
+		installing          []*hivev1.ClusterDeployment
+		ready               []*hivev1.ClusterDeployment
+		expectedFailing     bool
+		expectedFailures    int32
+		expectedLastFailure *time.Time
+		expectedRetry       *time.Time
+	}{
+		{
+			name: "first failure",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+			),
+			installing:          []*hivev1.ClusterDeployment{failingVariantCD("a")},
+			expectedFailing:     true,
+			expectedFailures:    1,
+			expectedLastFailure: timePtr(now),
+			expectedRetry:       timePtr(now.Add(failingVariantBackoff)),
+		},
+		{
+			name: "failing clusters not counted again before retry time",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				withVariantFailures("a", 1, now.Add(-time.Minute), now.Add(14*time.Minute)),
+			),
+			installing:          []*hivev1.ClusterDeployment{failingVariantCD("a")},
+			expectedFailing:     true,
+			expectedFailures:    1,
+			expectedLastFailure: timePtr(now.Add(-time.Minute)),
+			expectedRetry:       timePtr(now.Add(14 * time.Minute)),
+		},
+		{
+			name: "backoff doubles on failure after retry time",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				withVariantFailures("a", 2, now.Add(-time.Hour), now.Add(-30*time.Minute)),
+			),
+			installing:          []*hivev1.ClusterDeployment{failingVariantCD("a")},
+			expectedFailing:     true,
+			expectedFailures:    3,
+			expectedLastFailure: timePtr(now),
+			expectedRetry:       timePtr(now.Add(4 * failingVariantBackoff)),
+		},
+		{
+			name: "backoff capped",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				withVariantFailures("a", 20, now.Add(-10*time.Hour), now.Add(-2*time.Hour)),
+			),
+			installing:          []*hivev1.ClusterDeployment{failingVariantCD("a")},
+			expectedFailing:     true,
+			expectedFailures:    21,
+			expectedLastFailure: timePtr(now),
+			expectedRetry:       timePtr(now.Add(failingVariantMaxBackoff)),
+		},
+		{
+			name: "failures kept after failing clusters are gone",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				withVariantFailures("a", 1, now.Add(-time.Minute), now.Add(14*time.Minute)),
+			),
+			expectedFailing:     true,
+			expectedFailures:    1,
+			expectedLastFailure: timePtr(now.Add(-time.Minute)),
+			expectedRetry:       timePtr(now.Add(14 * time.Minute)),
+		},
+		{
+			name: "failures kept when cluster installed before last failure",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				withVariantFailures("a", 2, now.Add(-time.Hour), now.Add(-30*time.Minute)),
+			),
+			ready:               []*hivev1.ClusterDeployment{installedVariantCD("a", now.Add(-2*time.Hour))},
+			expectedFailures:    2,
+			expectedLastFailure: timePtr(now.Add(-time.Hour)),
+			expectedRetry:       timePtr(now.Add(-30 * time.Minute)),
+		},
+		{
+			name: "failures reset when cluster installed after last failure",
+			pool: testcp.BasicBuilder().Build(
+				testcp.WithPlatformVariant("a", 1, "r1", ""),
+				withVariantFailures("a", 2, now.Add(-time.Hour), now.Add(-30*time.Minute)),
+			),
+			ready: []*hivev1.ClusterDeployment{installedVariantCD("a", now.Add(-10*time.Minute))},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			variants := newPlatformVariants(tc.pool, tc.installing, tc.ready, now)
+			status := variants.status()
+			if !assert.Len(t, status, 1, "unexpected variant status") {
+				return
+			}
+			assert.Equal(t, tc.expectedFailing, status[0].Failing, "unexpected failing")
+			assert.Equal(t, tc.expectedFailures, status[0].ConsecutiveFailures, "unexpected consecutive failures")
+			assertTime(t, tc.expectedLastFailure, status[0].LastFailureTime, "unexpected last failure time")
+			assertTime(t, tc.expectedRetry, status[0].RetryTime, "unexpected retry time")
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func assertTime(t *testing.T, expected *time.Time, actual *metav1.Time, msg string) {
+	if expected == nil {
+		assert.Nil(t, actual, msg)
+	} else if assert.NotNil(t, actual, msg) {
+		assert.Equal(t, *expected, actual.Time, msg)
+	}
+}
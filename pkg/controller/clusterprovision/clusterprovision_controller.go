@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -45,6 +46,9 @@ const (
 	resultFailure = "failure"
 
 	podStatusCheckDelay = 60 * time.Second
+
+	// unknownInstallVersion is the version reported for install phases when the version installed is not known.
+	unknownInstallVersion = "unknown"
 )
 
 var (
@@ -63,11 +67,20 @@ var (
 	},
 		[]string{"cluster_type", "reason"},
 	)
+	metricInstallPhaseDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_cluster_provision_install_phase_duration_seconds",
+			Help:    "Distribution of the time taken by each phase of cluster installs.",
+			Buckets: []float64{60, 120, 300, 600, 900, 1200, 1800, 2700, 3600},
+		},
+		[]string{"platform", "version", "phase"},
+	)
 )
 
 func init() {
 	metrics.Registry.MustRegister(metricInstallErrors)
 	metrics.Registry.MustRegister(metricClusterProvisionsTotal)
+	metrics.Registry.MustRegister(metricInstallPhaseDurationSeconds)
 }
 
 // Add creates a new ClusterProvision Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -280,6 +293,10 @@ func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.Cluster
 
 	switch {
 	case controllerutils.IsSuccessful(job):
+		if instance.Spec.DryRun {
+			pLog.Info("install job completed dry run")
+			return r.transitionStage(instance, hivev1.ClusterProvisionStageComplete, "DryRunComplete", "Dry run has rendered the install assets", pLog)
+		}
 		if instance.Spec.Stage == hivev1.ClusterProvisionStageInitializing {
 			pLog.Error("install job completed without completing initialization")
 			return r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, "InitializationNotComplete", "Install job completed without completing initialization", pLog)
@@ -376,25 +393,66 @@ func (r *ReconcileClusterProvision) reconcileSuccessfulJob(instance *hivev1.Clus
 	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageComplete, "InstallComplete", "Install job has completed successfully", pLog)
 	if err == nil {
 		metricClusterProvisionsTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), resultSuccess).Inc()
+		r.observeInstallPhaseDurations(instance, pLog)
 	}
 	return result, err
 }
 
 func (r *ReconcileClusterProvision) reconcileFailedJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("install job failed")
-	reason, message := r.parseInstallLog(instance.Spec.InstallLog, pLog)
+	reason, message, retryPolicy := r.parseInstallLog(instance.Spec.InstallLog, pLog)
 	if controllerutils.IsDeadlineExceeded(job) && reason == unknownReason {
 		reason, message = "AttemptDeadlineExceeded", "Install job failed due to deadline being exceeded for the attempt"
 	}
+	// The retry policy is saved along with the Failed condition when transitioning stage.
+	instance.Status.RetryPolicy = retryPolicy
 	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, reason, message, pLog)
 	if err == nil {
 		// Increment a counter metric for this cluster type and error reason:
 		metricInstallErrors.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), reason).Inc()
 		metricClusterProvisionsTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), resultFailure).Inc()
+		r.observeInstallPhaseDurations(instance, pLog)
 	}
 	return result, err
 }
 
+// observeInstallPhaseDurations reports the durations of the completed install phases of a finished provision, labeled
+// with the platform of the provision and the version installed by its ClusterDeployment.
+func (r *ReconcileClusterProvision) observeInstallPhaseDurations(instance *hivev1.ClusterProvision, pLog log.FieldLogger) {
+	if len(instance.Status.InstallPhases) == 0 {
+		return
+	}
+	version := unknownInstallVersion
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.ClusterDeploymentRef.Name}, cd); err != nil {
+		pLog.WithError(err).Warn("could not get clusterdeployment to report install phase durations")
+	} else {
+		version = installVersionMajorMinor(cd)
+	}
+	for _, phase := range instance.Status.InstallPhases {
+		if phase.CompletionTime == nil {
+			continue
+		}
+		metricInstallPhaseDurationSeconds.WithLabelValues(
+			instance.Labels[hivev1.HiveClusterPlatformLabel],
+			version,
+			string(phase.Name),
+		).Observe(phase.CompletionTime.Sub(phase.StartTime.Time).Seconds())
+	}
+}
+
+// installVersionMajorMinor returns the major and minor version of the release installed by the ClusterDeployment.
+func installVersionMajorMinor(cd *hivev1.ClusterDeployment) string {
+	if cd.Status.InstallVersion == nil {
+		return unknownInstallVersion
+	}
+	version, err := semver.ParseTolerant(*cd.Status.InstallVersion)
+	if err != nil {
+		return unknownInstallVersion
+	}
+	return fmt.Sprintf("%d.%d", version.Major, version.Minor)
+}
+
 func (r *ReconcileClusterProvision) startProvisioning(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("provision initialization complete")
 	return r.transitionStage(instance, hivev1.ClusterProvisionStageProvisioning, "InitializationComplete", "Install job has completed its initialization. Provisioning started.", pLog)
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -127,6 +130,15 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			expectedStage:      hivev1.ClusterProvisionStageFailed,
 			expectedFailReason: "InitializationNotComplete",
 		},
+		{
+			name: "completed dry run job",
+			existing: []runtime.Object{
+				testProvision(withJob(), dryRun()),
+				testJob(completed()),
+				testPod("foo", success()),
+			},
+			expectedStage: hivev1.ClusterProvisionStageComplete,
+		},
 		{
 			name: "failed job",
 			existing: []runtime.Object{
@@ -136,6 +148,10 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			},
 			expectedStage:      hivev1.ClusterProvisionStageFailed,
 			expectedFailReason: unknownReason,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				assert.Equal(t, hivev1.RetryWithBackoffInstallFailureRetryPolicy, provision.Status.RetryPolicy, "unexpected retry policy")
+			},
 		},
 		{
 			name: "deadline exceeded job",
@@ -380,6 +396,12 @@ func withJob() provisionOption {
 	}
 }
 
+func dryRun() provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Spec.DryRun = true
+	}
+}
+
 func withCreationTime(creationTime time.Time) provisionOption {
 	return func(p *hivev1.ClusterProvision) {
 		p.CreationTimestamp.Time = creationTime
@@ -530,3 +552,71 @@ func assertConditionReason(t *testing.T, cd *hivev1.ClusterProvision, condType h
 	}
 	t.Errorf("did not find expected condition type: %v", condType)
 }
+
+func TestObserveInstallPhaseDurations(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	cases := []struct {
+		name            string
+		installVersion  *string
+		expectedVersion string
+	}{
+		{
+			name:            "install version",
+			installVersion:  pointer.StringPtr("4.8.2"),
+			expectedVersion: "4.8",
+		},
+		{
+			name:            "pre-release install version",
+			installVersion:  pointer.StringPtr("4.9.0-rc.1"),
+			expectedVersion: "4.9",
+		},
+		{
+			name:            "no install version",
+			expectedVersion: unknownInstallVersion,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			metricInstallPhaseDurationSeconds.Reset()
+			cd := &hivev1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testDeploymentName},
+			}
+			cd.Status.InstallVersion = tc.installVersion
+			start := metav1.NewTime(time.Now().Add(-time.Hour))
+			end := metav1.NewTime(start.Add(10 * time.Minute))
+			provision := testProvision(succeeded(), func(p *hivev1.ClusterProvision) {
+				p.Labels[hivev1.HiveClusterPlatformLabel] = "aws"
+				p.Status.InstallPhases = []hivev1.InstallPhase{
+					{Name: hivev1.InfrastructureInstallPhase, StartTime: start, CompletionTime: &end},
+					{Name: hivev1.WaitingForAPIInstallPhase, StartTime: end},
+				}
+			})
+			rcp := &ReconcileClusterProvision{
+				Client: fake.NewFakeClient(cd, provision),
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", "clusterProvision"),
+			}
+
+			rcp.observeInstallPhaseDurations(provision, rcp.logger)
+
+			metrics := make(chan prometheus.Metric, 10)
+			metricInstallPhaseDurationSeconds.Collect(metrics)
+			close(metrics)
+			var labels []map[string]string
+			for m := range metrics {
+				out := &dto.Metric{}
+				require.NoError(t, m.Write(out), "unexpected error reading metric")
+				l := map[string]string{}
+				for _, pair := range out.Label {
+					l[pair.GetName()] = pair.GetValue()
+				}
+				labels = append(labels, l)
+			}
+			assert.Equal(t, []map[string]string{{
+				"platform": "aws",
+				"version":  tc.expectedVersion,
+				"phase":    string(hivev1.InfrastructureInstallPhase),
+			}}, labels, "unexpected install phase metrics")
+		})
+	}
+}
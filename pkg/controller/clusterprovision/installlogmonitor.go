@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
@@ -24,12 +25,18 @@ const (
 	unknownMessage               = "Cluster install failed but no known errors found in logs"
 )
 
-// parseInstallLog parses install log to monitor for known issues.
-func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string) {
+// parseInstallLog parses install log to monitor for known issues. Returns the reason and message for the failure,
+// along with the retry policy for it.
+func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string, hivev1.InstallFailureRetryPolicy) {
 	if log == nil {
-		return unknownReason, logMissingMessage
+		return unknownReason, logMissingMessage, hivev1.RetryWithBackoffInstallFailureRetryPolicy
 	}
 
+	// Apply the additional redaction rules before the log is written to the hive log or used as a message. The
+	// install pod applies them as well, but the rules may have changed since it ran.
+	redactedLog := controllerutils.Redact(*log)
+	log = &redactedLog
+
 	// Load the regex configmap, if we don't have one, there's not much point proceeding here.
 	regexCM := &corev1.ConfigMap{}
 	if err := r.Get(context.TODO(), types.NamespacedName{Name: regexConfigMapName, Namespace: controllerutils.GetHiveNamespace()}, regexCM); err != nil {
@@ -37,19 +44,19 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 		// Even if the error was a transient error in fetching the configmap, we should not block
 		// the continuation of deploying the cluster just so that we can potentially get a
 		// better failure message.
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, hivev1.RetryWithBackoffInstallFailureRetryPolicy
 	}
 
 	regexesRaw, ok := regexCM.Data[regexDataEntryName]
 	if !ok {
 		pLog.Errorf("%s configmap does not have a %q data entry", regexConfigMapName, regexDataEntryName)
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, hivev1.RetryWithBackoffInstallFailureRetryPolicy
 	}
 
 	regexes := []installLogRegex{}
 	if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
 		pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, hivev1.RetryWithBackoffInstallFailureRetryPolicy
 	}
 
 	// Load additional regex configmap, continue anyway if configmap isn't present
@@ -90,11 +97,20 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 			case err != nil:
 				ssLog.WithError(err).Error("unable to compile regex")
 			case match:
-				pLog.WithField("reason", ilr.InstallFailingReason).Info("found known install failure string")
-				return ilr.InstallFailingReason, ilr.InstallFailingMessage
+				retryPolicy := ilr.RetryPolicy
+				switch retryPolicy {
+				case hivev1.RetryInstallFailureRetryPolicy, hivev1.RetryWithBackoffInstallFailureRetryPolicy, hivev1.StopInstallFailureRetryPolicy:
+				case "":
+					retryPolicy = hivev1.RetryWithBackoffInstallFailureRetryPolicy
+				default:
+					ilrLog.WithField("retryPolicy", retryPolicy).Warn("unknown retry policy, retrying with backoff")
+					retryPolicy = hivev1.RetryWithBackoffInstallFailureRetryPolicy
+				}
+				pLog.WithField("reason", ilr.InstallFailingReason).WithField("retryPolicy", retryPolicy).Info("found known install failure string")
+				return ilr.InstallFailingReason, ilr.InstallFailingMessage, retryPolicy
 			}
 		}
 	}
 
-	return unknownReason, *log
+	return unknownReason, *log, hivev1.RetryWithBackoffInstallFailureRetryPolicy
 }
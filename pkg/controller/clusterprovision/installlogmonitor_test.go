@@ -7,6 +7,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,7 +17,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/util/redact"
 )
 
 func init() {
@@ -40,11 +44,13 @@ const (
 func TestParseInstallLog(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 	tests := []struct {
-		name            string
-		log             *string
-		existing        []runtime.Object
-		expectedReason  string
-		expectedMessage *string
+		name                string
+		log                 *string
+		existing            []runtime.Object
+		redactionRules      string
+		expectedReason      string
+		expectedMessage     *string
+		expectedRetryPolicy hivev1.InstallFailureRetryPolicy
 	}{
 		{
 			name:           "DNS already exists",
@@ -89,10 +95,54 @@ func TestParseInstallLog(t *testing.T) {
 			expectedReason: "ResourceLimitExceeded",
 		},
 		{
-			name:           "Credentials are invalid",
-			log:            pointer.StringPtr(invalidCredentials),
-			existing:       []runtime.Object{buildRegexConfigMap()},
-			expectedReason: "InvalidCredentials",
+			name:                "Credentials are invalid",
+			log:                 pointer.StringPtr(invalidCredentials),
+			existing:            []runtime.Object{buildRegexConfigMap()},
+			expectedReason:      "InvalidCredentials",
+			expectedRetryPolicy: hivev1.StopInstallFailureRetryPolicy,
+		},
+		{
+			name: "retry policy",
+			log:  pointer.StringPtr(dnsAlreadyExistsLog),
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      regexConfigMapName,
+					Namespace: constants.DefaultHiveNamespace,
+				},
+				Data: map[string]string{
+					"regexes": `
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "aws_route53_record.*Error building changeset:.*Tried to create resource record set.*but it already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+  retryPolicy: Retry
+`,
+				},
+			}},
+			expectedReason:      "DNSAlreadyExists",
+			expectedRetryPolicy: hivev1.RetryInstallFailureRetryPolicy,
+		},
+		{
+			name: "unknown retry policy",
+			log:  pointer.StringPtr(dnsAlreadyExistsLog),
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      regexConfigMapName,
+					Namespace: constants.DefaultHiveNamespace,
+				},
+				Data: map[string]string{
+					"regexes": `
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "aws_route53_record.*Error building changeset:.*Tried to create resource record set.*but it already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+  retryPolicy: Sometimes
+`,
+				},
+			}},
+			expectedReason: "DNSAlreadyExists",
 		},
 		{
 			name:           "Failed waiting for Kubernetes API",
@@ -186,6 +236,18 @@ func TestParseInstallLog(t *testing.T) {
 			expectedReason:  unknownReason,
 			expectedMessage: pointer.StringPtr(noMatchLog),
 		},
+		{
+			name:     "no matching log with redaction rules",
+			log:      pointer.StringPtr("lookup proxy-1.corp.example.com: no such host"),
+			existing: []runtime.Object{buildRegexConfigMap()},
+			redactionRules: `
+- name: hostnames
+  regex: '[a-z0-9-]+\.corp\.example\.com'
+  replacement: REDACTED-HOST
+`,
+			expectedReason:  unknownReason,
+			expectedMessage: pointer.StringPtr("lookup REDACTED-HOST: no such host"),
+		},
 		{
 			name:           "missing regex configmap",
 			log:            pointer.StringPtr(dnsAlreadyExistsLog),
@@ -266,13 +328,24 @@ func TestParseInstallLog(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			if test.redactionRules != "" {
+				redactor, err := redact.Parse(test.redactionRules)
+				require.NoError(t, err, "unexpected error parsing redaction rules")
+				controllerutils.SetRedactor(redactor)
+				defer controllerutils.SetRedactor(nil)
+			}
 			fakeClient := fake.NewFakeClient(test.existing...)
 			r := &ReconcileClusterProvision{
 				Client: fakeClient,
 				scheme: scheme.Scheme,
 			}
-			reason, message := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
+			reason, message, retryPolicy := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
 			assert.Equal(t, test.expectedReason, reason, "unexpected reason")
+			expectedRetryPolicy := test.expectedRetryPolicy
+			if expectedRetryPolicy == "" {
+				expectedRetryPolicy = hivev1.RetryWithBackoffInstallFailureRetryPolicy
+			}
+			assert.Equal(t, expectedRetryPolicy, retryPolicy, "unexpected retry policy")
 			if test.expectedMessage != nil {
 				assert.Equal(t, *test.expectedMessage, message)
 			} else {
@@ -331,6 +404,7 @@ func buildRegexConfigMap() *corev1.ConfigMap {
   - "InvalidClientTokenId: The security token included in the request is invalid."
   installFailingReason: InvalidCredentials
   installFailingMessage: Credentials are invalid
+  retryPolicy: Stop
 - name: KubeAPIWaitFailed
   searchRegexStrings:
   - "Failed waiting for Kubernetes API. This error usually happens when there is a problem on the bootstrap host that prevents creating a temporary control plane"
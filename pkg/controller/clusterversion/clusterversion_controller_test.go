@@ -15,7 +15,6 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -25,6 +24,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 )
@@ -35,8 +35,12 @@ const (
 	testClusterID                   = "testFooClusterUUID"
 	testNamespace                   = "default"
 	pullSecretSecret                = "pull-secret"
-	testRemoteClusterCurrentVersion = "4.0.0"
+	testRemoteClusterCurrentVersion = "2.3.4"
 	remoteClusterVersionObjectName  = "version"
+	testUpgradeVersion              = "2.4.0"
+	testUpgradeImage                = "quay.io/openshift-release-dev/ocp-release@sha256:2400"
+	testClusterImageSetName         = "openshift-2.4.0"
+	testClusterImageSetImage        = "quay.io/openshift-release-dev/ocp-release:2.4.0-x86_64"
 )
 
 func init() {
@@ -48,11 +52,14 @@ func TestClusterVersionReconcile(t *testing.T) {
 	configv1.Install(scheme.Scheme)
 
 	tests := []struct {
-		name         string
-		existing     []runtime.Object
-		noRemoteCall bool
-		expectError  bool
-		validate     func(*testing.T, *hivev1.ClusterDeployment)
+		name                 string
+		existing             []runtime.Object
+		remoteClusterVersion *configv1.ClusterVersion
+		noRemoteCall         bool
+		expectError          bool
+		expectedRequeueAfter time.Duration
+		validate             func(*testing.T, *hivev1.ClusterDeployment)
+		validateRemote       func(*testing.T, *configv1.ClusterVersion)
 	}{
 		{
 			// no cluster deployment, no error expected
@@ -78,6 +85,282 @@ func TestClusterVersionReconcile(t *testing.T) {
 				assert.Equal(t, "2.3.4", cd.Labels[constants.VersionMajorMinorPatchLabel], "unexpected version major-minor-patch label")
 			},
 		},
+		{
+			name: "upgrade to available version",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion}),
+				testKubeconfigSecret(),
+			},
+			expectedRequeueAfter: upgradePollInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradingCondition, corev1.ConditionTrue, upgradeRequestedReason)
+				validateCondition(t, cd, hivev1.UpgradeFailedCondition, corev1.ConditionFalse, upgradeNotFailingReason)
+				if assert.Len(t, cd.Status.UpgradeHistory, 1, "unexpected upgrade history") {
+					assert.Equal(t, testRemoteClusterCurrentVersion, cd.Status.UpgradeHistory[0].Version, "unexpected upgrade history version")
+					assert.Equal(t, string(configv1.CompletedUpdate), cd.Status.UpgradeHistory[0].State, "unexpected upgrade history state")
+				}
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				if assert.NotNil(t, cv.Spec.DesiredUpdate, "expected desired update") {
+					assert.Equal(t, testUpgradeVersion, cv.Spec.DesiredUpdate.Version, "unexpected desired version")
+					assert.Equal(t, testUpgradeImage, cv.Spec.DesiredUpdate.Image, "unexpected desired image")
+					assert.False(t, cv.Spec.DesiredUpdate.Force, "unexpected forced update")
+				}
+			},
+		},
+		{
+			name: "upgrade to unavailable version",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{Version: "2.5.0"}),
+				testKubeconfigSecret(),
+			},
+			expectedRequeueAfter: upgradePollInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradeFailedCondition, corev1.ConditionTrue, versionNotAvailableReason)
+				assert.Nil(t, controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UpgradingCondition), "unexpected Upgrading condition")
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "forced upgrade to cluster image set",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: testClusterImageSetName},
+					Force:       true,
+				}),
+				testKubeconfigSecret(),
+				testClusterImageSet(),
+			},
+			expectedRequeueAfter: upgradePollInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradingCondition, corev1.ConditionTrue, upgradeRequestedReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				if assert.NotNil(t, cv.Spec.DesiredUpdate, "expected desired update") {
+					assert.Empty(t, cv.Spec.DesiredUpdate.Version, "unexpected desired version")
+					assert.Equal(t, testClusterImageSetImage, cv.Spec.DesiredUpdate.Image, "unexpected desired image")
+					assert.True(t, cv.Spec.DesiredUpdate.Force, "expected forced update")
+				}
+			},
+		},
+		{
+			name: "cluster image set for upgrade not found",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: testClusterImageSetName},
+				}),
+				testKubeconfigSecret(),
+			},
+			expectedRequeueAfter: upgradePollInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradeFailedCondition, corev1.ConditionTrue, clusterImageSetNotFoundReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "cluster image set version unknown",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: testClusterImageSetName},
+					Force:       true,
+				}),
+				testKubeconfigSecret(),
+				func() *hivev1.ClusterImageSet {
+					imageSet := testClusterImageSet()
+					imageSet.Spec.ReleaseImage = "quay.io/openshift-release-dev/ocp-release@sha256:0123456789abcdef"
+					return imageSet
+				}(),
+			},
+			expectedRequeueAfter: upgradePollInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradeFailedCondition, corev1.ConditionTrue, versionUnknownReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "cluster already newer than cluster image set version",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: testClusterImageSetName},
+					Force:       true,
+				}),
+				testKubeconfigSecret(),
+				testClusterImageSet(),
+			},
+			remoteClusterVersion: func() *configv1.ClusterVersion {
+				cv := testRemoteClusterVersion()
+				cv.Status.Desired = configv1.Update{Version: "2.5.0", Image: "TESTIMAGE-2.5.0"}
+				cv.Status.History = append([]configv1.UpdateHistory{{
+					State:   configv1.CompletedUpdate,
+					Version: "2.5.0",
+					Image:   "TESTIMAGE-2.5.0",
+				}}, cv.Status.History...)
+				return cv
+			}(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradingCondition, corev1.ConditionFalse, upgradeCompletedReason)
+				validateCondition(t, cd, hivev1.UpgradeFailedCondition, corev1.ConditionFalse, upgradeNotFailingReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "upgrade in progress",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion}),
+				testKubeconfigSecret(),
+			},
+			remoteClusterVersion: func() *configv1.ClusterVersion {
+				cv := testRemoteClusterVersion()
+				cv.Spec.DesiredUpdate = &configv1.Update{Version: testUpgradeVersion, Image: testUpgradeImage}
+				cv.Status.Conditions = []configv1.ClusterOperatorStatusCondition{{
+					Type:    configv1.OperatorProgressing,
+					Status:  configv1.ConditionTrue,
+					Message: "Working towards 2.4.0: 50% complete",
+				}}
+				return cv
+			}(),
+			expectedRequeueAfter: upgradePollInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := validateCondition(t, cd, hivev1.UpgradingCondition, corev1.ConditionTrue, upgradeInProgressReason)
+				if cond != nil {
+					assert.Equal(t, "Working towards 2.4.0: 50% complete", cond.Message, "unexpected Upgrading message")
+				}
+				validateCondition(t, cd, hivev1.UpgradeFailedCondition, corev1.ConditionFalse, upgradeNotFailingReason)
+			},
+		},
+		{
+			name: "upgrade failing",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion}),
+				testKubeconfigSecret(),
+			},
+			remoteClusterVersion: func() *configv1.ClusterVersion {
+				cv := testRemoteClusterVersion()
+				cv.Spec.DesiredUpdate = &configv1.Update{Version: testUpgradeVersion, Image: testUpgradeImage}
+				cv.Status.Conditions = []configv1.ClusterOperatorStatusCondition{{
+					Type:    clusterVersionFailingCondition,
+					Status:  configv1.ConditionTrue,
+					Message: "Cluster operator etcd is degraded",
+				}}
+				return cv
+			}(),
+			expectedRequeueAfter: upgradePollInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradingCondition, corev1.ConditionTrue, upgradeInProgressReason)
+				cond := validateCondition(t, cd, hivev1.UpgradeFailedCondition, corev1.ConditionTrue, upgradeFailingReason)
+				if cond != nil {
+					assert.Equal(t, "Cluster operator etcd is degraded", cond.Message, "unexpected UpgradeFailed message")
+				}
+			},
+		},
+		{
+			name: "upgrade completed",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion})
+					cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.UpgradingCondition,
+						Status: corev1.ConditionTrue,
+						Reason: upgradeInProgressReason,
+					})
+					return cd
+				}(),
+				testKubeconfigSecret(),
+			},
+			remoteClusterVersion: func() *configv1.ClusterVersion {
+				cv := testRemoteClusterVersion()
+				cv.Spec.DesiredUpdate = &configv1.Update{Version: testUpgradeVersion, Image: testUpgradeImage}
+				cv.Status.Desired = configv1.Update{Version: testUpgradeVersion, Image: testUpgradeImage}
+				cv.Status.History = append([]configv1.UpdateHistory{{
+					State:   configv1.CompletedUpdate,
+					Version: testUpgradeVersion,
+					Image:   testUpgradeImage,
+				}}, cv.Status.History...)
+				return cv
+			}(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradingCondition, corev1.ConditionFalse, upgradeCompletedReason)
+				assert.Len(t, cd.Status.UpgradeHistory, 2, "unexpected upgrade history")
+				assert.Equal(t, "2.4.0", cd.Labels[constants.VersionMajorMinorPatchLabel], "unexpected version major-minor-patch label")
+			},
+		},
+		{
+			name: "cluster already newer than upgrade version",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion}),
+				testKubeconfigSecret(),
+			},
+			remoteClusterVersion: func() *configv1.ClusterVersion {
+				cv := testRemoteClusterVersion()
+				cv.Status.Desired = configv1.Update{Version: "2.5.0", Image: "TESTIMAGE-2.5.0"}
+				cv.Status.History = append([]configv1.UpdateHistory{{
+					State:   configv1.CompletedUpdate,
+					Version: "2.5.0",
+					Image:   "TESTIMAGE-2.5.0",
+				}}, cv.Status.History...)
+				return cv
+			}(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradingCondition, corev1.ConditionFalse, upgradeCompletedReason)
+				validateCondition(t, cd, hivev1.UpgradeFailedCondition, corev1.ConditionFalse, upgradeNotFailingReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "cluster image set upgrade completed before a later upgrade",
+			existing: []runtime.Object{
+				testUpgradingClusterDeployment(&hivev1.ClusterUpgrade{
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: testClusterImageSetName},
+				}),
+				testClusterImageSet(),
+				testKubeconfigSecret(),
+			},
+			remoteClusterVersion: func() *configv1.ClusterVersion {
+				cv := testRemoteClusterVersion()
+				cv.Status.Desired = configv1.Update{Version: "2.5.0", Image: "TESTIMAGE-2.5.0"}
+				cv.Status.History = append([]configv1.UpdateHistory{
+					{State: configv1.CompletedUpdate, Version: "2.5.0", Image: "TESTIMAGE-2.5.0"},
+					{State: configv1.CompletedUpdate, Version: "2.4.1", Image: testClusterImageSetImage},
+				}, cv.Status.History...)
+				return cv
+			}(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradingCondition, corev1.ConditionFalse, upgradeCompletedReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "upgrade status cleared when no upgrade requested",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.UpgradeFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: versionNotAvailableReason,
+					})
+					cd.Status.UpgradeHistory = []hivev1.ClusterUpgradeHistory{{State: string(configv1.CompletedUpdate), Version: "2.3.4"}}
+					return cd
+				}(),
+				testKubeconfigSecret(),
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				validateCondition(t, cd, hivev1.UpgradeFailedCondition, corev1.ConditionFalse, noUpgradeRequestedReason)
+				assert.Empty(t, cd.Status.UpgradeHistory, "unexpected upgrade history")
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -86,8 +369,13 @@ func TestClusterVersionReconcile(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
 			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			remoteClusterVersion := test.remoteClusterVersion
+			if remoteClusterVersion == nil {
+				remoteClusterVersion = testRemoteClusterVersion()
+			}
+			remoteClient := fake.NewFakeClient(remoteClusterVersion)
 			if !test.noRemoteCall {
-				mockRemoteClientBuilder.EXPECT().Build().Return(testRemoteClusterAPIClient(), nil)
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil)
 			}
 			rcd := &ReconcileClusterVersion{
 				Client:                        fakeClient,
@@ -100,7 +388,8 @@ func TestClusterVersionReconcile(t *testing.T) {
 				Namespace: testNamespace,
 			}
 
-			_, err := rcd.Reconcile(context.TODO(), reconcile.Request{NamespacedName: namespacedName})
+			result, err := rcd.Reconcile(context.TODO(), reconcile.Request{NamespacedName: namespacedName})
+			assert.Equal(t, test.expectedRequeueAfter, result.RequeueAfter, "unexpected requeue after")
 
 			if test.validate != nil {
 				cd := &hivev1.ClusterDeployment{}
@@ -111,6 +400,15 @@ func TestClusterVersionReconcile(t *testing.T) {
 				}
 				test.validate(t, cd)
 			}
+			if test.validateRemote != nil {
+				cv := &configv1.ClusterVersion{}
+				err := remoteClient.Get(context.TODO(), types.NamespacedName{Name: remoteClusterVersionObjectName}, cv)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				test.validateRemote(t, cv)
+			}
 
 			if err != nil && !test.expectError {
 				t.Errorf("Unexpected error: %v", err)
@@ -185,15 +483,59 @@ func testSecret(name, key, value string) *corev1.Secret {
 	return s
 }
 
-func testRemoteClusterAPIClient() client.Client {
+func testUpgradingClusterDeployment(upgrade *hivev1.ClusterUpgrade) *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Upgrade = upgrade
+	return cd
+}
+
+func TestReleaseImageVersion(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{image: "quay.io/openshift-release-dev/ocp-release:4.7.0-x86_64", expected: "4.7.0"},
+		{image: "quay.io/openshift-release-dev/ocp-release:4.8.0-rc.1-s390x", expected: "4.8.0-rc.1"},
+		{image: "registry.example.com:5000/ocp/release:4.7.3", expected: "4.7.3"},
+		{image: "registry.example.com:5000/ocp/release", expected: ""},
+		{image: "quay.io/openshift-release-dev/ocp-release:latest", expected: ""},
+		{image: "quay.io/openshift-release-dev/ocp-release@sha256:0123456789abcdef", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			assert.Equal(t, test.expected, releaseImageVersion(test.image))
+		})
+	}
+}
+
+func testClusterImageSet() *hivev1.ClusterImageSet {
+	return &hivev1.ClusterImageSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testClusterImageSetName,
+		},
+		Spec: hivev1.ClusterImageSetSpec{
+			ReleaseImage: testClusterImageSetImage,
+		},
+	}
+}
+
+func testRemoteClusterVersion() *configv1.ClusterVersion {
 	remoteClusterVersion := &configv1.ClusterVersion{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: remoteClusterVersionObjectName,
 		},
 	}
 	remoteClusterVersion.Status = *testRemoteClusterVersionStatus()
+	return remoteClusterVersion
+}
 
-	return fake.NewFakeClient(remoteClusterVersion)
+func validateCondition(t *testing.T, cd *hivev1.ClusterDeployment, conditionType hivev1.ClusterDeploymentConditionType, status corev1.ConditionStatus, reason string) *hivev1.ClusterDeploymentCondition {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, conditionType)
+	if assert.NotNil(t, cond, "expected %s condition", conditionType) {
+		assert.Equal(t, status, cond.Status, "unexpected %s condition status", conditionType)
+		assert.Equal(t, reason, cond.Reason, "unexpected %s condition reason", conditionType)
+	}
+	return cond
 }
 
 func testRemoteClusterVersionStatus() *configv1.ClusterVersionStatus {
@@ -210,6 +552,12 @@ func testRemoteClusterVersionStatus() *configv1.ClusterVersionStatus {
 				CompletionTime: &zeroTime,
 			},
 		},
+		AvailableUpdates: []configv1.Update{
+			{
+				Version: testUpgradeVersion,
+				Image:   testUpgradeImage,
+			},
+		},
 		ObservedGeneration: 123456789,
 		VersionHash:        "TESTVERSIONHASH",
 	}
@@ -0,0 +1,313 @@
+package clusterversion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// upgradePollInterval is how often the ClusterVersion of a cluster is checked while the cluster is upgrading,
+	// or while its upgrade cannot be started.
+	upgradePollInterval = time.Minute
+
+	// maxUpgradeHistory is the number of upgrades kept in the status of the ClusterDeployment.
+	maxUpgradeHistory = 10
+
+	// clusterVersionFailingCondition is set True by the cluster version operator when it cannot make progress
+	// towards the desired release.
+	clusterVersionFailingCondition openshiftapiv1.ClusterStatusConditionType = "Failing"
+
+	noUpgradeRequestedReason      = "NoUpgradeRequested"
+	upgradeRequestedReason        = "UpgradeRequested"
+	upgradeInProgressReason       = "UpgradeInProgress"
+	upgradeCompletedReason        = "UpgradeCompleted"
+	upgradeFailingReason          = "UpgradeFailing"
+	upgradeNotFailingReason       = "UpgradeNotFailing"
+	clusterImageSetNotFoundReason = "ClusterImageSetNotFound"
+	versionNotAvailableReason     = "VersionNotAvailable"
+	versionUnknownReason          = "VersionUnknown"
+)
+
+// releaseArchitectureSuffixes are the architecture suffixes of the tags of OpenShift release images.
+var releaseArchitectureSuffixes = []string{"-x86_64", "-aarch64", "-arm64", "-ppc64le", "-s390x", "-multi"}
+
+// reconcileUpgrade upgrades the cluster to the release of Spec.Upgrade by setting the desired update of the remote
+// ClusterVersion, and follows the progress of the upgrade in the Upgrading and UpgradeFailed conditions and the
+// upgrade history of the ClusterDeployment. The returned requeueAfter is non-zero while the upgrade has not finished,
+// as changes to the remote ClusterVersion are not watched.
+func (r *ReconcileClusterVersion) reconcileUpgrade(cd *hivev1.ClusterDeployment, remoteClient client.Client, clusterVersion *openshiftapiv1.ClusterVersion, cdLog log.FieldLogger) (requeueAfter time.Duration, returnErr error) {
+	if cd.Spec.Upgrade == nil {
+		statusChanged := false
+		for _, conditionType := range []hivev1.ClusterDeploymentConditionType{hivev1.UpgradingCondition, hivev1.UpgradeFailedCondition} {
+			if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, conditionType); cond != nil && cond.Status == corev1.ConditionTrue {
+				setUpgradeCondition(cd, conditionType, corev1.ConditionFalse, noUpgradeRequestedReason, "No upgrade requested")
+				statusChanged = true
+			}
+		}
+		if cd.Status.UpgradeHistory != nil {
+			cd.Status.UpgradeHistory = nil
+			statusChanged = true
+		}
+		return 0, r.updateUpgradeStatus(cd, statusChanged, cdLog)
+	}
+
+	statusChanged := false
+	history := upgradeHistory(clusterVersion)
+	if !equality.Semantic.DeepEqual(history, cd.Status.UpgradeHistory) {
+		cd.Status.UpgradeHistory = history
+		statusChanged = true
+	}
+
+	target := openshiftapiv1.Update{Version: cd.Spec.Upgrade.Version}
+	if ref := cd.Spec.Upgrade.ImageSetRef; ref != nil {
+		imageSet := &hivev1.ClusterImageSet{}
+		switch err := r.Get(context.TODO(), types.NamespacedName{Name: ref.Name}, imageSet); {
+		case apierrors.IsNotFound(err):
+			cdLog.WithField("clusterImageSet", ref.Name).Warn("cluster image set for upgrade not found")
+			statusChanged = setUpgradeCondition(cd, hivev1.UpgradeFailedCondition, corev1.ConditionTrue, clusterImageSetNotFoundReason,
+				fmt.Sprintf("ClusterImageSet %s not found", ref.Name)) || statusChanged
+			return upgradePollInterval, r.updateUpgradeStatus(cd, statusChanged, cdLog)
+		case err != nil:
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster image set for upgrade")
+			return 0, err
+		}
+		target.Image = imageSet.Spec.ReleaseImage
+	}
+	upgradeLog := cdLog.WithField("upgrade", describeUpdate(target))
+	current := currentVersion(clusterVersion)
+	targetVersion := updateVersion(clusterVersion, target)
+
+	switch {
+	case hasCompletedUpdate(clusterVersion, target):
+		upgradeLog.Debug("cluster has been upgraded")
+		return 0, r.setUpgradeCompleted(cd, statusChanged, fmt.Sprintf("Cluster has been upgraded to %s", describeUpdate(target)), cdLog)
+
+	case versionNotOlder(current, targetVersion):
+		// The cluster may have been upgraded past the target by other means. Setting the desired update would
+		// downgrade it.
+		upgradeLog.WithField("current", current).Debug("cluster is already at or past the upgrade version")
+		return 0, r.setUpgradeCompleted(cd, statusChanged, fmt.Sprintf("Cluster version %s is not older than %s", current, targetVersion), cdLog)
+
+	case clusterVersion.Spec.DesiredUpdate != nil && updateMatches(*clusterVersion.Spec.DesiredUpdate, target):
+		upgradeLog.Debug("cluster is upgrading")
+		message := fmt.Sprintf("Upgrading to %s", describeUpdate(target))
+		if cond := findClusterVersionCondition(clusterVersion, openshiftapiv1.OperatorProgressing); cond != nil && cond.Message != "" {
+			message = cond.Message
+		}
+		statusChanged = setUpgradeCondition(cd, hivev1.UpgradingCondition, corev1.ConditionTrue, upgradeInProgressReason, message) || statusChanged
+		if cond := findClusterVersionCondition(clusterVersion, clusterVersionFailingCondition); cond != nil && cond.Status == openshiftapiv1.ConditionTrue {
+			upgradeLog.WithField("message", cond.Message).Warn("cluster upgrade is failing")
+			statusChanged = setUpgradeCondition(cd, hivev1.UpgradeFailedCondition, corev1.ConditionTrue, upgradeFailingReason, cond.Message) || statusChanged
+		} else {
+			statusChanged = setUpgradeCondition(cd, hivev1.UpgradeFailedCondition, corev1.ConditionFalse, upgradeNotFailingReason,
+				"Upgrade is not failing") || statusChanged
+		}
+		return upgradePollInterval, r.updateUpgradeStatus(cd, statusChanged, cdLog)
+	}
+
+	// The cluster version operator needs the release image of a version, which it only knows for the available
+	// updates of the cluster.
+	if target.Image == "" {
+		for _, update := range clusterVersion.Status.AvailableUpdates {
+			if update.Version == target.Version {
+				target.Image = update.Image
+				break
+			}
+		}
+		if target.Image == "" {
+			upgradeLog.Warn("version is not an available update of the cluster")
+			statusChanged = setUpgradeCondition(cd, hivev1.UpgradeFailedCondition, corev1.ConditionTrue, versionNotAvailableReason,
+				fmt.Sprintf("Version %s is not an available update of the cluster", target.Version)) || statusChanged
+			return upgradePollInterval, r.updateUpgradeStatus(cd, statusChanged, cdLog)
+		}
+	}
+	target.Force = cd.Spec.Upgrade.Force
+
+	// Without the version of the release image, the checks above cannot rule out a downgrade.
+	if targetVersion == "" {
+		upgradeLog.Warn("version of release image is not known")
+		statusChanged = setUpgradeCondition(cd, hivev1.UpgradeFailedCondition, corev1.ConditionTrue, versionUnknownReason,
+			fmt.Sprintf("Version of release image %s is not known", target.Image)) || statusChanged
+		return upgradePollInterval, r.updateUpgradeStatus(cd, statusChanged, cdLog)
+	}
+
+	upgradeLog.Info("upgrading cluster")
+	clusterVersion.Spec.DesiredUpdate = &target
+	if err := remoteClient.Update(context.TODO(), clusterVersion); err != nil {
+		upgradeLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting desired update of remote clusterversion")
+		return 0, err
+	}
+	statusChanged = setUpgradeCondition(cd, hivev1.UpgradingCondition, corev1.ConditionTrue, upgradeRequestedReason,
+		fmt.Sprintf("Requested upgrade to %s", describeUpdate(target))) || statusChanged
+	statusChanged = setUpgradeCondition(cd, hivev1.UpgradeFailedCondition, corev1.ConditionFalse, upgradeNotFailingReason,
+		"Upgrade is not failing") || statusChanged
+	return upgradePollInterval, r.updateUpgradeStatus(cd, statusChanged, cdLog)
+}
+
+func (r *ReconcileClusterVersion) setUpgradeCompleted(cd *hivev1.ClusterDeployment, statusChanged bool, message string, cdLog log.FieldLogger) error {
+	statusChanged = setUpgradeCondition(cd, hivev1.UpgradingCondition, corev1.ConditionFalse, upgradeCompletedReason, message) || statusChanged
+	statusChanged = setUpgradeCondition(cd, hivev1.UpgradeFailedCondition, corev1.ConditionFalse, upgradeNotFailingReason,
+		"Upgrade is not failing") || statusChanged
+	return r.updateUpgradeStatus(cd, statusChanged, cdLog)
+}
+
+func (r *ReconcileClusterVersion) updateUpgradeStatus(cd *hivev1.ClusterDeployment, statusChanged bool, cdLog log.FieldLogger) error {
+	if !statusChanged {
+		return nil
+	}
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster deployment upgrade status")
+		return err
+	}
+	return nil
+}
+
+func setUpgradeCondition(cd *hivev1.ClusterDeployment, conditionType hivev1.ClusterDeploymentConditionType, status corev1.ConditionStatus, reason, message string) bool {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		conditionType,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	cd.Status.Conditions = conditions
+	return changed
+}
+
+// upgradeHistory returns the most recent upgrades in the history of the ClusterVersion.
+func upgradeHistory(clusterVersion *openshiftapiv1.ClusterVersion) []hivev1.ClusterUpgradeHistory {
+	var history []hivev1.ClusterUpgradeHistory
+	for i, h := range clusterVersion.Status.History {
+		if i == maxUpgradeHistory {
+			break
+		}
+		history = append(history, hivev1.ClusterUpgradeHistory{
+			State:          string(h.State),
+			Version:        h.Version,
+			Image:          h.Image,
+			StartedTime:    h.StartedTime,
+			CompletionTime: h.CompletionTime,
+		})
+	}
+	return history
+}
+
+// updateMatches returns true when the update is for the version and release image of the target, where set.
+func updateMatches(update, target openshiftapiv1.Update) bool {
+	if target.Version != "" && update.Version != target.Version {
+		return false
+	}
+	if target.Image != "" && update.Image != target.Image {
+		return false
+	}
+	return true
+}
+
+// hasCompletedUpdate returns true when the history of the ClusterVersion has a completed update to the target.
+func hasCompletedUpdate(clusterVersion *openshiftapiv1.ClusterVersion, target openshiftapiv1.Update) bool {
+	for _, h := range clusterVersion.Status.History {
+		if h.State == openshiftapiv1.CompletedUpdate && updateMatches(openshiftapiv1.Update{Version: h.Version, Image: h.Image}, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// currentVersion returns the version of the most recent completed update of the ClusterVersion, which is the version
+// the whole cluster is running.
+func currentVersion(clusterVersion *openshiftapiv1.ClusterVersion) string {
+	for _, h := range clusterVersion.Status.History {
+		if h.State == openshiftapiv1.CompletedUpdate {
+			return h.Version
+		}
+	}
+	return ""
+}
+
+// updateVersion returns the version of the target, looking up the version of a release image among the updates
+// known to the ClusterVersion, or else taking it from the tag of the release image. It returns an empty string if the
+// version is not known.
+func updateVersion(clusterVersion *openshiftapiv1.ClusterVersion, target openshiftapiv1.Update) string {
+	if target.Version != "" {
+		return target.Version
+	}
+	for _, update := range clusterVersion.Status.AvailableUpdates {
+		if update.Image == target.Image {
+			return update.Version
+		}
+	}
+	for _, h := range clusterVersion.Status.History {
+		if h.Image == target.Image {
+			return h.Version
+		}
+	}
+	return releaseImageVersion(target.Image)
+}
+
+// releaseImageVersion returns the version in the tag of a release image, such as 4.7.0 for
+// quay.io/openshift-release-dev/ocp-release:4.7.0-x86_64. It returns an empty string for an image referenced by digest,
+// or whose tag is not a version.
+func releaseImageVersion(image string) string {
+	if strings.Contains(image, "@") {
+		return ""
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+	tag := image[i+1:]
+	for _, suffix := range releaseArchitectureSuffixes {
+		tag = strings.TrimSuffix(tag, suffix)
+	}
+	if _, err := semver.Parse(tag); err != nil {
+		return ""
+	}
+	return tag
+}
+
+// versionNotOlder returns true when both versions are valid semantic versions, and the current version is not older
+// than the target version.
+func versionNotOlder(current, target string) bool {
+	currentSemver, err := semver.ParseTolerant(current)
+	if err != nil {
+		return false
+	}
+	targetSemver, err := semver.ParseTolerant(target)
+	if err != nil {
+		return false
+	}
+	return currentSemver.GTE(targetSemver)
+}
+
+func describeUpdate(update openshiftapiv1.Update) string {
+	if update.Version != "" {
+		return update.Version
+	}
+	return update.Image
+}
+
+func findClusterVersionCondition(clusterVersion *openshiftapiv1.ClusterVersion, conditionType openshiftapiv1.ClusterStatusConditionType) *openshiftapiv1.ClusterOperatorStatusCondition {
+	for i, cond := range clusterVersion.Status.Conditions {
+		if cond.Type == conditionType {
+			return &clusterVersion.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -214,7 +214,9 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 	}
 
 	// Check if HibernateAfter is set, and if the cluster has been in running state for longer than this duration, put it to sleep.
-	if cd.Spec.HibernateAfter != nil && cd.Spec.PowerState != hivev1.HibernatingClusterPowerState {
+	// The power state of unclaimed clusters in a pool is managed by the clusterpool controller, which may keep some of them
+	// running, so HibernateAfter only applies once the cluster has been claimed.
+	if cd.Spec.HibernateAfter != nil && cd.Spec.PowerState != hivev1.HibernatingClusterPowerState && !isUnclaimedPoolCluster(cd) {
 		hibernateAfterDur := cd.Spec.HibernateAfter.Duration
 		runningSince := cd.Status.InstalledTimestamp.Time
 		hibLog := cdLog.WithFields(log.Fields{
@@ -570,3 +572,7 @@ func isNodeReady(node *corev1.Node) bool {
 	}
 	return false
 }
+
+func isUnclaimedPoolCluster(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.ClusterPoolRef != nil && cd.Spec.ClusterPoolRef.ClaimName == ""
+}
@@ -516,6 +516,28 @@ func TestHibernateAfter(t *testing.T) {
 			cs:                 csBuilder.Build(),
 			expectedPowerState: hivev1.HibernatingClusterPowerState,
 		},
+		{
+			name: "unclaimed pool cluster with running condition due for hibernate",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithUnclaimedClusterPoolReference(namespace, "test-pool"),
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason, 9*time.Hour)),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectedPowerState: hivev1.RunningClusterPowerState,
+		},
+		{
+			name: "claimed pool cluster with running condition due for hibernate",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithClusterPoolReference(namespace, "test-pool", "test-claim"),
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason, 9*time.Hour)),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+		},
 		{
 			name: "cluster with running condition not due for hibernate",
 			cd: cdBuilder.Build(
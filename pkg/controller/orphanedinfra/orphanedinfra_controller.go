@@ -0,0 +1,640 @@
+package orphanedinfra
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/manageddns"
+)
+
+const (
+	// ControllerName is the name of this controller
+	ControllerName = hivev1.OrphanedInfraControllerName
+
+	defaultScanInterval = 6 * time.Hour
+
+	// stateConfigMapName is the name of the ConfigMap in the hive namespace holding the orphaned infrastructure
+	// found by the last scan, the cloud accounts that are scanned, and the infra IDs of the clusters Hive manages.
+	stateConfigMapName = "orphaned-infra"
+	orphansKey         = "orphans"
+	accountsKey        = "accounts"
+	managedKey         = "managed"
+
+	clusterTagPrefix = "kubernetes.io/cluster/"
+)
+
+var (
+	metricOrphanedInfra = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hive_orphaned_infra",
+			Help: "Number of infra IDs with cloud resources but no ClusterDeployment, found by the last scan.",
+		},
+		[]string{"platform", "region"},
+	)
+	metricOrphanedInfraDeprovisionsCreated = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_orphaned_infra_deprovisions_created_total",
+			Help: "Counter incremented every time a ClusterDeprovision is created for orphaned infrastructure.",
+		},
+		[]string{"platform"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricOrphanedInfra)
+	metrics.Registry.MustRegister(metricOrphanedInfraDeprovisionsCreated)
+}
+
+// account is a set of cloud credentials and the region in which they are used.
+type account struct {
+	Platform             string                 `json:"platform"`
+	Region               string                 `json:"region"`
+	CredentialsSecretRef corev1.SecretReference `json:"credentialsSecretRef"`
+}
+
+func (a account) key() string {
+	return strings.Join([]string{a.Platform, a.Region, a.CredentialsSecretRef.Namespace, a.CredentialsSecretRef.Name}, "/")
+}
+
+// orphan is the infrastructure of a cluster which has no ClusterDeployment.
+type orphan struct {
+	InfraID string  `json:"infraID"`
+	Account account `json:"account"`
+	// Resources is the number of resources of the cluster that were found in the account.
+	Resources int `json:"resources"`
+	// FirstSeen is the time of the first scan that found the infrastructure orphaned.
+	FirstSeen metav1.Time `json:"firstSeen"`
+	// Managed is whether the infra ID was recorded by an earlier scan as that of a cluster Hive managed. Only managed
+	// infrastructure is deprovisioned.
+	Managed bool `json:"managed"`
+	// ClusterDeprovision is the name of the ClusterDeprovision created to delete the infrastructure, in the hive
+	// namespace.
+	ClusterDeprovision string `json:"clusterDeprovision,omitempty"`
+}
+
+// Add creates a new orphaned infrastructure Reaper and adds it to the Manager, if enabled in HiveConfig.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+
+	scanIntervalValue, ok := os.LookupEnv(constants.OrphanedInfraReaperScanIntervalEnvVar)
+	if !ok {
+		return nil
+	}
+	scanInterval := defaultScanInterval
+	if scanIntervalValue != "" {
+		var err error
+		scanInterval, err = time.ParseDuration(scanIntervalValue)
+		if err != nil {
+			logger.WithError(err).Error("invalid orphaned infrastructure scan interval")
+			return err
+		}
+	}
+	var deprovisionAfter time.Duration
+	if value := os.Getenv(constants.OrphanedInfraReaperDeprovisionAfterEnvVar); value != "" {
+		var err error
+		deprovisionAfter, err = time.ParseDuration(value)
+		if err != nil {
+			logger.WithError(err).Error("invalid orphaned infrastructure deprovision delay")
+			return err
+		}
+	}
+	managedDomains, err := manageddns.ReadManagedDomainsFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read managed domains file")
+		return err
+	}
+
+	return mgr.Add(&Reaper{
+		Client:           mgr.GetClient(),
+		ScanInterval:     scanInterval,
+		DeprovisionAfter: deprovisionAfter,
+		managedDomains:   managedDomains,
+		awsClientFn:      getAWSClient,
+	})
+}
+
+// Reaper runs in a goroutine and periodically searches the cloud accounts known to Hive for the infrastructure of
+// clusters that no longer have a ClusterDeployment. This can be left behind when the hub crashes, a finalizer is
+// removed by hand, or a relocate is interrupted. The infrastructure found is reported, and, if configured,
+// deprovisioned once it has been orphaned for long enough. Only infrastructure of clusters which an earlier scan
+// recorded as managed by Hive is deprovisioned, as the accounts can hold clusters Hive never knew about.
+//
+// The accounts scanned are those of the managed domains, and of the ClusterDeployments and ClusterDeprovisions seen
+// by earlier scans, for as long as their credentials secrets exist. Currently only AWS accounts are scanned.
+type Reaper struct {
+	Client client.Client
+
+	// ScanInterval is the length of time we sleep between scans.
+	ScanInterval time.Duration
+
+	// DeprovisionAfter is how long managed infrastructure must have been orphaned before it is deprovisioned. Zero
+	// disables deprovisioning, leaving orphaned infrastructure only reported.
+	DeprovisionAfter time.Duration
+
+	managedDomains []hivev1.ManageDNSConfig
+
+	// awsClientFn is the function to build an AWS client, here for testing
+	awsClientFn func(client.Client, account) (awsclient.Client, error)
+}
+
+// Start begins the scan loop.
+func (r *Reaper) Start(ctx context.Context) error {
+	log.Info("started orphaned infrastructure reaper goroutine")
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		logger := log.WithField("controller", ControllerName)
+		recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+		defer recobsrv.ObserveControllerReconcileTime()
+
+		if err := r.scan(logger); err != nil {
+			recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeUnspecified)
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error scanning for orphaned infrastructure")
+		}
+	}, r.ScanInterval)
+
+	return nil
+}
+
+func (r *Reaper) scan(logger log.FieldLogger) error {
+	logger.Info("scanning for orphaned infrastructure")
+	now := metav1.Now()
+
+	cm, previousOrphans, previousAccounts, previousManaged, err := r.loadState()
+	if err != nil {
+		return err
+	}
+	accounts, err := r.knownAccounts(previousAccounts, logger)
+	if err != nil {
+		return err
+	}
+	// The live infra IDs are listed before the cloud resources, so that a cluster installed during the scan is not
+	// mistaken for an orphan.
+	live, deprovisioning, err := r.liveInfraIDs(logger)
+	if err != nil {
+		return err
+	}
+	hubInfraID, err := r.hubInfraID(logger)
+	if err != nil {
+		return err
+	}
+
+	orphans := map[string]*orphan{}
+	for _, acct := range accounts {
+		acctLog := logger.WithField("platform", acct.Platform).WithField("region", acct.Region).
+			WithField("secret", acct.CredentialsSecretRef.Namespace+"/"+acct.CredentialsSecretRef.Name)
+		counts, err := r.countClusterResources(acct)
+		if err != nil {
+			acctLog.WithError(err).Warn("could not scan account for orphaned infrastructure")
+			// Keep reporting what was found in the account before, rather than losing track of it.
+			for _, o := range previousOrphans {
+				if o.Account.key() == acct.key() && !live[o.InfraID] && orphans[o.InfraID] == nil {
+					orphans[o.InfraID] = o
+				}
+			}
+			continue
+		}
+		for infraID, count := range counts {
+			if live[infraID] || infraID == hubInfraID {
+				continue
+			}
+			// The same cluster can be found in several accounts or regions, such as with global resources. The
+			// region holding most of its resources is taken to be that of the cluster.
+			if o := orphans[infraID]; o == nil || o.Resources < count {
+				orphans[infraID] = &orphan{InfraID: infraID, Account: acct, Resources: count, FirstSeen: now}
+			}
+		}
+	}
+
+	// Infrastructure being deleted by a ClusterDeprovision of the reaper stays orphaned until it is done, even once no
+	// tagged resources are left, so that the ClusterDeprovision remains on record.
+	for infraID := range deprovisioning {
+		if o := previousOrphans[infraID]; o != nil && orphans[infraID] == nil {
+			orphans[infraID] = o
+		}
+	}
+
+	// The infra IDs of the clusters Hive manages are recorded, so that their infrastructure is known to be Hive's
+	// once it is orphaned and the objects that referenced it are gone. An infra ID is forgotten once it is neither
+	// live nor orphaned.
+	managed := map[string]bool{}
+	for infraID := range live {
+		managed[infraID] = true
+	}
+	for infraID := range orphans {
+		if previousManaged[infraID] {
+			managed[infraID] = true
+		}
+	}
+
+	metricOrphanedInfra.Reset()
+	var toDeprovision []*orphan
+	for _, o := range orphans {
+		if previous := previousOrphans[o.InfraID]; previous != nil {
+			o.FirstSeen = previous.FirstSeen
+			o.ClusterDeprovision = previous.ClusterDeprovision
+		}
+		o.Managed = managed[o.InfraID]
+		oLog := logger.WithField("infraID", o.InfraID).WithField("region", o.Account.Region).WithField("resources", o.Resources).
+			WithField("firstSeen", o.FirstSeen).WithField("managed", o.Managed)
+		oLog.Warn("found orphaned infrastructure")
+		metricOrphanedInfra.WithLabelValues(o.Account.Platform, o.Account.Region).Inc()
+
+		if r.DeprovisionAfter == 0 || now.Sub(o.FirstSeen.Time) < r.DeprovisionAfter {
+			continue
+		}
+		if !o.Managed {
+			oLog.Warn("not deprovisioning orphaned infrastructure of a cluster not known to have been managed by hive")
+			continue
+		}
+		o.ClusterDeprovision = apihelpers.GetResourceName(o.InfraID, "orphaned")
+		toDeprovision = append(toDeprovision, o)
+	}
+	logger.WithField("orphans", len(orphans)).Info("finished scanning for orphaned infrastructure")
+
+	// The state is saved first, as the ClusterDeprovisions are only run once they are on record.
+	if err := r.saveState(cm, orphans, accounts, managed); err != nil {
+		return err
+	}
+	for _, o := range toDeprovision {
+		if err := r.ensureDeprovision(o, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// knownAccounts returns the accounts to scan: those found by earlier scans and those of the managed domains,
+// ClusterDeployments and ClusterDeprovisions, less any whose credentials secret no longer exists.
+func (r *Reaper) knownAccounts(previous []account, logger log.FieldLogger) ([]account, error) {
+	candidates := append([]account{}, previous...)
+	hiveNamespace := controllerutils.GetHiveNamespace()
+	for _, md := range r.managedDomains {
+		if md.AWS == nil {
+			continue
+		}
+		region := md.AWS.Region
+		if region == "" {
+			region = constants.AWSRoute53Region
+		}
+		candidates = append(candidates, awsAccount(region, hiveNamespace, md.AWS.CredentialsSecretRef.Name))
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.Client.List(context.TODO(), cdList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error listing cluster deployments")
+		return nil, err
+	}
+	for _, cd := range cdList.Items {
+		if p := cd.Spec.Platform.AWS; p != nil && p.CredentialsSecretRef.Name != "" {
+			candidates = append(candidates, awsAccount(p.Region, cd.Namespace, p.CredentialsSecretRef.Name))
+		}
+	}
+	cdpList := &hivev1.ClusterDeprovisionList{}
+	if err := r.Client.List(context.TODO(), cdpList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error listing cluster deprovisions")
+		return nil, err
+	}
+	for _, cdp := range cdpList.Items {
+		if isReaperClusterDeprovision(&cdp) {
+			// These use a copy of the credentials of an account that is already known.
+			continue
+		}
+		if p := cdp.Spec.Platform.AWS; p != nil && p.CredentialsSecretRef != nil && p.CredentialsSecretRef.Name != "" {
+			candidates = append(candidates, awsAccount(p.Region, cdp.Namespace, p.CredentialsSecretRef.Name))
+		}
+	}
+
+	seen := map[string]bool{}
+	var accounts []account
+	for _, acct := range candidates {
+		if seen[acct.key()] {
+			continue
+		}
+		seen[acct.key()] = true
+		secret := &corev1.Secret{}
+		switch err := r.Client.Get(context.TODO(), client.ObjectKey{Namespace: acct.CredentialsSecretRef.Namespace, Name: acct.CredentialsSecretRef.Name}, secret); {
+		case apierrors.IsNotFound(err):
+			logger.WithField("secret", acct.CredentialsSecretRef.Namespace+"/"+acct.CredentialsSecretRef.Name).
+				Debug("credentials secret no longer exists, no longer scanning account")
+			continue
+		case err != nil:
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting credentials secret")
+			return nil, err
+		}
+		accounts = append(accounts, acct)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].key() < accounts[j].key() })
+	return accounts, nil
+}
+
+func awsAccount(region, namespace, secretName string) account {
+	return account{
+		Platform:             constants.PlatformAWS,
+		Region:               region,
+		CredentialsSecretRef: corev1.SecretReference{Namespace: namespace, Name: secretName},
+	}
+}
+
+// liveInfraIDs returns the infra IDs of the clusters managed by Hive: those of ClusterDeployments, of the
+// ClusterProvisions installing them, and of ClusterDeprovisions still deleting them. The infra IDs of orphaned
+// infrastructure still being deleted by a ClusterDeprovision of the reaper are returned separately.
+func (r *Reaper) liveInfraIDs(logger log.FieldLogger) (live, deprovisioning map[string]bool, err error) {
+	live = map[string]bool{}
+	deprovisioning = map[string]bool{}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.Client.List(context.TODO(), cdList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error listing cluster deployments")
+		return nil, nil, err
+	}
+	for _, cd := range cdList.Items {
+		if cd.Spec.ClusterMetadata != nil {
+			live[cd.Spec.ClusterMetadata.InfraID] = true
+		}
+	}
+
+	provisionList := &hivev1.ClusterProvisionList{}
+	if err := r.Client.List(context.TODO(), provisionList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error listing cluster provisions")
+		return nil, nil, err
+	}
+	for _, provision := range provisionList.Items {
+		if provision.Spec.InfraID != nil {
+			live[*provision.Spec.InfraID] = true
+		}
+	}
+
+	cdpList := &hivev1.ClusterDeprovisionList{}
+	if err := r.Client.List(context.TODO(), cdpList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error listing cluster deprovisions")
+		return nil, nil, err
+	}
+	for _, cdp := range cdpList.Items {
+		if cdp.Status.Completed || cdp.Spec.DryRun {
+			continue
+		}
+		if isReaperClusterDeprovision(&cdp) {
+			deprovisioning[cdp.Spec.InfraID] = true
+			continue
+		}
+		live[cdp.Spec.InfraID] = true
+	}
+	return live, deprovisioning, nil
+}
+
+// hubInfraID returns the infra ID of the hub cluster itself, if it has one.
+func (r *Reaper) hubInfraID(logger log.FieldLogger) (string, error) {
+	infrastructure := &openshiftapiv1.Infrastructure{}
+	switch err := r.Client.Get(context.TODO(), client.ObjectKey{Name: "cluster"}, infrastructure); {
+	case err == nil:
+		return infrastructure.Status.InfrastructureName, nil
+	case apierrors.IsNotFound(err):
+		logger.Debug("hub cluster has no infrastructure")
+		return "", nil
+	default:
+		// Without the infra ID of the hub, its own infrastructure could be mistaken for an orphan.
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting hub cluster infrastructure")
+		return "", err
+	}
+}
+
+// countClusterResources returns the number of resources of each cluster found in the account, by infra ID.
+func (r *Reaper) countClusterResources(acct account) (map[string]int, error) {
+	awsClient, err := r.awsClientFn(r.Client, acct)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create AWS client")
+	}
+	counts := map[string]int{}
+	err = awsClient.GetResourcesPages(&resourcegroupstaggingapi.GetResourcesInput{}, func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+		for _, mapping := range page.ResourceTagMappingList {
+			for _, tag := range mapping.Tags {
+				key := aws.StringValue(tag.Key)
+				if strings.HasPrefix(key, clusterTagPrefix) && aws.StringValue(tag.Value) == "owned" {
+					counts[strings.TrimPrefix(key, clusterTagPrefix)]++
+				}
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting tagged resources")
+	}
+	return counts, nil
+}
+
+// ensureDeprovision creates a ClusterDeprovision for the orphaned infrastructure in the hive namespace. If the
+// credentials it was found with are in another namespace, they are copied alongside the ClusterDeprovision, and
+// deleted along with it.
+func (r *Reaper) ensureDeprovision(o *orphan, logger log.FieldLogger) error {
+	hiveNamespace := controllerutils.GetHiveNamespace()
+	copyCredentials := o.Account.CredentialsSecretRef.Namespace != hiveNamespace
+	secretName := o.Account.CredentialsSecretRef.Name
+	if copyCredentials {
+		secretName = apihelpers.GetResourceName(o.ClusterDeprovision, "creds")
+	}
+	cdp := &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: hiveNamespace,
+			Name:      o.ClusterDeprovision,
+			Labels: map[string]string{
+				constants.OrphanedInfraLabel: "true",
+			},
+		},
+		Spec: hivev1.ClusterDeprovisionSpec{
+			InfraID: o.InfraID,
+			Platform: hivev1.ClusterDeprovisionPlatform{
+				AWS: &hivev1.AWSClusterDeprovision{
+					Region: o.Account.Region,
+					CredentialsSecretRef: &corev1.LocalObjectReference{
+						Name: secretName,
+					},
+				},
+			},
+		},
+	}
+	cdpLog := logger.WithField("infraID", o.InfraID).WithField("clusterDeprovision", cdp.Namespace+"/"+cdp.Name)
+	switch err := r.Client.Create(context.TODO(), cdp); {
+	case apierrors.IsAlreadyExists(err):
+		cdpLog.Debug("cluster deprovision for orphaned infrastructure already exists")
+		if err := r.Client.Get(context.TODO(), client.ObjectKey{Namespace: cdp.Namespace, Name: cdp.Name}, cdp); err != nil {
+			cdpLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster deprovision for orphaned infrastructure")
+			return err
+		}
+	case err != nil:
+		cdpLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating cluster deprovision for orphaned infrastructure")
+		return err
+	default:
+		cdpLog.Info("created cluster deprovision for orphaned infrastructure")
+		metricOrphanedInfraDeprovisionsCreated.WithLabelValues(o.Account.Platform).Inc()
+	}
+	if !copyCredentials || cdp.Status.Completed {
+		return nil
+	}
+	return r.ensureCredentialsCopy(o.Account, cdp, secretName, cdpLog)
+}
+
+// ensureCredentialsCopy copies the credentials secret of the account to the namespace of the ClusterDeprovision,
+// owned by it.
+func (r *Reaper) ensureCredentialsCopy(acct account, cdp *hivev1.ClusterDeprovision, secretName string, logger log.FieldLogger) error {
+	source := &corev1.Secret{}
+	if err := r.Client.Get(context.TODO(), client.ObjectKey{Namespace: acct.CredentialsSecretRef.Namespace, Name: acct.CredentialsSecretRef.Name}, source); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting credentials secret")
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cdp.Namespace,
+			Name:      secretName,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cdp, hivev1.SchemeGroupVersion.WithKind("ClusterDeprovision")),
+			},
+		},
+		Type: source.Type,
+		Data: source.Data,
+	}
+	switch err := r.Client.Create(context.TODO(), secret); {
+	case apierrors.IsAlreadyExists(err):
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error copying credentials secret for orphaned infrastructure")
+		return err
+	}
+	logger.WithField("secret", secret.Name).Info("copied credentials secret for orphaned infrastructure")
+	return nil
+}
+
+// isReaperClusterDeprovision returns whether the ClusterDeprovision claims to have been created by the reaper.
+func isReaperClusterDeprovision(cdp *hivev1.ClusterDeprovision) bool {
+	return cdp.Namespace == controllerutils.GetHiveNamespace() && cdp.Labels[constants.OrphanedInfraLabel] == "true"
+}
+
+// IsOrphanedInfraDeprovision returns whether the ClusterDeprovision was created by the reaper to delete the orphaned
+// infrastructure of a cluster that Hive managed. It must be in the hive namespace, with the orphaned infrastructure
+// label, and be on record in the state of the reaper for a managed orphan with the same infra ID. Such a
+// ClusterDeprovision is run without an owning ClusterDeployment.
+func IsOrphanedInfraDeprovision(c client.Client, cdp *hivev1.ClusterDeprovision) (bool, error) {
+	if !isReaperClusterDeprovision(cdp) {
+		return false, nil
+	}
+	r := &Reaper{Client: c}
+	_, orphans, _, _, err := r.loadState()
+	if err != nil {
+		return false, err
+	}
+	o := orphans[cdp.Spec.InfraID]
+	return o != nil && o.Managed && o.ClusterDeprovision == cdp.Name, nil
+}
+
+func (r *Reaper) loadState() (*corev1.ConfigMap, map[string]*orphan, []account, map[string]bool, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(context.TODO(), client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: stateConfigMapName}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "could not get orphaned infrastructure configmap")
+	}
+	var orphanList []*orphan
+	if data := cm.Data[orphansKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &orphanList); err != nil {
+			return nil, nil, nil, nil, errors.Wrap(err, "could not parse orphaned infrastructure")
+		}
+	}
+	orphans := make(map[string]*orphan, len(orphanList))
+	for _, o := range orphanList {
+		orphans[o.InfraID] = o
+	}
+	var accounts []account
+	if data := cm.Data[accountsKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &accounts); err != nil {
+			return nil, nil, nil, nil, errors.Wrap(err, "could not parse scanned accounts")
+		}
+	}
+	var managedList []string
+	if data := cm.Data[managedKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &managedList); err != nil {
+			return nil, nil, nil, nil, errors.Wrap(err, "could not parse managed infra IDs")
+		}
+	}
+	managed := make(map[string]bool, len(managedList))
+	for _, infraID := range managedList {
+		managed[infraID] = true
+	}
+	return cm, orphans, accounts, managed, nil
+}
+
+func (r *Reaper) saveState(cm *corev1.ConfigMap, orphans map[string]*orphan, accounts []account, managed map[string]bool) error {
+	orphanList := make([]*orphan, 0, len(orphans))
+	for _, o := range orphans {
+		orphanList = append(orphanList, o)
+	}
+	sort.Slice(orphanList, func(i, j int) bool { return orphanList[i].InfraID < orphanList[j].InfraID })
+	orphansData, err := json.MarshalIndent(orphanList, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal orphaned infrastructure")
+	}
+	if accounts == nil {
+		accounts = []account{}
+	}
+	accountsData, err := json.MarshalIndent(accounts, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal scanned accounts")
+	}
+	managedList := make([]string, 0, len(managed))
+	for infraID := range managed {
+		managedList = append(managedList, infraID)
+	}
+	sort.Strings(managedList)
+	managedData, err := json.MarshalIndent(managedList, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal managed infra IDs")
+	}
+	data := map[string]string{
+		orphansKey:  string(orphansData),
+		accountsKey: string(accountsData),
+		managedKey:  string(managedData),
+	}
+
+	if cm == nil {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: controllerutils.GetHiveNamespace(),
+				Name:      stateConfigMapName,
+			},
+			Data: data,
+		}
+		return errors.Wrap(r.Client.Create(context.TODO(), cm), "could not create orphaned infrastructure configmap")
+	}
+	cm.Data = data
+	return errors.Wrap(r.Client.Update(context.TODO(), cm), "could not update orphaned infrastructure configmap")
+}
+
+func getAWSClient(c client.Client, acct account) (awsclient.Client, error) {
+	return awsclient.NewClient(c, acct.CredentialsSecretRef.Name, acct.CredentialsSecretRef.Namespace, acct.Region)
+}
@@ -0,0 +1,440 @@
+package orphanedinfra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	testNamespace     = "test-namespace"
+	testSecretName    = "aws-creds"
+	testRegion        = "us-east-1"
+	testHubInfraID    = "hub-abcde"
+	testLiveInfraID   = "live-abcde"
+	testOrphanInfraID = "orphan-abcde"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReaperScan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	openshiftapiv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name                       string
+		existing                   []runtime.Object
+		managedDomains             []hivev1.ManageDNSConfig
+		deprovisionAfter           time.Duration
+		taggedInfraIDs             []string
+		scanError                  error
+		expectedScans              int
+		expectedOrphans            []string
+		expectedManaged            []string
+		expectedClusterDeprovision bool
+	}{
+		{
+			name:     "no accounts",
+			existing: []runtime.Object{testSecret(testNamespace)},
+		},
+		{
+			name: "orphan found in cluster deployment account",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testClusterDeployment(testLiveInfraID),
+			},
+			taggedInfraIDs:  []string{testLiveInfraID, testOrphanInfraID, testOrphanInfraID, testHubInfraID},
+			expectedScans:   1,
+			expectedOrphans: []string{testOrphanInfraID},
+			expectedManaged: []string{testLiveInfraID},
+		},
+		{
+			name:     "orphan found in managed domain account",
+			existing: []runtime.Object{testSecret(constants.DefaultHiveNamespace)},
+			managedDomains: []hivev1.ManageDNSConfig{{
+				Domains: []string{"example.com"},
+				AWS: &hivev1.ManageDNSAWSConfig{
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: testSecretName},
+				},
+			}},
+			taggedInfraIDs:  []string{testOrphanInfraID},
+			expectedScans:   1,
+			expectedOrphans: []string{testOrphanInfraID},
+		},
+		{
+			name: "cluster being provisioned is not orphaned",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testClusterDeployment(""),
+				testClusterProvision(testOrphanInfraID),
+			},
+			taggedInfraIDs:  []string{testOrphanInfraID},
+			expectedScans:   1,
+			expectedManaged: []string{testOrphanInfraID},
+		},
+		{
+			name: "cluster being deprovisioned is not orphaned",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testClusterDeprovision(testOrphanInfraID),
+			},
+			taggedInfraIDs:  []string{testOrphanInfraID},
+			expectedScans:   1,
+			expectedManaged: []string{testOrphanInfraID},
+		},
+		{
+			name: "orphan of managed cluster",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testStateConfigMap(nil, testOrphanInfraID),
+			},
+			taggedInfraIDs:  []string{testOrphanInfraID},
+			expectedScans:   1,
+			expectedOrphans: []string{testOrphanInfraID},
+			expectedManaged: []string{testOrphanInfraID},
+		},
+		{
+			name: "managed cluster forgotten once its infrastructure is gone",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testStateConfigMap(nil, testOrphanInfraID),
+			},
+			expectedScans: 1,
+		},
+		{
+			name: "account from earlier scan",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testStateConfigMap(nil),
+			},
+			taggedInfraIDs:  []string{testOrphanInfraID},
+			expectedScans:   1,
+			expectedOrphans: []string{testOrphanInfraID},
+		},
+		{
+			name:     "account from earlier scan dropped when secret deleted",
+			existing: []runtime.Object{testStateConfigMap(nil)},
+		},
+		{
+			name: "orphans from earlier scan kept when scan fails",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testStateConfigMap([]*orphan{testOrphan(time.Hour)}),
+			},
+			scanError:       errors.New("access denied"),
+			expectedScans:   1,
+			expectedOrphans: []string{testOrphanInfraID},
+		},
+		{
+			name: "no deprovision when not enabled",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testStateConfigMap([]*orphan{testOrphan(48 * time.Hour)}),
+			},
+			taggedInfraIDs:  []string{testOrphanInfraID},
+			expectedScans:   1,
+			expectedOrphans: []string{testOrphanInfraID},
+		},
+		{
+			name: "no deprovision before grace period",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testStateConfigMap([]*orphan{testOrphan(time.Hour)}),
+			},
+			deprovisionAfter: 24 * time.Hour,
+			taggedInfraIDs:   []string{testOrphanInfraID},
+			expectedScans:    1,
+			expectedOrphans:  []string{testOrphanInfraID},
+		},
+		{
+			name: "no deprovision of unmanaged cluster",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testStateConfigMap([]*orphan{testOrphan(48 * time.Hour)}),
+			},
+			deprovisionAfter: 24 * time.Hour,
+			taggedInfraIDs:   []string{testOrphanInfraID},
+			expectedScans:    1,
+			expectedOrphans:  []string{testOrphanInfraID},
+		},
+		{
+			name: "deprovision after grace period",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testStateConfigMap([]*orphan{testOrphan(48 * time.Hour)}, testOrphanInfraID),
+			},
+			deprovisionAfter:           24 * time.Hour,
+			taggedInfraIDs:             []string{testOrphanInfraID},
+			expectedScans:              1,
+			expectedOrphans:            []string{testOrphanInfraID},
+			expectedManaged:            []string{testOrphanInfraID},
+			expectedClusterDeprovision: true,
+		},
+		{
+			name: "orphan kept while being deprovisioned",
+			existing: []runtime.Object{
+				testSecret(testNamespace),
+				testStateConfigMap([]*orphan{testDeprovisioningOrphan()}, testOrphanInfraID),
+				testReaperClusterDeprovision(),
+			},
+			deprovisionAfter:           24 * time.Hour,
+			expectedScans:              1,
+			expectedOrphans:            []string{testOrphanInfraID},
+			expectedManaged:            []string{testOrphanInfraID},
+			expectedClusterDeprovision: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockAWSClient := mockaws.NewMockClient(mockCtrl)
+			mockAWSClient.EXPECT().
+				GetResourcesPages(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+					if test.scanError != nil {
+						return test.scanError
+					}
+					output := &resourcegroupstaggingapi.GetResourcesOutput{}
+					for _, infraID := range test.taggedInfraIDs {
+						output.ResourceTagMappingList = append(output.ResourceTagMappingList, &resourcegroupstaggingapi.ResourceTagMapping{
+							ResourceARN: aws.String("arn:aws:ec2:us-east-1:123456789012:instance/i-" + infraID),
+							Tags: []*resourcegroupstaggingapi.Tag{
+								{Key: aws.String("Name"), Value: aws.String(infraID)},
+								{Key: aws.String("kubernetes.io/cluster/" + infraID), Value: aws.String("owned")},
+							},
+						})
+					}
+					fn(output, true)
+					return nil
+				}).
+				Times(test.expectedScans)
+
+			existing := append(test.existing, testHubInfrastructure())
+			c := fake.NewFakeClientWithScheme(scheme, existing...)
+			r := &Reaper{
+				Client:           c,
+				ScanInterval:     defaultScanInterval,
+				DeprovisionAfter: test.deprovisionAfter,
+				managedDomains:   test.managedDomains,
+				awsClientFn: func(client.Client, account) (awsclient.Client, error) {
+					return mockAWSClient, nil
+				},
+			}
+
+			err := r.scan(log.WithField("controller", ControllerName))
+			require.NoError(t, err, "unexpected error from scan")
+
+			cm := &corev1.ConfigMap{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: stateConfigMapName}, cm)
+			require.NoError(t, err, "expected state configmap")
+			var orphans []*orphan
+			require.NoError(t, json.Unmarshal([]byte(cm.Data[orphansKey]), &orphans), "could not parse orphans")
+			var infraIDs []string
+			for _, o := range orphans {
+				infraIDs = append(infraIDs, o.InfraID)
+			}
+			sort.Strings(infraIDs)
+			assert.Equal(t, test.expectedOrphans, infraIDs, "unexpected orphans")
+			var managed []string
+			require.NoError(t, json.Unmarshal([]byte(cm.Data[managedKey]), &managed), "could not parse managed infra IDs")
+			if test.expectedManaged == nil {
+				test.expectedManaged = []string{}
+			}
+			assert.Equal(t, test.expectedManaged, managed, "unexpected managed infra IDs")
+			for _, o := range orphans {
+				assert.Equal(t, sets.NewString(test.expectedManaged...).Has(o.InfraID), o.Managed, "unexpected managed orphan %s", o.InfraID)
+			}
+
+			cdpList := &hivev1.ClusterDeprovisionList{}
+			require.NoError(t, c.List(context.TODO(), cdpList, client.MatchingLabels{constants.OrphanedInfraLabel: "true"}))
+			if !test.expectedClusterDeprovision {
+				assert.Empty(t, cdpList.Items, "expected no cluster deprovision")
+				return
+			}
+			if assert.Len(t, cdpList.Items, 1, "expected cluster deprovision") {
+				cdp := cdpList.Items[0]
+				assert.Equal(t, controllerutils.GetHiveNamespace(), cdp.Namespace, "unexpected cluster deprovision namespace")
+				assert.Equal(t, apihelpers.GetResourceName(testOrphanInfraID, "orphaned"), cdp.Name, "unexpected cluster deprovision name")
+				assert.Equal(t, testOrphanInfraID, cdp.Spec.InfraID, "unexpected infra ID")
+				credsName := apihelpers.GetResourceName(cdp.Name, "creds")
+				if assert.NotNil(t, cdp.Spec.Platform.AWS, "expected AWS platform") {
+					assert.Equal(t, testRegion, cdp.Spec.Platform.AWS.Region, "unexpected region")
+					assert.Equal(t, credsName, cdp.Spec.Platform.AWS.CredentialsSecretRef.Name, "unexpected credentials secret")
+				}
+				assert.Equal(t, cdp.Name, orphans[0].ClusterDeprovision, "expected cluster deprovision to be recorded")
+
+				secret := &corev1.Secret{}
+				if assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: cdp.Namespace, Name: credsName}, secret), "expected copied credentials secret") {
+					assert.Equal(t, testSecretData(), secret.Data, "unexpected copied credentials")
+					if assert.Len(t, secret.OwnerReferences, 1, "expected copied credentials to be owned") {
+						assert.Equal(t, cdp.Name, secret.OwnerReferences[0].Name, "unexpected owner of copied credentials")
+					}
+				}
+
+				created, err := IsOrphanedInfraDeprovision(c, &cdp)
+				require.NoError(t, err, "unexpected error checking cluster deprovision")
+				assert.True(t, created, "expected cluster deprovision to be recognised as created by the reaper")
+			}
+		})
+	}
+}
+
+func testSecret(namespace string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      testSecretName,
+		},
+		Data: testSecretData(),
+	}
+}
+
+func testSecretData() map[string][]byte {
+	return map[string][]byte{
+		"aws_access_key_id":     []byte("key"),
+		"aws_secret_access_key": []byte("secret"),
+	}
+}
+
+func testClusterDeployment(infraID string) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "test-cluster",
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					Region:               testRegion,
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: testSecretName},
+				},
+			},
+		},
+	}
+	if infraID != "" {
+		cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{InfraID: infraID}
+	}
+	return cd
+}
+
+func testClusterProvision(infraID string) *hivev1.ClusterProvision {
+	return &hivev1.ClusterProvision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "test-cluster-0-abcde",
+		},
+		Spec: hivev1.ClusterProvisionSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{Name: "test-cluster"},
+			InfraID:              aws.String(infraID),
+		},
+	}
+}
+
+func testClusterDeprovision(infraID string) *hivev1.ClusterDeprovision {
+	return &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "test-cluster",
+		},
+		Spec: hivev1.ClusterDeprovisionSpec{
+			InfraID: infraID,
+			Platform: hivev1.ClusterDeprovisionPlatform{
+				AWS: &hivev1.AWSClusterDeprovision{
+					Region:               testRegion,
+					CredentialsSecretRef: &corev1.LocalObjectReference{Name: testSecretName},
+				},
+			},
+		},
+	}
+}
+
+func testReaperClusterDeprovision() *hivev1.ClusterDeprovision {
+	cdp := testClusterDeprovision(testOrphanInfraID)
+	cdp.Namespace = controllerutils.GetHiveNamespace()
+	cdp.Name = apihelpers.GetResourceName(testOrphanInfraID, "orphaned")
+	cdp.Labels = map[string]string{constants.OrphanedInfraLabel: "true"}
+	cdp.Spec.Platform.AWS.CredentialsSecretRef.Name = apihelpers.GetResourceName(cdp.Name, "creds")
+	return cdp
+}
+
+func testHubInfrastructure() *openshiftapiv1.Infrastructure {
+	return &openshiftapiv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Status: openshiftapiv1.InfrastructureStatus{
+			InfrastructureName: testHubInfraID,
+		},
+	}
+}
+
+func testAccount() account {
+	return awsAccount(testRegion, testNamespace, testSecretName)
+}
+
+func testOrphan(age time.Duration) *orphan {
+	return &orphan{
+		InfraID:   testOrphanInfraID,
+		Account:   testAccount(),
+		Resources: 1,
+		FirstSeen: metav1.NewTime(time.Now().Add(-age)),
+	}
+}
+
+func testDeprovisioningOrphan() *orphan {
+	o := testOrphan(48 * time.Hour)
+	o.Managed = true
+	o.ClusterDeprovision = apihelpers.GetResourceName(testOrphanInfraID, "orphaned")
+	return o
+}
+
+func testStateConfigMap(orphans []*orphan, managed ...string) *corev1.ConfigMap {
+	if orphans == nil {
+		orphans = []*orphan{}
+	}
+	orphansData, _ := json.Marshal(orphans)
+	accountsData, _ := json.Marshal([]account{testAccount()})
+	managedData, _ := json.Marshal(managed)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controllerutils.GetHiveNamespace(),
+			Name:      stateConfigMapName,
+		},
+		Data: map[string]string{
+			orphansKey:  string(orphansData),
+			accountsKey: string(accountsData),
+			managedKey:  string(managedData),
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"strconv"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -18,3 +19,53 @@ func IsClaimedClusterMarkedForRemoval(cd *hivev1.ClusterDeployment) bool {
 	}
 	return toRemove
 }
+
+// ClusterClaimRequester returns the user that created the claim and the groups of the user, as recorded on the claim
+// by the ClusterClaim admission webhook.
+func ClusterClaimRequester(claim *hivev1.ClusterClaim) (user string, groups []string) {
+	user = claim.Annotations[constants.ClusterClaimRequesterAnnotation]
+	if v := claim.Annotations[constants.ClusterClaimRequesterGroupsAnnotation]; v != "" {
+		if err := json.Unmarshal([]byte(v), &groups); err != nil {
+			groups = nil
+		}
+	}
+	return user, groups
+}
+
+// ClusterClaimPoolName returns the name of the pool from which the claim requests a cluster, or from which it was
+// assigned one when the claim selects its pool by label.
+func ClusterClaimPoolName(claim *hivev1.ClusterClaim) string {
+	if claim.Spec.ClusterPoolName != "" {
+		return claim.Spec.ClusterPoolName
+	}
+	return claim.Status.ClusterPoolName
+}
+
+// ClusterClaimMatchesQuota returns true if the claim quota of a pool applies to the claim.
+func ClusterClaimMatchesQuota(claim *hivev1.ClusterClaim, quota *hivev1.ClusterPoolClaimQuota) bool {
+	user, groups := ClusterClaimRequester(claim)
+	switch {
+	case quota.Namespace != "":
+		return claim.Namespace == quota.Namespace
+	case quota.User != "":
+		return user == quota.User
+	case quota.Group != "":
+		for _, group := range groups {
+			if group == quota.Group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ClusterPoolClaimQuotaName identifies a claim quota of a pool in messages and metrics.
+func ClusterPoolClaimQuotaName(quota *hivev1.ClusterPoolClaimQuota) string {
+	switch {
+	case quota.User != "":
+		return "user:" + quota.User
+	case quota.Group != "":
+		return "group:" + quota.Group
+	}
+	return "namespace:" + quota.Namespace
+}
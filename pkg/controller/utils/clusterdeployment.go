@@ -3,8 +3,10 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,6 +15,65 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 )
 
+// DefaultExpirationWarningLeadTimes are the warning lead times used for a ClusterDeployment expiration which sets none.
+var DefaultExpirationWarningLeadTimes = []time.Duration{24 * time.Hour, time.Hour}
+
+// ExpirationTime returns the time at which the cluster deployment is deleted, computed from the Expiration of the
+// spec, or from the delete-after annotation if there is no Expiration. Nil is returned if the cluster deployment does
+// not expire, or if its expiration is relative to a creation time which has not been set yet.
+func ExpirationTime(cd *hivev1.ClusterDeployment) (*time.Time, error) {
+	var deleteAfter time.Duration
+	switch exp := cd.Spec.Expiration; {
+	case exp != nil && exp.DeleteAt != nil:
+		deleteAt := exp.DeleteAt.Time
+		return &deleteAt, nil
+	case exp != nil && exp.DeleteAfter != nil:
+		deleteAfter = exp.DeleteAfter.Duration
+	default:
+		value, ok := cd.Annotations[constants.DeleteAfterAnnotation]
+		if !ok {
+			return nil, nil
+		}
+		var err error
+		if deleteAfter, err = time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("error parsing %s as a duration: %v", constants.DeleteAfterAnnotation, err)
+		}
+	}
+	if cd.CreationTimestamp.IsZero() {
+		return nil, nil
+	}
+	deleteAt := cd.CreationTimestamp.Add(deleteAfter)
+	return &deleteAt, nil
+}
+
+// OriginalExpirationTime returns the first expiration of the cluster deployment, as recorded by hive in the
+// original-expiration annotation, or nil if it has not been recorded.
+func OriginalExpirationTime(cd *hivev1.ClusterDeployment) (*time.Time, error) {
+	value, ok := cd.Annotations[constants.OriginalExpirationAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	originalExpiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s as a time: %v", constants.OriginalExpirationAnnotation, err)
+	}
+	return &originalExpiry, nil
+}
+
+// ExpirationWarningLeadTimes returns the warning lead times for the expiration of the cluster deployment, from the
+// largest to the smallest.
+func ExpirationWarningLeadTimes(cd *hivev1.ClusterDeployment) []time.Duration {
+	if cd.Spec.Expiration == nil || len(cd.Spec.Expiration.WarningLeadTimes) == 0 {
+		return DefaultExpirationWarningLeadTimes
+	}
+	leadTimes := make([]time.Duration, len(cd.Spec.Expiration.WarningLeadTimes))
+	for i, leadTime := range cd.Spec.Expiration.WarningLeadTimes {
+		leadTimes[i] = leadTime.Duration
+	}
+	sort.Slice(leadTimes, func(i, j int) bool { return leadTimes[i] > leadTimes[j] })
+	return leadTimes
+}
+
 func IsDeleteProtected(cd *hivev1.ClusterDeployment) bool {
 	protectedDelete, err := strconv.ParseBool(cd.Annotations[constants.ProtectedDeleteAnnotation])
 	return protectedDelete && err == nil
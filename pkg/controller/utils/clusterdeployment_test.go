@@ -2,11 +2,14 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -57,6 +60,105 @@ func TestIsDeleteProtected(t *testing.T) {
 	}
 }
 
+func TestExpirationTime(t *testing.T) {
+	created := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	deleteAt := time.Date(2021, 6, 3, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name        string
+		options     []clusterdeployment.Option
+		expected    *time.Time
+		expectedErr bool
+	}{
+		{
+			name: "no expiration",
+			options: []clusterdeployment.Option{
+				clusterdeployment.Generic(generic.WithCreationTimestamp(created)),
+			},
+		},
+		{
+			name: "delete at",
+			options: []clusterdeployment.Option{
+				clusterdeployment.Generic(generic.WithCreationTimestamp(created)),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.Expiration = &hivev1.ClusterExpiration{DeleteAt: &metav1.Time{Time: deleteAt}}
+				},
+			},
+			expected: &deleteAt,
+		},
+		{
+			name: "delete after",
+			options: []clusterdeployment.Option{
+				clusterdeployment.Generic(generic.WithCreationTimestamp(created)),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.Expiration = &hivev1.ClusterExpiration{DeleteAfter: &metav1.Duration{Duration: 8 * time.Hour}}
+				},
+			},
+			expected: func() *time.Time { t := created.Add(8 * time.Hour); return &t }(),
+		},
+		{
+			name: "delete after without creation timestamp",
+			options: []clusterdeployment.Option{
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.Expiration = &hivev1.ClusterExpiration{DeleteAfter: &metav1.Duration{Duration: 8 * time.Hour}}
+				},
+			},
+		},
+		{
+			name: "annotation",
+			options: []clusterdeployment.Option{
+				clusterdeployment.Generic(generic.WithCreationTimestamp(created)),
+				clusterdeployment.Generic(generic.WithAnnotation(constants.DeleteAfterAnnotation, "2h")),
+			},
+			expected: func() *time.Time { t := created.Add(2 * time.Hour); return &t }(),
+		},
+		{
+			name: "expiration takes precedence over annotation",
+			options: []clusterdeployment.Option{
+				clusterdeployment.Generic(generic.WithCreationTimestamp(created)),
+				clusterdeployment.Generic(generic.WithAnnotation(constants.DeleteAfterAnnotation, "2h")),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.Expiration = &hivev1.ClusterExpiration{DeleteAt: &metav1.Time{Time: deleteAt}}
+				},
+			},
+			expected: &deleteAt,
+		},
+		{
+			name: "invalid annotation",
+			options: []clusterdeployment.Option{
+				clusterdeployment.Generic(generic.WithCreationTimestamp(created)),
+				clusterdeployment.Generic(generic.WithAnnotation(constants.DeleteAfterAnnotation, "tomorrow")),
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := clusterdeployment.Build(tc.options...)
+			actual, err := ExpirationTime(cd)
+			if tc.expectedErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			if tc.expected == nil {
+				assert.Nil(t, actual, "expected no expiration time")
+			} else if assert.NotNil(t, actual, "expected expiration time") {
+				assert.True(t, tc.expected.Equal(*actual), "unexpected expiration time %s", actual)
+			}
+		})
+	}
+}
+
+func TestExpirationWarningLeadTimes(t *testing.T) {
+	cd := clusterdeployment.Build()
+	assert.Equal(t, DefaultExpirationWarningLeadTimes, ExpirationWarningLeadTimes(cd), "expected default lead times")
+
+	cd.Spec.Expiration = &hivev1.ClusterExpiration{
+		WarningLeadTimes: []metav1.Duration{{Duration: time.Hour}, {Duration: 72 * time.Hour}, {Duration: 10 * time.Minute}},
+	}
+	assert.Equal(t, []time.Duration{72 * time.Hour, time.Hour, 10 * time.Minute}, ExpirationWarningLeadTimes(cd), "expected sorted lead times")
+}
+
 func TestIsClusterPausedOrRelocating(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -94,6 +94,7 @@ func SetClusterDeploymentConditionWithChangeCheck(
 	message string,
 	updateConditionCheck UpdateConditionCheck,
 ) ([]hivev1.ClusterDeploymentCondition, bool) {
+	message = Redact(message)
 	changed := false
 	now := metav1.Now()
 	existingCondition := FindClusterDeploymentCondition(conditions, conditionType)
@@ -206,6 +207,7 @@ func SetClusterClaimConditionWithChangeCheck(
 	message string,
 	updateConditionCheck UpdateConditionCheck,
 ) ([]hivev1.ClusterClaimCondition, bool) {
+	message = Redact(message)
 	changed := false
 	now := metav1.Now()
 	existingCondition := FindClusterClaimCondition(conditions, conditionType)
@@ -293,6 +295,7 @@ func SetClusterPoolConditionWithChangeCheck(
 	message string,
 	updateConditionCheck UpdateConditionCheck,
 ) ([]hivev1.ClusterPoolCondition, bool) {
+	message = Redact(message)
 	changed := false
 	now := metav1.Now()
 	existingCondition := FindClusterPoolCondition(conditions, conditionType)
@@ -337,6 +340,7 @@ func SetClusterProvisionCondition(
 	message string,
 	updateConditionCheck UpdateConditionCheck,
 ) []hivev1.ClusterProvisionCondition {
+	message = Redact(message)
 	now := metav1.Now()
 	existingCondition := FindClusterProvisionCondition(conditions, conditionType)
 	if existingCondition == nil {
@@ -380,6 +384,7 @@ func SetSyncCondition(
 	message string,
 	updateConditionCheck UpdateConditionCheck,
 ) []hivev1.SyncCondition {
+	message = Redact(message)
 	now := metav1.Now()
 	existingCondition := FindSyncCondition(conditions, conditionType)
 	if existingCondition == nil {
@@ -445,6 +450,7 @@ func SetDNSZoneConditionWithChangeCheck(
 	message string,
 	updateConditionCheck UpdateConditionCheck,
 ) ([]hivev1.DNSZoneCondition, bool) {
+	message = Redact(message)
 	changed := false
 	now := metav1.Now()
 	existingCondition := FindDNSZoneCondition(conditions, conditionType)
@@ -533,6 +539,7 @@ func SetMachinePoolConditionWithChangeCheck(
 	message string,
 	updateConditionCheck UpdateConditionCheck,
 ) ([]hivev1.MachinePoolCondition, bool) {
+	message = Redact(message)
 	changed := false
 	now := metav1.Now()
 	existingCondition := FindMachinePoolCondition(conditions, conditionType)
@@ -599,6 +606,7 @@ func SetClusterDeprovisionConditionWithChangeCheck(
 	message string,
 	updateConditionCheck UpdateConditionCheck,
 ) ([]hivev1.ClusterDeprovisionCondition, bool) {
+	message = Redact(message)
 	changed := false
 	now := metav1.Now()
 	existingCondition := FindClusterDeprovisionCondition(conditions, conditionType)
@@ -648,6 +656,7 @@ func SetClusterInstallConditionWithChangeCheck(
 	message string,
 	updateConditionCheck UpdateConditionCheck,
 ) ([]hivev1.ClusterInstallCondition, bool) {
+	message = Redact(message)
 
 	changed := false
 	now := metav1.Now()
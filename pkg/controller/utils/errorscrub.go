@@ -11,11 +11,12 @@ var (
 
 // ErrorScrub scrubs cloud error messages destined for CRD status to remove things that
 // change every attempt, such as request IDs, which subsequently cause an infinite update/reconcile loop.
+// The additional redaction rules configured in HiveConfig are applied as well.
 func ErrorScrub(err error) string {
 	if err == nil {
 		return ""
 	}
 	s := newlineTabRE.ReplaceAllString(err.Error(), ", ")
 	s = awsRequestIDRE.ReplaceAllString(s, "")
-	return s
+	return Redact(s)
 }
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/util/redact"
+)
+
+// redactor holds the additional redaction rules configured in HiveConfig. It is set once when the controllers start.
+var redactor *redact.Redactor
+
+// LoadRedactionRules loads the additional redaction rules from the ConfigMap named by the environment variable, if set.
+// The rules are then applied to condition messages and passed on to install pods. An error is returned if the
+// ConfigMap cannot be read or parsed.
+func LoadRedactionRules(c client.Reader) error {
+	name := os.Getenv(constants.RedactionRulesConfigMapEnvVar)
+	if name == "" {
+		return nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: GetHiveNamespace(), Name: name}, cm); err != nil {
+		return errors.Wrap(err, "failed to read redaction rules ConfigMap")
+	}
+	r, err := redact.FromConfigMap(cm)
+	if err != nil {
+		return err
+	}
+	SetRedactor(r)
+	return nil
+}
+
+// SetRedactor sets the additional redaction rules.
+func SetRedactor(r *redact.Redactor) {
+	redactor = r
+}
+
+// GetRedactor returns the additional redaction rules, or nil if none are configured.
+func GetRedactor() *redact.Redactor {
+	return redactor
+}
+
+// Redact applies the additional redaction rules to the text.
+func Redact(s string) string {
+	return redactor.Redact(s)
+}
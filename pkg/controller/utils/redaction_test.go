@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testRedactionRules = `
+- name: hostnames
+  regex: '[a-z0-9-]+\.corp\.example\.com'
+  replacement: REDACTED-HOST
+`
+
+func TestLoadRedactionRules(t *testing.T) {
+	cases := []struct {
+		name          string
+		configMapName string
+		existing      []runtime.Object
+		expectedText  string
+		expectedError bool
+	}{
+		{
+			name:         "not configured",
+			expectedText: "lookup proxy-1.corp.example.com",
+		},
+		{
+			name:          "configured",
+			configMapName: "redaction-rules",
+			existing:      []runtime.Object{testRedactionRulesConfigMap(testRedactionRules)},
+			expectedText:  "lookup REDACTED-HOST",
+		},
+		{
+			name:          "missing configmap",
+			configMapName: "redaction-rules",
+			expectedError: true,
+		},
+		{
+			name:          "invalid rules",
+			configMapName: "redaction-rules",
+			existing:      []runtime.Object{testRedactionRulesConfigMap("- name: bad\n  regex: '('\n")},
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.configMapName != "" {
+				os.Setenv(constants.RedactionRulesConfigMapEnvVar, tc.configMapName)
+				defer os.Unsetenv(constants.RedactionRulesConfigMapEnvVar)
+			}
+			defer SetRedactor(nil)
+			err := LoadRedactionRules(fake.NewFakeClient(tc.existing...))
+			if tc.expectedError {
+				assert.Error(t, err, "expected error loading redaction rules")
+				return
+			}
+			require.NoError(t, err, "unexpected error loading redaction rules")
+			assert.Equal(t, tc.expectedText, Redact("lookup proxy-1.corp.example.com"), "unexpected redacted text")
+		})
+	}
+}
+
+func TestRedactionRulesApplied(t *testing.T) {
+	cm := testRedactionRulesConfigMap(testRedactionRules)
+	os.Setenv(constants.RedactionRulesConfigMapEnvVar, cm.Name)
+	defer os.Unsetenv(constants.RedactionRulesConfigMapEnvVar)
+	defer SetRedactor(nil)
+	require.NoError(t, LoadRedactionRules(fake.NewFakeClient(cm)), "unexpected error loading redaction rules")
+
+	assert.Equal(t,
+		"dial tcp: lookup REDACTED-HOST, status code: 500",
+		ErrorScrub(errors.New("dial tcp: lookup api.corp.example.com\n\tstatus code: 500, request id: 0604c1a4-0a68-4d1a-b8e6-cdcf68176d71")),
+		"unexpected scrubbed error")
+
+	conditions, changed := SetClusterDeploymentConditionWithChangeCheck(
+		nil,
+		hivev1.ProvisionFailedCondition,
+		corev1.ConditionTrue,
+		"InstallFailed",
+		"failed to reach registry.corp.example.com",
+		UpdateConditionIfReasonOrMessageChange,
+	)
+	assert.True(t, changed, "expected conditions to change")
+	require.Len(t, conditions, 1, "unexpected number of conditions")
+	assert.Equal(t, "failed to reach REDACTED-HOST", conditions[0].Message, "unexpected condition message")
+
+	_, changed = SetClusterDeploymentConditionWithChangeCheck(
+		conditions,
+		hivev1.ProvisionFailedCondition,
+		corev1.ConditionTrue,
+		"InstallFailed",
+		"failed to reach registry.corp.example.com",
+		UpdateConditionIfReasonOrMessageChange,
+	)
+	assert.False(t, changed, "expected redacted message to match the existing condition")
+}
+
+func TestLoadRedactionRulesKeepsPreviousRules(t *testing.T) {
+	os.Setenv(constants.RedactionRulesConfigMapEnvVar, "redaction-rules")
+	defer os.Unsetenv(constants.RedactionRulesConfigMapEnvVar)
+	defer SetRedactor(nil)
+	require.NoError(t, LoadRedactionRules(fake.NewFakeClient(testRedactionRulesConfigMap(testRedactionRules))), "unexpected error loading redaction rules")
+
+	err := LoadRedactionRules(fake.NewFakeClient(testRedactionRulesConfigMap("- name: bad\n  regex: '('\n")))
+	assert.Error(t, err, "expected error loading invalid redaction rules")
+	assert.Equal(t, "lookup REDACTED-HOST", Redact("lookup proxy-1.corp.example.com"), "expected previous rules to be kept")
+}
+
+func testRedactionRulesConfigMap(rules string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redaction-rules",
+			Namespace: constants.DefaultHiveNamespace,
+		},
+		Data: map[string]string{
+			"rules": rules,
+		},
+	}
+}
@@ -0,0 +1,386 @@
+package deprovision
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/hive/pkg/awsclient"
+)
+
+// AWSLister lists the AWS resources that the aws-tag-deprovision destroyer would delete, following the same search:
+//   - resources matching any of a set of tag filters, in the cluster region and in the global region
+//   - IAM roles and users matching any of the tag filters
+//   - the untaggable master and worker IAM instance profiles of the cluster
+//   - the record sets of the cluster's private hosted zones, and the matching record sets in the shared public zone
+//
+// A resource matches a filter if it has all of the tags of the filter. Terminated EC2 instances, which the tagging
+// API continues to return for a while, are not listed.
+type AWSLister struct {
+	Client awsclient.Client
+	// GlobalClient is a client for the global region of the partition of the cluster region, see AWSGlobalRegion.
+	// It is nil when the cluster is in the global region.
+	GlobalClient awsclient.Client
+	// ClusterID is the infra ID of the cluster, which prefixes the names of the untaggable resources.
+	ClusterID string
+	Filters   []map[string]string
+}
+
+// AWSGlobalRegion returns the region holding the global resources, such as CloudFront distributions and Route53
+// hosted zones, for the partition of the given region.
+func AWSGlobalRegion(region string) string {
+	switch region {
+	case endpoints.CnNorth1RegionID, endpoints.CnNorthwest1RegionID:
+		return endpoints.CnNorthwest1RegionID
+	case endpoints.UsGovEast1RegionID, endpoints.UsGovWest1RegionID:
+		return endpoints.UsGovWest1RegionID
+	default:
+		return endpoints.UsEast1RegionID
+	}
+}
+
+// ListResources implements Lister.
+func (l *AWSLister) ListResources() ([]Resource, error) {
+	seen := map[string]bool{}
+	var resources []Resource
+	add := func(r Resource) {
+		key := r.String()
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		resources = append(resources, r)
+	}
+
+	tagClients := []awsclient.Client{l.Client}
+	if l.GlobalClient != nil {
+		tagClients = append(tagClients, l.GlobalClient)
+	}
+	for _, client := range tagClients {
+		if err := l.listTaggedResources(client, add); err != nil {
+			return nil, err
+		}
+	}
+	if err := l.listIAMRoles(add); err != nil {
+		return nil, err
+	}
+	if err := l.listIAMUsers(add); err != nil {
+		return nil, err
+	}
+	if err := l.listInstanceProfiles(add); err != nil {
+		return nil, err
+	}
+
+	var hostedZones []string
+	for _, r := range resources {
+		if r.Type == "route53:hostedzone" {
+			hostedZones = append(hostedZones, r.ID[strings.LastIndex(r.ID, "/")+1:])
+		}
+	}
+	for _, zoneID := range hostedZones {
+		if err := l.listRecordSets(zoneID, add); err != nil {
+			return nil, err
+		}
+	}
+	return l.withoutTerminatedInstances(resources)
+}
+
+func (l *AWSLister) listTaggedResources(client awsclient.Client, add func(Resource)) error {
+	for _, filter := range l.Filters {
+		input := &resourcegroupstaggingapi.GetResourcesInput{}
+		for key, value := range filter {
+			input.TagFilters = append(input.TagFilters, &resourcegroupstaggingapi.TagFilter{
+				Key:    aws.String(key),
+				Values: aws.StringSlice([]string{value}),
+			})
+		}
+		err := client.GetResourcesPages(input, func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, mapping := range page.ResourceTagMappingList {
+				id := aws.StringValue(mapping.ResourceARN)
+				add(Resource{Type: awsResourceType(id), ID: id})
+			}
+			return !lastPage
+		})
+		if err != nil {
+			return errors.Wrap(err, "error getting tagged resources")
+		}
+	}
+	return nil
+}
+
+// listIAMRoles lists the IAM roles matching the filters. The tags of a role are not returned when listing roles, so
+// each role has to be fetched.
+func (l *AWSLister) listIAMRoles(add func(Resource)) error {
+	var lastErr error
+	err := l.Client.ListRolesPages(&iam.ListRolesInput{}, func(page *iam.ListRolesOutput, lastPage bool) bool {
+		for _, role := range page.Roles {
+			output, err := l.Client.GetRole(&iam.GetRoleInput{RoleName: role.RoleName})
+			if isAWSErrorCode(err, iam.ErrCodeNoSuchEntityException) {
+				continue
+			}
+			if err != nil {
+				lastErr = errors.Wrapf(err, "error getting IAM role %s", aws.StringValue(role.RoleName))
+				return false
+			}
+			tags := map[string]string{}
+			for _, tag := range output.Role.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			if l.tagsMatch(tags) {
+				id := aws.StringValue(output.Role.Arn)
+				add(Resource{Type: awsResourceType(id), ID: id})
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return errors.Wrap(err, "error listing IAM roles")
+	}
+	return lastErr
+}
+
+// listIAMUsers lists the IAM users matching the filters. The tags of a user are not returned when listing users, so
+// each user has to be fetched.
+func (l *AWSLister) listIAMUsers(add func(Resource)) error {
+	var lastErr error
+	err := l.Client.ListUsersPages(&iam.ListUsersInput{}, func(page *iam.ListUsersOutput, lastPage bool) bool {
+		for _, user := range page.Users {
+			output, err := l.Client.GetUser(&iam.GetUserInput{UserName: user.UserName})
+			if isAWSErrorCode(err, iam.ErrCodeNoSuchEntityException) {
+				continue
+			}
+			if err != nil {
+				lastErr = errors.Wrapf(err, "error getting IAM user %s", aws.StringValue(user.UserName))
+				return false
+			}
+			tags := map[string]string{}
+			for _, tag := range output.User.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			if l.tagsMatch(tags) {
+				id := aws.StringValue(output.User.Arn)
+				add(Resource{Type: awsResourceType(id), ID: id})
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return errors.Wrap(err, "error listing IAM users")
+	}
+	return lastErr
+}
+
+// listInstanceProfiles lists the master and worker IAM instance profiles, which cannot be tagged.
+func (l *AWSLister) listInstanceProfiles(add func(Resource)) error {
+	if l.ClusterID == "" {
+		return nil
+	}
+	for _, role := range []string{"master", "worker"} {
+		name := fmt.Sprintf("%s-%s-profile", l.ClusterID, role)
+		output, err := l.Client.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(name)})
+		if isAWSErrorCode(err, iam.ErrCodeNoSuchEntityException) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "error getting IAM instance profile %s", name)
+		}
+		id := aws.StringValue(output.InstanceProfile.Arn)
+		add(Resource{Type: awsResourceType(id), ID: id})
+	}
+	return nil
+}
+
+// listRecordSets lists the record sets of a private hosted zone of the cluster, other than the SOA and NS records
+// which are deleted with the zone, along with the record sets of the same type and name in the shared public zone.
+func (l *AWSLister) listRecordSets(zoneID string, add func(Resource)) error {
+	recordSets, err := l.recordSets(zoneID)
+	if isAWSErrorCode(errors.Cause(err), route53.ErrCodeNoSuchHostedZone) {
+		// The tagging API can return hosted zones for a while after they have been deleted.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	sharedZoneID, err := l.sharedHostedZone(zoneID)
+	if err != nil {
+		return err
+	}
+	sharedRecordSets := map[string]bool{}
+	if sharedZoneID != "" {
+		shared, err := l.recordSets(sharedZoneID)
+		if err != nil {
+			return err
+		}
+		for _, key := range shared {
+			sharedRecordSets[key] = true
+		}
+	}
+	for _, key := range recordSets {
+		if strings.HasPrefix(key, "SOA ") || strings.HasPrefix(key, "NS ") {
+			continue
+		}
+		add(Resource{Type: "route53:recordset", ID: zoneID + " " + key})
+		if sharedRecordSets[key] {
+			add(Resource{Type: "route53:recordset", ID: sharedZoneID + " " + key})
+		}
+	}
+	return nil
+}
+
+// recordSets returns the type and name of each of the record sets in a hosted zone. eg. A api.example.com.
+func (l *AWSLister) recordSets(zoneID string) ([]string, error) {
+	var recordSets []string
+	input := &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)}
+	for {
+		output, err := l.Client.ListResourceRecordSets(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listing record sets of hosted zone %s", zoneID)
+		}
+		for _, recordSet := range output.ResourceRecordSets {
+			recordSets = append(recordSets, aws.StringValue(recordSet.Type)+" "+aws.StringValue(recordSet.Name))
+		}
+		if !aws.BoolValue(output.IsTruncated) {
+			return recordSets, nil
+		}
+		input.StartRecordName = output.NextRecordName
+		input.StartRecordType = output.NextRecordType
+		input.StartRecordIdentifier = output.NextRecordIdentifier
+	}
+}
+
+// sharedHostedZone returns the ID of the public hosted zone for the closest parent domain of a private hosted zone,
+// or "" if there is none.
+func (l *AWSLister) sharedHostedZone(privateZoneID string) (string, error) {
+	output, err := l.Client.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(privateZoneID)})
+	if err != nil {
+		return "", errors.Wrapf(err, "error getting hosted zone %s", privateZoneID)
+	}
+	if output.HostedZone.Config == nil || !aws.BoolValue(output.HostedZone.Config.PrivateZone) {
+		return "", nil
+	}
+	for domain := aws.StringValue(output.HostedZone.Name); domain != ""; {
+		zoneID, err := l.publicHostedZone(domain)
+		if err != nil || zoneID != "" {
+			return zoneID, err
+		}
+		i := strings.Index(domain, ".")
+		if i == -1 {
+			break
+		}
+		domain = domain[i+1:]
+	}
+	return "", nil
+}
+
+func (l *AWSLister) publicHostedZone(domain string) (string, error) {
+	input := &route53.ListHostedZonesByNameInput{DNSName: aws.String(domain)}
+	for {
+		output, err := l.Client.ListHostedZonesByName(input)
+		if err != nil {
+			return "", errors.Wrapf(err, "error listing hosted zones for %s", domain)
+		}
+		for _, zone := range output.HostedZones {
+			if aws.StringValue(zone.Name) != domain {
+				// The zones are sorted by name, so no later zone can match.
+				return "", nil
+			}
+			if zone.Config != nil && !aws.BoolValue(zone.Config.PrivateZone) {
+				return strings.TrimPrefix(aws.StringValue(zone.Id), "/hostedzone/"), nil
+			}
+		}
+		if !aws.BoolValue(output.IsTruncated) || aws.StringValue(output.NextDNSName) != domain {
+			return "", nil
+		}
+		input.HostedZoneId = output.NextHostedZoneId
+	}
+}
+
+// tagsMatch returns true if the tags include all of the tags of any of the filters.
+func (l *AWSLister) tagsMatch(tags map[string]string) bool {
+	for _, filter := range l.Filters {
+		match := true
+		for key, value := range filter {
+			if v, ok := tags[key]; !ok || v != value {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *AWSLister) withoutTerminatedInstances(resources []Resource) ([]Resource, error) {
+	instanceARNs := map[string]string{}
+	var instanceIDs []*string
+	for _, r := range resources {
+		if r.Type != "ec2:instance" {
+			continue
+		}
+		id := r.ID[strings.LastIndex(r.ID, "/")+1:]
+		instanceARNs[id] = r.ID
+		instanceIDs = append(instanceIDs, aws.String(id))
+	}
+	if len(instanceIDs) == 0 {
+		return resources, nil
+	}
+
+	terminated := map[string]bool{}
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("instance-id"), Values: instanceIDs},
+			{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{ec2.InstanceStateNameTerminated})},
+		},
+	}
+	for {
+		output, err := l.Client.DescribeInstances(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "error describing instances")
+		}
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				terminated[instanceARNs[aws.StringValue(instance.InstanceId)]] = true
+			}
+		}
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	remaining := make([]Resource, 0, len(resources))
+	for _, r := range resources {
+		if !terminated[r.ID] {
+			remaining = append(remaining, r)
+		}
+	}
+	return remaining, nil
+}
+
+// awsResourceType returns the service and resource type of an ARN. eg. ec2:instance
+func awsResourceType(id string) string {
+	parsed, err := arn.Parse(id)
+	if err != nil {
+		return "unknown"
+	}
+	if i := strings.IndexAny(parsed.Resource, "/:"); i > 0 {
+		return parsed.Service + ":" + parsed.Resource[:i]
+	}
+	return parsed.Service
+}
+
+func isAWSErrorCode(err error, code string) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == code
+}
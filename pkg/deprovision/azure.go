@@ -0,0 +1,43 @@
+package deprovision
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/hive/pkg/azureclient"
+)
+
+// AzureLister lists the resources in the resource group of a cluster, which the Azure destroyer deletes along with
+// the resource group.
+type AzureLister struct {
+	Client  azureclient.Client
+	InfraID string
+}
+
+// ListResources implements Lister.
+func (l *AzureLister) ListResources() ([]Resource, error) {
+	ctx := context.TODO()
+	resourceGroupName := fmt.Sprintf("%s-rg", l.InfraID)
+	page, err := l.Client.ListResourcesByResourceGroup(ctx, resourceGroupName)
+	if err != nil {
+		if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "error listing resources in resource group %s", resourceGroupName)
+	}
+	var resources []Resource
+	for page.NotDone() {
+		for _, r := range page.Values() {
+			resources = append(resources, Resource{Type: to.String(r.Type), ID: to.String(r.ID)})
+		}
+		if err := page.NextWithContext(ctx); err != nil {
+			return nil, errors.Wrapf(err, "error listing resources in resource group %s", resourceGroupName)
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,87 @@
+// Package deprovision lists the cloud resources that the installer destroyers would delete for a cluster, without
+// deleting them.
+package deprovision
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// ResourcesKey is the key of the data in a dry run ConfigMap that holds the list of resources.
+	ResourcesKey = "resources"
+)
+
+// Resource is a cloud resource belonging to a cluster.
+type Resource struct {
+	// Type is the platform specific type of the resource. eg. ec2:instance or Microsoft.Compute/virtualMachines
+	Type string
+	// ID identifies the resource within its type.
+	ID string
+}
+
+func (r Resource) String() string {
+	return fmt.Sprintf("%s %s", r.Type, r.ID)
+}
+
+// Lister lists the cloud resources of a cluster.
+type Lister interface {
+	ListResources() ([]Resource, error)
+}
+
+// DryRunConfigMapName returns the name of the ConfigMap holding the results of a dry run of the named
+// ClusterDeprovision.
+func DryRunConfigMapName(clusterDeprovisionName string) string {
+	return fmt.Sprintf("%s-dry-run", clusterDeprovisionName)
+}
+
+// LeakedResourcesConfigMapName returns the name of the ConfigMap, in the hive namespace, holding the resources that
+// were found remaining after the named ClusterDeprovision completed.
+func LeakedResourcesConfigMapName(clusterDeprovisionNamespace, clusterDeprovisionName string) string {
+	return fmt.Sprintf("%s-%s-leaked-resources", clusterDeprovisionNamespace, clusterDeprovisionName)
+}
+
+// FormatResources returns the resources sorted by type and ID, one per line, as stored in a dry run ConfigMap.
+func FormatResources(resources []Resource) string {
+	lines := make([]string, len(resources))
+	for i, r := range resources {
+		lines[i] = r.String()
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// ParseResources parses the resources stored in a dry run ConfigMap.
+func ParseResources(cm *corev1.ConfigMap) ([]Resource, error) {
+	var resources []Resource
+	for _, line := range strings.Split(cm.Data[ResourcesKey], "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid resource line %q", line)
+		}
+		resources = append(resources, Resource{Type: parts[0], ID: parts[1]})
+	}
+	return resources, nil
+}
+
+// Summarize returns the number of resources of each type, sorted by type.
+func Summarize(resources []Resource) []hivev1.DeprovisionResourceCount {
+	counts := map[string]int{}
+	for _, r := range resources {
+		counts[r.Type]++
+	}
+	summary := make([]hivev1.DeprovisionResourceCount, 0, len(counts))
+	for t, c := range counts {
+		summary = append(summary, hivev1.DeprovisionResourceCount{Type: t, Count: c})
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Type < summary[j].Type })
+	return summary
+}
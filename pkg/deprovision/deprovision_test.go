@@ -0,0 +1,499 @@
+package deprovision
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+	compute "google.golang.org/api/compute/v1"
+	dns "google.golang.org/api/dns/v1"
+	iamgcp "google.golang.org/api/iam/v1"
+	storage "google.golang.org/api/storage/v1"
+
+	corev1 "k8s.io/api/core/v1"
+
+	typesgcp "github.com/openshift/installer/pkg/types/gcp"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	awsmock "github.com/openshift/hive/pkg/awsclient/mock"
+	azuremock "github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/gcpclient"
+	gcpmock "github.com/openshift/hive/pkg/gcpclient/mock"
+)
+
+func TestFormatAndParseResources(t *testing.T) {
+	resources := []Resource{
+		{Type: "s3", ID: "arn:aws:s3:::bucket"},
+		{Type: "ec2:instance", ID: "arn:aws:ec2:us-east-1:123456789012:instance/i-2"},
+		{Type: "ec2:instance", ID: "arn:aws:ec2:us-east-1:123456789012:instance/i-1"},
+	}
+	formatted := FormatResources(resources)
+	assert.Equal(t,
+		"ec2:instance arn:aws:ec2:us-east-1:123456789012:instance/i-1\n"+
+			"ec2:instance arn:aws:ec2:us-east-1:123456789012:instance/i-2\n"+
+			"s3 arn:aws:s3:::bucket",
+		formatted,
+		"unexpected formatted resources",
+	)
+
+	parsed, err := ParseResources(&corev1.ConfigMap{Data: map[string]string{ResourcesKey: formatted}})
+	require.NoError(t, err, "unexpected error parsing resources")
+	assert.ElementsMatch(t, resources, parsed, "unexpected parsed resources")
+	assert.Equal(t,
+		[]hivev1.DeprovisionResourceCount{{Type: "ec2:instance", Count: 2}, {Type: "s3", Count: 1}},
+		Summarize(parsed),
+		"unexpected summary",
+	)
+
+	_, err = ParseResources(&corev1.ConfigMap{Data: map[string]string{ResourcesKey: "invalid"}})
+	assert.Error(t, err, "expected error parsing invalid resources")
+}
+
+func TestAWSLister(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	client := awsmock.NewMockClient(mockCtrl)
+	instanceARN := "arn:aws:ec2:us-east-1:123456789012:instance/i-1"
+	bucketARN := "arn:aws:s3:::bucket"
+	// The instance matches both filters, and is only listed once.
+	client.EXPECT().GetResourcesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+			fn(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{ResourceARN: aws.String(instanceARN)},
+				},
+			}, false)
+			fn(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{ResourceARN: aws.String(bucketARN)},
+				},
+			}, true)
+			return nil
+		},
+	)
+	client.EXPECT().GetResourcesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+			if assert.Len(t, input.TagFilters, 1, "unexpected tag filters") {
+				assert.Equal(t, "openshiftClusterID", aws.StringValue(input.TagFilters[0].Key), "unexpected tag filter key")
+			}
+			fn(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{ResourceARN: aws.String(instanceARN)},
+				},
+			}, true)
+			return nil
+		},
+	)
+	expectNoIAMResources(client)
+	// The instance is running, and so is listed.
+	client.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{}, nil)
+	lister := &AWSLister{
+		Client: client,
+		Filters: []map[string]string{
+			{"kubernetes.io/cluster/test-infra-id": "owned"},
+			{"openshiftClusterID": "test-cluster-id"},
+		},
+	}
+	resources, err := lister.ListResources()
+	require.NoError(t, err, "unexpected error listing resources")
+	assert.Equal(t,
+		[]Resource{{Type: "ec2:instance", ID: instanceARN}, {Type: "s3", ID: bucketARN}},
+		resources,
+		"unexpected resources",
+	)
+}
+
+func TestAWSListerTerminatedInstances(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	client := awsmock.NewMockClient(mockCtrl)
+	terminatedARN := "arn:aws:ec2:us-east-1:123456789012:instance/i-terminated"
+	runningARN := "arn:aws:ec2:us-east-1:123456789012:instance/i-running"
+	client.EXPECT().GetResourcesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+			fn(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{ResourceARN: aws.String(terminatedARN)},
+					{ResourceARN: aws.String(runningARN)},
+				},
+			}, true)
+			return nil
+		},
+	)
+	expectNoIAMResources(client)
+	client.EXPECT().DescribeInstances(gomock.Any()).DoAndReturn(
+		func(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+			if assert.Len(t, input.Filters, 2, "unexpected filters") {
+				assert.ElementsMatch(t, []string{"i-terminated", "i-running"}, aws.StringValueSlice(input.Filters[0].Values), "unexpected instance IDs")
+			}
+			return &ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{{InstanceId: aws.String("i-terminated")}}},
+				},
+			}, nil
+		},
+	)
+	lister := &AWSLister{
+		Client:  client,
+		Filters: []map[string]string{{"kubernetes.io/cluster/test-infra-id": "owned"}},
+	}
+	resources, err := lister.ListResources()
+	require.NoError(t, err, "unexpected error listing resources")
+	assert.Equal(t, []Resource{{Type: "ec2:instance", ID: runningARN}}, resources, "unexpected resources")
+}
+
+func TestAWSListerGlobalAndUntaggableResources(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	client := awsmock.NewMockClient(mockCtrl)
+	globalClient := awsmock.NewMockClient(mockCtrl)
+	zoneARN := "arn:aws:route53:::hostedzone/private-zone"
+	distributionARN := "arn:aws:cloudfront::123456789012:distribution/d"
+	roleARN := "arn:aws:iam::123456789012:role/test-infra-id-master-role"
+	userARN := "arn:aws:iam::123456789012:user/test-infra-id-user"
+	profileARN := "arn:aws:iam::123456789012:instance-profile/test-infra-id-master-profile"
+	filter := map[string]string{"kubernetes.io/cluster/test-infra-id": "owned"}
+	ownedTags := []*iam.Tag{{Key: aws.String("kubernetes.io/cluster/test-infra-id"), Value: aws.String("owned")}}
+
+	tagged := func(arn string) func(*resourcegroupstaggingapi.GetResourcesInput, func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+		return func(_ *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+			fn(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{{ResourceARN: aws.String(arn)}},
+			}, true)
+			return nil
+		}
+	}
+	client.EXPECT().GetResourcesPages(gomock.Any(), gomock.Any()).DoAndReturn(tagged(zoneARN))
+	globalClient.EXPECT().GetResourcesPages(gomock.Any(), gomock.Any()).DoAndReturn(tagged(distributionARN))
+
+	// Only the role and user with the cluster tags are listed.
+	client.EXPECT().ListRolesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error {
+			fn(&iam.ListRolesOutput{Roles: []*iam.Role{{RoleName: aws.String("owned")}, {RoleName: aws.String("other")}}}, true)
+			return nil
+		},
+	)
+	client.EXPECT().GetRole(&iam.GetRoleInput{RoleName: aws.String("owned")}).Return(&iam.GetRoleOutput{Role: &iam.Role{Arn: aws.String(roleARN), Tags: ownedTags}}, nil)
+	client.EXPECT().GetRole(&iam.GetRoleInput{RoleName: aws.String("other")}).Return(&iam.GetRoleOutput{Role: &iam.Role{Arn: aws.String("arn:aws:iam::123456789012:role/other")}}, nil)
+	client.EXPECT().ListUsersPages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *iam.ListUsersInput, fn func(*iam.ListUsersOutput, bool) bool) error {
+			fn(&iam.ListUsersOutput{Users: []*iam.User{{UserName: aws.String("owned")}}}, true)
+			return nil
+		},
+	)
+	client.EXPECT().GetUser(&iam.GetUserInput{UserName: aws.String("owned")}).Return(&iam.GetUserOutput{User: &iam.User{Arn: aws.String(userARN), Tags: ownedTags}}, nil)
+
+	// The worker instance profile has already been deleted.
+	client.EXPECT().GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String("test-infra-id-master-profile")}).
+		Return(&iam.GetInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{Arn: aws.String(profileARN)}}, nil)
+	client.EXPECT().GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String("test-infra-id-worker-profile")}).
+		Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil))
+
+	// The api record is also in the shared public zone, the apps record is not.
+	client.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{HostedZoneId: aws.String("private-zone")}).Return(
+		&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{
+			{Type: aws.String("SOA"), Name: aws.String("test.example.com.")},
+			{Type: aws.String("NS"), Name: aws.String("test.example.com.")},
+			{Type: aws.String("A"), Name: aws.String("api.test.example.com.")},
+			{Type: aws.String("A"), Name: aws.String("*.apps.test.example.com.")},
+		}}, nil)
+	client.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+		HostedZone: &route53.HostedZone{Name: aws.String("test.example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}},
+	}, nil)
+	client.EXPECT().ListHostedZonesByName(&route53.ListHostedZonesByNameInput{DNSName: aws.String("test.example.com.")}).Return(
+		&route53.ListHostedZonesByNameOutput{HostedZones: []*route53.HostedZone{
+			{Id: aws.String("/hostedzone/private-zone"), Name: aws.String("test.example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}},
+		}}, nil)
+	client.EXPECT().ListHostedZonesByName(&route53.ListHostedZonesByNameInput{DNSName: aws.String("example.com.")}).Return(
+		&route53.ListHostedZonesByNameOutput{HostedZones: []*route53.HostedZone{
+			{Id: aws.String("/hostedzone/public-zone"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)}},
+		}}, nil)
+	client.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{HostedZoneId: aws.String("public-zone")}).Return(
+		&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{
+			{Type: aws.String("NS"), Name: aws.String("example.com.")},
+			{Type: aws.String("A"), Name: aws.String("api.test.example.com.")},
+		}}, nil)
+
+	lister := &AWSLister{
+		Client:       client,
+		GlobalClient: globalClient,
+		ClusterID:    "test-infra-id",
+		Filters:      []map[string]string{filter},
+	}
+	resources, err := lister.ListResources()
+	require.NoError(t, err, "unexpected error listing resources")
+	assert.ElementsMatch(t,
+		[]Resource{
+			{Type: "route53:hostedzone", ID: zoneARN},
+			{Type: "cloudfront:distribution", ID: distributionARN},
+			{Type: "iam:role", ID: roleARN},
+			{Type: "iam:user", ID: userARN},
+			{Type: "iam:instance-profile", ID: profileARN},
+			{Type: "route53:recordset", ID: "private-zone A api.test.example.com."},
+			{Type: "route53:recordset", ID: "public-zone A api.test.example.com."},
+			{Type: "route53:recordset", ID: "private-zone A *.apps.test.example.com."},
+		},
+		resources,
+		"unexpected resources",
+	)
+}
+
+func TestAWSGlobalRegion(t *testing.T) {
+	cases := map[string]string{
+		"us-east-1":      "us-east-1",
+		"eu-west-1":      "us-east-1",
+		"cn-north-1":     "cn-northwest-1",
+		"cn-northwest-1": "cn-northwest-1",
+		"us-gov-east-1":  "us-gov-west-1",
+	}
+	for region, expected := range cases {
+		assert.Equal(t, expected, AWSGlobalRegion(region), "unexpected global region for %s", region)
+	}
+}
+
+func expectNoIAMResources(client *awsmock.MockClient) {
+	client.EXPECT().ListRolesPages(gomock.Any(), gomock.Any()).Return(nil)
+	client.EXPECT().ListUsersPages(gomock.Any(), gomock.Any()).Return(nil)
+}
+
+func TestAzureLister(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	client := azuremock.NewMockClient(mockCtrl)
+	page := azuremock.NewMockResourcePage(mockCtrl)
+	client.EXPECT().ListResourcesByResourceGroup(gomock.Any(), "test-infra-id-rg").Return(page, nil)
+	page.EXPECT().NotDone().Return(false).Times(1).After(page.EXPECT().NotDone().Return(true).Times(1))
+	page.EXPECT().NextWithContext(gomock.Any()).Return(nil)
+	page.EXPECT().Values().Return([]resources.GenericResourceExpanded{
+		{Type: to.StringPtr("Microsoft.Compute/virtualMachines"), ID: to.StringPtr("/subscriptions/s/resourceGroups/test-infra-id-rg/providers/Microsoft.Compute/virtualMachines/vm")},
+	})
+	lister := &AzureLister{Client: client, InfraID: "test-infra-id"}
+	actual, err := lister.ListResources()
+	require.NoError(t, err, "unexpected error listing resources")
+	assert.Equal(t,
+		[]Resource{{Type: "Microsoft.Compute/virtualMachines", ID: "/subscriptions/s/resourceGroups/test-infra-id-rg/providers/Microsoft.Compute/virtualMachines/vm"}},
+		actual,
+		"unexpected resources",
+	)
+}
+
+func TestGCPLister(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	client := gcpmock.NewMockClient(mockCtrl)
+	filter := `name eq "test-infra-id-.*"`
+	zoneURL := "https://www.googleapis.com/compute/v1/projects/p/zones/us-east1-b"
+	uid := typesgcp.CloudControllerUID("test-infra-id")
+
+	client.EXPECT().ListComputeInstances(gcpclient.ListComputeInstancesOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeInstancesOptions, fn func(*compute.InstanceAggregatedList) error) error {
+			return fn(&compute.InstanceAggregatedList{Items: map[string]compute.InstancesScopedList{
+				"zones/us-east1-b": {Instances: []*compute.Instance{{Name: "test-infra-id-master-0", Zone: zoneURL}}},
+			}})
+		},
+	)
+	// Instances created by machine sets are labelled rather than named after the cluster.
+	client.EXPECT().ListComputeInstances(gcpclient.ListComputeInstancesOptions{Filter: `labels.kubernetes-io-cluster-test-infra-id eq "owned"`}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeInstancesOptions, fn func(*compute.InstanceAggregatedList) error) error {
+			return fn(&compute.InstanceAggregatedList{Items: map[string]compute.InstancesScopedList{
+				"zones/us-east1-b": {Instances: []*compute.Instance{
+					{Name: "test-infra-id-master-0", Zone: zoneURL},
+					{Name: "worker", Zone: zoneURL},
+				}},
+			}})
+		},
+	)
+	client.EXPECT().ListComputeDisks(gcpclient.ListComputeDisksOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeDisksOptions, fn func(*compute.DiskAggregatedList) error) error {
+			return fn(&compute.DiskAggregatedList{Items: map[string]compute.DisksScopedList{
+				"zones/us-east1-b": {Disks: []*compute.Disk{{Name: "test-infra-id-master-0", Zone: zoneURL}}},
+			}})
+		},
+	)
+	client.EXPECT().ListServiceAccounts(gomock.Any()).DoAndReturn(
+		func(fn func(*iamgcp.ListServiceAccountsResponse) error) error {
+			return fn(&iamgcp.ListServiceAccountsResponse{Accounts: []*iamgcp.ServiceAccount{
+				{Email: "test-infra-id-m@p.iam.gserviceaccount.com"},
+				{Email: "other@p.iam.gserviceaccount.com"},
+			}})
+		},
+	)
+	client.EXPECT().GetProjectIamPolicy().Return(&cloudresourcemanager.Policy{Bindings: []*cloudresourcemanager.Binding{
+		{Role: "roles/compute.instanceAdmin", Members: []string{"serviceAccount:test-infra-id-m@p.iam.gserviceaccount.com", "serviceAccount:other@p.iam.gserviceaccount.com"}},
+	}}, nil)
+	client.EXPECT().ListComputeImages(gcpclient.ListComputeImagesOptions{Filter: filter}).Return(
+		&compute.ImageList{Items: []*compute.Image{{Name: "test-infra-id-rhcos-image"}}}, nil)
+	client.EXPECT().ListManagedZones(gomock.Any()).Return(&dns.ManagedZonesListResponse{ManagedZones: []*dns.ManagedZone{
+		{Name: "test-infra-id-private-zone", DnsName: "test.example.com.", Visibility: "private"},
+		{Name: "public-zone", DnsName: "example.com.", Visibility: "public"},
+	}}, nil)
+	client.EXPECT().ListResourceRecordSets("test-infra-id-private-zone", gomock.Any()).Return(&dns.ResourceRecordSetsListResponse{Rrsets: []*dns.ResourceRecordSet{
+		{Type: "SOA", Name: "test.example.com."},
+		{Type: "A", Name: "api.test.example.com."},
+	}}, nil)
+	client.EXPECT().ListResourceRecordSets("public-zone", gomock.Any()).Return(&dns.ResourceRecordSetsListResponse{Rrsets: []*dns.ResourceRecordSet{
+		{Type: "A", Name: "api.test.example.com."},
+	}}, nil)
+	client.EXPECT().ListStorageBuckets(gcpclient.ListStorageBucketsOptions{Prefix: "test-infra-id-"}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListStorageBucketsOptions, fn func(*storage.Buckets) error) error {
+			return fn(&storage.Buckets{Items: []*storage.Bucket{{Name: "test-infra-id-image-registry"}}})
+		},
+	)
+	client.EXPECT().ListComputeRoutes(gcpclient.ListComputeRoutesOptions{Filter: filter}, gomock.Any()).Return(nil)
+	client.EXPECT().ListComputeRoutes(gcpclient.ListComputeRoutesOptions{Filter: `network eq "network-url"`}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeRoutesOptions, fn func(*compute.RouteList) error) error {
+			return fn(&compute.RouteList{Items: []*compute.Route{{Name: "default-route-1"}, {Name: "route-1"}}})
+		},
+	)
+	client.EXPECT().ListComputeNetworks(gcpclient.ListComputeNetworksOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeNetworksOptions, fn func(*compute.NetworkList) error) error {
+			return fn(&compute.NetworkList{Items: []*compute.Network{{Name: "test-infra-id-network", SelfLink: "network-url"}}})
+		},
+	).Times(2)
+	client.EXPECT().ListComputeFirewalls(gcpclient.ListComputeFirewallsOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeFirewallsOptions, fn func(*compute.FirewallList) error) error {
+			return fn(&compute.FirewallList{Items: []*compute.Firewall{{Name: "test-infra-id-api"}}})
+		},
+	)
+	client.EXPECT().ListComputeAddresses("us-east1", gcpclient.ListComputeAddressesOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ string, _ gcpclient.ListComputeAddressesOptions, fn func(*compute.AddressList) error) error {
+			return fn(&compute.AddressList{Items: []*compute.Address{{Name: "test-infra-id-cluster-ip"}}})
+		},
+	)
+	client.EXPECT().ListComputeTargetPools("us-east1", gcpclient.ListComputeTargetPoolsOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ string, _ gcpclient.ListComputeTargetPoolsOptions, fn func(*compute.TargetPoolList) error) error {
+			return fn(&compute.TargetPoolList{Items: []*compute.TargetPool{{Name: "test-infra-id-api"}}})
+		},
+	)
+	client.EXPECT().ListComputeForwardingRules("us-east1", gcpclient.ListComputeForwardingRulesOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ string, _ gcpclient.ListComputeForwardingRulesOptions, fn func(*compute.ForwardingRuleList) error) error {
+			return fn(&compute.ForwardingRuleList{Items: []*compute.ForwardingRule{{Name: "test-infra-id-api"}}})
+		},
+	)
+	client.EXPECT().ListComputeBackendServices("us-east1", gcpclient.ListComputeBackendServicesOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ string, _ gcpclient.ListComputeBackendServicesOptions, fn func(*compute.BackendServiceList) error) error {
+			return fn(&compute.BackendServiceList{Items: []*compute.BackendService{{Name: "test-infra-id-api-internal"}}})
+		},
+	)
+	client.EXPECT().ListComputeHealthChecks(gcpclient.ListComputeHealthChecksOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeHealthChecksOptions, fn func(*compute.HealthCheckList) error) error {
+			return fn(&compute.HealthCheckList{Items: []*compute.HealthCheck{{Name: "test-infra-id-api-internal"}}})
+		},
+	)
+	client.EXPECT().ListComputeHTTPHealthChecks(gcpclient.ListComputeHTTPHealthChecksOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeHTTPHealthChecksOptions, fn func(*compute.HttpHealthCheckList) error) error {
+			return fn(&compute.HttpHealthCheckList{Items: []*compute.HttpHealthCheck{{Name: "test-infra-id-api"}}})
+		},
+	)
+	client.EXPECT().ListComputeRouters("us-east1", gcpclient.ListComputeRoutersOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ string, _ gcpclient.ListComputeRoutersOptions, fn func(*compute.RouterList) error) error {
+			return fn(&compute.RouterList{Items: []*compute.Router{{Name: "test-infra-id-router"}}})
+		},
+	)
+	client.EXPECT().ListComputeSubnetworks("us-east1", gcpclient.ListComputeSubnetworksOptions{Filter: filter}, gomock.Any()).DoAndReturn(
+		func(_ string, _ gcpclient.ListComputeSubnetworksOptions, fn func(*compute.SubnetworkList) error) error {
+			return fn(&compute.SubnetworkList{Items: []*compute.Subnetwork{{Name: "test-infra-id-master-subnet"}}})
+		},
+	)
+
+	// The cloud controller created a load balancer pointing at a cluster instance group, and one pointing at
+	// instances of another cluster.
+	client.EXPECT().ListComputeInstanceGroups(gcpclient.ListComputeInstanceGroupsOptions{Filter: fmt.Sprintf(`name eq "k8s-ig--%s"`, uid)}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeInstanceGroupsOptions, fn func(*compute.InstanceGroupAggregatedList) error) error {
+			return fn(&compute.InstanceGroupAggregatedList{Items: map[string]compute.InstanceGroupsScopedList{
+				"zones/us-east1-b": {InstanceGroups: []*compute.InstanceGroup{{Name: "k8s-ig--" + uid, Zone: zoneURL, SelfLink: "ig-url"}}},
+			}})
+		},
+	)
+	client.EXPECT().ListComputeInstanceGroups(gcpclient.ListComputeInstanceGroupsOptions{Filter: filter}, gomock.Any()).Return(nil)
+	client.EXPECT().ListComputeBackendServices("us-east1", gcpclient.ListComputeBackendServicesOptions{Filter: `name eq "a[0-9a-f]{30,50}"`}, gomock.Any()).DoAndReturn(
+		func(_ string, _ gcpclient.ListComputeBackendServicesOptions, fn func(*compute.BackendServiceList) error) error {
+			return fn(&compute.BackendServiceList{Items: []*compute.BackendService{
+				{Name: "a0123456789abcdef0123456789abcdef", Backends: []*compute.Backend{{Group: "ig-url"}}},
+				{Name: "afedcba9876543210fedcba9876543210", Backends: []*compute.Backend{{Group: "other-ig-url"}}},
+			}})
+		},
+	)
+	client.EXPECT().ListComputeTargetPools("us-east1", gcpclient.ListComputeTargetPoolsOptions{Filter: `name eq "a[0-9a-f]{30,50}"`}, gomock.Any()).DoAndReturn(
+		func(_ string, _ gcpclient.ListComputeTargetPoolsOptions, fn func(*compute.TargetPoolList) error) error {
+			return fn(&compute.TargetPoolList{Items: []*compute.TargetPool{
+				{Name: "a11111111111111111111111111111111", Instances: []string{zoneURL + "/instances/other-worker"}},
+			}})
+		},
+	)
+	lb := "a0123456789abcdef0123456789abcdef"
+	client.EXPECT().ListComputeAddresses("us-east1", gcpclient.ListComputeAddressesOptions{Filter: fmt.Sprintf(`name eq "%s"`, lb)}, gomock.Any()).Return(nil)
+	for _, name := range []string{lb, lb + "-hc", "k8s-fw-" + lb, "k8s-" + lb + "-http-hc"} {
+		name := name
+		client.EXPECT().ListComputeFirewalls(gcpclient.ListComputeFirewallsOptions{Filter: fmt.Sprintf(`name eq "%s"`, name)}, gomock.Any()).DoAndReturn(
+			func(_ gcpclient.ListComputeFirewallsOptions, fn func(*compute.FirewallList) error) error {
+				return fn(&compute.FirewallList{Items: []*compute.Firewall{{Name: name}}})
+			},
+		)
+	}
+	client.EXPECT().ListComputeForwardingRules("us-east1", gcpclient.ListComputeForwardingRulesOptions{Filter: fmt.Sprintf(`name eq "%s"`, lb)}, gomock.Any()).DoAndReturn(
+		func(_ string, _ gcpclient.ListComputeForwardingRulesOptions, fn func(*compute.ForwardingRuleList) error) error {
+			return fn(&compute.ForwardingRuleList{Items: []*compute.ForwardingRule{{Name: lb}}})
+		},
+	)
+	client.EXPECT().ListComputeHealthChecks(gcpclient.ListComputeHealthChecksOptions{Filter: fmt.Sprintf(`name eq "%s"`, lb)}, gomock.Any()).Return(nil)
+	client.EXPECT().ListComputeHTTPHealthChecks(gcpclient.ListComputeHTTPHealthChecksOptions{Filter: fmt.Sprintf(`name eq "%s"`, lb)}, gomock.Any()).Return(nil)
+	client.EXPECT().ListComputeHealthChecks(gcpclient.ListComputeHealthChecksOptions{Filter: fmt.Sprintf(`name eq "k8s-%s-node"`, uid)}, gomock.Any()).Return(nil)
+	client.EXPECT().ListComputeHTTPHealthChecks(gcpclient.ListComputeHTTPHealthChecksOptions{Filter: fmt.Sprintf(`name eq "k8s-%s-node"`, uid)}, gomock.Any()).DoAndReturn(
+		func(_ gcpclient.ListComputeHTTPHealthChecksOptions, fn func(*compute.HttpHealthCheckList) error) error {
+			return fn(&compute.HttpHealthCheckList{Items: []*compute.HttpHealthCheck{{Name: "k8s-" + uid + "-node"}}})
+		},
+	)
+	client.EXPECT().ListComputeFirewalls(gcpclient.ListComputeFirewallsOptions{Filter: fmt.Sprintf(`name eq "k8s-%s-node-hc"`, uid)}, gomock.Any()).Return(nil)
+	client.EXPECT().ListComputeFirewalls(gcpclient.ListComputeFirewallsOptions{Filter: fmt.Sprintf(`name eq "k8s-%s-node-http-hc"`, uid)}, gomock.Any()).Return(nil)
+
+	lister := &GCPLister{Client: client, InfraID: "test-infra-id", Region: "us-east1"}
+	actual, err := lister.ListResources()
+	require.NoError(t, err, "unexpected error listing resources")
+	assert.ElementsMatch(t,
+		[]Resource{
+			{Type: "compute:instance", ID: "us-east1-b/test-infra-id-master-0"},
+			{Type: "compute:instance", ID: "us-east1-b/worker"},
+			{Type: "compute:disk", ID: "us-east1-b/test-infra-id-master-0"},
+			{Type: "iam:serviceaccount", ID: "test-infra-id-m@p.iam.gserviceaccount.com"},
+			{Type: "iam:policybinding", ID: "roles/compute.instanceAdmin serviceAccount:test-infra-id-m@p.iam.gserviceaccount.com"},
+			{Type: "compute:image", ID: "test-infra-id-rhcos-image"},
+			{Type: "dns:recordset", ID: "test-infra-id-private-zone A api.test.example.com."},
+			{Type: "dns:recordset", ID: "public-zone A api.test.example.com."},
+			{Type: "dns:managedzone", ID: "test-infra-id-private-zone"},
+			{Type: "storage:bucket", ID: "test-infra-id-image-registry"},
+			{Type: "compute:route", ID: "route-1"},
+			{Type: "compute:firewall", ID: "test-infra-id-api"},
+			{Type: "compute:address", ID: "test-infra-id-cluster-ip"},
+			{Type: "compute:targetpool", ID: "test-infra-id-api"},
+			{Type: "compute:forwardingrule", ID: "test-infra-id-api"},
+			{Type: "compute:backendservice", ID: "test-infra-id-api-internal"},
+			{Type: "compute:healthcheck", ID: "test-infra-id-api-internal"},
+			{Type: "compute:httphealthcheck", ID: "test-infra-id-api"},
+			{Type: "compute:router", ID: "test-infra-id-router"},
+			{Type: "compute:subnetwork", ID: "test-infra-id-master-subnet"},
+			{Type: "compute:network", ID: "test-infra-id-network"},
+			{Type: "compute:instancegroup", ID: "us-east1-b/k8s-ig--" + uid},
+			{Type: "compute:backendservice", ID: lb},
+			{Type: "compute:firewall", ID: lb},
+			{Type: "compute:firewall", ID: lb + "-hc"},
+			{Type: "compute:firewall", ID: "k8s-fw-" + lb},
+			{Type: "compute:firewall", ID: "k8s-" + lb + "-http-hc"},
+			{Type: "compute:forwardingrule", ID: lb},
+			{Type: "compute:httphealthcheck", ID: "k8s-" + uid + "-node"},
+		},
+		actual,
+		"unexpected resources",
+	)
+}
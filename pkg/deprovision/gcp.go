@@ -0,0 +1,517 @@
+package deprovision
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+	dns "google.golang.org/api/dns/v1"
+	iam "google.golang.org/api/iam/v1"
+	storage "google.golang.org/api/storage/v1"
+
+	typesgcp "github.com/openshift/installer/pkg/types/gcp"
+
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+// gcpLoadBalancerFilter matches the names the cloud controller gives to the load balancer resources of services.
+const gcpLoadBalancerFilter = `name eq "a[0-9a-f]{30,50}"`
+
+// multiDashes is a regexp matching multiple dashes in a sequence, which are not allowed in bucket prefixes.
+var multiDashes = regexp.MustCompile(`-{2,}`)
+
+// GCPLister lists the GCP resources that the gcp destroyer would delete, following the same search. Most of the
+// resources are named by the installer with the infra ID as a prefix. The load balancer resources created by the
+// cloud controller for services are found through the cluster instance groups and instances that they point at.
+// The record sets of the cluster's private DNS zone are listed, along with the matching record sets in the parent
+// public zone, as are the project IAM policy bindings of the cluster service accounts.
+type GCPLister struct {
+	Client  gcpclient.Client
+	InfraID string
+	// Region is the region of the cluster, in which the regional resources are listed.
+	Region string
+
+	seen      map[string]bool
+	resources []Resource
+}
+
+// ListResources implements Lister.
+func (l *GCPLister) ListResources() ([]Resource, error) {
+	l.seen = map[string]bool{}
+	l.resources = nil
+	for _, list := range []func() error{
+		l.listInstances,
+		l.listDisks,
+		l.listServiceAccounts,
+		l.listImages,
+		l.listDNS,
+		l.listBuckets,
+		l.listRoutes,
+		l.listFirewalls,
+		l.listAddresses,
+		l.listTargetPools,
+		l.listForwardingRules,
+		l.listBackendServices,
+		l.listHealthChecks,
+		l.listHTTPHealthChecks,
+		l.listRouters,
+		l.listSubnetworks,
+		l.listNetworks,
+		l.listCloudControllerResources,
+	} {
+		if err := list(); err != nil {
+			return nil, err
+		}
+	}
+	return l.resources, nil
+}
+
+func (l *GCPLister) add(resourceType, id string) {
+	r := Resource{Type: resourceType, ID: id}
+	if l.seen[r.String()] {
+		return
+	}
+	l.seen[r.String()] = true
+	l.resources = append(l.resources, r)
+}
+
+func (l *GCPLister) clusterIDFilter() string {
+	return fmt.Sprintf("name eq \"%s-.*\"", l.InfraID)
+}
+
+func (l *GCPLister) isClusterResource(name string) bool {
+	return strings.HasPrefix(name, l.InfraID+"-")
+}
+
+func (l *GCPLister) listInstances() error {
+	for _, filter := range []string{
+		l.clusterIDFilter(),
+		fmt.Sprintf("labels.kubernetes-io-cluster-%s eq \"owned\"", l.InfraID),
+	} {
+		err := l.Client.ListComputeInstances(gcpclient.ListComputeInstancesOptions{Filter: filter}, func(list *compute.InstanceAggregatedList) error {
+			for _, scoped := range list.Items {
+				for _, instance := range scoped.Instances {
+					l.add("compute:instance", path.Base(instance.Zone)+"/"+instance.Name)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *GCPLister) listDisks() error {
+	return l.Client.ListComputeDisks(gcpclient.ListComputeDisksOptions{Filter: l.clusterIDFilter()}, func(list *compute.DiskAggregatedList) error {
+		for _, scoped := range list.Items {
+			for _, disk := range scoped.Disks {
+				l.add("compute:disk", path.Base(disk.Zone)+"/"+disk.Name)
+			}
+		}
+		return nil
+	})
+}
+
+// listServiceAccounts lists the service accounts with an email or display name prefixed with the infra ID, along
+// with the project IAM policy bindings of their members. Service accounts cannot be filtered by the API.
+func (l *GCPLister) listServiceAccounts() error {
+	members := map[string]bool{}
+	err := l.Client.ListServiceAccounts(func(list *iam.ListServiceAccountsResponse) error {
+		for _, account := range list.Accounts {
+			if l.isClusterResource(account.Email) || l.isClusterResource(account.DisplayName) {
+				l.add("iam:serviceaccount", account.Email)
+				members["serviceAccount:"+account.Email] = true
+			}
+		}
+		return nil
+	})
+	if err != nil || len(members) == 0 {
+		return err
+	}
+	policy, err := l.Client.GetProjectIamPolicy()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch project IAM policy")
+	}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if members[member] {
+				l.add("iam:policybinding", binding.Role+" "+member)
+			}
+		}
+	}
+	return nil
+}
+
+func (l *GCPLister) listImages() error {
+	opts := gcpclient.ListComputeImagesOptions{Filter: l.clusterIDFilter()}
+	for {
+		list, err := l.Client.ListComputeImages(opts)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch compute images")
+		}
+		for _, image := range list.Items {
+			l.add("compute:image", image.Name)
+		}
+		if list.NextPageToken == "" {
+			return nil
+		}
+		opts.PageToken = list.NextPageToken
+	}
+}
+
+// listDNS lists the private DNS zone of the cluster and its record sets, other than the NS and SOA records of the
+// zone which are deleted with it, along with the record sets of the same type and name in the parent public zone.
+func (l *GCPLister) listDNS() error {
+	var private *dns.ManagedZone
+	var public []*dns.ManagedZone
+	opts := gcpclient.ListManagedZonesOptions{}
+	for {
+		list, err := l.Client.ListManagedZones(opts)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch dns zones")
+		}
+		for _, zone := range list.ManagedZones {
+			switch {
+			case zone.Visibility != "private":
+				public = append(public, zone)
+			case l.isClusterResource(zone.Name):
+				private = zone
+			}
+		}
+		if list.NextPageToken == "" {
+			break
+		}
+		opts.PageToken = list.NextPageToken
+	}
+	if private == nil {
+		return nil
+	}
+
+	recordSets, err := l.recordSets(private.Name)
+	if err != nil {
+		return err
+	}
+	var parent *dns.ManagedZone
+	for domain := private.DnsName; domain != "" && parent == nil; {
+		for _, zone := range public {
+			if zone.DnsName == domain {
+				parent = zone
+				break
+			}
+		}
+		i := strings.Index(domain, ".")
+		if i == -1 {
+			break
+		}
+		domain = domain[i+1:]
+	}
+	parentRecordSets := map[string]bool{}
+	if parent != nil {
+		keys, err := l.recordSets(parent.Name)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			parentRecordSets[key] = true
+		}
+	}
+	for _, key := range recordSets {
+		if parentRecordSets[key] && !isZoneApexRecord(parent, key) {
+			l.add("dns:recordset", parent.Name+" "+key)
+		}
+		if !isZoneApexRecord(private, key) {
+			l.add("dns:recordset", private.Name+" "+key)
+		}
+	}
+	l.add("dns:managedzone", private.Name)
+	return nil
+}
+
+// isZoneApexRecord returns true for the NS and SOA records of a zone, which cannot be deleted from it.
+func isZoneApexRecord(zone *dns.ManagedZone, key string) bool {
+	parts := strings.SplitN(key, " ", 2)
+	return (parts[0] == "NS" || parts[0] == "SOA") && strings.TrimRight(parts[1], ".") == strings.TrimRight(zone.DnsName, ".")
+}
+
+// recordSets returns the type and name of each of the record sets in a managed zone. eg. A api.example.com.
+func (l *GCPLister) recordSets(zone string) ([]string, error) {
+	var recordSets []string
+	opts := gcpclient.ListResourceRecordSetsOptions{}
+	for {
+		list, err := l.Client.ListResourceRecordSets(zone, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch resource record sets for zone: %s", zone)
+		}
+		for _, recordSet := range list.Rrsets {
+			recordSets = append(recordSets, recordSet.Type+" "+recordSet.Name)
+		}
+		if list.NextPageToken == "" {
+			return recordSets, nil
+		}
+		opts.PageToken = list.NextPageToken
+	}
+}
+
+func (l *GCPLister) listBuckets() error {
+	prefix := multiDashes.ReplaceAllString(l.InfraID+"-", "-")
+	return l.Client.ListStorageBuckets(gcpclient.ListStorageBucketsOptions{Prefix: prefix}, func(list *storage.Buckets) error {
+		for _, bucket := range list.Items {
+			l.add("storage:bucket", bucket.Name)
+		}
+		return nil
+	})
+}
+
+// listRoutes lists the routes named after the infra ID, and those of the cluster networks, other than the default
+// routes of the networks which are deleted with them.
+func (l *GCPLister) listRoutes() error {
+	addRoutes := func(list *compute.RouteList) error {
+		for _, route := range list.Items {
+			if !strings.HasPrefix(route.Name, "default-route-") {
+				l.add("compute:route", route.Name)
+			}
+		}
+		return nil
+	}
+	if err := l.Client.ListComputeRoutes(gcpclient.ListComputeRoutesOptions{Filter: l.clusterIDFilter()}, addRoutes); err != nil {
+		return err
+	}
+	var networks []string
+	err := l.Client.ListComputeNetworks(gcpclient.ListComputeNetworksOptions{Filter: l.clusterIDFilter()}, func(list *compute.NetworkList) error {
+		for _, network := range list.Items {
+			networks = append(networks, network.SelfLink)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, network := range networks {
+		if err := l.Client.ListComputeRoutes(gcpclient.ListComputeRoutesOptions{Filter: fmt.Sprintf("network eq %q", network)}, addRoutes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *GCPLister) listFirewalls() error {
+	return l.listFirewallsWithFilter(l.clusterIDFilter())
+}
+
+func (l *GCPLister) listFirewallsWithFilter(filter string) error {
+	return l.Client.ListComputeFirewalls(gcpclient.ListComputeFirewallsOptions{Filter: filter}, func(list *compute.FirewallList) error {
+		for _, firewall := range list.Items {
+			l.add("compute:firewall", firewall.Name)
+		}
+		return nil
+	})
+}
+
+func (l *GCPLister) listAddresses() error {
+	return l.listAddressesWithFilter(l.clusterIDFilter())
+}
+
+func (l *GCPLister) listAddressesWithFilter(filter string) error {
+	return l.Client.ListComputeAddresses(l.Region, gcpclient.ListComputeAddressesOptions{Filter: filter}, func(list *compute.AddressList) error {
+		for _, address := range list.Items {
+			l.add("compute:address", address.Name)
+		}
+		return nil
+	})
+}
+
+func (l *GCPLister) listTargetPools() error {
+	return l.Client.ListComputeTargetPools(l.Region, gcpclient.ListComputeTargetPoolsOptions{Filter: l.clusterIDFilter()}, func(list *compute.TargetPoolList) error {
+		for _, pool := range list.Items {
+			l.add("compute:targetpool", pool.Name)
+		}
+		return nil
+	})
+}
+
+// instanceGroups lists the instance groups matching the filter, and returns their URLs.
+func (l *GCPLister) instanceGroups(filter string) ([]string, error) {
+	var urls []string
+	err := l.Client.ListComputeInstanceGroups(gcpclient.ListComputeInstanceGroupsOptions{Filter: filter}, func(list *compute.InstanceGroupAggregatedList) error {
+		for _, scoped := range list.Items {
+			for _, group := range scoped.InstanceGroups {
+				l.add("compute:instancegroup", path.Base(group.Zone)+"/"+group.Name)
+				urls = append(urls, group.SelfLink)
+			}
+		}
+		return nil
+	})
+	return urls, err
+}
+
+func (l *GCPLister) listForwardingRules() error {
+	return l.listForwardingRulesWithFilter(l.clusterIDFilter())
+}
+
+func (l *GCPLister) listForwardingRulesWithFilter(filter string) error {
+	return l.Client.ListComputeForwardingRules(l.Region, gcpclient.ListComputeForwardingRulesOptions{Filter: filter}, func(list *compute.ForwardingRuleList) error {
+		for _, rule := range list.Items {
+			l.add("compute:forwardingrule", rule.Name)
+		}
+		return nil
+	})
+}
+
+func (l *GCPLister) listBackendServices() error {
+	return l.Client.ListComputeBackendServices(l.Region, gcpclient.ListComputeBackendServicesOptions{Filter: l.clusterIDFilter()}, func(list *compute.BackendServiceList) error {
+		for _, service := range list.Items {
+			l.add("compute:backendservice", service.Name)
+		}
+		return nil
+	})
+}
+
+func (l *GCPLister) listHealthChecks() error {
+	return l.listHealthChecksWithFilter(l.clusterIDFilter())
+}
+
+func (l *GCPLister) listHealthChecksWithFilter(filter string) error {
+	return l.Client.ListComputeHealthChecks(gcpclient.ListComputeHealthChecksOptions{Filter: filter}, func(list *compute.HealthCheckList) error {
+		for _, check := range list.Items {
+			l.add("compute:healthcheck", check.Name)
+		}
+		return nil
+	})
+}
+
+func (l *GCPLister) listHTTPHealthChecks() error {
+	return l.listHTTPHealthChecksWithFilter(l.clusterIDFilter())
+}
+
+func (l *GCPLister) listHTTPHealthChecksWithFilter(filter string) error {
+	return l.Client.ListComputeHTTPHealthChecks(gcpclient.ListComputeHTTPHealthChecksOptions{Filter: filter}, func(list *compute.HttpHealthCheckList) error {
+		for _, check := range list.Items {
+			l.add("compute:httphealthcheck", check.Name)
+		}
+		return nil
+	})
+}
+
+func (l *GCPLister) listRouters() error {
+	return l.Client.ListComputeRouters(l.Region, gcpclient.ListComputeRoutersOptions{Filter: l.clusterIDFilter()}, func(list *compute.RouterList) error {
+		for _, router := range list.Items {
+			l.add("compute:router", router.Name)
+		}
+		return nil
+	})
+}
+
+func (l *GCPLister) listSubnetworks() error {
+	return l.Client.ListComputeSubnetworks(l.Region, gcpclient.ListComputeSubnetworksOptions{Filter: l.clusterIDFilter()}, func(list *compute.SubnetworkList) error {
+		for _, subnetwork := range list.Items {
+			l.add("compute:subnetwork", subnetwork.Name)
+		}
+		return nil
+	})
+}
+
+func (l *GCPLister) listNetworks() error {
+	return l.Client.ListComputeNetworks(gcpclient.ListComputeNetworksOptions{Filter: l.clusterIDFilter()}, func(list *compute.NetworkList) error {
+		for _, network := range list.Items {
+			l.add("compute:network", network.Name)
+		}
+		return nil
+	})
+}
+
+// listCloudControllerResources lists the instance groups of the cluster, and the resources created by the cloud
+// controller for the load balancers of services: the backend services that only point at cluster instance groups, the target pools that only contain
+// cluster instances, and the addresses, firewalls, forwarding rules and health checks named after them.
+func (l *GCPLister) listCloudControllerResources() error {
+	uid := typesgcp.CloudControllerUID(l.InfraID)
+	instanceGroups, err := l.instanceGroups(fmt.Sprintf("name eq \"k8s-ig--%s\"", uid))
+	if err != nil {
+		return err
+	}
+	installerInstanceGroups, err := l.instanceGroups(l.clusterIDFilter())
+	if err != nil {
+		return err
+	}
+	clusterInstanceGroups := map[string]bool{}
+	for _, url := range append(instanceGroups, installerInstanceGroups...) {
+		clusterInstanceGroups[url] = true
+	}
+
+	var loadBalancers []string
+	if len(clusterInstanceGroups) > 0 {
+		err := l.Client.ListComputeBackendServices(l.Region, gcpclient.ListComputeBackendServicesOptions{Filter: gcpLoadBalancerFilter}, func(list *compute.BackendServiceList) error {
+			for _, service := range list.Items {
+				if len(service.Backends) == 0 {
+					continue
+				}
+				owned := true
+				for _, backend := range service.Backends {
+					owned = owned && clusterInstanceGroups[backend.Group]
+				}
+				if owned {
+					l.add("compute:backendservice", service.Name)
+					loadBalancers = append(loadBalancers, service.Name)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err = l.Client.ListComputeTargetPools(l.Region, gcpclient.ListComputeTargetPoolsOptions{Filter: gcpLoadBalancerFilter}, func(list *compute.TargetPoolList) error {
+		for _, pool := range list.Items {
+			if len(pool.Instances) == 0 {
+				continue
+			}
+			owned := true
+			for _, instance := range pool.Instances {
+				owned = owned && l.isClusterResource(path.Base(instance))
+			}
+			if owned {
+				l.add("compute:targetpool", pool.Name)
+				loadBalancers = append(loadBalancers, pool.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range loadBalancers {
+		filter := fmt.Sprintf("name eq \"%s\"", name)
+		for _, list := range []func() error{
+			func() error { return l.listAddressesWithFilter(filter) },
+			func() error { return l.listFirewallsWithFilter(filter) },
+			func() error { return l.listFirewallsWithFilter(fmt.Sprintf("name eq \"%s-hc\"", name)) },
+			func() error { return l.listFirewallsWithFilter(fmt.Sprintf("name eq \"k8s-fw-%s\"", name)) },
+			func() error { return l.listFirewallsWithFilter(fmt.Sprintf("name eq \"k8s-%s-http-hc\"", name)) },
+			func() error { return l.listForwardingRulesWithFilter(filter) },
+			func() error { return l.listHealthChecksWithFilter(filter) },
+			func() error { return l.listHTTPHealthChecksWithFilter(filter) },
+		} {
+			if err := list(); err != nil {
+				return err
+			}
+		}
+	}
+
+	nodeFilter := fmt.Sprintf("name eq \"k8s-%s-node\"", uid)
+	for _, list := range []func() error{
+		func() error { return l.listHealthChecksWithFilter(nodeFilter) },
+		func() error { return l.listHTTPHealthChecksWithFilter(nodeFilter) },
+		func() error { return l.listFirewallsWithFilter(fmt.Sprintf("name eq \"k8s-%s-node-hc\"", uid)) },
+		func() error { return l.listFirewallsWithFilter(fmt.Sprintf("name eq \"k8s-%s-node-http-hc\"", uid)) },
+	} {
+		if err := list(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
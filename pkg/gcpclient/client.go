@@ -2,6 +2,7 @@ package gcpclient
 
 import (
 	"context"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -14,8 +15,10 @@ import (
 	compute "google.golang.org/api/compute/v1"
 	dns "google.golang.org/api/dns/v1"
 	"google.golang.org/api/googleapi"
+	iam "google.golang.org/api/iam/v1"
 	"google.golang.org/api/option"
 	serviceusage "google.golang.org/api/serviceusage/v1"
+	storage "google.golang.org/api/storage/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -47,9 +50,51 @@ type Client interface {
 
 	ListComputeInstances(ListComputeInstancesOptions, func(*compute.InstanceAggregatedList) error) error
 
+	ListComputeDisks(ListComputeDisksOptions, func(*compute.DiskAggregatedList) error) error
+
+	ListComputeNetworks(ListComputeNetworksOptions, func(*compute.NetworkList) error) error
+
+	ListComputeFirewalls(ListComputeFirewallsOptions, func(*compute.FirewallList) error) error
+
+	ListComputeSubnetworks(region string, opts ListComputeSubnetworksOptions, pagesFn func(*compute.SubnetworkList) error) error
+
+	ListComputeRouters(region string, opts ListComputeRoutersOptions, pagesFn func(*compute.RouterList) error) error
+
+	ListComputeRoutes(ListComputeRoutesOptions, func(*compute.RouteList) error) error
+
+	ListComputeAddresses(region string, opts ListComputeAddressesOptions, pagesFn func(*compute.AddressList) error) error
+
+	ListComputeForwardingRules(region string, opts ListComputeForwardingRulesOptions, pagesFn func(*compute.ForwardingRuleList) error) error
+
+	ListComputeTargetPools(region string, opts ListComputeTargetPoolsOptions, pagesFn func(*compute.TargetPoolList) error) error
+
+	ListComputeBackendServices(region string, opts ListComputeBackendServicesOptions, pagesFn func(*compute.BackendServiceList) error) error
+
+	ListComputeInstanceGroups(ListComputeInstanceGroupsOptions, func(*compute.InstanceGroupAggregatedList) error) error
+
+	ListComputeHealthChecks(ListComputeHealthChecksOptions, func(*compute.HealthCheckList) error) error
+
+	ListComputeHTTPHealthChecks(ListComputeHTTPHealthChecksOptions, func(*compute.HttpHealthCheckList) error) error
+
+	ListStorageBuckets(ListStorageBucketsOptions, func(*storage.Buckets) error) error
+
+	ListServiceAccounts(func(*iam.ListServiceAccountsResponse) error) error
+
+	GetProjectIamPolicy() (*cloudresourcemanager.Policy, error)
+
 	StopInstance(*compute.Instance) error
 
 	StartInstance(*compute.Instance) error
+
+	GetProject() (*compute.Project, error)
+
+	GetRegion(region string) (*compute.Region, error)
+
+	GetMachineType(zone, machineType string) (*compute.MachineType, error)
+
+	TestIamPermissions(permissions []string) ([]string, error)
+
+	UploadObject(bucket, name string, content io.Reader) error
 }
 
 // ListManagedZonesOptions are the options for listing managed zones.
@@ -72,6 +117,76 @@ type ListComputeInstancesOptions struct {
 	Fields string
 }
 
+// ListComputeDisksOptions are the options for listing compute disks.
+type ListComputeDisksOptions struct {
+	Filter string
+}
+
+// ListComputeNetworksOptions are the options for listing compute networks.
+type ListComputeNetworksOptions struct {
+	Filter string
+}
+
+// ListComputeFirewallsOptions are the options for listing compute firewalls.
+type ListComputeFirewallsOptions struct {
+	Filter string
+}
+
+// ListComputeSubnetworksOptions are the options for listing compute subnetworks.
+type ListComputeSubnetworksOptions struct {
+	Filter string
+}
+
+// ListComputeRoutersOptions are the options for listing compute routers.
+type ListComputeRoutersOptions struct {
+	Filter string
+}
+
+// ListComputeRoutesOptions are the options for listing compute routes.
+type ListComputeRoutesOptions struct {
+	Filter string
+}
+
+// ListComputeAddressesOptions are the options for listing compute addresses.
+type ListComputeAddressesOptions struct {
+	Filter string
+}
+
+// ListComputeForwardingRulesOptions are the options for listing compute forwarding rules.
+type ListComputeForwardingRulesOptions struct {
+	Filter string
+}
+
+// ListComputeTargetPoolsOptions are the options for listing compute target pools.
+type ListComputeTargetPoolsOptions struct {
+	Filter string
+}
+
+// ListComputeBackendServicesOptions are the options for listing compute backend services.
+type ListComputeBackendServicesOptions struct {
+	Filter string
+}
+
+// ListComputeInstanceGroupsOptions are the options for listing compute instance groups.
+type ListComputeInstanceGroupsOptions struct {
+	Filter string
+}
+
+// ListComputeHealthChecksOptions are the options for listing compute health checks.
+type ListComputeHealthChecksOptions struct {
+	Filter string
+}
+
+// ListComputeHTTPHealthChecksOptions are the options for listing compute HTTP health checks.
+type ListComputeHTTPHealthChecksOptions struct {
+	Filter string
+}
+
+// ListStorageBucketsOptions are the options for listing storage buckets.
+type ListStorageBucketsOptions struct {
+	Prefix string
+}
+
 type gcpClient struct {
 	projectName                string
 	creds                      *google.Credentials
@@ -79,6 +194,8 @@ type gcpClient struct {
 	computeClient              *compute.Service
 	serviceUsageClient         *serviceusage.Service
 	dnsClient                  *dns.Service
+	storageClient              *storage.Service
+	iamClient                  *iam.Service
 }
 
 const (
@@ -247,6 +364,190 @@ func (c *gcpClient) ListComputeInstances(opts ListComputeInstancesOptions, pages
 	return nil
 }
 
+func (c *gcpClient) ListComputeDisks(opts ListComputeDisksOptions, pagesFn func(*compute.DiskAggregatedList) error) error {
+	req := c.computeClient.Disks.AggregatedList(c.projectName)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute disks")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeNetworks(opts ListComputeNetworksOptions, pagesFn func(*compute.NetworkList) error) error {
+	req := c.computeClient.Networks.List(c.projectName)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute networks")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeFirewalls(opts ListComputeFirewallsOptions, pagesFn func(*compute.FirewallList) error) error {
+	req := c.computeClient.Firewalls.List(c.projectName)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute firewalls")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeSubnetworks(region string, opts ListComputeSubnetworksOptions, pagesFn func(*compute.SubnetworkList) error) error {
+	req := c.computeClient.Subnetworks.List(c.projectName, region)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute subnetworks")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeRouters(region string, opts ListComputeRoutersOptions, pagesFn func(*compute.RouterList) error) error {
+	req := c.computeClient.Routers.List(c.projectName, region)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute routers")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeRoutes(opts ListComputeRoutesOptions, pagesFn func(*compute.RouteList) error) error {
+	req := c.computeClient.Routes.List(c.projectName)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute routes")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeAddresses(region string, opts ListComputeAddressesOptions, pagesFn func(*compute.AddressList) error) error {
+	req := c.computeClient.Addresses.List(c.projectName, region)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute addresses")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeForwardingRules(region string, opts ListComputeForwardingRulesOptions, pagesFn func(*compute.ForwardingRuleList) error) error {
+	req := c.computeClient.ForwardingRules.List(c.projectName, region)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute forwarding rules")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeTargetPools(region string, opts ListComputeTargetPoolsOptions, pagesFn func(*compute.TargetPoolList) error) error {
+	req := c.computeClient.TargetPools.List(c.projectName, region)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute target pools")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeBackendServices(region string, opts ListComputeBackendServicesOptions, pagesFn func(*compute.BackendServiceList) error) error {
+	req := c.computeClient.RegionBackendServices.List(c.projectName, region)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute backend services")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeInstanceGroups(opts ListComputeInstanceGroupsOptions, pagesFn func(*compute.InstanceGroupAggregatedList) error) error {
+	req := c.computeClient.InstanceGroups.AggregatedList(c.projectName)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute instance groups")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeHealthChecks(opts ListComputeHealthChecksOptions, pagesFn func(*compute.HealthCheckList) error) error {
+	req := c.computeClient.HealthChecks.List(c.projectName)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute health checks")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeHTTPHealthChecks(opts ListComputeHTTPHealthChecksOptions, pagesFn func(*compute.HttpHealthCheckList) error) error {
+	req := c.computeClient.HttpHealthChecks.List(c.projectName)
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute http health checks")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListStorageBuckets(opts ListStorageBucketsOptions, pagesFn func(*storage.Buckets) error) error {
+	req := c.storageClient.Buckets.List(c.projectName)
+	if len(opts.Prefix) > 0 {
+		req = req.Prefix(opts.Prefix)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch storage buckets")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListServiceAccounts(pagesFn func(*iam.ListServiceAccountsResponse) error) error {
+	req := c.iamClient.Projects.ServiceAccounts.List("projects/" + c.projectName)
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch service accounts")
+	}
+	return nil
+}
+
+func (c *gcpClient) GetProjectIamPolicy() (*cloudresourcemanager.Policy, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	return c.cloudResourceManagerClient.Projects.GetIamPolicy(c.projectName, &cloudresourcemanager.GetIamPolicyRequest{}).Context(ctx).Do()
+}
+
 func (c *gcpClient) StopInstance(instance *compute.Instance) error {
 	zone := instanceZone(instance)
 	_, err := c.computeClient.Instances.Stop(c.projectName, zone, instance.Name).Do()
@@ -265,6 +566,50 @@ func (c *gcpClient) StartInstance(instance *compute.Instance) error {
 	return nil
 }
 
+func (c *gcpClient) GetProject() (*compute.Project, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	return c.computeClient.Projects.Get(c.projectName).Context(ctx).Do()
+}
+
+func (c *gcpClient) GetRegion(region string) (*compute.Region, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	return c.computeClient.Regions.Get(c.projectName, region).Context(ctx).Do()
+}
+
+func (c *gcpClient) GetMachineType(zone, machineType string) (*compute.MachineType, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	return c.computeClient.MachineTypes.Get(c.projectName, zone, machineType).Context(ctx).Do()
+}
+
+// TestIamPermissions returns the subset of the given permissions that the credentials have on the project.
+func (c *gcpClient) TestIamPermissions(permissions []string) ([]string, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	resp, err := c.cloudResourceManagerClient.Projects.TestIamPermissions(
+		c.projectName,
+		&cloudresourcemanager.TestIamPermissionsRequest{Permissions: permissions},
+	).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Permissions, nil
+}
+
+func (c *gcpClient) UploadObject(bucket, name string, content io.Reader) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	_, err := c.storageClient.Objects.Insert(bucket, &storage.Object{Name: name}).Media(content).Context(ctx).Do()
+	return err
+}
+
 // NewClient creates our client wrapper object for interacting with GCP. The supplied byte slice contains the GCP creds.
 func NewClient(authJSON []byte) (Client, error) {
 	return newClient(authJSONPassthroughSource(authJSON))
@@ -348,6 +693,16 @@ func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
 		return nil, err
 	}
 
+	storageClient, err := storage.NewService(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	iamClient, err := iam.NewService(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
 	return &gcpClient{
 		projectName:                creds.ProjectID,
 		creds:                      creds,
@@ -355,6 +710,8 @@ func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
 		computeClient:              computeClient,
 		serviceUsageClient:         serviceUsageClient,
 		dnsClient:                  dnsClient,
+		storageClient:              storageClient,
+		iamClient:                  iamClient,
 	}, nil
 }
 
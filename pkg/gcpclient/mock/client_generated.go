@@ -7,8 +7,12 @@ package mock
 import (
 	gomock "github.com/golang/mock/gomock"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 	compute "google.golang.org/api/compute/v1"
 	dns "google.golang.org/api/dns/v1"
+	iam "google.golang.org/api/iam/v1"
+	storage "google.golang.org/api/storage/v1"
+	io "io"
 	reflect "reflect"
 )
 
@@ -209,6 +213,231 @@ func (mr *MockClientMockRecorder) ListComputeInstances(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeInstances", reflect.TypeOf((*MockClient)(nil).ListComputeInstances), arg0, arg1)
 }
 
+// ListComputeDisks mocks base method
+func (m *MockClient) ListComputeDisks(arg0 gcpclient.ListComputeDisksOptions, arg1 func(*compute.DiskAggregatedList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeDisks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeDisks indicates an expected call of ListComputeDisks
+func (mr *MockClientMockRecorder) ListComputeDisks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeDisks", reflect.TypeOf((*MockClient)(nil).ListComputeDisks), arg0, arg1)
+}
+
+// ListComputeNetworks mocks base method
+func (m *MockClient) ListComputeNetworks(arg0 gcpclient.ListComputeNetworksOptions, arg1 func(*compute.NetworkList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeNetworks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeNetworks indicates an expected call of ListComputeNetworks
+func (mr *MockClientMockRecorder) ListComputeNetworks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeNetworks", reflect.TypeOf((*MockClient)(nil).ListComputeNetworks), arg0, arg1)
+}
+
+// ListComputeFirewalls mocks base method
+func (m *MockClient) ListComputeFirewalls(arg0 gcpclient.ListComputeFirewallsOptions, arg1 func(*compute.FirewallList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeFirewalls", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeFirewalls indicates an expected call of ListComputeFirewalls
+func (mr *MockClientMockRecorder) ListComputeFirewalls(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeFirewalls", reflect.TypeOf((*MockClient)(nil).ListComputeFirewalls), arg0, arg1)
+}
+
+// ListComputeSubnetworks mocks base method
+func (m *MockClient) ListComputeSubnetworks(region string, opts gcpclient.ListComputeSubnetworksOptions, pagesFn func(*compute.SubnetworkList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeSubnetworks", region, opts, pagesFn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeSubnetworks indicates an expected call of ListComputeSubnetworks
+func (mr *MockClientMockRecorder) ListComputeSubnetworks(region, opts, pagesFn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeSubnetworks", reflect.TypeOf((*MockClient)(nil).ListComputeSubnetworks), region, opts, pagesFn)
+}
+
+// ListComputeRouters mocks base method
+func (m *MockClient) ListComputeRouters(region string, opts gcpclient.ListComputeRoutersOptions, pagesFn func(*compute.RouterList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeRouters", region, opts, pagesFn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeRouters indicates an expected call of ListComputeRouters
+func (mr *MockClientMockRecorder) ListComputeRouters(region, opts, pagesFn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeRouters", reflect.TypeOf((*MockClient)(nil).ListComputeRouters), region, opts, pagesFn)
+}
+
+// ListComputeRoutes mocks base method
+func (m *MockClient) ListComputeRoutes(arg0 gcpclient.ListComputeRoutesOptions, arg1 func(*compute.RouteList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeRoutes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeRoutes indicates an expected call of ListComputeRoutes
+func (mr *MockClientMockRecorder) ListComputeRoutes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeRoutes", reflect.TypeOf((*MockClient)(nil).ListComputeRoutes), arg0, arg1)
+}
+
+// ListComputeAddresses mocks base method
+func (m *MockClient) ListComputeAddresses(region string, opts gcpclient.ListComputeAddressesOptions, pagesFn func(*compute.AddressList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeAddresses", region, opts, pagesFn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeAddresses indicates an expected call of ListComputeAddresses
+func (mr *MockClientMockRecorder) ListComputeAddresses(region, opts, pagesFn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeAddresses", reflect.TypeOf((*MockClient)(nil).ListComputeAddresses), region, opts, pagesFn)
+}
+
+// ListComputeForwardingRules mocks base method
+func (m *MockClient) ListComputeForwardingRules(region string, opts gcpclient.ListComputeForwardingRulesOptions, pagesFn func(*compute.ForwardingRuleList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeForwardingRules", region, opts, pagesFn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeForwardingRules indicates an expected call of ListComputeForwardingRules
+func (mr *MockClientMockRecorder) ListComputeForwardingRules(region, opts, pagesFn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeForwardingRules", reflect.TypeOf((*MockClient)(nil).ListComputeForwardingRules), region, opts, pagesFn)
+}
+
+// ListComputeTargetPools mocks base method
+func (m *MockClient) ListComputeTargetPools(region string, opts gcpclient.ListComputeTargetPoolsOptions, pagesFn func(*compute.TargetPoolList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeTargetPools", region, opts, pagesFn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeTargetPools indicates an expected call of ListComputeTargetPools
+func (mr *MockClientMockRecorder) ListComputeTargetPools(region, opts, pagesFn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeTargetPools", reflect.TypeOf((*MockClient)(nil).ListComputeTargetPools), region, opts, pagesFn)
+}
+
+// ListComputeBackendServices mocks base method
+func (m *MockClient) ListComputeBackendServices(region string, opts gcpclient.ListComputeBackendServicesOptions, pagesFn func(*compute.BackendServiceList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeBackendServices", region, opts, pagesFn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeBackendServices indicates an expected call of ListComputeBackendServices
+func (mr *MockClientMockRecorder) ListComputeBackendServices(region, opts, pagesFn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeBackendServices", reflect.TypeOf((*MockClient)(nil).ListComputeBackendServices), region, opts, pagesFn)
+}
+
+// ListComputeInstanceGroups mocks base method
+func (m *MockClient) ListComputeInstanceGroups(arg0 gcpclient.ListComputeInstanceGroupsOptions, arg1 func(*compute.InstanceGroupAggregatedList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeInstanceGroups", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeInstanceGroups indicates an expected call of ListComputeInstanceGroups
+func (mr *MockClientMockRecorder) ListComputeInstanceGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeInstanceGroups", reflect.TypeOf((*MockClient)(nil).ListComputeInstanceGroups), arg0, arg1)
+}
+
+// ListComputeHealthChecks mocks base method
+func (m *MockClient) ListComputeHealthChecks(arg0 gcpclient.ListComputeHealthChecksOptions, arg1 func(*compute.HealthCheckList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeHealthChecks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeHealthChecks indicates an expected call of ListComputeHealthChecks
+func (mr *MockClientMockRecorder) ListComputeHealthChecks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeHealthChecks", reflect.TypeOf((*MockClient)(nil).ListComputeHealthChecks), arg0, arg1)
+}
+
+// ListComputeHTTPHealthChecks mocks base method
+func (m *MockClient) ListComputeHTTPHealthChecks(arg0 gcpclient.ListComputeHTTPHealthChecksOptions, arg1 func(*compute.HttpHealthCheckList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeHTTPHealthChecks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeHTTPHealthChecks indicates an expected call of ListComputeHTTPHealthChecks
+func (mr *MockClientMockRecorder) ListComputeHTTPHealthChecks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeHTTPHealthChecks", reflect.TypeOf((*MockClient)(nil).ListComputeHTTPHealthChecks), arg0, arg1)
+}
+
+// ListStorageBuckets mocks base method
+func (m *MockClient) ListStorageBuckets(arg0 gcpclient.ListStorageBucketsOptions, arg1 func(*storage.Buckets) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStorageBuckets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListStorageBuckets indicates an expected call of ListStorageBuckets
+func (mr *MockClientMockRecorder) ListStorageBuckets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStorageBuckets", reflect.TypeOf((*MockClient)(nil).ListStorageBuckets), arg0, arg1)
+}
+
+// ListServiceAccounts mocks base method
+func (m *MockClient) ListServiceAccounts(arg0 func(*iam.ListServiceAccountsResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServiceAccounts", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListServiceAccounts indicates an expected call of ListServiceAccounts
+func (mr *MockClientMockRecorder) ListServiceAccounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceAccounts", reflect.TypeOf((*MockClient)(nil).ListServiceAccounts), arg0)
+}
+
+// GetProjectIamPolicy mocks base method
+func (m *MockClient) GetProjectIamPolicy() (*cloudresourcemanager.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectIamPolicy")
+	ret0, _ := ret[0].(*cloudresourcemanager.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectIamPolicy indicates an expected call of GetProjectIamPolicy
+func (mr *MockClientMockRecorder) GetProjectIamPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectIamPolicy", reflect.TypeOf((*MockClient)(nil).GetProjectIamPolicy))
+}
+
 // StopInstance mocks base method
 func (m *MockClient) StopInstance(arg0 *compute.Instance) error {
 	m.ctrl.T.Helper()
@@ -236,3 +465,77 @@ func (mr *MockClientMockRecorder) StartInstance(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInstance", reflect.TypeOf((*MockClient)(nil).StartInstance), arg0)
 }
+
+// GetProject mocks base method
+func (m *MockClient) GetProject() (*compute.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject")
+	ret0, _ := ret[0].(*compute.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject
+func (mr *MockClientMockRecorder) GetProject() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockClient)(nil).GetProject))
+}
+
+// GetRegion mocks base method
+func (m *MockClient) GetRegion(region string) (*compute.Region, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegion", region)
+	ret0, _ := ret[0].(*compute.Region)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegion indicates an expected call of GetRegion
+func (mr *MockClientMockRecorder) GetRegion(region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegion", reflect.TypeOf((*MockClient)(nil).GetRegion), region)
+}
+
+// GetMachineType mocks base method
+func (m *MockClient) GetMachineType(zone, machineType string) (*compute.MachineType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachineType", zone, machineType)
+	ret0, _ := ret[0].(*compute.MachineType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachineType indicates an expected call of GetMachineType
+func (mr *MockClientMockRecorder) GetMachineType(zone, machineType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineType", reflect.TypeOf((*MockClient)(nil).GetMachineType), zone, machineType)
+}
+
+// TestIamPermissions mocks base method
+func (m *MockClient) TestIamPermissions(permissions []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestIamPermissions", permissions)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestIamPermissions indicates an expected call of TestIamPermissions
+func (mr *MockClientMockRecorder) TestIamPermissions(permissions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestIamPermissions", reflect.TypeOf((*MockClient)(nil).TestIamPermissions), permissions)
+}
+
+// UploadObject mocks base method
+func (m *MockClient) UploadObject(bucket, name string, content io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadObject", bucket, name, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadObject indicates an expected call of UploadObject
+func (mr *MockClientMockRecorder) UploadObject(bucket, name, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadObject", reflect.TypeOf((*MockClient)(nil).UploadObject), bucket, name, content)
+}
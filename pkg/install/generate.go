@@ -28,6 +28,7 @@ import (
 	"github.com/openshift/hive/pkg/controller/images"
 	"github.com/openshift/hive/pkg/controller/utils"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/deprovision"
 )
 
 const (
@@ -475,6 +476,25 @@ func InstallerPodSpec(
 		})
 	}
 
+	// Mount the PersistentVolumeClaim the logs of failed installs are copied to:
+	for _, envVar := range extraEnvVars {
+		if envVar.Name != constants.InstallLogsPVCClaimNameEnvVar || envVar.Value == "" {
+			continue
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: "install-logs",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: envVar.Value,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "install-logs",
+			MountPath: constants.InstallLogsPVCMountPath,
+		})
+	}
+
 	// Signal to fake an installation:
 	if utils.IsFakeCluster(cd) {
 		env = append(env, corev1.EnvVar{
@@ -668,6 +688,19 @@ func GenerateUninstallerJobForDeprovision(
 		return nil, errors.New("deprovision requests currently not supported for platform")
 	}
 
+	if req.Spec.DryRun {
+		if !DryRunDeprovisionSupported(req) {
+			return nil, errors.New("dry run deprovision requests currently not supported for platform")
+		}
+		job.Spec.Template.Spec.Containers[0].Args = append(job.Spec.Template.Spec.Containers[0].Args,
+			"--dry-run",
+			"--dry-run-configmap",
+			deprovision.DryRunConfigMapName(req.Name),
+			"--namespace",
+			req.Namespace,
+		)
+	}
+
 	for idx := range job.Spec.Template.Spec.Containers {
 		job.Spec.Template.Spec.Containers[idx].Env = append(job.Spec.Template.Spec.Containers[idx].Env, extraEnvVars...)
 	}
@@ -676,6 +709,13 @@ func GenerateUninstallerJobForDeprovision(
 	return job, nil
 }
 
+// DryRunDeprovisionSupported returns true if the resources that a deprovision would delete can be listed for the
+// platform of the ClusterDeprovision.
+func DryRunDeprovisionSupported(req *hivev1.ClusterDeprovision) bool {
+	p := req.Spec.Platform
+	return p.AWS != nil || p.Azure != nil || p.GCP != nil
+}
+
 func completeAWSDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
 	credentialRef := *req.Spec.Platform.AWS.CredentialsSecretRef
 	if credentialRef.Name == "" {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	hiveassert "github.com/openshift/hive/pkg/test/assert"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -37,6 +38,24 @@ func TestGenerateDeprovision(t *testing.T) {
 	hiveassert.AssertAllContainersHaveEnvVar(t, &job.Spec.Template.Spec, "NO_PROXY", testNoProxy)
 }
 
+func TestGenerateDryRunDeprovision(t *testing.T) {
+	dr := testClusterDeprovision()
+	dr.Spec.DryRun = true
+	job, err := GenerateUninstallerJobForDeprovision(dr, "someseviceaccount", "", "", "", nil)
+	assert.NoError(t, err)
+	if assert.NotNil(t, job) {
+		assert.Subset(t, job.Spec.Template.Spec.Containers[0].Args,
+			[]string{"--dry-run", "--dry-run-configmap", "foo-dry-run", "--namespace", "default"},
+			"expected dry run arguments")
+	}
+
+	dr.Spec.Platform = hivev1.ClusterDeprovisionPlatform{
+		VSphere: &hivev1.VSphereClusterDeprovision{},
+	}
+	_, err = GenerateUninstallerJobForDeprovision(dr, "someseviceaccount", "", "", "", nil)
+	assert.Error(t, err, "expected error for a platform without dry run support")
+}
+
 func testClusterDeprovision() *hivev1.ClusterDeprovision {
 	return &hivev1.ClusterDeprovision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -103,6 +122,42 @@ func TestInstallerPodSpec(t *testing.T) {
 				assert.NoError(t, actualError)
 			},
 		},
+		{
+			name: "Test Provision Pod Install Logs PersistentVolumeClaim",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{
+						InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "foo"},
+					},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName: "testprovision",
+			extraEnvVars: []corev1.EnvVar{
+				{
+					Name:  constants.InstallLogsPVCClaimNameEnvVar,
+					Value: "install-logs",
+				},
+			},
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				assert.NoError(t, actualError)
+				assert.Contains(t, actualPodSpec.Volumes, corev1.Volume{
+					Name: "install-logs",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "install-logs"},
+					},
+				}, "missing install logs volume")
+				for _, container := range actualPodSpec.Containers {
+					assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+						Name:      "install-logs",
+						MountPath: constants.InstallLogsPVCMountPath,
+					}, "missing install logs volume mount")
+				}
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,86 @@
+package installmanager
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+
+	"github.com/pkg/errors"
+)
+
+// Ensure azureLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &azureLogUploaderActuator{}
+
+// azureLogUploaderActuator uploads installer logs to an Azure Blob storage container.
+type azureLogUploaderActuator struct {
+	// azureClientFn is the function to build an Azure client, here for lazy loading the client.
+	azureClientFn func(client.Client, string, string, log.FieldLogger) (azureclient.Client, error)
+}
+
+// IsConfigured returns true if the actuator can handle a particular case
+func (a *azureLogUploaderActuator) IsConfigured() bool {
+	provider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
+	if !foundProviderEnvVar {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return false
+	}
+
+	return provider == constants.InstallLogsUploadProviderAzure
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *azureLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if !foundSecretName {
+		return errors.New("couldn't find secret name in environment variable. Skipping upload")
+	}
+
+	storageAccount, foundStorageAccountEnvVar := os.LookupEnv(constants.InstallLogsAzureStorageAccountEnvVar)
+	if !foundStorageAccountEnvVar {
+		return errors.New("couldn't find storage account in environment variable. Skipping upload")
+	}
+
+	container, foundContainerEnvVar := os.LookupEnv(constants.InstallLogsAzureContainerEnvVar)
+	if !foundContainerEnvVar {
+		return errors.New("couldn't find container in environment variable. Skipping upload")
+	}
+
+	azurec, err := a.azureClientFn(c, secretName, clusterprovision.Namespace, log)
+	if err != nil {
+		return err
+	}
+
+	folder := installLogsFolder(clusterName, clusterprovision)
+
+	log.Infof("Uploading log(s) to Azure Blob storage: %v/%v/%v/", storageAccount, container, folder)
+
+	return uploadLogFiles(folder, clusterprovision, filenames, func(key string, file *os.File) error {
+		content, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		return azurec.UploadBlob(context.TODO(), storageAccount, container, key, content)
+	})
+}
+
+func getAzureClient(c client.Client, secretName, namespace string, logger log.FieldLogger) (azureclient.Client, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: secretName}, secret); err != nil {
+		logger.WithError(err).Error("failed to get Azure credentials secret")
+		return nil, err
+	}
+	azureClient, err := azureclient.NewClientFromSecret(secret)
+	if err != nil {
+		logger.WithError(err).Error("failed to get Azure client")
+	}
+	return azureClient, err
+}
@@ -0,0 +1,80 @@
+package installmanager
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestAzureUploadLogs(t *testing.T) {
+	tests := []struct {
+		name                    string
+		uploadBlobError         error
+		setupUploadBlobMock     bool
+		setupEnvVars            bool
+		expectedUploadLogsError bool
+	}{
+		{
+			name:                    "missing env vars",
+			expectedUploadLogsError: true,
+		},
+		{
+			name:                "successfully upload blobs",
+			setupUploadBlobMock: true,
+			setupEnvVars:        true,
+		},
+		{
+			name:                    "upload failure",
+			uploadBlobError:         errors.New("upload failed"),
+			setupUploadBlobMock:     true,
+			setupEnvVars:            true,
+			expectedUploadLogsError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t)
+			defer mocks.mockCtrl.Finish()
+
+			if test.setupEnvVars {
+				os.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderAzure)
+				os.Setenv(constants.InstallLogsCredentialsSecretRefEnvVar, "notarealsecret")
+				os.Setenv(constants.InstallLogsAzureStorageAccountEnvVar, "account1")
+				os.Setenv(constants.InstallLogsAzureContainerEnvVar, "container1")
+				defer func() {
+					os.Unsetenv(constants.InstallLogsUploadProviderEnvVar)
+					os.Unsetenv(constants.InstallLogsCredentialsSecretRefEnvVar)
+					os.Unsetenv(constants.InstallLogsAzureStorageAccountEnvVar)
+					os.Unsetenv(constants.InstallLogsAzureContainerEnvVar)
+				}()
+			}
+			if test.setupUploadBlobMock {
+				mocks.mockAzureClient.EXPECT().
+					UploadBlob(gomock.Any(), "account1", "container1", "notarealcluster-"+testNamespace+"/"+testProvisionName+"-issue", gomock.Any()).
+					Return(test.uploadBlobError)
+			}
+
+			actuator := &azureLogUploaderActuator{azureClientFn: func(client.Client, string, string, log.FieldLogger) (azureclient.Client, error) {
+				return mocks.mockAzureClient, nil
+			}}
+			provision := testClusterProvision()
+
+			err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), "/etc/issue")
+
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+			} else {
+				assert.NoError(t, err, "Function errored unexpectedly")
+			}
+		})
+	}
+}
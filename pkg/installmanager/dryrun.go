@@ -0,0 +1,246 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+	"github.com/openshift/hive/pkg/util/redact"
+)
+
+const (
+	dryRunConfigMapNameTemplate = "%s-dry-run"
+
+	// Keys of the dry run result ConfigMap.
+	dryRunInstallConfigKey    = "install-config.yaml"
+	dryRunManifestsKey        = "manifests"
+	dryRunReleaseImageKey     = "release-image"
+	dryRunInstallerImageKey   = "installer-image"
+	dryRunInstallVersionKey   = "install-version"
+	dryRunInstallerVersionKey = "installer-version"
+
+	redactedValue = "REDACTED"
+)
+
+// sensitiveInstallConfigKeyRE matches the keys of install-config fields that hold credentials, such as the pull
+// secret and platform passwords. Credentials in URLs, such as those of the proxy, are stripped from any field.
+var sensitiveInstallConfigKeyRE = regexp.MustCompile(`(?i)password|secret|token`)
+
+// manifestDirs are the directories of the work dir the installer renders manifests into.
+var manifestDirs = []string{"manifests", "openshift"}
+
+// runDryRun renders the manifests for the cluster without creating any infrastructure, and saves the redacted
+// install-config, the list of manifests and the release image to a ConfigMap referenced by the ClusterProvision.
+func (m *InstallManager) runDryRun(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, installConfig []byte, scrubInstallLog bool) error {
+	m.log.Info("rendering manifests for dry run")
+	renderErr := m.generateManifests(cd)
+
+	if installLog, err := m.readInstallerLog(provision, m, scrubInstallLog); err == nil {
+		if err := m.updateClusterProvision(
+			provision,
+			m,
+			func(provision *hivev1.ClusterProvision) {
+				provision.Spec.InstallLog = pointer.StringPtr(installLog)
+			},
+		); err != nil {
+			m.log.WithError(err).Warning("error updating cluster provision with installer log")
+		}
+	} else {
+		m.log.WithError(err).Error("error reading installer log")
+	}
+
+	if renderErr != nil {
+		m.log.WithError(renderErr).Error("dry run failed to render manifests")
+		return renderErr
+	}
+
+	cm, err := m.generateDryRunConfigMap(cd, provision, installConfig)
+	if err != nil {
+		m.log.WithError(err).Error("error generating dry run result")
+		return err
+	}
+	if err := m.saveDryRunConfigMap(cm); err != nil {
+		m.log.WithError(err).Error("error saving dry run result")
+		return err
+	}
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := m.loadClusterProvision(provision); err != nil {
+			return err
+		}
+		provision.Status.DryRunResultRef = &corev1.LocalObjectReference{Name: cm.Name}
+		return m.DynamicClient.Status().Update(context.Background(), provision)
+	}); err != nil {
+		m.log.WithError(err).Error("error updating cluster provision with dry run result")
+		return err
+	}
+
+	m.log.WithField("configMap", cm.Name).Info("dry run completed successfully")
+	return nil
+}
+
+// generateDryRunConfigMap returns the ConfigMap holding the results of the dry run, owned by the ClusterProvision.
+func (m *InstallManager) generateDryRunConfigMap(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, installConfig []byte) (*corev1.ConfigMap, error) {
+	redactedInstallConfig, err := redactInstallConfig(installConfig, m.redactor)
+	if err != nil {
+		return nil, err
+	}
+	manifests, err := listManifests(m.WorkDir)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]string{
+		dryRunInstallConfigKey: string(redactedInstallConfig),
+		dryRunManifestsKey:     strings.Join(manifests, "\n"),
+		dryRunReleaseImageKey:  os.Getenv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE"),
+	}
+	if cd.Status.InstallerImage != nil {
+		data[dryRunInstallerImageKey] = *cd.Status.InstallerImage
+	}
+	if cd.Status.InstallVersion != nil {
+		data[dryRunInstallVersionKey] = *cd.Status.InstallVersion
+	}
+	// The installer reports the release image it was built for, which is used when no override is set.
+	if out, err := exec.Command(filepath.Join(m.binaryDir, "openshift-install"), "version").Output(); err == nil {
+		data[dryRunInstallerVersionKey] = string(out)
+	} else {
+		m.log.WithError(err).Warn("error getting installer version")
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(dryRunConfigMapNameTemplate, provision.Name),
+			Namespace: provision.Namespace,
+		},
+		Data: data,
+	}
+	cm.Labels = k8slabels.AddLabel(cm.Labels, constants.ClusterProvisionNameLabel, provision.Name)
+	cm.Labels = k8slabels.AddLabel(cm.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+
+	provisionGVK, err := apiutil.GVKForObject(provision, scheme.Scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting GVK for provision")
+	}
+	cm.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion:         provisionGVK.GroupVersion().String(),
+		Kind:               provisionGVK.Kind,
+		Name:               provision.Name,
+		UID:                provision.UID,
+		BlockOwnerDeletion: pointer.BoolPtr(true),
+	}}
+	return cm, nil
+}
+
+// saveDryRunConfigMap creates the dry run result ConfigMap, replacing the data of one left by an earlier run of the
+// install pod.
+func (m *InstallManager) saveDryRunConfigMap(cm *corev1.ConfigMap) error {
+	existing := &corev1.ConfigMap{}
+	switch err := m.DynamicClient.Get(context.Background(), client.ObjectKeyFromObject(cm), existing); {
+	case apierrors.IsNotFound(err):
+		return createWithRetries(cm, m)
+	case err != nil:
+		return err
+	}
+	existing.Data = cm.Data
+	return m.DynamicClient.Update(context.Background(), existing)
+}
+
+// redactInstallConfig returns the install-config with the values of fields holding credentials replaced, and the
+// additional redaction rules applied.
+func redactInstallConfig(installConfig []byte, redactor *redact.Redactor) ([]byte, error) {
+	ic := map[string]interface{}{}
+	if err := yaml.Unmarshal(installConfig, &ic); err != nil {
+		return nil, errors.Wrap(err, "error parsing install-config")
+	}
+	redactSensitiveFields(ic)
+	redacted, err := yaml.Marshal(ic)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling install-config")
+	}
+	return []byte(redactor.Redact(string(redacted))), nil
+}
+
+func redactSensitiveFields(obj interface{}) {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		for k, v := range o {
+			if s, isString := v.(string); isString {
+				if sensitiveInstallConfigKeyRE.MatchString(k) {
+					o[k] = redactedValue
+				} else {
+					o[k] = stripURLUserinfo(s)
+				}
+				continue
+			}
+			redactSensitiveFields(v)
+		}
+	case []interface{}:
+		for i, v := range o {
+			if s, isString := v.(string); isString {
+				o[i] = stripURLUserinfo(s)
+				continue
+			}
+			redactSensitiveFields(v)
+		}
+	}
+}
+
+// stripURLUserinfo removes the user name and password from the value if it is a URL, such as the URL of a proxy.
+func stripURLUserinfo(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" || u.User == nil {
+		return s
+	}
+	u.User = nil
+	return u.String()
+}
+
+// listManifests returns the paths, relative to the work dir, of the manifests rendered by the installer.
+func listManifests(workDir string) ([]string, error) {
+	var manifests []string
+	for _, dir := range manifestDirs {
+		err := filepath.Walk(filepath.Join(workDir, dir), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(workDir, path)
+			if err != nil {
+				return err
+			}
+			manifests = append(manifests, rel)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing manifests")
+		}
+	}
+	sort.Strings(manifests)
+	return manifests, nil
+}
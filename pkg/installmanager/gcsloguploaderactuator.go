@@ -0,0 +1,76 @@
+package installmanager
+
+import (
+	"context"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/gcpclient"
+
+	"github.com/pkg/errors"
+)
+
+// Ensure gcsLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &gcsLogUploaderActuator{}
+
+// gcsLogUploaderActuator uploads installer logs to a Google Cloud Storage bucket.
+type gcsLogUploaderActuator struct {
+	// gcpClientFn is the function to build a GCP client, here for lazy loading the client.
+	gcpClientFn func(client.Client, string, string, log.FieldLogger) (gcpclient.Client, error)
+}
+
+// IsConfigured returns true if the actuator can handle a particular case
+func (a *gcsLogUploaderActuator) IsConfigured() bool {
+	provider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
+	if !foundProviderEnvVar {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return false
+	}
+
+	return provider == constants.InstallLogsUploadProviderGCP
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *gcsLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if !foundSecretName {
+		return errors.New("couldn't find secret name in environment variable. Skipping upload")
+	}
+
+	bucket, foundBucketEnvVar := os.LookupEnv(constants.InstallLogsGCSBucketEnvVar)
+	if !foundBucketEnvVar {
+		return errors.New("couldn't find bucket in environment variable. Skipping upload")
+	}
+
+	gcpc, err := a.gcpClientFn(c, secretName, clusterprovision.Namespace, log)
+	if err != nil {
+		return err
+	}
+
+	folder := installLogsFolder(clusterName, clusterprovision)
+
+	log.Infof("Uploading log(s) to GCS: gs://%v/%v/", bucket, folder)
+
+	return uploadLogFiles(folder, clusterprovision, filenames, func(key string, file *os.File) error {
+		return gcpc.UploadObject(bucket, key, file)
+	})
+}
+
+func getGCPClient(c client.Client, secretName, namespace string, logger log.FieldLogger) (gcpclient.Client, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: secretName}, secret); err != nil {
+		logger.WithError(err).Error("failed to get GCP credentials secret")
+		return nil, err
+	}
+	gcpClient, err := gcpclient.NewClientFromSecret(secret)
+	if err != nil {
+		logger.WithError(err).Error("failed to get GCP client")
+	}
+	return gcpClient, err
+}
@@ -0,0 +1,78 @@
+package installmanager
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+func TestGCSUploadLogs(t *testing.T) {
+	tests := []struct {
+		name                    string
+		uploadObjectError       error
+		setupUploadObjectMock   bool
+		setupEnvVars            bool
+		expectedUploadLogsError bool
+	}{
+		{
+			name:                    "missing env vars",
+			expectedUploadLogsError: true,
+		},
+		{
+			name:                  "successfully upload objects",
+			setupUploadObjectMock: true,
+			setupEnvVars:          true,
+		},
+		{
+			name:                    "upload failure",
+			uploadObjectError:       errors.New("upload failed"),
+			setupUploadObjectMock:   true,
+			setupEnvVars:            true,
+			expectedUploadLogsError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t)
+			defer mocks.mockCtrl.Finish()
+
+			if test.setupEnvVars {
+				os.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderGCP)
+				os.Setenv(constants.InstallLogsCredentialsSecretRefEnvVar, "notarealsecret")
+				os.Setenv(constants.InstallLogsGCSBucketEnvVar, "bucket1")
+				defer func() {
+					os.Unsetenv(constants.InstallLogsUploadProviderEnvVar)
+					os.Unsetenv(constants.InstallLogsCredentialsSecretRefEnvVar)
+					os.Unsetenv(constants.InstallLogsGCSBucketEnvVar)
+				}()
+			}
+			if test.setupUploadObjectMock {
+				mocks.mockGCPClient.EXPECT().
+					UploadObject("bucket1", "notarealcluster-"+testNamespace+"/"+testProvisionName+"-issue", gomock.Any()).
+					Return(test.uploadObjectError)
+			}
+
+			actuator := &gcsLogUploaderActuator{gcpClientFn: func(client.Client, string, string, log.FieldLogger) (gcpclient.Client, error) {
+				return mocks.mockGCPClient, nil
+			}}
+			provision := testClusterProvision()
+
+			err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), "/etc/issue")
+
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+			} else {
+				assert.NoError(t, err, "Function errored unexpectedly")
+			}
+		})
+	}
+}
@@ -59,6 +59,7 @@ import (
 	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/hive/pkg/resource"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
+	"github.com/openshift/hive/pkg/util/redact"
 )
 
 const (
@@ -110,9 +111,11 @@ type InstallManager struct {
 	provisionCluster                 func(*InstallManager) error
 	readInstallerLog                 func(*hivev1.ClusterProvision, *InstallManager, bool) (string, error)
 	waitForProvisioningStage         func(*hivev1.ClusterProvision, *InstallManager) error
+	updateInstallPhases              func(*InstallManager, []hivev1.InstallPhase) error
 	waitForInstallCompleteExecutions int
 	binaryDir                        string
 	actuator                         LogUploaderActuator
+	redactor                         *redact.Redactor
 }
 
 // NewInstallManagerCommand is the entrypoint to create the 'install-manager' subcommand
@@ -126,8 +129,8 @@ func NewInstallManagerCommand() *cobra.Command {
 		Long:  "The Hive Install Manager runs the phases of the openshift-installer, edits generated assets before completing install, and monitors for artifacts that need to be uploaded back to Hive.",
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := im.Complete(args); err != nil {
-				log.WithError(err).Error("cannot complete command")
-				return
+				// Fail the install pod rather than exiting cleanly, so that the provision is not left waiting.
+				log.WithError(err).Fatal("cannot complete command")
 			}
 
 			if len(args) != 2 {
@@ -178,6 +181,7 @@ func (m *InstallManager) Complete(args []string) error {
 	m.cleanupFailedProvision = cleanupFailedProvision
 	m.provisionCluster = provisionCluster
 	m.waitForProvisioningStage = waitForProvisioningStage
+	m.updateInstallPhases = updateInstallPhases
 
 	// Set log level
 	level, err := log.ParseLevel(m.LogLevel)
@@ -198,6 +202,14 @@ func (m *InstallManager) Complete(args []string) error {
 	// install attempts in the namespace.
 	m.log = m.log.WithField("installID", utilrand.String(8))
 
+	if rules := os.Getenv(constants.RedactionRulesEnvVar); rules != "" {
+		// The install is not run without the configured redaction rules, as its logs could leak sensitive text.
+		if m.redactor, err = redact.Parse(rules); err != nil {
+			m.log.WithError(err).Error("cannot parse redaction rules")
+			return err
+		}
+	}
+
 	absPath, err := filepath.Abs(m.WorkDir)
 	if err != nil {
 		m.log.WithError(err).Fatalf("error finding absolute workdir path")
@@ -328,6 +340,11 @@ func (m *InstallManager) Run() error {
 		}
 	}
 
+	// A dry run only renders the manifests, it must not touch any cloud resources.
+	if provision.Spec.DryRun {
+		return m.runDryRun(cd, provision, icData, scrubInstallLog)
+	}
+
 	// If the cluster provision has an infraID set, this implies we failed an install
 	// and are re-trying. Cleanup any resources that may have been provisioned.
 	m.log.Info("cleaning up from past install attempts")
@@ -466,9 +483,17 @@ func (m *InstallManager) Run() error {
 }
 
 func getActuator() LogUploaderActuator {
+	if _, found := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar); !found {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return nil
+	}
+
 	// As we add more LogUploaderActuators, add them here
 	actuators := []LogUploaderActuator{
 		&s3LogUploaderActuator{awsClientFn: getAWSClient},
+		&gcsLogUploaderActuator{gcpClientFn: getGCPClient},
+		&azureLogUploaderActuator{azureClientFn: getAzureClient},
+		&pvcLogUploaderActuator{dir: constants.InstallLogsPVCMountPath},
 	}
 
 	for _, a := range actuators {
@@ -668,6 +693,22 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 // generateAssets runs openshift-install commands to generate on-disk assets we need to
 // upload or modify prior to provisioning resources in the cloud.
 func (m *InstallManager) generateAssets(cd *hivev1.ClusterDeployment) error {
+	if err := m.generateManifests(cd); err != nil {
+		return err
+	}
+
+	m.log.Info("running openshift-install create ignition-configs")
+	if err := m.runOpenShiftInstallCommand("create", "ignition-configs"); err != nil {
+		m.log.WithError(err).Error("error generating installer assets")
+		return err
+	}
+
+	m.log.Info("assets generated successfully")
+	return nil
+}
+
+// generateManifests runs openshift-install create manifests and adds the user-provided manifests.
+func (m *InstallManager) generateManifests(cd *hivev1.ClusterDeployment) error {
 	m.log.Info("running openshift-install create manifests")
 	err := m.runOpenShiftInstallCommand("create", "manifests")
 	if err != nil {
@@ -704,13 +745,6 @@ func (m *InstallManager) generateAssets(cd *hivev1.ClusterDeployment) error {
 		m.log.Infof("copied %s to %s", src, dest)
 	}
 
-	m.log.Info("running openshift-install create ignition-configs")
-	if err := m.runOpenShiftInstallCommand("create", "ignition-configs"); err != nil {
-		m.log.WithError(err).Error("error generating installer assets")
-		return err
-	}
-
-	m.log.Info("assets generated successfully")
 	return nil
 }
 
@@ -771,7 +805,8 @@ func (m *InstallManager) runOpenShiftInstallCommand(args ...string) error {
 }
 
 // tailFullInstallLog streams the full install log to standard out so that
-// the log can be seen from the pods logs.
+// the log can be seen from the pods logs. The install phases found in the log
+// are saved to the status of the ClusterProvision.
 func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 	logfileName := filepath.Join(m.WorkDir, installerFullLogFile)
 	m.waitForFiles([]string{logfileName})
@@ -786,6 +821,7 @@ func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 
 	r := bufio.NewReader(logfile)
 	fullLine := ""
+	phases := &installPhaseTracker{}
 	fiveMS := time.Millisecond * 5
 
 	// this loop will store up a full line worth of text into fullLine before
@@ -814,9 +850,14 @@ func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 
 		if scrubInstallLog {
 			cleanLine := cleanupLogOutput(fullLine)
-			fmt.Println(cleanLine)
+			fmt.Println(m.redactor.Redact(cleanLine))
 		} else {
-			fmt.Println(fullLine)
+			fmt.Println(m.redactor.Redact(fullLine))
+		}
+		if phases.observe(fullLine, metav1.Now()) {
+			if err := m.updateInstallPhases(m, phases.phases); err != nil {
+				m.log.WithError(err).Warn("error updating install phases")
+			}
 		}
 		// clear out the line buffer so we can start again
 		fullLine = ""
@@ -1070,6 +1111,9 @@ func readInstallerLog(provision *hivev1.ClusterProvision, m *InstallManager, scr
 	if scrubInstallLog {
 		consoleLog = cleanupLogOutput(consoleLog)
 	}
+	// The additional redaction rules from HiveConfig are always applied, they are not affected by the annotation
+	// disabling password redaction.
+	consoleLog = m.redactor.Redact(consoleLog)
 
 	m.log.Debugf("installer console log: %v", consoleLog)
 
@@ -0,0 +1,76 @@
+package installmanager
+
+import (
+	"context"
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// installPhaseRegexes match the installer log lines marking the start of each install phase.
+var installPhaseRegexes = []struct {
+	phase hivev1.InstallPhaseName
+	regex *regexp.Regexp
+}{
+	{phase: hivev1.InfrastructureInstallPhase, regex: regexp.MustCompile(`Creating infrastructure resources`)},
+	{phase: hivev1.WaitingForAPIInstallPhase, regex: regexp.MustCompile(`Waiting up to .* for the Kubernetes API`)},
+	{phase: hivev1.BootstrapInstallPhase, regex: regexp.MustCompile(`Waiting up to .* for bootstrapping to complete`)},
+	{phase: hivev1.ClusterOperatorsInstallPhase, regex: regexp.MustCompile(`Waiting up to .* for the cluster at .* to initialize`)},
+}
+
+// installCompleteRegex matches the installer log line marking the end of the install.
+var installCompleteRegex = regexp.MustCompile(`Install complete!`)
+
+// installPhaseTracker follows the install phases through the lines of the installer log.
+type installPhaseTracker struct {
+	phases []hivev1.InstallPhase
+}
+
+// observe updates the phases from a line of the installer log, returning true if they changed. A phase is only
+// recorded the first time it is reached, as the installer repeats some of its waits when they are retried.
+func (t *installPhaseTracker) observe(line string, now metav1.Time) bool {
+	if installCompleteRegex.MatchString(line) {
+		return t.completeCurrentPhase(now)
+	}
+	for _, p := range installPhaseRegexes {
+		if !p.regex.MatchString(line) {
+			continue
+		}
+		for _, existing := range t.phases {
+			if existing.Name == p.phase {
+				return false
+			}
+		}
+		t.completeCurrentPhase(now)
+		t.phases = append(t.phases, hivev1.InstallPhase{Name: p.phase, StartTime: now})
+		return true
+	}
+	return false
+}
+
+func (t *installPhaseTracker) completeCurrentPhase(now metav1.Time) bool {
+	if len(t.phases) == 0 {
+		return false
+	}
+	current := &t.phases[len(t.phases)-1]
+	if current.CompletionTime != nil {
+		return false
+	}
+	current.CompletionTime = &now
+	return true
+}
+
+// updateInstallPhases saves the install phases to the status of the ClusterProvision.
+func updateInstallPhases(m *InstallManager, phases []hivev1.InstallPhase) error {
+	provision := &hivev1.ClusterProvision{}
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := m.loadClusterProvision(provision); err != nil {
+			return err
+		}
+		provision.Status.InstallPhases = phases
+		return m.DynamicClient.Status().Update(context.Background(), provision)
+	})
+}
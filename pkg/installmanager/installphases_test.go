@@ -0,0 +1,97 @@
+package installmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestInstallPhaseTracker(t *testing.T) {
+	start := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) metav1.Time {
+		return metav1.NewTime(start.Add(time.Duration(minutes) * time.Minute))
+	}
+	completedAt := func(minutes int) *metav1.Time {
+		t := at(minutes)
+		return &t
+	}
+
+	tests := []struct {
+		name            string
+		lines           []string
+		expectedPhases  []hivev1.InstallPhase
+		expectedChanges int
+	}{
+		{
+			name: "no phases",
+			lines: []string{
+				`time="2021-06-01T12:00:00Z" level=info msg="Consuming Install Config from target directory"`,
+			},
+		},
+		{
+			name: "infrastructure in progress",
+			lines: []string{
+				`time="2021-06-01T12:00:00Z" level=info msg="Creating infrastructure resources..."`,
+				`time="2021-06-01T12:01:00Z" level=debug msg="module.vpc.aws_vpc.new_vpc: Creating..."`,
+			},
+			expectedPhases: []hivev1.InstallPhase{
+				{Name: hivev1.InfrastructureInstallPhase, StartTime: at(0)},
+			},
+			expectedChanges: 1,
+		},
+		{
+			name: "complete install",
+			lines: []string{
+				`time="2021-06-01T12:00:00Z" level=info msg="Creating infrastructure resources..."`,
+				`time="2021-06-01T12:05:00Z" level=info msg="Waiting up to 20m0s for the Kubernetes API at https://api.test.example.com:6443..."`,
+				`time="2021-06-01T12:10:00Z" level=info msg="API v1.21.1 up"`,
+				`time="2021-06-01T12:10:00Z" level=info msg="Waiting up to 30m0s for bootstrapping to complete..."`,
+				`time="2021-06-01T12:20:00Z" level=info msg="Destroying the bootstrap resources..."`,
+				`time="2021-06-01T12:25:00Z" level=info msg="Waiting up to 40m0s for the cluster at https://api.test.example.com:6443 to initialize..."`,
+				`time="2021-06-01T12:45:00Z" level=info msg="Install complete!"`,
+			},
+			expectedPhases: []hivev1.InstallPhase{
+				{Name: hivev1.InfrastructureInstallPhase, StartTime: at(0), CompletionTime: completedAt(1)},
+				{Name: hivev1.WaitingForAPIInstallPhase, StartTime: at(1), CompletionTime: completedAt(3)},
+				{Name: hivev1.BootstrapInstallPhase, StartTime: at(3), CompletionTime: completedAt(5)},
+				{Name: hivev1.ClusterOperatorsInstallPhase, StartTime: at(5), CompletionTime: completedAt(6)},
+			},
+			expectedChanges: 5,
+		},
+		{
+			name: "repeated wait",
+			lines: []string{
+				`time="2021-06-01T12:00:00Z" level=info msg="Waiting up to 40m0s for the cluster at https://api.test.example.com:6443 to initialize..."`,
+				`time="2021-06-01T12:40:00Z" level=info msg="Waiting up to 40m0s for the cluster at https://api.test.example.com:6443 to initialize..."`,
+			},
+			expectedPhases: []hivev1.InstallPhase{
+				{Name: hivev1.ClusterOperatorsInstallPhase, StartTime: at(0)},
+			},
+			expectedChanges: 1,
+		},
+		{
+			name: "install complete without phases",
+			lines: []string{
+				`time="2021-06-01T12:00:00Z" level=info msg="Install complete!"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := &installPhaseTracker{}
+			changes := 0
+			for i, line := range test.lines {
+				if tracker.observe(line, at(i)) {
+					changes++
+				}
+			}
+			assert.Equal(t, test.expectedPhases, tracker.phases, "unexpected install phases")
+			assert.Equal(t, test.expectedChanges, changes, "unexpected number of changes")
+		})
+	}
+}
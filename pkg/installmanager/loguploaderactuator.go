@@ -1,9 +1,15 @@
 package installmanager
 
 import (
-	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 )
 
 // LogUploaderActuator interface is the interface that is used to add provider support for uploading logs.
@@ -14,3 +20,37 @@ type LogUploaderActuator interface {
 	// UploadLogs uploads installer logs to the provider's storage mechanism.
 	UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error
 }
+
+// installLogsFolder returns the folder the logs of a cluster are stored in. All of the actuators store the logs
+// under the same paths.
+func installLogsFolder(clusterName string, clusterprovision *hivev1.ClusterProvision) string {
+	return fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+}
+
+// uploadLogFiles opens each of the log files and passes it to upload along with the key to store it under. Failures
+// are collected so that the remaining files are still uploaded.
+func uploadLogFiles(folder string, clusterprovision *hivev1.ClusterProvision, filenames []string, upload func(key string, file *os.File) error) error {
+	retvalErrs := []error{}
+
+	for _, filename := range filenames {
+		if err := func() error {
+			file, err := os.Open(filename)
+			if err != nil {
+				return errors.Wrapf(err, "Failed opening log file: %v", filename)
+			}
+			defer file.Close()
+
+			stat, err := file.Stat()
+			if err != nil {
+				return errors.Wrapf(err, "Failed stat on log file: %v", filename)
+			}
+
+			logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
+			return errors.Wrapf(upload(logkey, file), "Failed uploading log file: %v", filename)
+		}(); err != nil {
+			retvalErrs = append(retvalErrs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(retvalErrs)
+}
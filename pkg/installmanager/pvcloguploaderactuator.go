@@ -0,0 +1,57 @@
+package installmanager
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+
+	"github.com/pkg/errors"
+)
+
+// Ensure pvcLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &pvcLogUploaderActuator{}
+
+// pvcLogUploaderActuator copies installer logs to a PersistentVolumeClaim mounted into the install pod.
+type pvcLogUploaderActuator struct {
+	// dir is the directory the PersistentVolumeClaim is mounted at.
+	dir string
+}
+
+// IsConfigured returns true if the actuator can handle a particular case
+func (a *pvcLogUploaderActuator) IsConfigured() bool {
+	provider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
+	if !foundProviderEnvVar {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return false
+	}
+
+	return provider == constants.InstallLogsUploadProviderPVC
+}
+
+// UploadLogs copies installer logs to the PersistentVolumeClaim.
+func (a *pvcLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	folder := installLogsFolder(clusterName, clusterprovision)
+	if err := os.MkdirAll(filepath.Join(a.dir, folder), 0755); err != nil {
+		return errors.Wrap(err, "failed to create install logs folder")
+	}
+
+	log.Infof("Copying log(s) to persistent volume: %v/", filepath.Join(a.dir, folder))
+
+	return uploadLogFiles(folder, clusterprovision, filenames, func(key string, file *os.File) error {
+		dest, err := os.Create(filepath.Join(a.dir, key))
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(dest, file); err != nil {
+			dest.Close()
+			return err
+		}
+		return dest.Close()
+	})
+}
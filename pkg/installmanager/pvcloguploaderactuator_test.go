@@ -0,0 +1,58 @@
+package installmanager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPVCUploadLogs(t *testing.T) {
+	tests := []struct {
+		name                    string
+		filenames               []string
+		expectedFiles           []string
+		expectedUploadLogsError bool
+	}{
+		{
+			name:          "successfully copy logs",
+			filenames:     []string{"/etc/issue"},
+			expectedFiles: []string{testProvisionName + "-issue"},
+		},
+		{
+			name:                    "missing log file",
+			filenames:               []string{"/etc/issue", "/does/not/exist"},
+			expectedFiles:           []string{testProvisionName + "-issue"},
+			expectedUploadLogsError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "installlogs")
+			require.NoError(t, err, "unexpected error creating temp dir")
+			defer os.RemoveAll(dir)
+
+			actuator := &pvcLogUploaderActuator{dir: dir}
+			provision := testClusterProvision()
+
+			err = actuator.UploadLogs("notarealcluster", provision, nil, log.New(), test.filenames...)
+
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+			} else {
+				assert.NoError(t, err, "Function errored unexpectedly")
+			}
+			files, err := ioutil.ReadDir(filepath.Join(dir, "notarealcluster-"+testNamespace))
+			require.NoError(t, err, "unexpected error reading install logs folder")
+			var names []string
+			for _, f := range files {
+				names = append(names, f.Name())
+			}
+			assert.Equal(t, test.expectedFiles, names, "unexpected install log files")
+		})
+	}
+}
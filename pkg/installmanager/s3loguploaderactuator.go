@@ -1,7 +1,6 @@
 package installmanager
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -14,7 +13,6 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 
 	"github.com/pkg/errors"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 // Ensure s3LogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
@@ -59,40 +57,18 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 		return err
 	}
 
-	retvalErrs := []error{}
-
-	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+	folder := installLogsFolder(clusterName, clusterprovision)
 
 	log.Infof("Uploading log(s) to S3: s3://%v/%v/", bucket, folder)
 
-	for _, filename := range filenames {
-		file, err := os.Open(filename)
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed opening log file: %v", filename))
-			continue
-		}
-		defer file.Close()
-
-		stat, err := file.Stat()
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed stat on log file: %v", filename))
-			continue
-		}
-
-		logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
-
-		_, err = awsc.Upload(&s3manager.UploadInput{
+	return uploadLogFiles(folder, clusterprovision, filenames, func(key string, file *os.File) error {
+		_, err := awsc.Upload(&s3manager.UploadInput{
 			Bucket: aws.String(bucket),
-			Key:    aws.String(logkey),
+			Key:    aws.String(key),
 			Body:   file,
 		})
-
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed uploading log file: %v", filename))
-		}
-	}
-
-	return utilerrors.NewAggregate(retvalErrs)
+		return err
+	})
 }
 
 func getAWSClient(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
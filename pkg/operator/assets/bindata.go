@@ -3,13 +3,17 @@
 // config/clustersync/service.yaml
 // config/clustersync/statefulset.yaml
 // config/hiveadmission/apiservice.yaml
+// config/hiveadmission/clusterclaim-mutating-webhook.yaml
+// config/hiveadmission/clusterclaim-webhook.yaml
 // config/hiveadmission/clusterdeployment-webhook.yaml
+// config/hiveadmission/clusterdeprovision-webhook.yaml
 // config/hiveadmission/clusterimageset-webhook.yaml
 // config/hiveadmission/clusterprovision-webhook.yaml
 // config/hiveadmission/deployment.yaml
 // config/hiveadmission/dnszones-webhook.yaml
 // config/hiveadmission/hiveadmission_rbac_role.yaml
 // config/hiveadmission/hiveadmission_rbac_role_binding.yaml
+// config/hiveadmission/hiveconfig-webhook.yaml
 // config/hiveadmission/machinepool-webhook.yaml
 // config/hiveadmission/selectorsyncset-webhook.yaml
 // config/hiveadmission/service-account.yaml
@@ -252,6 +256,89 @@ func configHiveadmissionApiserviceYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusterclaimMutatingWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: clusterclaimmutators.admission.hive.openshift.io
+webhooks:
+- name: clusterclaimmutators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterclaimmutators
+  rules:
+  - operations:
+    - CREATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterclaims
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterclaimMutatingWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterclaimMutatingWebhookYaml, nil
+}
+
+func configHiveadmissionClusterclaimMutatingWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterclaimMutatingWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterclaim-mutating-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configHiveadmissionClusterclaimWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: clusterclaimvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusterclaimvalidators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterclaimvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterclaims
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterclaimWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterclaimWebhookYaml, nil
+}
+
+func configHiveadmissionClusterclaimWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterclaimWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterclaim-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterdeploymentWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1beta1
 kind: ValidatingWebhookConfiguration
@@ -295,6 +382,48 @@ func configHiveadmissionClusterdeploymentWebhookYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusterdeprovisionWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: clusterdeprovisionvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusterdeprovisionvalidators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterdeprovisionvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterdeprovisions
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterdeprovisionWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterdeprovisionWebhookYaml, nil
+}
+
+func configHiveadmissionClusterdeprovisionWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterdeprovisionWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterdeprovision-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterimagesetWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1beta1
 kind: ValidatingWebhookConfiguration
@@ -424,6 +553,11 @@ spec:
         envFrom:
         - configMapRef:
             name: hive-feature-gates
+        env:
+        - name: HIVE_NS
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.namespace
         volumeMounts:
         - mountPath: /var/serving-cert
           name: serving-cert
@@ -589,6 +723,49 @@ func configHiveadmissionHiveadmission_rbac_role_bindingYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionHiveconfigWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: hiveconfigvalidators.admission.hive.openshift.io
+webhooks:
+- name: hiveconfigvalidators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/hiveconfigvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - hiveconfigs
+  # The admission server is deployed from the HiveConfig, which must remain editable while the server is unavailable.
+  failurePolicy: Ignore
+  sideEffects: None
+`)
+
+func configHiveadmissionHiveconfigWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionHiveconfigWebhookYaml, nil
+}
+
+func configHiveadmissionHiveconfigWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionHiveconfigWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/hiveconfig-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionMachinepoolWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1beta1
 kind: ValidatingWebhookConfiguration
@@ -930,6 +1107,12 @@ rules:
   - update
   - patch
   - delete
+- apiGroups:
+  - config.openshift.io
+  resources:
+  - infrastructures
+  verbs:
+  - get
 - apiGroups:
   - velero.io
   resources:
@@ -1242,6 +1425,7 @@ rules:
   resources:
   - clusterpools
   - clusterclaims
+  - clusterdeploymentcustomizations
   verbs:
   - get
   - list
@@ -1544,6 +1728,7 @@ metadata:
   name: install-log-regexes
   namespace: hive
 data:
+  # Each entry may set a retryPolicy for the failure: Retry, RetryWithBackoff (the default) or Stop.
   regexes: |
     # AWS Specific
     - name: AWSNATGatewayLimitExceeded
@@ -1576,6 +1761,7 @@ data:
       - "CIDR range start.*is outside of the specified machine networks"
       installFailingReason: InvalidInstallConfigSubnet
       installFailingMessage: Invalid subnet in install config. Subnet's CIDR range start is outside of the specified machine networks
+      retryPolicy: Stop
     # https://bugzilla.redhat.com/show_bug.cgi?id=1844320
     - name: AWSUnableToFindMatchingRouteTable
       searchRegexStrings:
@@ -1612,17 +1798,20 @@ data:
       - "InvalidClientTokenId: The security token included in the request is invalid."
       installFailingReason: InvalidCredentials
       installFailingMessage: Credentials are invalid
+      retryPolicy: Stop
     # GCP Specific
     - name: GCPInvalidProjectID
       searchRegexStrings:
       - "platform.gcp.project.* invalid project ID"
       installFailingReason: GCPInvalidProjectID
       installFailingMessage: Invalid GCP project ID
+      retryPolicy: Stop
     - name: GCPInstanceTypeNotFound
       searchRegexStrings:
       - "platform.gcp.type: Invalid value:.* instance type.* not found]"
       installFailingReason: GCPInstanceTypeNotFound
       installFailingMessage: GCP instance type not found
+      retryPolicy: Stop
     - name: GCPPreconditionFailed
       searchRegexStrings:
       - "googleapi: Error 412"
@@ -1639,6 +1828,7 @@ data:
       - "platform.baremetal.libvirtURI: Internal error: could not connect to libvirt: virError.Code=38, Domain=7, Message=.Cannot recv data: Permission denied"
       installFailingReason: LibvirtSSHKeyPermissionDenied
       installFailingMessage: "Permission denied connecting to libvirt host, check SSH key configuration and pass phrase"
+      retryPolicy: Stop
     # Generic OpenShift Install
     - name: KubeAPIWaitTimeout
       searchRegexStrings:
@@ -1678,6 +1868,7 @@ data:
       - "failed to load asset \\\"Install Config\\\""
       installFailingReason: InvalidInstallConfig
       installFailingMessage: Installer failed to load install config
+      retryPolicy: Stop
     - name: LibvirtConnectionFailed
       searchRegexStrings:
       - "could not connect to libvirt"
@@ -1760,13 +1951,17 @@ var _bindata = map[string]func() (*asset, error){
 	"config/clustersync/service.yaml":                           configClustersyncServiceYaml,
 	"config/clustersync/statefulset.yaml":                       configClustersyncStatefulsetYaml,
 	"config/hiveadmission/apiservice.yaml":                      configHiveadmissionApiserviceYaml,
+	"config/hiveadmission/clusterclaim-mutating-webhook.yaml":   configHiveadmissionClusterclaimMutatingWebhookYaml,
+	"config/hiveadmission/clusterclaim-webhook.yaml":            configHiveadmissionClusterclaimWebhookYaml,
 	"config/hiveadmission/clusterdeployment-webhook.yaml":       configHiveadmissionClusterdeploymentWebhookYaml,
+	"config/hiveadmission/clusterdeprovision-webhook.yaml":      configHiveadmissionClusterdeprovisionWebhookYaml,
 	"config/hiveadmission/clusterimageset-webhook.yaml":         configHiveadmissionClusterimagesetWebhookYaml,
 	"config/hiveadmission/clusterprovision-webhook.yaml":        configHiveadmissionClusterprovisionWebhookYaml,
 	"config/hiveadmission/deployment.yaml":                      configHiveadmissionDeploymentYaml,
 	"config/hiveadmission/dnszones-webhook.yaml":                configHiveadmissionDnszonesWebhookYaml,
 	"config/hiveadmission/hiveadmission_rbac_role.yaml":         configHiveadmissionHiveadmission_rbac_roleYaml,
 	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml": configHiveadmissionHiveadmission_rbac_role_bindingYaml,
+	"config/hiveadmission/hiveconfig-webhook.yaml":              configHiveadmissionHiveconfigWebhookYaml,
 	"config/hiveadmission/machinepool-webhook.yaml":             configHiveadmissionMachinepoolWebhookYaml,
 	"config/hiveadmission/selectorsyncset-webhook.yaml":         configHiveadmissionSelectorsyncsetWebhookYaml,
 	"config/hiveadmission/service-account.yaml":                 configHiveadmissionServiceAccountYaml,
@@ -1846,13 +2041,17 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 		"hiveadmission": {nil, map[string]*bintree{
 			"apiservice.yaml":                      {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
+			"clusterclaim-mutating-webhook.yaml":   {configHiveadmissionClusterclaimMutatingWebhookYaml, map[string]*bintree{}},
+			"clusterclaim-webhook.yaml":            {configHiveadmissionClusterclaimWebhookYaml, map[string]*bintree{}},
 			"clusterdeployment-webhook.yaml":       {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
+			"clusterdeprovision-webhook.yaml":      {configHiveadmissionClusterdeprovisionWebhookYaml, map[string]*bintree{}},
 			"clusterimageset-webhook.yaml":         {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
 			"clusterprovision-webhook.yaml":        {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
 			"deployment.yaml":                      {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
 			"dnszones-webhook.yaml":                {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
 			"hiveadmission_rbac_role.yaml":         {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
 			"hiveadmission_rbac_role_binding.yaml": {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
+			"hiveconfig-webhook.yaml":              {configHiveadmissionHiveconfigWebhookYaml, map[string]*bintree{}},
 			"machinepool-webhook.yaml":             {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
 			"selectorsyncset-webhook.yaml":         {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
 			"service-account.yaml":                 {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
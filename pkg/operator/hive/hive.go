@@ -104,34 +104,7 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 
 	hiveNSName := getHiveNamespace(instance)
 
-	if instance.Spec.FailedProvisionConfig.AWS != nil {
-		awsSpec := instance.Spec.FailedProvisionConfig.AWS
-
-		// By default we will try to gather logs on failed installs:
-		awsLogsEnvVars := []corev1.EnvVar{
-			{
-				Name:  constants.InstallLogsUploadProviderEnvVar,
-				Value: constants.InstallLogsUploadProviderAWS,
-			},
-			{
-				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
-				Value: awsSpec.CredentialsSecretRef.Name,
-			},
-			{
-				Name:  constants.InstallLogsAWSRegionEnvVar,
-				Value: awsSpec.Region,
-			},
-			{
-				Name:  constants.InstallLogsAWSServiceEndpointEnvVar,
-				Value: awsSpec.ServiceEndpoint,
-			},
-			{
-				Name:  constants.InstallLogsAWSS3BucketEnvVar,
-				Value: awsSpec.Bucket,
-			},
-		}
-		hiveContainer.Env = append(hiveContainer.Env, awsLogsEnvVars...)
-	}
+	hiveContainer.Env = append(hiveContainer.Env, installLogsEnvVars(instance.Spec.FailedProvisionConfig)...)
 
 	if awssp := instance.Spec.ServiceProviderCredentialsConfig.AWS; awssp != nil && awssp.CredentialsSecretRef.Name != "" {
 		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
@@ -193,6 +166,24 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, tmpEnvVar)
 	}
 
+	if reaper := instance.Spec.OrphanedInfraReaper; reaper != nil {
+		hLog.Info("orphaned infrastructure reaper enabled in hiveconfig")
+		scanInterval := ""
+		if reaper.ScanInterval != nil {
+			scanInterval = reaper.ScanInterval.Duration.String()
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.OrphanedInfraReaperScanIntervalEnvVar,
+			Value: scanInterval,
+		})
+		if reaper.DeprovisionAfter != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  hiveconstants.OrphanedInfraReaperDeprovisionAfterEnvVar,
+				Value: reaper.DeprovisionAfter.Duration.String(),
+			})
+		}
+	}
+
 	if instance.Spec.Backup.MinBackupPeriodSeconds != nil {
 		hLog.Infof("MinBackupPeriodSeconds specified.")
 		tmpEnvVar := corev1.EnvVar{
@@ -227,6 +218,14 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 
 	r.includeGlobalPullSecret(hLog, h, instance, hiveDeployment)
 
+	if instance.Spec.RedactionRulesConfigMapRef != nil && instance.Spec.RedactionRulesConfigMapRef.Name != "" {
+		hLog.Info("Additional redaction rules configured")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.RedactionRulesConfigMapEnvVar,
+			Value: instance.Spec.RedactionRulesConfigMapRef.Name,
+		})
+	}
+
 	if instance.Spec.MaintenanceMode != nil && *instance.Spec.MaintenanceMode {
 		hLog.Warn("maintenanceMode enabled in HiveConfig, setting hive-controllers replicas to 0")
 		replicas := int32(0)
@@ -513,3 +512,79 @@ func (r *ReconcileHiveConfig) deleteAllSyncSetInstances(hLog log.FieldLogger) (n
 	}
 	return
 }
+
+// installLogsEnvVars returns the environment variables configuring where the controllers have install pods upload
+// the logs of failed installs.
+func installLogsEnvVars(config hivev1.FailedProvisionConfig) []corev1.EnvVar {
+	switch {
+	case config.AWS != nil:
+		return []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderAWS,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: config.AWS.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsAWSRegionEnvVar,
+				Value: config.AWS.Region,
+			},
+			{
+				Name:  constants.InstallLogsAWSServiceEndpointEnvVar,
+				Value: config.AWS.ServiceEndpoint,
+			},
+			{
+				Name:  constants.InstallLogsAWSS3BucketEnvVar,
+				Value: config.AWS.Bucket,
+			},
+		}
+	case config.GCP != nil:
+		return []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderGCP,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: config.GCP.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsGCSBucketEnvVar,
+				Value: config.GCP.Bucket,
+			},
+		}
+	case config.Azure != nil:
+		return []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderAzure,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: config.Azure.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsAzureStorageAccountEnvVar,
+				Value: config.Azure.StorageAccount,
+			},
+			{
+				Name:  constants.InstallLogsAzureContainerEnvVar,
+				Value: config.Azure.Container,
+			},
+		}
+	case config.PersistentVolumeClaim != nil:
+		return []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderPVC,
+			},
+			{
+				Name:  constants.InstallLogsPVCClaimNameEnvVar,
+				Value: config.PersistentVolumeClaim.ClaimName,
+			},
+		}
+	}
+	return nil
+}
@@ -260,20 +260,22 @@ var _ reconcile.Reconciler = &ReconcileHiveConfig{}
 // ReconcileHiveConfig reconciles a Hive object
 type ReconcileHiveConfig struct {
 	client.Client
-	scheme                 *runtime.Scheme
-	kubeClient             kubernetes.Interface
-	discoveryClient        discovery.DiscoveryInterface
-	dynamicClient          dynamic.Interface
-	restConfig             *rest.Config
-	hiveImage              string
-	hiveOperatorNamespace  string
-	hiveImagePullPolicy    corev1.PullPolicy
-	syncAggregatorCA       bool
-	managedConfigCMLister  corev1listers.ConfigMapLister
-	ctrlr                  controller.Controller
-	hiveSecretLister       corev1listers.SecretLister
-	secretWatchEstablished bool
-	mgr                    manager.Manager
+	scheme                    *runtime.Scheme
+	kubeClient                kubernetes.Interface
+	discoveryClient           discovery.DiscoveryInterface
+	dynamicClient             dynamic.Interface
+	restConfig                *rest.Config
+	hiveImage                 string
+	hiveOperatorNamespace     string
+	hiveImagePullPolicy       corev1.PullPolicy
+	syncAggregatorCA          bool
+	managedConfigCMLister     corev1listers.ConfigMapLister
+	ctrlr                     controller.Controller
+	hiveSecretLister          corev1listers.SecretLister
+	secretWatchEstablished    bool
+	hiveConfigMapLister       corev1listers.ConfigMapLister
+	configMapWatchEstablished bool
+	mgr                       manager.Manager
 }
 
 // Reconcile reads that state of the cluster for a Hive object and makes changes based on the state read
@@ -304,6 +306,7 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 			// For additional cleanup logic use finalizers.
 			hLog.Debug("HiveConfig not found, deleted?")
 			r.secretWatchEstablished = false
+			r.configMapWatchEstablished = false
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -329,6 +332,12 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	if err := r.establishConfigMapWatch(hLog, hiveNSName); err != nil {
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorEstablishingConfigMapWatch", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
 	recorder := events.NewRecorder(r.kubeClient.CoreV1().Events(r.hiveOperatorNamespace), "hive-operator", &corev1.ObjectReference{
 		Name:      request.Name,
 		Namespace: r.hiveOperatorNamespace,
@@ -414,7 +423,15 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	confighash, err := r.deployHiveControllersConfigMap(hLog, h, instance, plConfigHash)
+	redactionRulesHash, err := r.computeRedactionRulesHash(hLog, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error computing redaction rules hash")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorReadingRedactionRules", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	confighash, err := r.deployHiveControllersConfigMap(hLog, h, instance, plConfigHash, redactionRulesHash)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying controllers configmap")
 		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingControllersConfigmap", err.Error())
@@ -551,6 +568,46 @@ func (r *ReconcileHiveConfig) establishSecretWatch(hLog *log.Entry, hiveNSName s
 	return nil
 }
 
+func (r *ReconcileHiveConfig) establishConfigMapWatch(hLog *log.Entry, hiveNSName string) error {
+	// Like the secret watch, the watch on ConfigMaps in the Hive namespace can only be established once we have a
+	// HiveConfig.
+	if r.configMapWatchEstablished {
+		hLog.Debug("configmap watch already established")
+		return nil
+	}
+	hLog.WithField("namespace", hiveNSName).Info("establishing watch on configmaps in hive namespace")
+
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(r.kubeClient, watchResyncInterval,
+		kubeinformers.WithNamespace(hiveNSName))
+	configMapInformer := kubeInformerFactory.Core().V1().ConfigMaps().Informer()
+	r.hiveConfigMapLister = kubeInformerFactory.Core().V1().ConfigMaps().Lister()
+	if err := r.mgr.Add(&informerRunnable{informer: configMapInformer}); err != nil {
+		hLog.WithError(err).Error("error adding configmap informer to manager")
+		return err
+	}
+
+	// Watch the redaction rules ConfigMap so changes to it are rolled out to the hive controllers.
+	err := r.ctrlr.Watch(&source.Informer{Informer: configMapInformer}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+		config := &hivev1.HiveConfig{}
+		if err := r.mgr.GetClient().Get(context.Background(), types.NamespacedName{Name: constants.HiveConfigName}, config); err != nil {
+			hLog.WithError(err).Debug("error getting HiveConfig for configmap")
+			return nil
+		}
+		if redactionRulesConfigMapName(config) != a.GetName() {
+			return nil
+		}
+		hLog.WithField("configMapName", a.GetName()).Debug("redaction rules change trigger reconcile for HiveConfig")
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: config.Name}}}
+	}))
+	if err != nil {
+		hLog.WithError(err).Error("error establishing configmap watch")
+		return err
+	}
+
+	r.configMapWatchEstablished = true
+	return nil
+}
+
 func (r *ReconcileHiveConfig) cleanupLegacyObjects(hLog log.FieldLogger) error {
 	gvrNSNames := []gvrNSName{
 		{group: "rbac.authorization.k8s.io", version: "v1", resource: "clusterroles", name: "manager-role"},
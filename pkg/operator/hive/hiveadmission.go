@@ -58,15 +58,22 @@ const (
 )
 
 var webhookAssets = []string{
+	"config/hiveadmission/clusterclaim-webhook.yaml",
 	"config/hiveadmission/clusterdeployment-webhook.yaml",
+	"config/hiveadmission/clusterdeprovision-webhook.yaml",
 	"config/hiveadmission/clusterimageset-webhook.yaml",
 	"config/hiveadmission/clusterprovision-webhook.yaml",
 	"config/hiveadmission/dnszones-webhook.yaml",
+	"config/hiveadmission/hiveconfig-webhook.yaml",
 	"config/hiveadmission/machinepool-webhook.yaml",
 	"config/hiveadmission/syncset-webhook.yaml",
 	"config/hiveadmission/selectorsyncset-webhook.yaml",
 }
 
+var mutatingWebhookAssets = []string{
+	"config/hiveadmission/clusterclaim-mutating-webhook.yaml",
+}
+
 func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, recorder events.Recorder, mdConfigMap *corev1.ConfigMap, additionalHashes ...string) error {
 	hiveNSName := getHiveNamespace(instance)
 
@@ -136,6 +143,7 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	addAWSPrivateLinkConfigVolume(&hiveAdmDeployment.Spec.Template.Spec)
 	addSupportedContractsConfigVolume(&hiveAdmDeployment.Spec.Template.Spec)
 	addReleaseImageVerificationConfigMapEnv(&hiveAdmDeployment.Spec.Template.Spec, instance)
+	addClusterExpirationEnv(&hiveAdmDeployment.Spec.Template.Spec, instance)
 
 	validatingWebhooks := make([]*admregv1.ValidatingWebhookConfiguration, len(webhookAssets))
 	for i, yaml := range webhookAssets {
@@ -144,6 +152,13 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		validatingWebhooks[i] = wh
 	}
 
+	mutatingWebhooks := make([]*admregv1.MutatingWebhookConfiguration, len(mutatingWebhookAssets))
+	for i, yaml := range mutatingWebhookAssets {
+		asset = assets.MustAsset(yaml)
+		wh := util.ReadMutatingWebhookConfigurationV1Beta1OrDie(asset, scheme.Scheme)
+		mutatingWebhooks[i] = wh
+	}
+
 	hLog.Debug("reading apiservice")
 	asset = assets.MustAsset("config/hiveadmission/apiservice.yaml")
 	apiService := util.ReadAPIServiceV1Beta1OrDie(asset, scheme.Scheme)
@@ -166,7 +181,7 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	}
 	if !isOpenShift || is311 {
 		hLog.Debug("non-OpenShift 4.x cluster detected, modifying hiveadmission webhooks for CA certs")
-		err = r.injectCerts(apiService, validatingWebhooks, nil, hiveNSName, hLog)
+		err = r.injectCerts(apiService, validatingWebhooks, mutatingWebhooks, hiveNSName, hLog)
 		if err != nil {
 			hLog.WithError(err).Error("error injecting certs")
 			return err
@@ -210,6 +225,15 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		hLog.WithField("webhook", webhook.Name).Infof("validating webhook: %s", result)
 	}
 
+	for _, webhook := range mutatingWebhooks {
+		result, err = util.ApplyRuntimeObjectWithGC(h, webhook, instance)
+		if err != nil {
+			hLog.WithField("webhook", webhook.Name).WithError(err).Errorf("error applying mutating webhook")
+			return err
+		}
+		hLog.WithField("webhook", webhook.Name).Infof("mutating webhook: %s", result)
+	}
+
 	hLog.Info("hiveadmission components reconciled successfully")
 	return nil
 }
@@ -451,6 +475,16 @@ func addReleaseImageVerificationConfigMapEnv(podSpec *corev1.PodSpec, instance *
 	})
 }
 
+func addClusterExpirationEnv(podSpec *corev1.PodSpec, instance *hivev1.HiveConfig) {
+	if instance.Spec.ClusterExpiration == nil || instance.Spec.ClusterExpiration.MaxExtension == nil {
+		return
+	}
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
+		Name:  hiveconstants.ClusterExpirationMaxExtensionEnvVar,
+		Value: instance.Spec.ClusterExpiration.MaxExtension.Duration.String(),
+	})
+}
+
 func computeConfigHash(cm *corev1.ConfigMap) string {
 	hasher := md5.New()
 	hasher.Write([]byte(fmt.Sprintf("%v", cm.Data)))
@@ -0,0 +1,40 @@
+package hive
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/util/redact"
+)
+
+// redactionRulesConfigMapName returns the name of the ConfigMap holding the additional redaction rules, or "" if
+// none are configured.
+func redactionRulesConfigMapName(instance *hivev1.HiveConfig) string {
+	if instance.Spec.RedactionRulesConfigMapRef == nil {
+		return ""
+	}
+	return instance.Spec.RedactionRulesConfigMapRef.Name
+}
+
+// computeRedactionRulesHash returns a hash of the additional redaction rules, which is added to the hive-controllers
+// config hash so that the controllers are restarted to pick up changes to the rules. The controllers refuse to start
+// without the rules, so an error is returned if the ConfigMap is missing or malformed, and the controllers are not
+// deployed until it is fixed.
+func (r *ReconcileHiveConfig) computeRedactionRulesHash(hLog log.FieldLogger, instance *hivev1.HiveConfig) (string, error) {
+	name := redactionRulesConfigMapName(instance)
+	if name == "" {
+		return "", nil
+	}
+	cmLog := hLog.WithField("configmap", name)
+	cm, err := r.hiveConfigMapLister.ConfigMaps(getHiveNamespace(instance)).Get(name)
+	if err != nil {
+		cmLog.WithError(err).Error("error reading redaction rules configmap")
+		return "", errors.Wrap(err, "error reading redaction rules configmap")
+	}
+	if _, err := redact.FromConfigMap(cm); err != nil {
+		cmLog.WithError(err).Error("invalid redaction rules")
+		return "", errors.Wrap(err, "invalid redaction rules")
+	}
+	return computeHash(cm.Data), nil
+}
@@ -293,10 +293,20 @@ func (b *builder) RESTConfig() (*rest.Config, error) {
 }
 
 func unadulteratedRESTConfig(c client.Client, cd *hivev1.ClusterDeployment) (*rest.Config, error) {
+	var kubeconfigSecretName string
+	switch {
+	case cd.Spec.ClusterMetadata != nil:
+		kubeconfigSecretName = cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name
+	case cd.Spec.Adoption != nil:
+		// A cluster being adopted has no metadata until it has been read from the cluster.
+		kubeconfigSecretName = cd.Spec.Adoption.AdminKubeconfigSecretRef.Name
+	default:
+		return nil, errors.New("cluster deployment has no admin kubeconfig secret")
+	}
 	kubeconfigSecret := &corev1.Secret{}
 	if err := c.Get(
 		context.Background(),
-		client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name},
+		client.ObjectKey{Namespace: cd.Namespace, Name: kubeconfigSecretName},
 		kubeconfigSecret,
 	); err != nil {
 		return nil, errors.Wrap(err, "could not get admin kubeconfig secret")
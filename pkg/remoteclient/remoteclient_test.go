@@ -51,6 +51,20 @@ func Test_InitialURL(t *testing.T) {
 	assert.Equal(t, expected, actual, "unexpected API URL")
 }
 
+func Test_InitialURL_Adoption(t *testing.T) {
+	cd := testClusterDeployment()
+	cd.Spec.ClusterMetadata = nil
+	cd.Spec.Adoption = &hivev1.ClusterAdoption{
+		AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: testKubeconfigSecretName},
+	}
+	kubeconfigSecret := testKubeconfigSecret(t)
+	c := fakeClient(cd, kubeconfigSecret)
+	expected := apiURL
+	actual, err := InitialURL(c, cd)
+	assert.NoError(t, err, "unexpected error getting API URL")
+	assert.Equal(t, expected, actual, "unexpected API URL")
+}
+
 func Test_builder_RESTConfig(t *testing.T) {
 	cases := []struct {
 		name           string
@@ -1,6 +1,7 @@
 package clusterclaim
 
 import (
+	"encoding/json"
 	"time"
 
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -8,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/test/generic"
 )
 
@@ -81,12 +83,55 @@ func WithPool(poolName string) Option {
 	}
 }
 
+// WithPoolSelector sets the claim to select the pools with the given labels.
+func WithPoolSelector(matchLabels map[string]string) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.ClusterPoolName = ""
+		clusterClaim.Spec.ClusterPoolSelector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+}
+
+func WithPoolOrdering(ordering hivev1.ClusterPoolOrderingPolicy) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.ClusterPoolOrdering = ordering
+	}
+}
+
+func WithPriority(priority int) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.Priority = int32(priority)
+	}
+}
+
+// WithStatusPool sets the pool from which the cluster was assigned to the claim.
+func WithStatusPool(poolName string) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Status.ClusterPoolName = poolName
+	}
+}
+
 func WithCluster(clusterName string) Option {
 	return func(clusterClaim *hivev1.ClusterClaim) {
 		clusterClaim.Spec.Namespace = clusterName
 	}
 }
 
+// WithCopyConnectionSecrets sets the claim to copy the connection secrets of the claimed cluster into its namespace.
+func WithCopyConnectionSecrets() Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.CopyConnectionSecrets = true
+	}
+}
+
+// WithRequester records the user that created the claim and the groups of the user.
+func WithRequester(user string, groups ...string) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		groupsJSON, _ := json.Marshal(groups)
+		Generic(generic.WithAnnotation(constants.ClusterClaimRequesterAnnotation, user))(clusterClaim)
+		Generic(generic.WithAnnotation(constants.ClusterClaimRequesterGroupsAnnotation, string(groupsJSON)))(clusterClaim)
+	}
+}
+
 func WithSubjects(subjects []rbacv1.Subject) Option {
 	return func(clusterClaim *hivev1.ClusterClaim) {
 		clusterClaim.Spec.Subjects = subjects
@@ -3,6 +3,7 @@ package clusterdeployment
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -125,6 +126,14 @@ func WithClusterPoolReference(namespace, poolName, claimName string) Option {
 	}
 }
 
+// WithCustomizationRef sets the ClusterDeploymentCustomization reference in the ClusterPoolRef of the
+// ClusterDeployment. The ClusterPoolRef must already be set.
+func WithCustomizationRef(name string) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.ClusterPoolRef.CustomizationRef = &corev1.LocalObjectReference{Name: name}
+	}
+}
+
 func Installed() Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
 		clusterDeployment.Spec.Installed = true
@@ -0,0 +1,92 @@
+package clusterdeploymentcustomization
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+// Option defines a function signature for any function that wants to be passed into Build
+type Option func(*hivev1.ClusterDeploymentCustomization)
+
+// Build runs each of the functions passed in to generate the object.
+func Build(opts ...Option) *hivev1.ClusterDeploymentCustomization {
+	retval := &hivev1.ClusterDeploymentCustomization{}
+	for _, o := range opts {
+		o(retval)
+	}
+
+	return retval
+}
+
+type Builder interface {
+	Build(opts ...Option) *hivev1.ClusterDeploymentCustomization
+
+	Options(opts ...Option) Builder
+
+	GenericOptions(opts ...generic.Option) Builder
+}
+
+func BasicBuilder() Builder {
+	return &builder{}
+}
+
+func FullBuilder(namespace, name string, typer runtime.ObjectTyper) Builder {
+	b := &builder{}
+	return b.GenericOptions(
+		generic.WithTypeMeta(typer),
+		generic.WithResourceVersion("1"),
+		generic.WithNamespace(namespace),
+		generic.WithName(name),
+	)
+}
+
+type builder struct {
+	options []Option
+}
+
+func (b *builder) Build(opts ...Option) *hivev1.ClusterDeploymentCustomization {
+	return Build(append(b.options, opts...)...)
+}
+
+func (b *builder) Options(opts ...Option) Builder {
+	return &builder{
+		options: append(b.options, opts...),
+	}
+}
+
+func (b *builder) GenericOptions(opts ...generic.Option) Builder {
+	options := make([]Option, len(opts))
+	for i, o := range opts {
+		options[i] = Generic(o)
+	}
+	return b.Options(options...)
+}
+
+// Generic allows common functions applicable to all objects to be used as Options to Build
+func Generic(opt generic.Option) Option {
+	return func(cdc *hivev1.ClusterDeploymentCustomization) {
+		opt(cdc)
+	}
+}
+
+// WithInstallConfigPatch adds the specified install-config patch to the ClusterDeploymentCustomization.
+func WithInstallConfigPatch(op, path, value string) Option {
+	return func(cdc *hivev1.ClusterDeploymentCustomization) {
+		cdc.Spec.InstallConfigPatches = append(cdc.Spec.InstallConfigPatches, hivev1.PatchEntity{
+			Op:    op,
+			Path:  path,
+			Value: value,
+		})
+	}
+}
+
+// WithClusterDeploymentRef sets the ClusterDeployment and ClusterPool references in the status.
+func WithClusterDeploymentRef(poolName, cdName string) Option {
+	return func(cdc *hivev1.ClusterDeploymentCustomization) {
+		cdc.Status.ClusterPoolRef = &corev1.LocalObjectReference{Name: poolName}
+		cdc.Status.ClusterDeploymentRef = &corev1.LocalObjectReference{Name: cdName}
+	}
+}
@@ -81,7 +81,7 @@ func ForAWS(credsSecretName, region string) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.Platform.AWS = &hivev1aws.Platform{
 			CredentialsSecretRef: corev1.LocalObjectReference{Name: credsSecretName},
-			Region:               "region",
+			Region:               region,
 		}
 	}
 }
@@ -122,6 +122,25 @@ func WithMaxSize(size int) Option {
 	}
 }
 
+func WithMaxStaleReplacements(size int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.MaxStaleReplacements = pointer.Int32Ptr(int32(size))
+	}
+}
+
+func WithRunningCount(size int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.RunningCount = int32(size)
+	}
+}
+
+// WithReady sets the number of ready clusters in the status of the pool.
+func WithReady(ready int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Status.Ready = int32(ready)
+	}
+}
+
 func WithMaxConcurrent(size int) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.MaxConcurrent = pointer.Int32Ptr(int32(size))
@@ -146,6 +165,110 @@ func WithMaximumClaimLifetime(d time.Duration) Option {
 	}
 }
 
+// WithInventory adds ClusterDeploymentCustomization entries with the specified names to the ClusterPool inventory.
+func WithInventory(names ...string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		for _, name := range names {
+			clusterPool.Spec.Inventory = append(clusterPool.Spec.Inventory, hivev1.InventoryEntry{
+				Kind: hivev1.ClusterDeploymentCustomizationInventoryEntry,
+				Name: name,
+			})
+		}
+	}
+}
+
+// WithSchedule adds a schedule overriding the size of the ClusterPool.
+func WithSchedule(name, start string, duration time.Duration, size int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Schedules = append(clusterPool.Spec.Schedules, hivev1.ClusterPoolSchedule{
+			Name:     name,
+			Start:    start,
+			Duration: metav1.Duration{Duration: duration},
+			Size:     int32(size),
+		})
+	}
+}
+
+// WithPlatformVariant adds a platform variant with the given weight to the pool. The credentials of the platform are
+// used when credsSecretName is empty.
+func WithPlatformVariant(name string, weight int, region, credsSecretName string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		w := int32(weight)
+		variant := hivev1.ClusterPoolPlatformVariant{
+			Name:   name,
+			Weight: &w,
+			Region: region,
+		}
+		if credsSecretName != "" {
+			variant.CredentialsSecretRef = &corev1.LocalObjectReference{Name: credsSecretName}
+		}
+		clusterPool.Spec.PlatformVariants = append(clusterPool.Spec.PlatformVariants, variant)
+	}
+}
+
+func WithMaxUnclaimedAge(age time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.MaxUnclaimedAge = &metav1.Duration{Duration: age}
+	}
+}
+
+// WithBrokenClusterThreshold sets a broken cluster policy on the pool with the given threshold.
+func WithBrokenClusterThreshold(threshold time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.BrokenClusterPolicy = &hivev1.ClusterPoolBrokenClusterPolicy{
+			Threshold: metav1.Duration{Duration: threshold},
+		}
+	}
+}
+
+// WithAutoscaling enables autoscaling of the pool between the given sizes, measuring claims over the given window.
+func WithAutoscaling(minSize, maxSize int32, window time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+			MinSize: minSize,
+			MaxSize: maxSize,
+			Window:  &metav1.Duration{Duration: window},
+		}
+	}
+}
+
+// WithRecentClaims counts a claim recently assigned a cluster from the pool at each of the given times.
+func WithRecentClaims(times ...time.Time) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		if clusterPool.Status.Autoscaling == nil {
+			clusterPool.Status.Autoscaling = &hivev1.ClusterPoolAutoscalingStatus{}
+		}
+		for _, t := range times {
+			clusterPool.Status.Autoscaling.RecentClaims = append(clusterPool.Status.Autoscaling.RecentClaims,
+				hivev1.ClusterPoolClaimCount{Start: metav1.NewTime(t), Count: 1})
+		}
+	}
+}
+
+// WithGroupClaimQuota adds a claim quota for the given group to the pool.
+func WithGroupClaimQuota(group string, maxClaimed int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ClaimQuotas = append(clusterPool.Spec.ClaimQuotas,
+			hivev1.ClusterPoolClaimQuota{Group: group, MaxClaimed: int32(maxClaimed)})
+	}
+}
+
+// WithUserClaimQuota adds a claim quota for the given user to the pool.
+func WithUserClaimQuota(user string, maxClaimed int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ClaimQuotas = append(clusterPool.Spec.ClaimQuotas,
+			hivev1.ClusterPoolClaimQuota{User: user, MaxClaimed: int32(maxClaimed)})
+	}
+}
+
+// WithNamespaceClaimQuota adds a claim quota for the given namespace to the pool.
+func WithNamespaceClaimQuota(namespace string, maxClaimed int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ClaimQuotas = append(clusterPool.Spec.ClaimQuotas,
+			hivev1.ClusterPoolClaimQuota{Namespace: namespace, MaxClaimed: int32(maxClaimed)})
+	}
+}
+
 // WithCondition adds the specified condition to the ClusterPool
 func WithCondition(cond hivev1.ClusterPoolCondition) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
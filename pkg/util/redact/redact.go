@@ -0,0 +1,94 @@
+package redact
+
+import (
+	"regexp"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// RulesDataKey is the key in the redaction rules ConfigMap holding the rules.
+	RulesDataKey = "rules"
+
+	// DefaultReplacement replaces matches of rules that do not specify a replacement.
+	DefaultReplacement = "REDACTED"
+)
+
+// Rule is a single redaction rule. Rules are serialized as yaml in the redaction rules ConfigMap.
+type Rule struct {
+	// Name is the name of the rule.
+	Name string `json:"name"`
+
+	// Regex is the regular expression matching the text to redact.
+	Regex string `json:"regex"`
+
+	// Replacement is the text that matches of the regex are replaced with. It may refer to groups of the regex
+	// with $1 or ${name}. Defaults to REDACTED.
+	Replacement string `json:"replacement,omitempty"`
+}
+
+type compiledRule struct {
+	regex       *regexp.Regexp
+	replacement string
+}
+
+// Redactor applies a set of redaction rules to text. A nil Redactor leaves text unchanged.
+type Redactor struct {
+	data  string
+	rules []compiledRule
+}
+
+// Parse returns a Redactor for the rules serialized in data. It fails if any of the rules are invalid.
+func Parse(data string) (*Redactor, error) {
+	rules := []Rule{}
+	if err := yaml.Unmarshal([]byte(data), &rules); err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal redaction rules")
+	}
+	r := &Redactor{data: data}
+	for i, rule := range rules {
+		if rule.Regex == "" {
+			return nil, errors.Errorf("redaction rule %d (%s) has no regex", i, rule.Name)
+		}
+		regex, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "redaction rule %d (%s) has an invalid regex", i, rule.Name)
+		}
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = DefaultReplacement
+		}
+		r.rules = append(r.rules, compiledRule{regex: regex, replacement: replacement})
+	}
+	return r, nil
+}
+
+// FromConfigMap returns a Redactor for the rules in a redaction rules ConfigMap.
+func FromConfigMap(cm *corev1.ConfigMap) (*Redactor, error) {
+	data, ok := cm.Data[RulesDataKey]
+	if !ok {
+		return nil, errors.Errorf("%s configmap does not have a %q data entry", cm.Name, RulesDataKey)
+	}
+	return Parse(data)
+}
+
+// Redact returns the text with the matches of each rule replaced, applying the rules in order.
+func (r *Redactor) Redact(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, rule := range r.rules {
+		s = rule.regex.ReplaceAllString(s, rule.replacement)
+	}
+	return s
+}
+
+// Data returns the serialized rules the Redactor was parsed from, for passing them on to other processes.
+func (r *Redactor) Data() string {
+	if r == nil {
+		return ""
+	}
+	return r.data
+}
@@ -0,0 +1,13 @@
+package schedule
+
+import (
+	"github.com/robfig/cron/v3"
+)
+
+// cronParser parses the standard five field cron expressions, along with descriptors such as @daily.
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Parse parses the start expression of a ClusterPoolSchedule.
+func Parse(start string) (cron.Schedule, error) {
+	return cronParser.Parse(start)
+}
@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ClusterClaimMutatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+// It records the user that created a ClusterClaim, and the groups of the user, on the claim so that the claim quotas of
+// cluster pools can be applied to the claim.
+type ClusterClaimMutatingAdmissionHook struct {
+	decoder *admission.Decoder
+}
+
+// NewClusterClaimMutatingAdmissionHook constructs a new ClusterClaimMutatingAdmissionHook
+func NewClusterClaimMutatingAdmissionHook(decoder *admission.Decoder) *ClusterClaimMutatingAdmissionHook {
+	return &ClusterClaimMutatingAdmissionHook{decoder: decoder}
+}
+
+// MutatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+//                  webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterclaimmutators".
+//              When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Admit() method below.
+func (a *ClusterClaimMutatingAdmissionHook) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimmutator",
+	}).Info("Registering mutation REST resource")
+	// NOTE: This GVR is meant to be different than the ClusterClaim CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "clusterclaimmutators",
+		},
+		"clusterclaimmutator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterClaimMutatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimmutator",
+	}).Info("Initializing mutation REST resource")
+	return nil // No initialization needed right now.
+}
+
+// Admit is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// Usually it's the kube apiserver that is making the admission mutation request.
+func (a *ClusterClaimMutatingAdmissionHook) Admit(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Admit",
+	})
+
+	// The requester is only recorded when the claim is created.
+	if admissionSpec.Resource.Group != clusterClaimGroup ||
+		admissionSpec.Resource.Version != clusterClaimVersion ||
+		admissionSpec.Resource.Resource != clusterClaimResource ||
+		admissionSpec.Operation != admissionv1beta1.Create {
+		contextLogger.Info("Skipping mutation for request")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	newObject := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, newObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Add the new data to the contextLogger
+	contextLogger.Data["object.Name"] = newObject.Name
+
+	patch, err := requesterPatch(newObject, admissionSpec.UserInfo.Username, admissionSpec.UserInfo.Groups)
+	if err != nil {
+		contextLogger.WithError(err).Error("failed to build patch")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	contextLogger.WithField("requester", admissionSpec.UserInfo.Username).Info("Recording requester of claim")
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// requesterPatch returns a JSON patch setting the requester annotations of the claim to the user and groups.
+func requesterPatch(claim *hivev1.ClusterClaim, user string, groups []string) ([]byte, error) {
+	if groups == nil {
+		groups = []string{}
+	}
+	groupsJSON, err := json.Marshal(groups)
+	if err != nil {
+		return nil, err
+	}
+	annotations := map[string]string{
+		constants.ClusterClaimRequesterAnnotation:       user,
+		constants.ClusterClaimRequesterGroupsAnnotation: string(groupsJSON),
+	}
+
+	type patchOperation struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}
+	var ops []patchOperation
+	if claim.Annotations == nil {
+		ops = append(ops, patchOperation{Op: "add", Path: "/metadata/annotations", Value: annotations})
+	} else {
+		for _, key := range []string{constants.ClusterClaimRequesterAnnotation, constants.ClusterClaimRequesterGroupsAnnotation} {
+			// "~" and "/" in the key are escaped as described in RFC 6901.
+			escapedKey := strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+			ops = append(ops, patchOperation{Op: "add", Path: "/metadata/annotations/" + escapedKey, Value: annotations[key]})
+		}
+	}
+	return json.Marshal(ops)
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClusterClaimMutatingResource(t *testing.T) {
+	// Arrange
+	data := NewClusterClaimMutatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "clusterclaimmutators",
+	}
+	expectedSingular := "clusterclaimmutator"
+
+	// Act
+	plural, singular := data.MutatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestClusterClaimAdmit(t *testing.T) {
+	cases := []struct {
+		name                string
+		annotations         map[string]string
+		operation           admissionv1beta1.Operation
+		resource            string
+		expectPatch         bool
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:        "create without annotations",
+			operation:   admissionv1beta1.Create,
+			expectPatch: true,
+			expectedAnnotations: map[string]string{
+				constants.ClusterClaimRequesterAnnotation:       "test-user",
+				constants.ClusterClaimRequesterGroupsAnnotation: `["test-group-1","test-group-2"]`,
+			},
+		},
+		{
+			name:      "create with annotations",
+			operation: admissionv1beta1.Create,
+			annotations: map[string]string{
+				"other-annotation":                        "other-value",
+				constants.ClusterClaimRequesterAnnotation: "other-user",
+			},
+			expectPatch: true,
+			expectedAnnotations: map[string]string{
+				"other-annotation":                              "other-value",
+				constants.ClusterClaimRequesterAnnotation:       "test-user",
+				constants.ClusterClaimRequesterGroupsAnnotation: `["test-group-1","test-group-2"]`,
+			},
+		},
+		{
+			name:      "update",
+			operation: admissionv1beta1.Update,
+		},
+		{
+			name:      "other resource",
+			operation: admissionv1beta1.Create,
+			resource:  "clusterpools",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := NewClusterClaimMutatingAdmissionHook(createDecoder(t))
+			claim := &hivev1.ClusterClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "test-namespace",
+					Name:        "test-claim",
+					Annotations: tc.annotations,
+				},
+				Spec: hivev1.ClusterClaimSpec{
+					ClusterPoolName: "test-pool",
+				},
+			}
+			raw, err := json.Marshal(claim)
+			require.NoError(t, err, "unexpected error marshaling claim")
+			if tc.resource == "" {
+				tc.resource = "clusterclaims"
+			}
+
+			response := data.Admit(&admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: tc.resource,
+				},
+				Object: runtime.RawExtension{Raw: raw},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "test-user",
+					Groups:   []string{"test-group-1", "test-group-2"},
+				},
+			})
+
+			assert.True(t, response.Allowed, "expected request to be allowed")
+			if !tc.expectPatch {
+				assert.Nil(t, response.Patch, "unexpected patch")
+				return
+			}
+			patch, err := jsonpatch.DecodePatch(response.Patch)
+			require.NoError(t, err, "unexpected error decoding patch")
+			patched, err := patch.Apply(raw)
+			require.NoError(t, err, "unexpected error applying patch")
+			patchedClaim := &hivev1.ClusterClaim{}
+			require.NoError(t, json.Unmarshal(patched, patchedClaim), "unexpected error unmarshaling patched claim")
+			assert.Equal(t, tc.expectedAnnotations, patchedClaim.Annotations, "unexpected annotations")
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	clusterClaimGroup    = "hive.openshift.io"
+	clusterClaimVersion  = "v1"
+	clusterClaimResource = "clusterclaims"
+)
+
+// ClusterClaimValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type ClusterClaimValidatingAdmissionHook struct {
+	decoder *admission.Decoder
+}
+
+// NewClusterClaimValidatingAdmissionHook constructs a new ClusterClaimValidatingAdmissionHook
+func NewClusterClaimValidatingAdmissionHook(decoder *admission.Decoder) *ClusterClaimValidatingAdmissionHook {
+	return &ClusterClaimValidatingAdmissionHook{decoder: decoder}
+}
+
+// ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+//                    webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterclaimvalidators".
+//              When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Validate() method below.
+func (a *ClusterClaimValidatingAdmissionHook) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimvalidator",
+	}).Info("Registering validation REST resource")
+	// NOTE: This GVR is meant to be different than the ClusterClaim CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "clusterclaimvalidators",
+		},
+		"clusterclaimvalidator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterClaimValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimvalidator",
+	}).Info("Initializing validation REST resource")
+	return nil // No initialization needed right now.
+}
+
+// Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// Usually it's the kube apiserver that is making the admission validation request.
+func (a *ClusterClaimValidatingAdmissionHook) Validate(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Validate",
+	})
+
+	if !a.shouldValidate(admissionSpec) {
+		contextLogger.Info("Skipping validation for request")
+		// The request object isn't something that this validator should validate.
+		// Therefore, we say that it's allowed.
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	contextLogger.Info("Validating request")
+
+	if admissionSpec.Operation == admissionv1beta1.Create {
+		return a.validateCreate(admissionSpec)
+	}
+
+	if admissionSpec.Operation == admissionv1beta1.Update {
+		return a.validateUpdate(admissionSpec)
+	}
+
+	// We're only validating creates and updates at this time, so all other operations are explicitly allowed.
+	contextLogger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// shouldValidate explicitly checks if the request should validated. For example, this webhook may have accidentally been registered to check
+// the validity of some other type of object with a different GVR.
+func (a *ClusterClaimValidatingAdmissionHook) shouldValidate(admissionSpec *admissionv1beta1.AdmissionRequest) bool {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "shouldValidate",
+	})
+
+	if admissionSpec.Resource.Group != clusterClaimGroup {
+		contextLogger.Debug("Returning False, not our group")
+		return false
+	}
+
+	if admissionSpec.Resource.Version != clusterClaimVersion {
+		contextLogger.Debug("Returning False, it's our group, but not the right version")
+		return false
+	}
+
+	if admissionSpec.Resource.Resource != clusterClaimResource {
+		contextLogger.Debug("Returning False, it's our group and version, but not the right resource")
+		return false
+	}
+
+	// If we get here, then we're supposed to validate the object.
+	contextLogger.Debug("Returning True, passed all prerequisites.")
+	return true
+}
+
+// validateCreate specifically validates create operations for ClusterClaim objects.
+func (a *ClusterClaimValidatingAdmissionHook) validateCreate(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "validateCreate",
+	})
+
+	newObject := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, newObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Add the new data to the contextLogger
+	contextLogger.Data["object.Name"] = newObject.Name
+
+	allErrs := validateClusterClaimSpec(field.NewPath("spec"), &newObject.Spec)
+	allErrs = append(allErrs, validateClusterClaimRequester(&newObject.ObjectMeta, admissionSpec.UserInfo.Username, admissionSpec.UserInfo.Groups)...)
+
+	if len(allErrs) > 0 {
+		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
+		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		}
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	contextLogger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// validateUpdate specifically validates update operations for ClusterClaim objects.
+func (a *ClusterClaimValidatingAdmissionHook) validateUpdate(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "validateUpdate",
+	})
+
+	newObject := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, newObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Add the new data to the contextLogger
+	contextLogger.Data["object.Name"] = newObject.Name
+
+	oldObject := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(admissionSpec.OldObject, oldObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling OldObject: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Add the new data to the contextLogger
+	contextLogger.Data["oldObject.Name"] = oldObject.Name
+
+	allErrs := validateClusterClaimSpec(field.NewPath("spec"), &newObject.Spec)
+	for _, key := range []string{constants.ClusterClaimRequesterAnnotation, constants.ClusterClaimRequesterGroupsAnnotation} {
+		if newObject.Annotations[key] != oldObject.Annotations[key] {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "annotations").Key(key), "the requester of a claim is immutable"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
+		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		}
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	contextLogger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+func validateClusterClaimSpec(path *field.Path, spec *hivev1.ClusterClaimSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case spec.ClusterPoolName == "" && spec.ClusterPoolSelector == nil:
+		allErrs = append(allErrs, field.Required(path.Child("clusterPoolName"), "one of clusterPoolName and clusterPoolSelector must be set"))
+	case spec.ClusterPoolName != "" && spec.ClusterPoolSelector != nil:
+		allErrs = append(allErrs, field.Forbidden(path.Child("clusterPoolSelector"), "only one of clusterPoolName and clusterPoolSelector may be set"))
+	case spec.ClusterPoolSelector != nil:
+		if _, err := metav1.LabelSelectorAsSelector(spec.ClusterPoolSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("clusterPoolSelector"), spec.ClusterPoolSelector, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateClusterClaimRequester checks that the requester recorded on a new claim is the user creating the claim.
+func validateClusterClaimRequester(meta *metav1.ObjectMeta, user string, groups []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	annotationsPath := field.NewPath("metadata", "annotations")
+	if meta.Annotations[constants.ClusterClaimRequesterAnnotation] != user {
+		allErrs = append(allErrs, field.Forbidden(annotationsPath.Key(constants.ClusterClaimRequesterAnnotation), "must be the user creating the claim"))
+	}
+	var recordedGroups []string
+	if v := meta.Annotations[constants.ClusterClaimRequesterGroupsAnnotation]; v != "" {
+		if err := json.Unmarshal([]byte(v), &recordedGroups); err != nil {
+			return append(allErrs, field.Invalid(annotationsPath.Key(constants.ClusterClaimRequesterGroupsAnnotation), v, err.Error()))
+		}
+	}
+	if !sets.NewString(recordedGroups...).Equal(sets.NewString(groups...)) {
+		allErrs = append(allErrs, field.Forbidden(annotationsPath.Key(constants.ClusterClaimRequesterGroupsAnnotation), "must be the groups of the user creating the claim"))
+	}
+	return allErrs
+}
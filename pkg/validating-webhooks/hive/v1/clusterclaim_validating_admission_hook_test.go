@@ -0,0 +1,303 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClusterClaimValidatingResource(t *testing.T) {
+	// Arrange
+	data := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "clusterclaimvalidators",
+	}
+	expectedSingular := "clusterclaimvalidator"
+
+	// Act
+	plural, singular := data.ValidatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestClusterClaimInitialize(t *testing.T) {
+	// Arrange
+	data := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(nil, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestClusterClaimValidate(t *testing.T) {
+	cases := []struct {
+		name            string
+		newSpec         hivev1.ClusterClaimSpec
+		oldSpec         hivev1.ClusterClaimSpec
+		newRequester    *testRequester
+		oldRequester    *testRequester
+		newObjectRaw    []byte
+		oldObjectRaw    []byte
+		operation       admissionv1beta1.Operation
+		expectedAllowed bool
+		gvr             *metav1.GroupVersionResource
+	}{
+		{
+			name: "Test valid ClusterClaim.Spec with pool name",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test valid ClusterClaim.Spec with pool selector",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"test-label": "test-value"}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test ClusterClaim.Spec without pool name or pool selector",
+			newSpec:         hivev1.ClusterClaimSpec{},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test ClusterClaim.Spec with both pool name and pool selector",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName:     "test-pool",
+				ClusterPoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"test-label": "test-value"}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test ClusterClaim.Spec with invalid pool selector",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "test-label",
+						Operator: "bad-operator",
+					}},
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test ClusterClaim.Spec with both pool name and pool selector on update",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName:     "test-pool",
+				ClusterPoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"test-label": "test-value"}},
+			},
+			oldSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test ClusterClaim created without requester",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+			newRequester:    &testRequester{},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test ClusterClaim created with requester of another user",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+			newRequester:    &testRequester{user: "other-user", groups: []string{"test-group"}},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test ClusterClaim created with groups of another user",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+			newRequester:    &testRequester{user: "test-user", groups: []string{"test-group", "other-group"}},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test ClusterClaim requester changed",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+			oldSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+			oldRequester:    &testRequester{user: "other-user", groups: []string{"test-group"}},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal new object during create",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal new object during update",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal old object during update",
+			oldObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test ClusterClaim.Spec update assigning a cluster",
+			newSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+				Namespace:       "test-cluster",
+			},
+			oldSpec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: "test-pool",
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test that we don't validate deletes",
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test doesn't validate with right version and resource, but wrong group",
+			gvr: &metav1.GroupVersionResource{
+				Group:    "not the right group",
+				Version:  "v1",
+				Resource: "clusterclaims",
+			},
+			expectedAllowed: true,
+		},
+		{
+			name: "Test doesn't validate with right group and resource, wrong version",
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "not the right version",
+				Resource: "clusterclaims",
+			},
+			expectedAllowed: true,
+		},
+		{
+			name: "Test doesn't validate with right group and version, wrong resource",
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "v1",
+				Resource: "not the right resource",
+			},
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+			if tc.newRequester == nil {
+				tc.newRequester = &testRequester{user: "test-user", groups: []string{"test-group"}}
+			}
+			if tc.oldRequester == nil {
+				tc.oldRequester = tc.newRequester
+			}
+			newObject := &hivev1.ClusterClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   testClaimNamespace,
+					Name:        "test-claim",
+					Annotations: tc.newRequester.annotations(),
+				},
+				Spec: tc.newSpec,
+			}
+			oldObject := &hivev1.ClusterClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   testClaimNamespace,
+					Name:        "test-claim",
+					Annotations: tc.oldRequester.annotations(),
+				},
+				Spec: tc.oldSpec,
+			}
+
+			if tc.newObjectRaw == nil {
+				tc.newObjectRaw, _ = json.Marshal(newObject)
+			}
+
+			if tc.oldObjectRaw == nil {
+				tc.oldObjectRaw, _ = json.Marshal(oldObject)
+			}
+
+			if tc.gvr == nil {
+				tc.gvr = &metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterclaims",
+				}
+			}
+
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource:  *tc.gvr,
+				Object: runtime.RawExtension{
+					Raw: tc.newObjectRaw,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: tc.oldObjectRaw,
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "test-user",
+					Groups:   []string{"test-group"},
+				},
+			}
+
+			// Act
+			response := data.Validate(request)
+
+			// Assert
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}
+
+const testClaimNamespace = "test-namespace"
+
+type testRequester struct {
+	user   string
+	groups []string
+}
+
+func (r *testRequester) annotations() map[string]string {
+	if r.user == "" {
+		return nil
+	}
+	groupsJSON, _ := json.Marshal(r.groups)
+	return map[string]string{
+		constants.ClusterClaimRequesterAnnotation:       r.user,
+		constants.ClusterClaimRequesterGroupsAnnotation: string(groupsJSON),
+	}
+}
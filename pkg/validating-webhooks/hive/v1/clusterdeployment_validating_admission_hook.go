@@ -3,15 +3,18 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +33,7 @@ import (
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/util/contracts"
 )
@@ -44,7 +48,7 @@ const (
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "MachineManagement"}
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "PreDeprovisionHooks", "ClusterPoolRef", "PowerState", "HibernateAfter", "Expiration", "Upgrade", "InstallAttemptsLimit", "MachineManagement"}
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
@@ -55,6 +59,10 @@ type ClusterDeploymentValidatingAdmissionHook struct {
 	fs                   *featureSet
 	awsPrivateLinkConfig *hivev1.AWSPrivateLinkConfig
 	supportedContracts   contracts.SupportedContractImplementationsList
+
+	// maxExpirationExtension is the furthest an update may move the expiration of a cluster later. Zero means
+	// there is no limit.
+	maxExpirationExtension time.Duration
 }
 
 // NewClusterDeploymentValidatingAdmissionHook constructs a new ClusterDeploymentValidatingAdmissionHook
@@ -80,13 +88,22 @@ func NewClusterDeploymentValidatingAdmissionHook(decoder *admission.Decoder) *Cl
 
 	}
 
+	var maxExpirationExtension time.Duration
+	if value := os.Getenv(constants.ClusterExpirationMaxExtensionEnvVar); value != "" {
+		maxExpirationExtension, err = time.ParseDuration(value)
+		if err != nil {
+			logger.WithError(err).WithField("maxExtension", value).Fatal("Unable to parse cluster expiration max extension")
+		}
+	}
+
 	logger.WithField("managedDomains", domains).Info("Read managed domains")
 	return &ClusterDeploymentValidatingAdmissionHook{
-		decoder:              decoder,
-		validManagedDomains:  domains,
-		fs:                   newFeatureSet(),
-		awsPrivateLinkConfig: aplConfig,
-		supportedContracts:   supportContractsConfig,
+		decoder:                decoder,
+		validManagedDomains:    domains,
+		fs:                     newFeatureSet(),
+		awsPrivateLinkConfig:   aplConfig,
+		supportedContracts:     supportContractsConfig,
+		maxExpirationExtension: maxExpirationExtension,
 	}
 }
 
@@ -275,11 +292,15 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 			allErrs = append(allErrs, field.Forbidden(specPath.Child("provisioning"), "provisioning and clusterInstallRef cannot be set at the same time"))
 		}
 
-		if cd.Spec.Provisioning == nil && cd.Spec.ClusterInstallRef == nil {
+		if cd.Spec.Provisioning == nil && cd.Spec.ClusterInstallRef == nil && cd.Spec.Adoption == nil {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning"), "provisioning is required if not installed"))
 		}
 	}
 
+	if cd.Spec.Adoption != nil {
+		allErrs = append(allErrs, validateClusterAdoption(specPath, cd.Spec)...)
+	}
+
 	if !cd.Spec.Installed && cd.Spec.Provisioning != nil {
 		// InstallConfigSecretRef is not required for anyone using the new ClusterInstall interface:
 		if cd.Spec.Provisioning.InstallConfigSecretRef == nil || cd.Spec.Provisioning.InstallConfigSecretRef.Name == "" {
@@ -287,8 +308,24 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		}
 	}
 
-	allErrs = append(allErrs, validateClusterPlatform(specPath.Child("platform"), cd.Spec.Platform)...)
+	platformErrs := validateClusterPlatform(specPath.Child("platform"), cd.Spec.Platform)
+	if cd.Spec.Adoption != nil {
+		// The AWS and GCP regions of an adopted cluster are read from the cluster.
+		platformErrs = platformErrs.Filter(func(err error) bool {
+			fieldErr, ok := err.(*field.Error)
+			return ok && fieldErr.Type == field.ErrorTypeRequired &&
+				(fieldErr.Field == specPath.Child("platform", "aws", "region").String() ||
+					fieldErr.Field == specPath.Child("platform", "gcp", "region").String())
+		})
+	}
+	allErrs = append(allErrs, platformErrs...)
 	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec)...)
+	allErrs = append(allErrs, validateExpiration(specPath.Child("expiration"), cd.Spec.Expiration)...)
+	allErrs = append(allErrs, validateUpgrade(specPath.Child("upgrade"), cd.Spec.Upgrade)...)
+	allErrs = append(allErrs, validatePreDeprovisionHooks(specPath.Child("preDeprovisionHooks"), cd.Spec.PreDeprovisionHooks)...)
+	if a.maxExpirationExtension > 0 {
+		allErrs = append(allErrs, validateOriginalExpiration(cd)...)
+	}
 
 	if cd.Spec.Platform.AWS != nil {
 		allErrs = append(allErrs, validateAWSPrivateLink(specPath.Child("platform", "aws"), cd.Spec.Platform.AWS, a.awsPrivateLinkConfig)...)
@@ -298,6 +335,7 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		if cd.Spec.Provisioning.SSHPrivateKeySecretRef != nil && cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name == "" {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning", "sshPrivateKeySecretRef", "name"), "must specify a name for the ssh private key secret if the ssh private key secret is specified"))
 		}
+		allErrs = append(allErrs, validateProvisionHooks(specPath.Child("provisioning", "hooks"), cd.Spec.Provisioning.Hooks)...)
 	}
 
 	if cd.Spec.ClusterInstallRef != nil {
@@ -365,6 +403,55 @@ func validateAWSPrivateLink(path *field.Path, platform *hivev1aws.Platform, conf
 	return allErrs
 }
 
+func validateProvisionHooks(path *field.Path, hooks []hivev1.ProvisionHook) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, hook := range hooks {
+		hookPath := path.Index(i)
+		allErrs = append(allErrs, validateHook(hookPath, hook.Name, hook.Template, names)...)
+		switch hook.Phase {
+		case hivev1.PreInstallProvisionHookPhase, hivev1.PostInstallProvisionHookPhase:
+		default:
+			allErrs = append(allErrs, field.NotSupported(hookPath.Child("phase"), hook.Phase,
+				[]string{string(hivev1.PreInstallProvisionHookPhase), string(hivev1.PostInstallProvisionHookPhase)}))
+		}
+	}
+	return allErrs
+}
+
+func validatePreDeprovisionHooks(path *field.Path, hooks []hivev1.PreDeprovisionHook) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, hook := range hooks {
+		allErrs = append(allErrs, validateHook(path.Index(i), hook.Name, hook.Template, names)...)
+		if hook.Timeout != nil && hook.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("timeout"), hook.Timeout.Duration.String(), "must be positive"))
+		}
+	}
+	return allErrs
+}
+
+// validateHook checks the name and pod template shared by provision and pre-deprovision hooks. The name of the hook
+// is added to the names already used by the other hooks.
+func validateHook(hookPath *field.Path, name string, template corev1.PodTemplateSpec, names sets.String) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case name == "":
+		allErrs = append(allErrs, field.Required(hookPath.Child("name"), "must specify a name for the hook"))
+	case names.Has(name):
+		allErrs = append(allErrs, field.Duplicate(hookPath.Child("name"), name))
+	default:
+		for _, msg := range validation.IsDNS1123Label(name) {
+			allErrs = append(allErrs, field.Invalid(hookPath.Child("name"), name, msg))
+		}
+	}
+	names.Insert(name)
+	if len(template.Spec.Containers) == 0 {
+		allErrs = append(allErrs, field.Required(hookPath.Child("template", "spec", "containers"), "must specify at least one container"))
+	}
+	return allErrs
+}
+
 /* TODO: move to explicit validation for AgentClusterInstall */
 /*
 func validateAgentInstallStrategy(specPath *field.Path, cd *hivev1.ClusterDeployment) field.ErrorList {
@@ -439,6 +526,148 @@ func validatefeatureGates(decoder *admission.Decoder, admissionSpec *admissionv1
 	return nil
 }
 
+func validateClusterAdoption(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	adoptionPath := specPath.Child("adoption")
+	if spec.Installed {
+		allErrs = append(allErrs, field.Forbidden(adoptionPath, "adoption cannot be set on an installed cluster"))
+	}
+	if spec.Provisioning != nil || spec.ClusterInstallRef != nil {
+		allErrs = append(allErrs, field.Forbidden(adoptionPath, "adoption cannot be set with provisioning or clusterInstallRef"))
+	}
+	if spec.ClusterMetadata != nil {
+		allErrs = append(allErrs, field.Forbidden(adoptionPath, "adoption cannot be set with clusterMetadata"))
+	}
+	if spec.ManageDNS {
+		allErrs = append(allErrs, field.Forbidden(adoptionPath, "adoption cannot be set with manageDNS"))
+	}
+	if spec.Adoption.AdminKubeconfigSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(adoptionPath.Child("adminKubeconfigSecretRef", "name"), "must specify the admin kubeconfig secret of the cluster to adopt"))
+	}
+	if spec.Adoption.AdminPasswordSecretRef != nil && spec.Adoption.AdminPasswordSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(adoptionPath.Child("adminPasswordSecretRef", "name"), "must specify a name for the admin password secret if the admin password secret is specified"))
+	}
+	return allErrs
+}
+
+// validateExpiration checks that exactly one of deleteAt and deleteAfter is set, and that the durations are positive.
+func validateExpiration(path *field.Path, expiration *hivev1.ClusterExpiration) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if expiration == nil {
+		return allErrs
+	}
+	switch {
+	case expiration.DeleteAt == nil && expiration.DeleteAfter == nil:
+		allErrs = append(allErrs, field.Required(path, "must specify one of deleteAt or deleteAfter"))
+	case expiration.DeleteAt != nil && expiration.DeleteAfter != nil:
+		allErrs = append(allErrs, field.Forbidden(path.Child("deleteAfter"), "cannot specify both deleteAt and deleteAfter"))
+	case expiration.DeleteAfter != nil && expiration.DeleteAfter.Duration <= 0:
+		allErrs = append(allErrs, field.Invalid(path.Child("deleteAfter"), expiration.DeleteAfter.Duration.String(), "must be positive"))
+	}
+	for i, leadTime := range expiration.WarningLeadTimes {
+		if leadTime.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("warningLeadTimes").Index(i), leadTime.Duration.String(), "must be positive"))
+		}
+	}
+	return allErrs
+}
+
+// validateUpgrade checks that exactly one of version and imageSetRef is set.
+func validateUpgrade(path *field.Path, upgrade *hivev1.ClusterUpgrade) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if upgrade == nil {
+		return allErrs
+	}
+	switch {
+	case upgrade.Version == "" && upgrade.ImageSetRef == nil:
+		allErrs = append(allErrs, field.Required(path, "must specify one of version or imageSetRef"))
+	case upgrade.Version != "" && upgrade.ImageSetRef != nil:
+		allErrs = append(allErrs, field.Forbidden(path.Child("imageSetRef"), "cannot specify both version and imageSetRef"))
+	case upgrade.ImageSetRef != nil && upgrade.ImageSetRef.Name == "":
+		allErrs = append(allErrs, field.Required(path.Child("imageSetRef", "name"), "must specify a ClusterImageSet name"))
+	}
+	return allErrs
+}
+
+// validateExpirationExtension checks that the expiration of a cluster, whether set through spec.expiration or the
+// delete-after annotation, is never extended by more than the max extension in total. Extensions are capped relative
+// to the original expiration recorded by hive, or to the old expiration until it has been recorded, so repeated
+// updates cannot push the expiration further out. A cluster which did not expire may be given an expiration no
+// further than the max extension from now, and the expiration of a cluster cannot be removed.
+func validateExpirationExtension(path *field.Path, oldObject, cd *hivev1.ClusterDeployment, maxExtension time.Duration) field.ErrorList {
+	allErrs := field.ErrorList{}
+	oldExpiry, err := controllerutils.ExpirationTime(oldObject)
+	if err != nil {
+		// The old expiration was never honored, so any valid expiration is treated as a new one.
+		oldExpiry = nil
+	}
+	newExpiry, err := controllerutils.ExpirationTime(cd)
+	if err != nil {
+		return append(allErrs, field.Invalid(field.NewPath("metadata", "annotations").Key(constants.DeleteAfterAnnotation), cd.Annotations[constants.DeleteAfterAnnotation], err.Error()))
+	}
+
+	originalPath := field.NewPath("metadata", "annotations").Key(constants.OriginalExpirationAnnotation)
+	oldOriginal, oldOriginalSet := oldObject.Annotations[constants.OriginalExpirationAnnotation]
+	newOriginal, newOriginalSet := cd.Annotations[constants.OriginalExpirationAnnotation]
+	switch {
+	case oldOriginalSet:
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newOriginal, oldOriginal, originalPath)...)
+	case newOriginalSet:
+		// The original expiration may only be recorded as the expiration of the cluster at the time.
+		if oldExpiry == nil || newOriginal != oldExpiry.UTC().Format(time.RFC3339) {
+			allErrs = append(allErrs, field.Invalid(originalPath, newOriginal, "must be the current expiration of the cluster"))
+		}
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	baseExpiry := oldExpiry
+	if originalExpiry, err := controllerutils.OriginalExpirationTime(oldObject); err == nil && originalExpiry != nil {
+		baseExpiry = originalExpiry
+	}
+	switch {
+	case oldExpiry != nil && newExpiry == nil:
+		allErrs = append(allErrs, field.Forbidden(path, "cannot remove the expiration of a cluster"))
+	case oldExpiry == nil && newExpiry != nil:
+		if limit := time.Now().Add(maxExtension); newExpiry.After(limit) {
+			allErrs = append(allErrs, field.Invalid(path, newExpiry.UTC().Format(time.RFC3339), fmt.Sprintf("cannot expire the cluster more than %s from now", maxExtension)))
+		}
+	case oldExpiry != nil && newExpiry != nil:
+		if !newExpiry.After(*oldExpiry) {
+			break
+		}
+		if limit := baseExpiry.Add(maxExtension); newExpiry.After(limit) {
+			allErrs = append(allErrs, field.Invalid(path, newExpiry.UTC().Format(time.RFC3339), fmt.Sprintf("cannot extend the expiration of the cluster by more than %s in total, to later than %s", maxExtension, limit.UTC().Format(time.RFC3339))))
+		}
+	}
+	return allErrs
+}
+
+// validateOriginalExpiration checks that the original expiration of a new cluster, if set, is not later than its
+// expiration, so that it cannot be used to lift the cap on extensions of the expiration.
+func validateOriginalExpiration(cd *hivev1.ClusterDeployment) field.ErrorList {
+	allErrs := field.ErrorList{}
+	originalPath := field.NewPath("metadata", "annotations").Key(constants.OriginalExpirationAnnotation)
+	originalExpiry, err := controllerutils.OriginalExpirationTime(cd)
+	switch {
+	case err != nil:
+		return append(allErrs, field.Invalid(originalPath, cd.Annotations[constants.OriginalExpirationAnnotation], err.Error()))
+	case originalExpiry == nil:
+		return allErrs
+	}
+	// The creation timestamp is not set yet, so an expiration relative to it is computed from now.
+	cdCopy := cd.DeepCopy()
+	if cdCopy.CreationTimestamp.IsZero() {
+		cdCopy.CreationTimestamp = metav1.Now()
+	}
+	expiry, err := controllerutils.ExpirationTime(cdCopy)
+	if err != nil || expiry == nil || originalExpiry.After(*expiry) {
+		allErrs = append(allErrs, field.Invalid(originalPath, cd.Annotations[constants.OriginalExpirationAnnotation], "cannot be later than the expiration of the cluster"))
+	}
+	return allErrs
+}
+
 func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.ErrorList {
 	allErrs := field.ErrorList{}
 	numberOfPlatforms := 0
@@ -604,7 +833,7 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 	// Add the new data to the contextLogger
 	contextLogger.Data["oldObject.Name"] = oldObject.Name
 
-	hasChangedImmutableField, unsupportedDiff := hasChangedImmutableField(&oldObject.Spec, &cd.Spec)
+	hasChangedImmutableField, unsupportedDiff := hasChangedImmutableField(adoptionFilledSpec(&oldObject.Spec, &cd.Spec), &cd.Spec)
 	if hasChangedImmutableField {
 		message := fmt.Sprintf("Attempted to change ClusterDeployment.Spec which is immutable except for %s fields. Unsupported change: \n%s", strings.Join(mutableFields, ","), unsupportedDiff)
 		contextLogger.Infof("Failed validation: %v", message)
@@ -679,6 +908,13 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		}
 	}
 
+	allErrs = append(allErrs, validateExpiration(specPath.Child("expiration"), cd.Spec.Expiration)...)
+	allErrs = append(allErrs, validateUpgrade(specPath.Child("upgrade"), cd.Spec.Upgrade)...)
+	allErrs = append(allErrs, validatePreDeprovisionHooks(specPath.Child("preDeprovisionHooks"), cd.Spec.PreDeprovisionHooks)...)
+	if a.maxExpirationExtension > 0 && len(allErrs) == 0 {
+		allErrs = append(allErrs, validateExpirationExtension(specPath.Child("expiration"), oldObject, cd, a.maxExpirationExtension)...)
+	}
+
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -756,6 +992,29 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateDelete(request *admis
 	}
 }
 
+// adoptionFilledSpec returns the old spec with the cluster name, base domain and platform region set to their new
+// values if they were empty in the old spec of a cluster being adopted, as these are filled in from the cluster.
+// Otherwise, the old spec is returned unchanged.
+func adoptionFilledSpec(oldSpec, newSpec *hivev1.ClusterDeploymentSpec) *hivev1.ClusterDeploymentSpec {
+	if oldSpec.Adoption == nil || oldSpec.Installed {
+		return oldSpec
+	}
+	filled := oldSpec.DeepCopy()
+	if filled.ClusterName == "" {
+		filled.ClusterName = newSpec.ClusterName
+	}
+	if filled.BaseDomain == "" {
+		filled.BaseDomain = newSpec.BaseDomain
+	}
+	if filled.Platform.AWS != nil && newSpec.Platform.AWS != nil && filled.Platform.AWS.Region == "" {
+		filled.Platform.AWS.Region = newSpec.Platform.AWS.Region
+	}
+	if filled.Platform.GCP != nil && newSpec.Platform.GCP != nil && filled.Platform.GCP.Region == "" {
+		filled.Platform.GCP.Region = newSpec.Platform.GCP.Region
+	}
+	return filled
+}
+
 // hasChangedImmutableField determines if a ClusterDeployment.spec immutable field was changed.
 // it returns the diff string that shows the changes that are not supported
 func hasChangedImmutableField(oldObject, cd *hivev1.ClusterDeploymentSpec) (bool, string) {
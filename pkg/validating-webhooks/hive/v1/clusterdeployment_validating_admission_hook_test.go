@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -84,6 +85,36 @@ func validAWSClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func expiringAWSClusterDeployment(deleteAt time.Time) *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.Expiration = &hivev1.ClusterExpiration{DeleteAt: &metav1.Time{Time: deleteAt}}
+	return cd
+}
+
+func withOriginalExpiration(cd *hivev1.ClusterDeployment, originalExpiry time.Time) *hivev1.ClusterDeployment {
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.OriginalExpirationAnnotation] = originalExpiry.UTC().Format(time.RFC3339)
+	return cd
+}
+
+func validAdoptionClusterDeployment() *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: "SameClusterName",
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "fake-creds-secret"},
+				},
+			},
+			Adoption: &hivev1.ClusterAdoption{
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "admin-kubeconfig"},
+			},
+		},
+	}
+}
+
 func validAWSClusterDeploymentFromPool(poolNS, poolName, claimName string) *hivev1.ClusterDeployment {
 	cd := clusterDeploymentTemplate()
 	cd.Spec.Platform.AWS = &hivev1aws.Platform{
@@ -174,6 +205,29 @@ func validClusterDeploymentDifferentMutableValue() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func testProvisionHook(name string, phase hivev1.ProvisionHookPhase) hivev1.ProvisionHook {
+	return hivev1.ProvisionHook{
+		Name:  name,
+		Phase: phase,
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "hook", Image: "hook-image"}},
+			},
+		},
+	}
+}
+
+func testPreDeprovisionHook(name string) hivev1.PreDeprovisionHook {
+	return hivev1.PreDeprovisionHook{
+		Name: name,
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "hook", Image: "hook-image"}},
+			},
+		},
+	}
+}
+
 func TestClusterDeploymentValidatingResource(t *testing.T) {
 	// Arrange
 	data := NewClusterDeploymentValidatingAdmissionHook(createDecoder(t))
@@ -204,18 +258,20 @@ func TestClusterDeploymentInitialize(t *testing.T) {
 }
 
 func TestClusterDeploymentValidate(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
 	cases := []struct {
-		name                string
-		newObject           *hivev1.ClusterDeployment
-		newObjectRaw        []byte
-		oldObject           *hivev1.ClusterDeployment
-		oldObjectRaw        []byte
-		operation           admissionv1beta1.Operation
-		expectedAllowed     bool
-		gvr                 *metav1.GroupVersionResource
-		enabledFeatureGates []string
-		awsPrivateLink      *hivev1.AWSPrivateLinkConfig
-		supportedContracts  contracts.SupportedContractImplementationsList
+		name                   string
+		newObject              *hivev1.ClusterDeployment
+		newObjectRaw           []byte
+		oldObject              *hivev1.ClusterDeployment
+		oldObjectRaw           []byte
+		operation              admissionv1beta1.Operation
+		expectedAllowed        bool
+		gvr                    *metav1.GroupVersionResource
+		enabledFeatureGates    []string
+		awsPrivateLink         *hivev1.AWSPrivateLinkConfig
+		supportedContracts     contracts.SupportedContractImplementationsList
+		maxExpirationExtension time.Duration
 	}{
 		{
 			name:            "Test valid create",
@@ -461,6 +517,127 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
+		{
+			name: "Test create with provision hooks",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Hooks = []hivev1.ProvisionHook{
+					testProvisionHook("pre", hivev1.PreInstallProvisionHookPhase),
+					testProvisionHook("post", hivev1.PostInstallProvisionHookPhase),
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with duplicate provision hook names",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Hooks = []hivev1.ProvisionHook{
+					testProvisionHook("hook", hivev1.PreInstallProvisionHookPhase),
+					testProvisionHook("hook", hivev1.PostInstallProvisionHookPhase),
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with invalid provision hook name",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Hooks = []hivev1.ProvisionHook{
+					testProvisionHook("Not_Valid", hivev1.PreInstallProvisionHookPhase),
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with pre-deprovision hooks",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.PreDeprovisionHooks = []hivev1.PreDeprovisionHook{
+					testPreDeprovisionHook("drain"),
+					testPreDeprovisionHook("deregister"),
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with duplicate pre-deprovision hook names",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.PreDeprovisionHooks = []hivev1.PreDeprovisionHook{
+					testPreDeprovisionHook("hook"),
+					testPreDeprovisionHook("hook"),
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with pre-deprovision hook without containers",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.PreDeprovisionHooks = []hivev1.PreDeprovisionHook{{Name: "hook"}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with pre-deprovision hook with negative timeout",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				hook := testPreDeprovisionHook("drain")
+				hook.Timeout = &metav1.Duration{Duration: -time.Hour}
+				cd.Spec.PreDeprovisionHooks = []hivev1.PreDeprovisionHook{hook}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test add pre-deprovision hooks to installed cluster",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.PreDeprovisionHooks = []hivev1.PreDeprovisionHook{testPreDeprovisionHook("drain")}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with unsupported provision hook phase",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Hooks = []hivev1.ProvisionHook{
+					testProvisionHook("hook", "PreDeprovision"),
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with provision hook without containers",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				hook := testProvisionHook("hook", hivev1.PreInstallProvisionHookPhase)
+				hook.Template.Spec.Containers = nil
+				cd.Spec.Provisioning.Hooks = []hivev1.ProvisionHook{hook}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test updating existing empty ingress to populated ingress",
 			oldObject:       validAWSClusterDeployment(),
@@ -1085,6 +1262,325 @@ func TestClusterDeploymentValidate(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name:            "Test create adoption",
+			newObject:       validAdoptionClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create adoption without admin kubeconfig",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAdoptionClusterDeployment()
+				cd.Spec.Adoption.AdminKubeconfigSecretRef.Name = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create adoption with provisioning",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAdoptionClusterDeployment()
+				cd.Spec.Provisioning = validAWSClusterDeployment().Spec.Provisioning
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create adoption of installed cluster",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAdoptionClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create adoption without Azure region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAdoptionClusterDeployment()
+				cd.Spec.Platform.AWS = nil
+				cd.Spec.Platform.Azure = &hivev1azure.Platform{
+					CredentialsSecretRef:        corev1.LocalObjectReference{Name: "fake-creds-secret"},
+					BaseDomainResourceGroupName: "os4-common",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update adoption filling in cluster metadata",
+			oldObject: validAdoptionClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAdoptionClusterDeployment()
+				cd.Spec.BaseDomain = "example.com"
+				cd.Spec.Platform.AWS.Region = "us-east-1"
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test update adopted cluster base domain",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAdoptionClusterDeployment()
+				cd.Spec.BaseDomain = "example.com"
+				cd.Spec.Platform.AWS.Region = "us-east-1"
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAdoptionClusterDeployment()
+				cd.Spec.BaseDomain = "other.example.com"
+				cd.Spec.Platform.AWS.Region = "us-east-1"
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test valid expiration",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Expiration = &hivev1.ClusterExpiration{
+					DeleteAfter:      &metav1.Duration{Duration: 8 * time.Hour},
+					WarningLeadTimes: []metav1.Duration{{Duration: time.Hour}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test expiration without deleteAt or deleteAfter",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Expiration = &hivev1.ClusterExpiration{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test expiration with deleteAt and deleteAfter",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := expiringAWSClusterDeployment(time.Now().Add(time.Hour))
+				cd.Spec.Expiration.DeleteAfter = &metav1.Duration{Duration: time.Hour}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test expiration with negative warning lead time",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := expiringAWSClusterDeployment(time.Now().Add(time.Hour))
+				cd.Spec.Expiration.WarningLeadTimes = []metav1.Duration{{Duration: -time.Hour}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test valid upgrade to version",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{Version: "4.8.2"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test add upgrade to cluster image set",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{ImageSetRef: &hivev1.ClusterImageSetReference{Name: "openshift-4.8.2"}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test upgrade without version or imageSetRef",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{Force: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test upgrade with version and imageSetRef",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{
+					Version:     "4.8.2",
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: "openshift-4.8.2"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update upgrade with empty imageSetRef name",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{ImageSetRef: &hivev1.ClusterImageSetReference{}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:                   "Test extend expiration within max extension",
+			oldObject:              expiringAWSClusterDeployment(time.Now().Add(time.Hour)),
+			newObject:              expiringAWSClusterDeployment(time.Now().Add(5 * time.Hour)),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        true,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test extend expiration beyond max extension",
+			oldObject:              expiringAWSClusterDeployment(time.Now().Add(time.Hour)),
+			newObject:              expiringAWSClusterDeployment(time.Now().Add(10 * time.Hour)),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        false,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:            "Test extend expiration without max extension",
+			oldObject:       expiringAWSClusterDeployment(time.Now().Add(time.Hour)),
+			newObject:       expiringAWSClusterDeployment(time.Now().Add(100 * time.Hour)),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:                   "Test shorten expiration with max extension",
+			oldObject:              expiringAWSClusterDeployment(time.Now().Add(10 * time.Hour)),
+			newObject:              expiringAWSClusterDeployment(time.Now().Add(time.Hour)),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        true,
+			maxExpirationExtension: time.Hour,
+		},
+		{
+			name:                   "Test add expiration beyond max extension",
+			oldObject:              validAWSClusterDeployment(),
+			newObject:              expiringAWSClusterDeployment(time.Now().Add(10 * time.Hour)),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        false,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test remove expiration with max extension",
+			oldObject:              expiringAWSClusterDeployment(time.Now().Add(time.Hour)),
+			newObject:              validAWSClusterDeployment(),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        false,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name: "Test extend delete-after annotation beyond max extension",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.CreationTimestamp = metav1.Now()
+				cd.Annotations = map[string]string{constants.DeleteAfterAnnotation: "1h"}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.CreationTimestamp = metav1.Now()
+				cd.Annotations = map[string]string{constants.DeleteAfterAnnotation: "24h"}
+				return cd
+			}(),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        false,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test extend expiration within max extension of original expiration",
+			oldObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry.Add(4*time.Hour)), expiry),
+			newObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry.Add(8*time.Hour)), expiry),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        true,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test extend expiration beyond max extension of original expiration",
+			oldObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry.Add(4*time.Hour)), expiry),
+			newObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry.Add(10*time.Hour)), expiry),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        false,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test shorten expiration beyond max extension of original expiration",
+			oldObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry.Add(20*time.Hour)), expiry),
+			newObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry.Add(10*time.Hour)), expiry),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        true,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test record original expiration",
+			oldObject:              expiringAWSClusterDeployment(expiry),
+			newObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry), expiry),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        true,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test record original expiration other than current expiration",
+			oldObject:              expiringAWSClusterDeployment(expiry),
+			newObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry), expiry.Add(100*time.Hour)),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        false,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test change original expiration",
+			oldObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry), expiry),
+			newObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry), expiry.Add(100*time.Hour)),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        false,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test remove original expiration",
+			oldObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry), expiry),
+			newObject:              expiringAWSClusterDeployment(expiry),
+			operation:              admissionv1beta1.Update,
+			expectedAllowed:        false,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test create with original expiration",
+			newObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry), expiry.Add(-time.Hour)),
+			operation:              admissionv1beta1.Create,
+			expectedAllowed:        true,
+			maxExpirationExtension: 8 * time.Hour,
+		},
+		{
+			name:                   "Test create with original expiration later than expiration",
+			newObject:              withOriginalExpiration(expiringAWSClusterDeployment(expiry), expiry.Add(100*time.Hour)),
+			operation:              admissionv1beta1.Create,
+			expectedAllowed:        false,
+			maxExpirationExtension: 8 * time.Hour,
+		},
 		{
 			name: "private link enabled, some inventory in given region",
 			newObject: func() *hivev1.ClusterDeployment {
@@ -1122,8 +1618,9 @@ func TestClusterDeploymentValidate(t *testing.T) {
 						Enabled: tc.enabledFeatureGates,
 					},
 				},
-				awsPrivateLinkConfig: tc.awsPrivateLink,
-				supportedContracts:   tc.supportedContracts,
+				awsPrivateLinkConfig:   tc.awsPrivateLink,
+				supportedContracts:     tc.supportedContracts,
+				maxExpirationExtension: tc.maxExpirationExtension,
 			}
 
 			if tc.gvr == nil {
@@ -1164,6 +1661,40 @@ func TestClusterDeploymentValidate(t *testing.T) {
 	}
 }
 
+func TestClusterDeploymentRepeatedExpirationExtension(t *testing.T) {
+	hook := ClusterDeploymentValidatingAdmissionHook{
+		decoder:                createDecoder(t),
+		validManagedDomains:    validTestManagedDomains,
+		fs:                     &featureSet{FeatureGatesEnabled: &hivev1.FeatureGatesEnabled{}},
+		maxExpirationExtension: 8 * time.Hour,
+	}
+	originalExpiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	cd := withOriginalExpiration(expiringAWSClusterDeployment(originalExpiry), originalExpiry)
+
+	// Each update extends the expiration by less than the max extension, but the total is capped.
+	for i, expectedAllowed := range []bool{true, true, true, true, false} {
+		newCD := withOriginalExpiration(expiringAWSClusterDeployment(originalExpiry.Add(time.Duration(i+1)*2*time.Hour-time.Minute)), originalExpiry)
+		oldRaw, _ := json.Marshal(cd)
+		newRaw, _ := json.Marshal(newCD)
+		response := hook.Validate(&admissionv1beta1.AdmissionRequest{
+			Operation: admissionv1beta1.Update,
+			Resource: metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "v1",
+				Resource: "clusterdeployments",
+			},
+			Object:    runtime.RawExtension{Raw: newRaw},
+			OldObject: runtime.RawExtension{Raw: oldRaw},
+		})
+		if !assert.Equal(t, expectedAllowed, response.Allowed, "unexpected response for extension %d", i+1) {
+			t.Logf("Response result = %#v", response.Result)
+		}
+		if response.Allowed {
+			cd = newCD
+		}
+	}
+}
+
 func TestNewClusterDeploymentValidatingAdmissionHook(t *testing.T) {
 	tempFile, err := ioutil.TempFile("", "")
 	if err != nil {
@@ -0,0 +1,216 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClusterDeprovisionValidatingResource(t *testing.T) {
+	// Arrange
+	data := NewClusterDeprovisionValidatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "clusterdeprovisionvalidators",
+	}
+	expectedSingular := "clusterdeprovisionvalidator"
+
+	// Act
+	plural, singular := data.ValidatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestClusterDeprovisionInitialize(t *testing.T) {
+	// Arrange
+	data := NewClusterDeprovisionValidatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(nil, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestClusterDeprovisionValidate(t *testing.T) {
+	cases := []struct {
+		name            string
+		newSpec         hivev1.ClusterDeprovisionSpec
+		oldSpec         hivev1.ClusterDeprovisionSpec
+		newLabels       map[string]string
+		oldLabels       map[string]string
+		namespace       string
+		newObjectRaw    []byte
+		oldObjectRaw    []byte
+		operation       admissionv1beta1.Operation
+		expectedAllowed bool
+		gvr             *metav1.GroupVersionResource
+	}{
+		{
+			name:            "Test create",
+			newSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id", DryRun: true},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create orphaned infra deprovision in hive namespace",
+			newSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			newLabels:       map[string]string{constants.OrphanedInfraLabel: "true"},
+			namespace:       constants.DefaultHiveNamespace,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create orphaned infra deprovision outside hive namespace",
+			newSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			newLabels:       map[string]string{constants.OrphanedInfraLabel: "true"},
+			namespace:       "other",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create orphaned infra deprovision with any label value outside hive namespace",
+			newSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			newLabels:       map[string]string{constants.OrphanedInfraLabel: "false"},
+			namespace:       "other",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal new object during create",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test adding orphaned infra label",
+			newSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			oldSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			newLabels:       map[string]string{constants.OrphanedInfraLabel: "true"},
+			namespace:       constants.DefaultHiveNamespace,
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test update keeping orphaned infra label",
+			newSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			oldSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			newLabels:       map[string]string{constants.OrphanedInfraLabel: "true", "other": "label"},
+			oldLabels:       map[string]string{constants.OrphanedInfraLabel: "true"},
+			namespace:       constants.DefaultHiveNamespace,
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test update without changing dry run",
+			newSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id", ClusterID: "cluster-id", DryRun: true},
+			oldSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id", DryRun: true},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test turning off dry run",
+			newSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			oldSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id", DryRun: true},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test turning on dry run",
+			newSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id", DryRun: true},
+			oldSpec:         hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal new object during update",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal old object during update",
+			oldObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test that we don't validate deletes",
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name:    "Test doesn't validate with right group and version, wrong resource",
+			newSpec: hivev1.ClusterDeprovisionSpec{InfraID: "infra-id"},
+			oldSpec: hivev1.ClusterDeprovisionSpec{InfraID: "infra-id", DryRun: true},
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "v1",
+				Resource: "not the right resource",
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewClusterDeprovisionValidatingAdmissionHook(createDecoder(t))
+			newObject := &hivev1.ClusterDeprovision{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tc.namespace, Labels: tc.newLabels},
+				Spec:       tc.newSpec,
+			}
+			oldObject := &hivev1.ClusterDeprovision{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tc.namespace, Labels: tc.oldLabels},
+				Spec:       tc.oldSpec,
+			}
+
+			if tc.newObjectRaw == nil {
+				tc.newObjectRaw, _ = json.Marshal(newObject)
+			}
+
+			if tc.oldObjectRaw == nil {
+				tc.oldObjectRaw, _ = json.Marshal(oldObject)
+			}
+
+			if tc.gvr == nil {
+				tc.gvr = &metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterdeprovisions",
+				}
+			}
+
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource:  *tc.gvr,
+				Namespace: tc.namespace,
+				Object: runtime.RawExtension{
+					Raw: tc.newObjectRaw,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: tc.oldObjectRaw,
+				},
+			}
+
+			// Act
+			response := data.Validate(request)
+
+			// Assert
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/util/schedule"
 )
 
 const (
@@ -178,6 +180,12 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateInventory(specPath.Child("inventory"), newObject.Spec.Inventory)...)
+	allErrs = append(allErrs, validateSchedules(specPath.Child("schedules"), newObject.Spec.Schedules)...)
+	allErrs = append(allErrs, validateClaimQuotas(specPath.Child("claimQuotas"), admissionSpec.Namespace, newObject.Spec.ClaimQuotas)...)
+	allErrs = append(allErrs, validateRecycling(specPath, &newObject.Spec)...)
+	allErrs = append(allErrs, validatePlatformVariants(specPath.Child("platformVariants"), &newObject.Spec)...)
+	allErrs = append(allErrs, validateAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
 
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -238,6 +246,12 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateInventory(specPath.Child("inventory"), newObject.Spec.Inventory)...)
+	allErrs = append(allErrs, validateSchedules(specPath.Child("schedules"), newObject.Spec.Schedules)...)
+	allErrs = append(allErrs, validateClaimQuotas(specPath.Child("claimQuotas"), admissionSpec.Namespace, newObject.Spec.ClaimQuotas)...)
+	allErrs = append(allErrs, validateRecycling(specPath, &newObject.Spec)...)
+	allErrs = append(allErrs, validatePlatformVariants(specPath.Child("platformVariants"), &newObject.Spec)...)
+	allErrs = append(allErrs, validateAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -254,3 +268,159 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 		Allowed: true,
 	}
 }
+
+func validateInventory(path *field.Path, inventory []hivev1.InventoryEntry) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, entry := range inventory {
+		entryPath := path.Index(i)
+		if entry.Name == "" {
+			allErrs = append(allErrs, field.Required(entryPath.Child("name"), "must specify a name"))
+		} else if names.Has(entry.Name) {
+			allErrs = append(allErrs, field.Duplicate(entryPath.Child("name"), entry.Name))
+		}
+		names.Insert(entry.Name)
+		switch entry.Kind {
+		case "", hivev1.ClusterDeploymentCustomizationInventoryEntry:
+		default:
+			allErrs = append(allErrs, field.NotSupported(entryPath.Child("kind"), entry.Kind, []string{string(hivev1.ClusterDeploymentCustomizationInventoryEntry)}))
+		}
+	}
+	return allErrs
+}
+
+func validateSchedules(path *field.Path, schedules []hivev1.ClusterPoolSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, s := range schedules {
+		schedulePath := path.Index(i)
+		if s.Name == "" {
+			allErrs = append(allErrs, field.Required(schedulePath.Child("name"), "must specify a name"))
+		} else if names.Has(s.Name) {
+			allErrs = append(allErrs, field.Duplicate(schedulePath.Child("name"), s.Name))
+		}
+		names.Insert(s.Name)
+		if _, err := schedule.Parse(s.Start); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("start"), s.Start, err.Error()))
+		}
+		if s.Duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("duration"), s.Duration.Duration.String(), "must be positive"))
+		}
+		if s.Size < 0 {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("size"), s.Size, "must not be negative"))
+		}
+		if s.MaxConcurrent != nil && *s.MaxConcurrent < 0 {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("maxConcurrent"), *s.MaxConcurrent, "must not be negative"))
+		}
+	}
+	return allErrs
+}
+
+func validateClaimQuotas(path *field.Path, poolNamespace string, quotas []hivev1.ClusterPoolClaimQuota) field.ErrorList {
+	allErrs := field.ErrorList{}
+	namespaces := sets.NewString()
+	users := sets.NewString()
+	groups := sets.NewString()
+	for i, quota := range quotas {
+		quotaPath := path.Index(i)
+		set := 0
+		for _, v := range []string{quota.Namespace, quota.User, quota.Group} {
+			if v != "" {
+				set++
+			}
+		}
+		switch {
+		case set > 1:
+			allErrs = append(allErrs, field.Invalid(quotaPath, quota, "must specify only one of namespace, user and group"))
+		case quota.Namespace != "":
+			// Claims are only ever made in the namespace of their pool.
+			if quota.Namespace != poolNamespace {
+				allErrs = append(allErrs, field.Invalid(quotaPath.Child("namespace"), quota.Namespace, "must be the namespace of the pool"))
+			}
+			if namespaces.Has(quota.Namespace) {
+				allErrs = append(allErrs, field.Duplicate(quotaPath.Child("namespace"), quota.Namespace))
+			}
+			namespaces.Insert(quota.Namespace)
+		case quota.User != "":
+			if users.Has(quota.User) {
+				allErrs = append(allErrs, field.Duplicate(quotaPath.Child("user"), quota.User))
+			}
+			users.Insert(quota.User)
+		case quota.Group != "":
+			if groups.Has(quota.Group) {
+				allErrs = append(allErrs, field.Duplicate(quotaPath.Child("group"), quota.Group))
+			}
+			groups.Insert(quota.Group)
+		default:
+			allErrs = append(allErrs, field.Required(quotaPath, "must specify one of namespace, user and group"))
+		}
+		if quota.MaxClaimed < 0 {
+			allErrs = append(allErrs, field.Invalid(quotaPath.Child("maxClaimed"), quota.MaxClaimed, "must not be negative"))
+		}
+	}
+	return allErrs
+}
+
+func validateRecycling(path *field.Path, spec *hivev1.ClusterPoolSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.MaxUnclaimedAge != nil && spec.MaxUnclaimedAge.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxUnclaimedAge"), spec.MaxUnclaimedAge.Duration.String(), "must be positive"))
+	}
+	if spec.BrokenClusterPolicy != nil && spec.BrokenClusterPolicy.Threshold.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("brokenClusterPolicy", "threshold"), spec.BrokenClusterPolicy.Threshold.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+func validateAutoscaling(path *field.Path, autoscaling *hivev1.ClusterPoolAutoscaling) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if autoscaling == nil {
+		return allErrs
+	}
+	if autoscaling.MinSize < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minSize"), autoscaling.MinSize, "must not be negative"))
+	}
+	if autoscaling.MaxSize < autoscaling.MinSize {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxSize"), autoscaling.MaxSize, "must not be less than minSize"))
+	}
+	if autoscaling.Window != nil && autoscaling.Window.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("window"), autoscaling.Window.Duration.String(), "must be positive"))
+	}
+	return allErrs
+}
+
+func validatePlatformVariants(path *field.Path, spec *hivev1.ClusterPoolSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(spec.PlatformVariants) == 0 {
+		return allErrs
+	}
+	if spec.Platform.AWS == nil && spec.Platform.GCP == nil && spec.Platform.Azure == nil {
+		allErrs = append(allErrs, field.Forbidden(path, "platform variants are only supported for AWS, Azure and GCP"))
+	}
+	names := sets.NewString()
+	weighted := false
+	for i, variant := range spec.PlatformVariants {
+		variantPath := path.Index(i)
+		if variant.Name == "" {
+			allErrs = append(allErrs, field.Required(variantPath.Child("name"), "must specify a name"))
+		} else if names.Has(variant.Name) {
+			allErrs = append(allErrs, field.Duplicate(variantPath.Child("name"), variant.Name))
+		}
+		names.Insert(variant.Name)
+		if variant.Region == "" {
+			allErrs = append(allErrs, field.Required(variantPath.Child("region"), "must specify a region"))
+		}
+		if variant.Weight == nil || *variant.Weight > 0 {
+			weighted = true
+		} else if *variant.Weight < 0 {
+			allErrs = append(allErrs, field.Invalid(variantPath.Child("weight"), *variant.Weight, "must not be negative"))
+		}
+		if variant.CredentialsSecretRef != nil && variant.CredentialsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(variantPath.Child("credentialsSecretRef", "name"), "must specify a secret name"))
+		}
+	}
+	if !weighted {
+		allErrs = append(allErrs, field.Invalid(path, spec.PlatformVariants, "at least one variant must have a non-zero weight"))
+	}
+	return allErrs
+}
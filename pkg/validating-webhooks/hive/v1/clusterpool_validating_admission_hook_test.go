@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -10,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
@@ -18,6 +20,8 @@ import (
 	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
 )
 
+const testPoolNamespace = "test-namespace"
+
 func clusterPoolTemplate() *hivev1.ClusterPool {
 	return &hivev1.ClusterPool{
 		Spec: hivev1.ClusterPoolSpec{
@@ -216,6 +220,230 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "create with inventory",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Inventory = []hivev1.InventoryEntry{
+					{Kind: hivev1.ClusterDeploymentCustomizationInventoryEntry, Name: "cdc1"},
+					{Name: "cdc2"},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with duplicate inventory entries",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Inventory = []hivev1.InventoryEntry{{Name: "cdc1"}, {Name: "cdc1"}}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with inventory entry missing name",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Inventory = []hivev1.InventoryEntry{{Kind: hivev1.ClusterDeploymentCustomizationInventoryEntry}}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with schedule",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Schedules = []hivev1.ClusterPoolSchedule{{
+					Name:     "business-hours",
+					Start:    "0 8 * * 1-5",
+					Duration: metav1.Duration{Duration: 10 * time.Hour},
+					Size:     10,
+				}}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with invalid schedule",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Schedules = []hivev1.ClusterPoolSchedule{{
+					Name:     "business-hours",
+					Start:    "0 8 * *",
+					Duration: metav1.Duration{Duration: 10 * time.Hour},
+					Size:     10,
+				}}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with schedule missing duration",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Schedules = []hivev1.ClusterPoolSchedule{{
+					Name:  "business-hours",
+					Start: "0 8 * * 1-5",
+					Size:  10,
+				}}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with claim quotas",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.ClaimQuotas = []hivev1.ClusterPoolClaimQuota{
+					{Group: "team-a", MaxClaimed: 2},
+					{User: "alice", MaxClaimed: 1},
+					{Namespace: testPoolNamespace, MaxClaimed: 5},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with claim quota for another namespace",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.ClaimQuotas = []hivev1.ClusterPoolClaimQuota{{Namespace: "ci", MaxClaimed: 5}}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with claim quota for group and namespace",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.ClaimQuotas = []hivev1.ClusterPoolClaimQuota{{Group: "team-a", Namespace: testPoolNamespace, MaxClaimed: 2}}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with claim quota for user and group",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.ClaimQuotas = []hivev1.ClusterPoolClaimQuota{{User: "alice", Group: "team-a", MaxClaimed: 2}}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with duplicate claim quota",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.ClaimQuotas = []hivev1.ClusterPoolClaimQuota{
+					{Group: "team-a", MaxClaimed: 2},
+					{Group: "team-a", MaxClaimed: 3},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with recycling",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.MaxUnclaimedAge = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+				cp.Spec.BrokenClusterPolicy = &hivev1.ClusterPoolBrokenClusterPolicy{
+					Threshold: metav1.Duration{Duration: time.Hour},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with zero max unclaimed age",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.MaxUnclaimedAge = &metav1.Duration{}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with platform variants",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.PlatformVariants = []hivev1.ClusterPoolPlatformVariant{
+					{Name: "east", Weight: pointer.Int32Ptr(2), Region: "us-east-1"},
+					{Name: "west", Region: "us-west-2", CredentialsSecretRef: &corev1.LocalObjectReference{Name: "west-creds"}},
+					{Name: "central", Weight: pointer.Int32Ptr(0), Region: "us-central-1"},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with platform variants all of weight zero",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.PlatformVariants = []hivev1.ClusterPoolPlatformVariant{
+					{Name: "east", Weight: pointer.Int32Ptr(0), Region: "us-east-1"},
+					{Name: "west", Weight: pointer.Int32Ptr(0), Region: "us-west-2"},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with platform variant missing region",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.PlatformVariants = []hivev1.ClusterPoolPlatformVariant{{Name: "east"}}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with autoscaling",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MinSize: 1,
+					MaxSize: 10,
+					Window:  &metav1.Duration{Duration: 2 * time.Hour},
+				}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with autoscaling max size less than min size",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MinSize: 5, MaxSize: 2}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test valid delete",
 			oldObject:       validAWSClusterPool(),
@@ -249,6 +477,7 @@ func TestClusterPoolValidate(t *testing.T) {
 
 			request := &admissionv1beta1.AdmissionRequest{
 				Operation: tc.operation,
+				Namespace: testPoolNamespace,
 				Resource:  *tc.gvr,
 				Object: runtime.RawExtension{
 					Raw: tc.newObjectRaw,
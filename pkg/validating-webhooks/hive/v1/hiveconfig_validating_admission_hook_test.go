@@ -0,0 +1,190 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestHiveConfigValidatingResource(t *testing.T) {
+	// Arrange
+	data := NewHiveConfigValidatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "hiveconfigvalidators",
+	}
+	expectedSingular := "hiveconfigvalidator"
+
+	// Act
+	plural, singular := data.ValidatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestHiveConfigInitialize(t *testing.T) {
+	// Arrange
+	data := NewHiveConfigValidatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(nil, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestHiveConfigValidate(t *testing.T) {
+	awsConfig := &hivev1.FailedProvisionAWSConfig{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"},
+		Bucket:               "logs",
+	}
+	gcpConfig := &hivev1.FailedProvisionGCPConfig{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "gcp-creds"},
+		Bucket:               "logs",
+	}
+	pvcConfig := &hivev1.FailedProvisionPersistentVolumeClaimConfig{
+		ClaimName: "logs",
+	}
+
+	cases := []struct {
+		name            string
+		newSpec         hivev1.HiveConfigSpec
+		oldSpec         hivev1.HiveConfigSpec
+		newObjectRaw    []byte
+		oldObjectRaw    []byte
+		operation       admissionv1beta1.Operation
+		expectedAllowed bool
+		gvr             *metav1.GroupVersionResource
+	}{
+		{
+			name:            "Test empty HiveConfig.Spec",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test single failed provision log destination",
+			newSpec: hivev1.HiveConfigSpec{
+				FailedProvisionConfig: hivev1.FailedProvisionConfig{GCP: gcpConfig},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test multiple failed provision log destinations on create",
+			newSpec: hivev1.HiveConfigSpec{
+				FailedProvisionConfig: hivev1.FailedProvisionConfig{AWS: awsConfig, GCP: gcpConfig},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test multiple failed provision log destinations on update",
+			newSpec: hivev1.HiveConfigSpec{
+				FailedProvisionConfig: hivev1.FailedProvisionConfig{AWS: awsConfig, PersistentVolumeClaim: pvcConfig},
+			},
+			oldSpec: hivev1.HiveConfigSpec{
+				FailedProvisionConfig: hivev1.FailedProvisionConfig{AWS: awsConfig},
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test changing failed provision log destination",
+			newSpec: hivev1.HiveConfigSpec{
+				FailedProvisionConfig: hivev1.FailedProvisionConfig{PersistentVolumeClaim: pvcConfig},
+			},
+			oldSpec: hivev1.HiveConfigSpec{
+				FailedProvisionConfig: hivev1.FailedProvisionConfig{AWS: awsConfig},
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test unable to marshal new object during create",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal old object during update",
+			oldObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test that we don't validate deletes",
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test doesn't validate with right group and version, wrong resource",
+			newSpec: hivev1.HiveConfigSpec{
+				FailedProvisionConfig: hivev1.FailedProvisionConfig{AWS: awsConfig, GCP: gcpConfig},
+			},
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "v1",
+				Resource: "not the right resource",
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewHiveConfigValidatingAdmissionHook(createDecoder(t))
+			newObject := &hivev1.HiveConfig{
+				Spec: tc.newSpec,
+			}
+			oldObject := &hivev1.HiveConfig{
+				Spec: tc.oldSpec,
+			}
+
+			if tc.newObjectRaw == nil {
+				tc.newObjectRaw, _ = json.Marshal(newObject)
+			}
+
+			if tc.oldObjectRaw == nil {
+				tc.oldObjectRaw, _ = json.Marshal(oldObject)
+			}
+
+			if tc.gvr == nil {
+				tc.gvr = &metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "hiveconfigs",
+				}
+			}
+
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource:  *tc.gvr,
+				Object: runtime.RawExtension{
+					Raw: tc.newObjectRaw,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: tc.oldObjectRaw,
+				},
+			}
+
+			// Act
+			response := data.Validate(request)
+
+			// Assert
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}